@@ -0,0 +1,74 @@
+package apply
+
+import "strconv"
+
+// Report represents parsed git apply --numstat --summary output.
+type Report struct {
+	NumStats []NumStat     // --numstat lines, in the order they appeared
+	Summary  []SummaryLine // --summary lines, in the order they appeared
+}
+
+// NumStat represents a single line of git apply --numstat output.
+//
+// For binary files, Binary is true and Added and Deleted are zero.
+type NumStat struct {
+	Added   int    // number of added lines
+	Deleted int    // number of deleted lines
+	Binary  bool   // true if the file is binary
+	Path    string // file path (the new path for renames and copies)
+}
+
+// SummaryKind identifies the kind of a --summary line.
+type SummaryKind int
+
+// Summary kind constants corresponding to git apply --summary lines.
+const (
+	SummaryCreate     SummaryKind = iota // " create mode <mode> <path>"
+	SummaryDelete                        // " delete mode <mode> <path>"
+	SummaryRename                        // " rename <orig> => <path> (<score>%)"
+	SummaryCopy                          // " copy <orig> => <path> (<score>%)"
+	SummaryRewrite                       // " rewrite <path> (<score>%)"
+	SummaryModeChange                    // " mode change <old> => <new> <path>"
+)
+
+var summaryKindNames = [...]string{
+	SummaryCreate:     "create",
+	SummaryDelete:     "delete",
+	SummaryRename:     "rename",
+	SummaryCopy:       "copy",
+	SummaryRewrite:    "rewrite",
+	SummaryModeChange: "mode change",
+}
+
+// String returns the keyword used by git for the summary kind, e.g. "rename".
+func (k SummaryKind) String() string {
+	if k < 0 || int(k) >= len(summaryKindNames) {
+		return "SummaryKind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return summaryKindNames[k]
+}
+
+// A FileMode represents the kind of tree entries used by git.
+type FileMode uint32
+
+// String returns the octal string representation of the FileMode, e.g. "100644".
+func (m FileMode) String() string {
+	return strconv.FormatUint(uint64(m), 8)
+}
+
+// SummaryLine represents a single line of git apply --summary output.
+//
+// Which fields are set depends on the Kind:
+//   - SummaryCreate, SummaryDelete: Path and Mode (Mode may be zero if git did
+//     not report one)
+//   - SummaryRename, SummaryCopy: Path, OrigPath and Score (similarity)
+//   - SummaryRewrite: Path and Score (dissimilarity)
+//   - SummaryModeChange: Path, OldMode and Mode
+type SummaryLine struct {
+	Kind     SummaryKind // kind of change
+	Path     string      // file path (the new path for renames and copies)
+	OrigPath string      `json:",omitempty"` // original path for renames and copies
+	Mode     FileMode    `json:",omitempty"` // file mode created, deleted, or changed to
+	OldMode  FileMode    `json:",omitempty"` // file mode changed from
+	Score    int         `json:",omitempty"` // similarity (or dissimilarity for rewrites) percentage
+}
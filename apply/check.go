@@ -0,0 +1,179 @@
+package apply
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+)
+
+// CheckReport represents parsed git apply --check diagnostics.
+//
+// A patch that applies cleanly produces no diagnostics, so an empty report
+// indicates success.
+type CheckReport struct {
+	Problems   []Problem         // errors and warnings, in the order they appeared
+	Whitespace []WhitespaceError // whitespace violations (with --whitespace=warn or error)
+}
+
+// OK reports whether the report contains no errors. Warnings and whitespace
+// violations alone do not prevent a patch from applying.
+func (r *CheckReport) OK() bool {
+	for _, p := range r.Problems {
+		if p.Severity == SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// FailedPaths returns the unique paths of all errors that identify a path,
+// in the order they first appeared.
+func (r *CheckReport) FailedPaths() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, p := range r.Problems {
+		if p.Severity != SeverityError || p.Path == "" || seen[p.Path] {
+			continue
+		}
+		seen[p.Path] = true
+		paths = append(paths, p.Path)
+	}
+	return paths
+}
+
+// Severity indicates whether a diagnostic is fatal.
+type Severity string
+
+// Severity prefixes used by git diagnostics.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Problem represents a single error or warning from git apply.
+//
+// When the diagnostic refers to a file, Path is set and Message contains the
+// remaining text. For "patch failed: <path>:<line>" errors, Line is the line in
+// the target file at which the hunk failed to apply.
+type Problem struct {
+	Severity Severity // error or warning
+	Path     string   `json:",omitempty"` // path the diagnostic refers to, if any
+	Line     int      `json:",omitempty"` // line number in the target file, if known
+	Message  string   // message text, e.g. "patch does not apply"
+}
+
+// WhitespaceError represents a whitespace violation found in a patch.
+type WhitespaceError struct {
+	Patch   string // name of the patch file ("<stdin>" when read from stdin)
+	Line    int    // line number within the patch
+	Reason  string // e.g. "trailing whitespace", "space before tab in indent"
+	Content string // the offending line of the patch, without the leading '+'
+}
+
+// ParseCheck parses the diagnostics written to stderr by `git apply --check`.
+//
+// Lines that are not recognized as diagnostics, such as the progress lines
+// emitted with --verbose, are ignored.
+func ParseCheck(r io.Reader) (*CheckReport, error) {
+	var report CheckReport
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		if p, ok := parseProblem(line); ok {
+			report.Problems = append(report.Problems, p)
+			continue
+		}
+
+		if w, ok := parseWhitespaceError(line); ok {
+			// The offending line of the patch is echoed on the following line.
+			if scanner.Scan() {
+				w.Content = scanner.Text()
+			}
+			report.Whitespace = append(report.Whitespace, w)
+		}
+	}
+	return &report, scanner.Err()
+}
+
+// fileMessages are the per-file diagnostics git apply emits during --check.
+var fileMessages = []string{
+	"patch does not apply",
+	"already exists in working directory",
+	"already exists in index",
+	"does not exist in index",
+	"does not match index",
+	"No such file or directory",
+}
+
+// Diagnostics have the following formats:
+//
+//	error: patch failed: <path>:<line>
+//	error: <path>: <message>
+//	error: <message>
+//
+// where "error" may also be "warning".
+func parseProblem(line []byte) (Problem, bool) {
+	var p Problem
+	switch {
+	case bytes.HasPrefix(line, []byte("error: ")):
+		p.Severity, line = SeverityError, line[len("error: "):]
+	case bytes.HasPrefix(line, []byte("warning: ")):
+		p.Severity, line = SeverityWarning, line[len("warning: "):]
+	default:
+		return p, false
+	}
+
+	if loc, ok := bytes.CutPrefix(line, []byte("patch failed: ")); ok {
+		if i := bytes.LastIndexByte(loc, ':'); i > 0 {
+			if n, err := strconv.Atoi(string(loc[i+1:])); err == nil {
+				p.Path, p.Line, p.Message = string(loc[:i]), n, "patch failed"
+				return p, true
+			}
+		}
+	}
+
+	// Per-file messages are of the form "<path>: <message>". Since paths may
+	// contain ": " themselves, well-known messages are matched by suffix first.
+	for _, msg := range fileMessages {
+		if path, ok := bytes.CutSuffix(line, []byte(": "+msg)); ok && len(path) > 0 {
+			p.Path, p.Message = string(path), msg
+			return p, true
+		}
+	}
+	// Otherwise, only treat a prefix without spaces as a path, since messages
+	// without a path may still contain ": " (e.g. "corrupt patch at line 5").
+	if path, msg, found := bytes.Cut(line, []byte(": ")); found && len(path) > 0 && !bytes.ContainsRune(path, ' ') {
+		p.Path, p.Message = string(path), string(msg)
+		return p, true
+	}
+	p.Message = string(line)
+	return p, true
+}
+
+// Whitespace errors have the following format:
+// <patch>:<line>: <reason>.
+func parseWhitespaceError(line []byte) (WhitespaceError, bool) {
+	var zero WhitespaceError
+	body, ok := bytes.CutSuffix(line, []byte{'.'})
+	if !ok {
+		return zero, false
+	}
+	loc, reason, found := bytes.Cut(body, []byte(": "))
+	if !found {
+		return zero, false
+	}
+	i := bytes.LastIndexByte(loc, ':')
+	if i <= 0 {
+		return zero, false
+	}
+	n, err := strconv.Atoi(string(loc[i+1:]))
+	if err != nil {
+		return zero, false
+	}
+	return WhitespaceError{Patch: string(loc[:i]), Line: n, Reason: string(reason)}, true
+}
@@ -0,0 +1,127 @@
+package apply
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// sampleCheckOutput is the stderr of `git apply --check --verbose` for a patch
+// that fails to apply to several files, including a whitespace violation.
+var sampleCheckOutput = "Checking patch added...\n" +
+	"error: added: already exists in working directory\n" +
+	"Checking patch bin...\n" +
+	"error: cannot apply binary patch to 'bin' without full index line\n" +
+	"error: bin: patch does not apply\n" +
+	"Checking patch keep...\n" +
+	"../p.diff:38: trailing whitespace.\n" +
+	"trail   \n" +
+	"error: patch failed: keep:26\n" +
+	"error: keep: patch does not apply\n" +
+	"warning: 1 line adds whitespace errors.\n"
+
+func TestParseCheck(t *testing.T) {
+	got, err := ParseCheck(strings.NewReader(sampleCheckOutput))
+	if err != nil {
+		t.Fatalf("ParseCheck() error = %v", err)
+	}
+	want := &CheckReport{
+		Problems: []Problem{
+			{Severity: SeverityError, Path: "added", Message: "already exists in working directory"},
+			{Severity: SeverityError, Message: "cannot apply binary patch to 'bin' without full index line"},
+			{Severity: SeverityError, Path: "bin", Message: "patch does not apply"},
+			{Severity: SeverityError, Path: "keep", Line: 26, Message: "patch failed"},
+			{Severity: SeverityError, Path: "keep", Message: "patch does not apply"},
+			{Severity: SeverityWarning, Message: "1 line adds whitespace errors."},
+		},
+		Whitespace: []WhitespaceError{
+			{Patch: "../p.diff", Line: 38, Reason: "trailing whitespace", Content: "trail   "},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseCheck() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got.OK() {
+		t.Errorf("OK() = true, want false")
+	}
+	wantPaths := []string{"added", "bin", "keep"}
+	if paths := got.FailedPaths(); !slices.Equal(paths, wantPaths) {
+		t.Errorf("FailedPaths() = %q, want %q", paths, wantPaths)
+	}
+}
+
+func TestParseCheck_Clean(t *testing.T) {
+	got, err := ParseCheck(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("ParseCheck() error = %v", err)
+	}
+	if !got.OK() {
+		t.Errorf("OK() = false for empty report")
+	}
+}
+
+func Test_parseProblem(t *testing.T) {
+	testcases := []struct {
+		name   string
+		input  string
+		want   Problem
+		wantOK bool
+	}{
+		{
+			name:   "patch failed",
+			input:  "error: patch failed: dir/file.go:12",
+			want:   Problem{Severity: SeverityError, Path: "dir/file.go", Line: 12, Message: "patch failed"},
+			wantOK: true,
+		},
+		{
+			name:   "patch failed path with colon",
+			input:  "error: patch failed: a:b.txt:3",
+			want:   Problem{Severity: SeverityError, Path: "a:b.txt", Line: 3, Message: "patch failed"},
+			wantOK: true,
+		},
+		{
+			name:   "known message for path with spaces",
+			input:  "error: my file.txt: does not exist in index",
+			want:   Problem{Severity: SeverityError, Path: "my file.txt", Message: "does not exist in index"},
+			wantOK: true,
+		},
+		{
+			name:   "unknown per-file message",
+			input:  "error: file.txt: wrong type",
+			want:   Problem{Severity: SeverityError, Path: "file.txt", Message: "wrong type"},
+			wantOK: true,
+		},
+		{
+			name:   "message without path",
+			input:  "error: corrupt patch at line 5",
+			want:   Problem{Severity: SeverityError, Message: "corrupt patch at line 5"},
+			wantOK: true,
+		},
+		{
+			name:   "warning",
+			input:  "warning: squelched 3 whitespace errors",
+			want:   Problem{Severity: SeverityWarning, Message: "squelched 3 whitespace errors"},
+			wantOK: true,
+		},
+		{
+			name:   "not a diagnostic",
+			input:  "Checking patch file.txt...",
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := parseProblem([]byte(tc.input))
+			if ok != tc.wantOK {
+				t.Fatalf("parseProblem() ok = %v, want %v", ok, tc.wantOK)
+			}
+			if ok && got != tc.want {
+				t.Errorf("parseProblem() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Package apply parses the machine-readable output of `git apply`.
+
+When given the --numstat, --summary, or --check flags, git apply does not
+apply the patch, but instead reports what it would do. This package parses
+those reports into typed results, so patch-application services can report
+precise outcomes.
+
+# Basic Usage
+
+[Parse] takes an [io.Reader] containing `git apply --numstat --summary` output.
+Either flag may be used alone, in which case the corresponding field of the
+returned [Report] will be empty.
+
+	r := bytes.NewReader(gitApplyOutput)
+	report, err := apply.Parse(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+[ParseZ] provides a variant that will work with NUL-terminated numstat output
+(from -z flag).
+
+[ParseCheck] parses the diagnostics that `git apply --check` writes to stderr
+when a patch would not apply cleanly:
+
+	check, err := apply.ParseCheck(bytes.NewReader(stderr))
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, p := range check.Problems {
+	    fmt.Printf("%s: %s\n", p.Path, p.Message)
+	}
+
+# Git Apply Format
+
+The --numstat output has one line per file:
+
+	<added> TAB <deleted> TAB <path>
+
+where binary files show "-" instead of line counts. The --summary output has
+one line per file creation, deletion, rename, copy, rewrite, or mode change,
+always prefixed with a single space (omitted below):
+
+	create mode 100644 <path>
+	rename <dir>/{<old> => <new>} (<score>%)
+	mode change 100644 => 100755 <path>
+
+For more information, see the Git documentation for [git apply].
+
+[git apply]: https://git-scm.com/docs/git-apply
+*/
+package apply
@@ -0,0 +1,44 @@
+package apply
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// Fuzz test for Parse and ParseZ functions
+func FuzzParse(f *testing.F) {
+	// Add some seed inputs
+	f.Add([]byte(sampleApplyOutput))
+	f.Add([]byte(sampleApplyZOutput))
+	fuzzseed.Add[[]byte](f, "apply")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Parser should never panic, only return an error for invalid input
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("Parse panicked with input %q: %v", data, r)
+			}
+		}()
+		Parse(bytes.NewReader(data))
+		ParseZ(bytes.NewReader(data))
+	})
+}
+
+// Fuzz test for ParseCheck function
+func FuzzParseCheck(f *testing.F) {
+	// Add some seed inputs
+	f.Add([]byte(sampleCheckOutput))
+	fuzzseed.Add[[]byte](f, "apply-check")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Parser should never panic
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("ParseCheck panicked with input %q: %v", data, r)
+			}
+		}()
+		ParseCheck(bytes.NewReader(data))
+	})
+}
@@ -0,0 +1,255 @@
+package apply
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mroth/porcelain/zscan"
+)
+
+// Parse parses the output of `git apply --numstat --summary`.
+//
+// Either flag may be used alone. Lines starting with a space are parsed as
+// summary lines, and all other lines as numstat lines.
+//
+// Path Handling: Paths containing special characters may be quoted by Git
+// according to core.quotePath configuration. This function preserves paths
+// exactly as provided by Git without unquoting.
+func Parse(r io.Reader) (*Report, error) {
+	var report Report
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if err := parseLine(scanner.Bytes(), &report); err != nil {
+			return nil, err
+		}
+	}
+	return &report, scanner.Err()
+}
+
+// ParseZ parses the output of `git apply --numstat --summary -z`.
+//
+// The -z flag only affects the numstat lines, which become NUL-terminated and
+// have their paths unquoted. Summary lines, which follow all numstat lines,
+// remain LF-terminated.
+func ParseZ(r io.Reader) (*Report, error) {
+	var report Report
+	scanner := bufio.NewScanner(r)
+	scanner.Split(zscan.ScanNUL)
+	for scanner.Scan() {
+		token := scanner.Bytes()
+		if len(token) > 0 && token[0] == ' ' {
+			// The trailing summary block is not NUL-terminated, so it is
+			// returned as a single final token containing all its lines.
+			for line := range bytes.Lines(token) {
+				if err := parseLine(bytes.TrimSuffix(line, []byte{'\n'}), &report); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if err := parseLine(token, &report); err != nil {
+			return nil, err
+		}
+	}
+	return &report, scanner.Err()
+}
+
+func parseLine(line []byte, report *Report) error {
+	if len(line) == 0 {
+		return nil
+	}
+	if line[0] == ' ' {
+		s, err := parseSummaryLine(line, report.Summary)
+		if err != nil {
+			return err
+		}
+		report.Summary = append(report.Summary, s)
+		return nil
+	}
+	n, err := parseNumStat(line)
+	if err != nil {
+		return err
+	}
+	report.NumStats = append(report.NumStats, n)
+	return nil
+}
+
+// Numstat lines have the following format:
+// <added>\t<deleted>\t<path>
+func parseNumStat(line []byte) (NumStat, error) {
+	var zero NumStat
+	fields := bytes.SplitN(line, []byte{'\t'}, 3)
+	if len(fields) < 3 || len(fields[2]) == 0 {
+		return zero, fmt.Errorf("invalid numstat line: %q", line)
+	}
+
+	path := string(fields[2])
+	if string(fields[0]) == "-" && string(fields[1]) == "-" {
+		return NumStat{Binary: true, Path: path}, nil
+	}
+
+	added, errA := strconv.Atoi(string(fields[0]))
+	deleted, errD := strconv.Atoi(string(fields[1]))
+	if errA != nil || errD != nil || added < 0 || deleted < 0 {
+		return zero, fmt.Errorf("invalid numstat line counts: %q", line)
+	}
+	return NumStat{Added: added, Deleted: deleted, Path: path}, nil
+}
+
+// Summary lines have one of the following formats, after the leading space:
+//
+//	create mode <mode> <path>
+//	delete mode <mode> <path>
+//	rename <orig> => <path> (<score>%)
+//	copy <orig> => <path> (<score>%)
+//	rewrite <path> (<score>%)
+//	mode change <old> => <new> <path>
+//	mode change <old> => <new>
+//
+// The final form, without a path, follows a rename or copy line and applies to
+// its path, so the previously parsed lines are needed to resolve it.
+func parseSummaryLine(line []byte, prev []SummaryLine) (SummaryLine, error) {
+	var zero SummaryLine
+	rest, ok := bytes.CutPrefix(line, []byte{' '})
+	if !ok {
+		return zero, fmt.Errorf("invalid summary line: %q", line)
+	}
+
+	switch {
+	case bytes.HasPrefix(rest, []byte("create ")):
+		return parseCreateDelete(SummaryCreate, rest[len("create "):], line)
+	case bytes.HasPrefix(rest, []byte("delete ")):
+		return parseCreateDelete(SummaryDelete, rest[len("delete "):], line)
+	case bytes.HasPrefix(rest, []byte("rename ")):
+		return parseRenameCopy(SummaryRename, rest[len("rename "):], line)
+	case bytes.HasPrefix(rest, []byte("copy ")):
+		return parseRenameCopy(SummaryCopy, rest[len("copy "):], line)
+	case bytes.HasPrefix(rest, []byte("rewrite ")):
+		path, score, err := cutScore(rest[len("rewrite "):])
+		if err != nil {
+			return zero, fmt.Errorf("invalid rewrite summary line %q: %w", line, err)
+		}
+		return SummaryLine{Kind: SummaryRewrite, Path: string(path), Score: score}, nil
+	case bytes.HasPrefix(rest, []byte("mode change ")):
+		return parseModeChange(rest[len("mode change "):], line, prev)
+	}
+	return zero, fmt.Errorf("unrecognized summary line: %q", line)
+}
+
+// <"mode" SP <mode> SP>? <path>
+func parseCreateDelete(kind SummaryKind, rest, line []byte) (SummaryLine, error) {
+	s := SummaryLine{Kind: kind}
+	if after, ok := bytes.CutPrefix(rest, []byte("mode ")); ok {
+		modeField, path, found := bytes.Cut(after, []byte{' '})
+		if !found {
+			return SummaryLine{}, fmt.Errorf("invalid %s summary line: %q", kind, line)
+		}
+		mode, err := parseFileMode(modeField)
+		if err != nil {
+			return SummaryLine{}, fmt.Errorf("invalid %s summary line %q: %w", kind, line, err)
+		}
+		s.Mode, rest = mode, path
+	}
+	if len(rest) == 0 {
+		return SummaryLine{}, fmt.Errorf("invalid %s summary line: %q", kind, line)
+	}
+	s.Path = string(rest)
+	return s, nil
+}
+
+// <orig> " => " <path> " (" <score> "%)"
+// <prefix> "{" <orig> " => " <path> "}" <suffix> " (" <score> "%)"
+func parseRenameCopy(kind SummaryKind, rest, line []byte) (SummaryLine, error) {
+	names, score, err := cutScore(rest)
+	if err != nil {
+		return SummaryLine{}, fmt.Errorf("invalid %s summary line %q: %w", kind, line, err)
+	}
+
+	orig, path, ok := expandRenamePaths(names)
+	if !ok {
+		return SummaryLine{}, fmt.Errorf("invalid %s summary line: %q", kind, line)
+	}
+
+	return SummaryLine{
+		Kind:     kind,
+		Path:     path,
+		OrigPath: orig,
+		Score:    score,
+	}, nil
+}
+
+// expandRenamePaths splits "<orig> => <path>" into its two paths. When the
+// paths share a leading or trailing part, git only prints it once, with the
+// differing middle wrapped in braces, e.g. "src/{old => new}/file.go". Either
+// side of the braces may be empty, e.g. "src/{ => sub}/file.go".
+func expandRenamePaths(names []byte) (orig, path string, ok bool) {
+	open := bytes.IndexByte(names, '{')
+	close := bytes.LastIndexByte(names, '}')
+	if open < 0 || close < open {
+		o, p, found := bytes.Cut(names, []byte(" => "))
+		return string(o), string(p), found
+	}
+
+	prefix, suffix := names[:open], names[close+1:]
+	o, p, found := bytes.Cut(names[open+1:close], []byte(" => "))
+	if !found {
+		return "", "", false
+	}
+	join := func(middle []byte) string {
+		if len(middle) == 0 && bytes.HasSuffix(prefix, []byte{'/'}) && bytes.HasPrefix(suffix, []byte{'/'}) {
+			return string(prefix) + string(suffix[1:])
+		}
+		return string(prefix) + string(middle) + string(suffix)
+	}
+	return join(o), join(p), true
+}
+
+// <old> " => " <new> (SP <path>)?
+func parseModeChange(rest, line []byte, prev []SummaryLine) (SummaryLine, error) {
+	oldField, after, found := bytes.Cut(rest, []byte(" => "))
+	if !found {
+		return SummaryLine{}, fmt.Errorf("invalid mode change summary line: %q", line)
+	}
+	newField, path, hasPath := bytes.Cut(after, []byte{' '})
+
+	oldMode, errO := parseFileMode(oldField)
+	newMode, errN := parseFileMode(newField)
+	if errO != nil || errN != nil {
+		return SummaryLine{}, fmt.Errorf("invalid mode change summary line: %q", line)
+	}
+
+	s := SummaryLine{Kind: SummaryModeChange, OldMode: oldMode, Mode: newMode}
+	switch {
+	case hasPath:
+		s.Path = string(path)
+	case len(prev) > 0 && (prev[len(prev)-1].Kind == SummaryRename || prev[len(prev)-1].Kind == SummaryCopy):
+		s.Path = prev[len(prev)-1].Path
+	default:
+		return SummaryLine{}, fmt.Errorf("mode change summary line without path: %q", line)
+	}
+	return s, nil
+}
+
+// cutScore splits a trailing " (<n>%)" similarity score from the field.
+func cutScore(field []byte) ([]byte, int, error) {
+	i := bytes.LastIndex(field, []byte(" ("))
+	if i < 0 || !bytes.HasSuffix(field, []byte("%)")) || i+2 > len(field)-2 {
+		return nil, 0, fmt.Errorf("missing score")
+	}
+	score, err := strconv.Atoi(string(field[i+2 : len(field)-2]))
+	if err != nil || score < 0 || score > 100 {
+		return nil, 0, fmt.Errorf("invalid score: %q", field[i+2:len(field)-2])
+	}
+	return field[:i], score, nil
+}
+
+func parseFileMode(field []byte) (FileMode, error) {
+	mode, err := strconv.ParseUint(string(field), 8, 32)
+	if err != nil {
+		return 0, err
+	}
+	return FileMode(mode), nil
+}
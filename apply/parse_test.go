@@ -0,0 +1,262 @@
+package apply
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// sampleApplyOutput is the output of `git apply --numstat --summary` for a
+// patch creating, deleting, renaming, and changing the mode of files.
+var sampleApplyOutput = "1\t0\tadded\n" +
+	"-\t-\tbin\n" +
+	"0\t0\td/b_f\n" +
+	"0\t1\tgone\n" +
+	"1\t2\tkeep\n" +
+	"0\t0\tsh\n" +
+	" create mode 100644 added\n" +
+	" rename d/{a/f => b_f} (100%)\n" +
+	" delete mode 100644 gone\n" +
+	" mode change 100644 => 100755 sh\n"
+
+// sampleApplyZOutput is the same as sampleApplyOutput, with the -z flag.
+var sampleApplyZOutput = "1\t0\tadded\x00" +
+	"-\t-\tbin\x00" +
+	"0\t0\td/b_f\x00" +
+	"0\t1\tgone\x00" +
+	"1\t2\tkeep\x00" +
+	"0\t0\tsh\x00" +
+	" create mode 100644 added\n" +
+	" rename d/{a/f => b_f} (100%)\n" +
+	" delete mode 100644 gone\n" +
+	" mode change 100644 => 100755 sh\n"
+
+var sampleReport = Report{
+	NumStats: []NumStat{
+		{Added: 1, Deleted: 0, Path: "added"},
+		{Binary: true, Path: "bin"},
+		{Added: 0, Deleted: 0, Path: "d/b_f"},
+		{Added: 0, Deleted: 1, Path: "gone"},
+		{Added: 1, Deleted: 2, Path: "keep"},
+		{Added: 0, Deleted: 0, Path: "sh"},
+	},
+	Summary: []SummaryLine{
+		{Kind: SummaryCreate, Path: "added", Mode: 0100644},
+		{Kind: SummaryRename, Path: "d/b_f", OrigPath: "d/a/f", Score: 100},
+		{Kind: SummaryDelete, Path: "gone", Mode: 0100644},
+		{Kind: SummaryModeChange, Path: "sh", OldMode: 0100644, Mode: 0100755},
+	},
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse(strings.NewReader(sampleApplyOutput))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if diff := cmp.Diff(&sampleReport, got); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseZ(t *testing.T) {
+	got, err := ParseZ(strings.NewReader(sampleApplyZOutput))
+	if err != nil {
+		t.Fatalf("ParseZ() error = %v", err)
+	}
+	if diff := cmp.Diff(&sampleReport, got); diff != "" {
+		t.Errorf("ParseZ() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseZ_NumstatOnly(t *testing.T) {
+	got, err := ParseZ(strings.NewReader("3\t1\tpath with\nnewline\x00"))
+	if err != nil {
+		t.Fatalf("ParseZ() error = %v", err)
+	}
+	want := &Report{NumStats: []NumStat{{Added: 3, Deleted: 1, Path: "path with\nnewline"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseZ() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	testcases := []struct {
+		name  string
+		input string
+	}{
+		{"numstat missing path", "1\t2\n"},
+		{"numstat invalid count", "x\t2\tfile\n"},
+		{"numstat negative count", "-1\t2\tfile\n"},
+		{"summary unrecognized", " frobnicate file\n"},
+		{"summary orphan mode change", " mode change 100644 => 100755\n"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tc.input)); err == nil {
+				t.Errorf("Parse(%q) expected error, got nil", tc.input)
+			}
+		})
+	}
+}
+
+func Test_parseNumStat(t *testing.T) {
+	testcases := []struct {
+		name    string
+		input   string
+		want    NumStat
+		wantErr bool
+	}{
+		{
+			name:  "text file",
+			input: "12\t3\tsrc/main.go",
+			want:  NumStat{Added: 12, Deleted: 3, Path: "src/main.go"},
+		},
+		{
+			name:  "binary file",
+			input: "-\t-\timage.png",
+			want:  NumStat{Binary: true, Path: "image.png"},
+		},
+		{
+			name:  "path with tab",
+			input: "1\t1\ta\tb",
+			want:  NumStat{Added: 1, Deleted: 1, Path: "a\tb"},
+		},
+		{
+			name:  "quoted path",
+			input: "1\t0\t\"t\\303\\251st\"",
+			want:  NumStat{Added: 1, Deleted: 0, Path: "\"t\\303\\251st\""},
+		},
+		{
+			name:    "half binary",
+			input:   "-\t3\tfile",
+			wantErr: true,
+		},
+		{
+			name:    "too few fields",
+			input:   "1\t2",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseNumStat([]byte(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseNumStat() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("parseNumStat() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_parseSummaryLine(t *testing.T) {
+	testcases := []struct {
+		name    string
+		input   string
+		prev    []SummaryLine
+		want    SummaryLine
+		wantErr bool
+	}{
+		{
+			name:  "create",
+			input: " create mode 100755 bin/run",
+			want:  SummaryLine{Kind: SummaryCreate, Path: "bin/run", Mode: 0100755},
+		},
+		{
+			name:  "create without mode",
+			input: " create file",
+			want:  SummaryLine{Kind: SummaryCreate, Path: "file"},
+		},
+		{
+			name:  "delete symlink",
+			input: " delete mode 120000 link",
+			want:  SummaryLine{Kind: SummaryDelete, Path: "link", Mode: 0120000},
+		},
+		{
+			name:  "rename without common prefix",
+			input: " rename old.txt => new.txt (87%)",
+			want:  SummaryLine{Kind: SummaryRename, Path: "new.txt", OrigPath: "old.txt", Score: 87},
+		},
+		{
+			name:  "rename with common prefix",
+			input: " rename src/{old => new}/file.go (100%)",
+			want:  SummaryLine{Kind: SummaryRename, Path: "src/new/file.go", OrigPath: "src/old/file.go", Score: 100},
+		},
+		{
+			name:  "rename into new subdirectory",
+			input: " rename src/{ => sub}/file.go (100%)",
+			want:  SummaryLine{Kind: SummaryRename, Path: "src/sub/file.go", OrigPath: "src/file.go", Score: 100},
+		},
+		{
+			name:  "copy with common prefix",
+			input: " copy lib/{a.go => b.go} (75%)",
+			want:  SummaryLine{Kind: SummaryCopy, Path: "lib/b.go", OrigPath: "lib/a.go", Score: 75},
+		},
+		{
+			name:  "rewrite",
+			input: " rewrite big.txt (92%)",
+			want:  SummaryLine{Kind: SummaryRewrite, Path: "big.txt", Score: 92},
+		},
+		{
+			name:  "mode change",
+			input: " mode change 100644 => 100755 script.sh",
+			want:  SummaryLine{Kind: SummaryModeChange, Path: "script.sh", OldMode: 0100644, Mode: 0100755},
+		},
+		{
+			name:  "mode change following rename",
+			input: " mode change 100644 => 100755",
+			prev:  []SummaryLine{{Kind: SummaryRename, Path: "new.sh", OrigPath: "old.sh", Score: 100}},
+			want:  SummaryLine{Kind: SummaryModeChange, Path: "new.sh", OldMode: 0100644, Mode: 0100755},
+		},
+		{
+			name:    "rename missing score",
+			input:   " rename a => b",
+			wantErr: true,
+		},
+		{
+			name:    "rename score out of range",
+			input:   " rename a => b (101%)",
+			wantErr: true,
+		},
+		{
+			name:    "rename missing arrow",
+			input:   " rename a b (100%)",
+			wantErr: true,
+		},
+		{
+			name:    "create invalid mode",
+			input:   " create mode 1009 file",
+			wantErr: true,
+		},
+		{
+			name:    "missing leading space",
+			input:   "create mode 100644 file",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseSummaryLine([]byte(tc.input), tc.prev)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseSummaryLine() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("parseSummaryLine() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSummaryKind_String(t *testing.T) {
+	if got := SummaryModeChange.String(); got != "mode change" {
+		t.Errorf("String() = %q, want %q", got, "mode change")
+	}
+	if got := SummaryKind(42).String(); got != "SummaryKind(42)" {
+		t.Errorf("String() = %q, want %q", got, "SummaryKind(42)")
+	}
+}
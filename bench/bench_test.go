@@ -0,0 +1,246 @@
+package bench
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+var table = flag.Bool("table", false, "print a table of benchmark results from TestResultsTable")
+
+// sizes are the numbers of tracked files of the benchmark repositories.
+var sizes = []int{100, 1000, 10000}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if _, err := exec.LookPath("git"); err != nil {
+		fmt.Fprintln(os.Stderr, "bench: git not found in PATH")
+		os.Exit(1)
+	}
+	dir, err := os.MkdirTemp("", "porcelain-bench-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	reposDir = dir
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// reposDir holds the benchmark repositories, created on first use.
+var (
+	reposDir string
+	reposMu  sync.Mutex
+	repos    = map[int]string{}
+)
+
+// repo returns the directory of a repository with n tracked files, creating
+// it if needed.
+func repo(tb testing.TB, n int) string {
+	tb.Helper()
+	reposMu.Lock()
+	defer reposMu.Unlock()
+	if dir, ok := repos[n]; ok {
+		return dir
+	}
+	dir := filepath.Join(reposDir, fmt.Sprintf("files-%d", n))
+	if err := createRepo(dir, n); err != nil {
+		tb.Fatalf("creating repository: %v", err)
+	}
+	repos[n] = dir
+	return dir
+}
+
+// createRepo creates a repository with n committed files, and changes to a
+// few percent of them.
+func createRepo(dir string, n int) error {
+	git := &gitexec.Git{Dir: dir, Env: []string{
+		"HOME=" + dir,
+		"XDG_CONFIG_HOME=" + dir,
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_AUTHOR_NAME=Porcelain Bench",
+		"GIT_AUTHOR_EMAIL=bench@example.com",
+		"GIT_COMMITTER_NAME=Porcelain Bench",
+		"GIT_COMMITTER_EMAIL=bench@example.com",
+	}}
+	ctx := context.Background()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if _, err := git.Run(ctx, "init", "--quiet"); err != nil {
+		return err
+	}
+	for i := range n {
+		if err := writeFile(dir, i, "original"); err != nil {
+			return err
+		}
+	}
+	if _, err := git.Run(ctx, "add", "--all"); err != nil {
+		return err
+	}
+	if _, err := git.Run(ctx, "commit", "--quiet", "-m", "initial"); err != nil {
+		return err
+	}
+
+	var staged []string
+	for i := range n {
+		switch {
+		case i%100 == 1:
+			if err := writeFile(dir, i, "modified"); err != nil {
+				return err
+			}
+		case i%100 == 2:
+			if err := writeFile(dir, i, "staged"); err != nil {
+				return err
+			}
+			staged = append(staged, fileName(i))
+		case i%200 == 3:
+			if err := os.Remove(filepath.Join(dir, fileName(i))); err != nil {
+				return err
+			}
+		case i%100 == 4:
+			if err := writeFile(dir, n+i, "untracked"); err != nil {
+				return err
+			}
+		}
+	}
+	if len(staged) > 0 {
+		if _, err := git.Run(ctx, append([]string{"add", "--"}, staged...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileName returns the path of the i-th file, in directories of 100.
+func fileName(i int) string {
+	return fmt.Sprintf("dir%03d/file%05d.txt", i/100, i)
+}
+
+func writeFile(dir string, i int, content string) error {
+	path := filepath.Join(dir, filepath.FromSlash(fileName(i)))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content+"\n"), 0o644)
+}
+
+// porcelainStatus gets the status by running git and parsing its output.
+// Untracked files are listed individually, rather than by directory, as
+// go-git does.
+func porcelainStatus(dir string) (*statusv2.Status, error) {
+	return statusv2.Get(context.Background(), gitexec.New(dir), "--untracked-files=all")
+}
+
+// goGitStatus gets the status natively with go-git.
+func goGitStatus(dir string) (git.Status, error) {
+	r, err := git.PlainOpen(dir)
+	if err != nil {
+		return nil, err
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	return w.Status()
+}
+
+// TestSameStatus checks that both approaches find the same changes, so that
+// the benchmarks compare equivalent work.
+func TestSameStatus(t *testing.T) {
+	dir := repo(t, sizes[0])
+	s, err := porcelainStatus(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, e := range s.Entries {
+		switch e := e.(type) {
+		case statusv2.ChangedEntry:
+			got[e.Path] = strings.ReplaceAll(e.XY.String(), ".", " ")
+		case statusv2.UntrackedEntry:
+			got[e.Path] = "??"
+		default:
+			t.Errorf("unexpected entry %+v", e)
+		}
+	}
+
+	gs, err := goGitStatus(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{}
+	for path, fs := range gs {
+		want[path] = string(fs.Staging) + string(fs.Worktree)
+	}
+	if len(got) == 0 || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("porcelain status = %v\ngo-git status = %v", got, want)
+	}
+}
+
+func BenchmarkPorcelain(b *testing.B) {
+	for _, n := range sizes {
+		b.Run(fmt.Sprintf("files=%d", n), func(b *testing.B) {
+			benchmarkPorcelain(b, repo(b, n))
+		})
+	}
+}
+
+func BenchmarkGoGit(b *testing.B) {
+	for _, n := range sizes {
+		b.Run(fmt.Sprintf("files=%d", n), func(b *testing.B) {
+			benchmarkGoGit(b, repo(b, n))
+		})
+	}
+}
+
+func benchmarkPorcelain(b *testing.B, dir string) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := porcelainStatus(dir); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkGoGit(b *testing.B, dir string) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := goGitStatus(dir); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestResultsTable runs the benchmarks and prints their results side by
+// side, when run with -table.
+func TestResultsTable(t *testing.T) {
+	if !*table {
+		t.Skip("run with -table to print the results table")
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "files\tporcelain\tgo-git\tspeedup\tporcelain allocs\tgo-git allocs\t")
+	for _, n := range sizes {
+		dir := repo(t, n)
+		p := testing.Benchmark(func(b *testing.B) { benchmarkPorcelain(b, dir) })
+		g := testing.Benchmark(func(b *testing.B) { benchmarkGoGit(b, dir) })
+		fmt.Fprintf(w, "%d\t%v\t%v\t%.1fx\t%d\t%d\t\n", n,
+			time.Duration(p.NsPerOp()), time.Duration(g.NsPerOp()),
+			float64(g.NsPerOp())/float64(p.NsPerOp()),
+			p.AllocsPerOp(), g.AllocsPerOp())
+	}
+	w.Flush()
+}
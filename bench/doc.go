@@ -0,0 +1,25 @@
+/*
+Package bench compares the cost of getting the status of a repository by
+running git and parsing its porcelain output, as this module does, with
+computing it natively in Go with [go-git], on the same repositories.
+
+It is a separate module, to keep go-git out of the dependencies of the
+library, and holds only benchmarks and tests. Run the benchmarks with:
+
+	cd bench && go test -bench .
+
+or print a table of the results, comparing the approaches for each size of
+repository, with:
+
+	cd bench && go test -run TestResultsTable -table
+
+The allocations reported are those of the Go process only, so exclude the
+work of the git process run for the porcelain approach.
+
+Each repository has its files in directories of 100, with 1% of them
+modified, 1% staged, 0.5% deleted and 1% untracked. They are created with git
+in a temporary directory, which requires git in PATH.
+
+[go-git]: https://github.com/go-git/go-git
+*/
+package bench
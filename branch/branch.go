@@ -0,0 +1,41 @@
+package branch
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// Branch represents a single branch listed by `git branch`.
+type Branch struct {
+	Name     string // short name, e.g. "main" or "origin/main"; for a detached HEAD, git's description of it
+	Ref      string // full ref name, e.g. "refs/heads/main"; empty for a detached HEAD
+	Object   string // object name of the commit the branch points to
+	Subject  string // subject of the commit the branch points to
+	Current  bool   // whether the branch is checked out in the current worktree
+	Detached bool   // whether the entry represents a detached HEAD rather than a branch
+	SymRef   string // full ref name the branch refers to, if symbolic (e.g. "refs/remotes/origin/HEAD")
+	Worktree string // path of the worktree the branch is checked out in, if any
+
+	Upstream     string // full ref name of the upstream branch, e.g. "refs/remotes/origin/main"; empty if none
+	UpstreamGone bool   // whether the configured upstream branch no longer exists
+	Ahead        int    // commits on the branch not on its upstream
+	Behind       int    // commits on the upstream not on the branch
+}
+
+// IsRemote reports whether the branch is a remote-tracking branch.
+func (b Branch) IsRemote() bool {
+	return strings.HasPrefix(b.Ref, "refs/remotes/")
+}
+
+// List runs `git branch` with args (e.g. "--all" or "--merged") and returns
+// the branches listed.
+func List(ctx context.Context, git gitexec.Runner, args ...string) ([]Branch, error) {
+	out, err := git.Run(ctx, Args(args...)...)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(bytes.NewReader(out))
+}
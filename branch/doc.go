@@ -0,0 +1,35 @@
+/*
+Package branch parses `git branch` output produced with a package-defined format.
+
+The default output of `git branch -vv` is intended for humans: it truncates
+subjects, aligns columns, and mixes upstream tracking information into
+brackets. This package instead defines a [Format] for `git branch --format`
+that captures every branch's ref name, commit, upstream, ahead/behind counts,
+HEAD marker, and worktree checkout in a form that can be parsed
+unambiguously.
+
+# Basic Usage
+
+[List] runs git through a [gitexec.Runner] and returns the branches:
+
+	branches, err := branch.List(ctx, gitexec.New("."), "--all")
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, b := range branches {
+	    fmt.Printf("%s ahead %d behind %d\n", b.Name, b.Ahead, b.Behind)
+	}
+
+To run git yourself, use the arguments returned by [Args] and pass the output
+to [Parse]. The same fields can be read from `git for-each-ref`, which
+accepts the same format, although it does not list a detached HEAD:
+
+	cmd := exec.Command("git", "for-each-ref", "--format="+branch.Format, "refs/heads")
+
+For more information, see the Git documentation for [git branch] and the
+field names of [git for-each-ref].
+
+[git branch]: https://git-scm.com/docs/git-branch
+[git for-each-ref]: https://git-scm.com/docs/git-for-each-ref#_field_names
+*/
+package branch
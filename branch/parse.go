@@ -0,0 +1,121 @@
+package branch
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format is the `git branch --format` string understood by this package.
+//
+// Fields are NUL-separated, and each branch is terminated by LF. Ref names and
+// paths cannot contain either byte, and commit subjects are single lines.
+const Format = "%(HEAD)%00%(refname)%00%(objectname)%00%(symref)%00%(upstream)%00%(upstream:track,nobracket)%00%(worktreepath)%00%(subject)"
+
+const numFields = 8
+
+// Args returns the git arguments for listing branches in [Format], followed
+// by args, which may contain other `git branch` listing options and patterns.
+func Args(args ...string) []string {
+	return append([]string{"branch", "--format=" + Format}, args...)
+}
+
+// Parse parses the output of `git branch` produced with [Args].
+func Parse(r io.Reader) ([]Branch, error) {
+	var branches []Branch
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		b, err := parseBranch(line)
+		if err != nil {
+			return nil, err
+		}
+		branches = append(branches, b)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
+// Branches have the following format, where fields are separated by NUL:
+// <head> <refname> <object> <symref> <upstream> <track> <worktree> <subject>
+func parseBranch(line []byte) (Branch, error) {
+	var zero Branch
+	fields := bytes.Split(line, []byte{'\x00'})
+	if len(fields) != numFields {
+		return zero, fmt.Errorf("invalid branch line: expected %d fields, got %d: %q", numFields, len(fields), line)
+	}
+
+	b := Branch{
+		Object:   string(fields[2]),
+		SymRef:   string(fields[3]),
+		Upstream: string(fields[4]),
+		Worktree: string(fields[6]),
+		Subject:  string(fields[7]),
+	}
+
+	switch head := string(fields[0]); head {
+	case "*":
+		b.Current = true
+	case " ":
+	default:
+		return zero, fmt.Errorf("invalid branch HEAD marker: %q", head)
+	}
+
+	refname := string(fields[1])
+	switch {
+	case strings.HasPrefix(refname, "("):
+		// detached HEAD, e.g. "(HEAD detached at 8390b27)"
+		b.Name = refname
+		b.Detached = true
+	case strings.HasPrefix(refname, "refs/heads/"):
+		b.Name = strings.TrimPrefix(refname, "refs/heads/")
+		b.Ref = refname
+	case strings.HasPrefix(refname, "refs/remotes/"):
+		b.Name = strings.TrimPrefix(refname, "refs/remotes/")
+		b.Ref = refname
+	default:
+		return zero, fmt.Errorf("invalid branch ref name: %q", refname)
+	}
+
+	if err := parseTrack(string(fields[5]), &b); err != nil {
+		return zero, err
+	}
+	return b, nil
+}
+
+// parseTrack parses the upstream tracking information, which is one of
+// "ahead <n>", "behind <n>", "ahead <n>, behind <n>", "gone", or empty if the
+// branch is up to date with its upstream (or has none).
+func parseTrack(track string, b *Branch) error {
+	if track == "" {
+		return nil
+	}
+	if track == "gone" {
+		b.UpstreamGone = true
+		return nil
+	}
+	for part := range strings.SplitSeq(track, ", ") {
+		kind, count, found := strings.Cut(part, " ")
+		n, err := strconv.Atoi(count)
+		if !found || err != nil || n < 0 {
+			return fmt.Errorf("invalid branch tracking info: %q", track)
+		}
+		switch kind {
+		case "ahead":
+			b.Ahead = n
+		case "behind":
+			b.Behind = n
+		default:
+			return fmt.Errorf("invalid branch tracking info: %q", track)
+		}
+	}
+	return nil
+}
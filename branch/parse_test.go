@@ -0,0 +1,137 @@
+package branch
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Output of `git branch --all` with [Format] in a clone with a detached HEAD,
+// a branch checked out in another worktree, and a gone upstream.
+const sampleOutput = "*\x00(HEAD detached from 8390b27)\x0022026e2c6b51d7bae6672ce4714f154e629ffd04\x00\x00\x00\x00\x00one\n" +
+	" \x00refs/heads/ahead\x008390b276a0f14289da09005c10eeab2907e643ba\x00\x00refs/remotes/origin/master\x00ahead 1, behind 1\x00\x00three\n" +
+	" \x00refs/heads/feat\x005590656afe2b7fcc6a3fb88a01a06aa1c84524c5\x00\x00refs/remotes/origin/gone-src\x00gone\x00/tmp/br-wt\x00two\n" +
+	" \x00refs/heads/master\x0022026e2c6b51d7bae6672ce4714f154e629ffd04\x00\x00refs/remotes/origin/master\x00behind 1\x00\x00one\n" +
+	" \x00refs/heads/old\x005590656afe2b7fcc6a3fb88a01a06aa1c84524c5\x00\x00refs/remotes/origin/master\x00\x00\x00two\n" +
+	" \x00refs/remotes/origin/HEAD\x005590656afe2b7fcc6a3fb88a01a06aa1c84524c5\x00refs/remotes/origin/master\x00\x00\x00\x00two\n" +
+	" \x00refs/remotes/origin/master\x005590656afe2b7fcc6a3fb88a01a06aa1c84524c5\x00\x00\x00\x00\x00two\n"
+
+func TestParse(t *testing.T) {
+	want := []Branch{
+		{
+			Name:     "(HEAD detached from 8390b27)",
+			Object:   "22026e2c6b51d7bae6672ce4714f154e629ffd04",
+			Subject:  "one",
+			Current:  true,
+			Detached: true,
+		},
+		{
+			Name:     "ahead",
+			Ref:      "refs/heads/ahead",
+			Object:   "8390b276a0f14289da09005c10eeab2907e643ba",
+			Subject:  "three",
+			Upstream: "refs/remotes/origin/master",
+			Ahead:    1,
+			Behind:   1,
+		},
+		{
+			Name:         "feat",
+			Ref:          "refs/heads/feat",
+			Object:       "5590656afe2b7fcc6a3fb88a01a06aa1c84524c5",
+			Subject:      "two",
+			Worktree:     "/tmp/br-wt",
+			Upstream:     "refs/remotes/origin/gone-src",
+			UpstreamGone: true,
+		},
+		{
+			Name:     "master",
+			Ref:      "refs/heads/master",
+			Object:   "22026e2c6b51d7bae6672ce4714f154e629ffd04",
+			Subject:  "one",
+			Upstream: "refs/remotes/origin/master",
+			Behind:   1,
+		},
+		{
+			Name:     "old",
+			Ref:      "refs/heads/old",
+			Object:   "5590656afe2b7fcc6a3fb88a01a06aa1c84524c5",
+			Subject:  "two",
+			Upstream: "refs/remotes/origin/master",
+		},
+		{
+			Name:    "origin/HEAD",
+			Ref:     "refs/remotes/origin/HEAD",
+			Object:  "5590656afe2b7fcc6a3fb88a01a06aa1c84524c5",
+			Subject: "two",
+			SymRef:  "refs/remotes/origin/master",
+		},
+		{
+			Name:    "origin/master",
+			Ref:     "refs/remotes/origin/master",
+			Object:  "5590656afe2b7fcc6a3fb88a01a06aa1c84524c5",
+			Subject: "two",
+		},
+	}
+
+	got, err := Parse(strings.NewReader(sampleOutput))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+	if got[1].IsRemote() || !got[6].IsRemote() {
+		t.Errorf("IsRemote() = %v, %v; want false, true", got[1].IsRemote(), got[6].IsRemote())
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"default format", "* main\n"},
+		{"too few fields", "*\x00refs/heads/main\x00abc\n"},
+		{"invalid HEAD marker", "+\x00refs/heads/main\x00abc\x00\x00\x00\x00\x00subject\n"},
+		{"invalid ref name", " \x00refs/tags/v1\x00abc\x00\x00\x00\x00\x00subject\n"},
+		{"invalid tracking info", " \x00refs/heads/main\x00abc\x00\x00refs/remotes/origin/main\x00ahead one\x00\x00subject\n"},
+		{"unknown tracking info", " \x00refs/heads/main\x00abc\x00\x00refs/remotes/origin/main\x00diverged 1\x00\x00subject\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.input)); err == nil {
+				t.Error("Parse() succeeded, want error")
+			}
+		})
+	}
+}
+
+// fakeRunner is a gitexec.Runner returning canned results, recording the
+// arguments it was called with.
+type fakeRunner struct {
+	out  string
+	err  error
+	args []string
+}
+
+func (f *fakeRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+	f.args = args
+	return []byte(f.out), f.err
+}
+
+func TestList(t *testing.T) {
+	git := &fakeRunner{out: "*\x00refs/heads/main\x00abc\x00\x00\x00\x00/src/repo\x00subject\n"}
+	got, err := List(context.Background(), git, "--all")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []Branch{{Name: "main", Ref: "refs/heads/main", Object: "abc", Subject: "subject", Current: true, Worktree: "/src/repo"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("List() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"branch", "--format=" + Format, "--all"}, git.args); diff != "" {
+		t.Errorf("List() args mismatch (-want +got):\n%s", diff)
+	}
+}
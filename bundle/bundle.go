@@ -0,0 +1,23 @@
+package bundle
+
+// Ref represents a ref contained in a bundle.
+type Ref struct {
+	OID  string // object name the ref points to
+	Name string // full ref name, e.g. "refs/heads/main"
+}
+
+// Prerequisite represents a commit that must exist in the repository for a
+// bundle to be unbundled.
+type Prerequisite struct {
+	OID     string // object name of the required commit
+	Comment string // optional comment recorded in the bundle (often empty)
+}
+
+// Verification represents the report of `git bundle verify`.
+type Verification struct {
+	Refs          []Ref          // refs contained in the bundle
+	Prerequisites []Prerequisite // commits required by the bundle; empty if Complete
+	Complete      bool           // true if the bundle records a complete history
+	HashAlgorithm string         // object format of the bundle, e.g. "sha1" or "sha256"
+	Filter        string         // object filter the bundle was created with, if any
+}
@@ -0,0 +1,33 @@
+/*
+Package bundle parses the output of `git bundle list-heads` and `git bundle verify`.
+
+Bundles package refs and objects into a single file, for backups and for
+transferring history between repositories without a network connection.
+
+# Basic Usage
+
+[ParseListHeads] takes an [io.Reader] containing `git bundle list-heads`
+output:
+
+	r := bytes.NewReader(gitBundleListHeadsOutput)
+	refs, err := bundle.ParseListHeads(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+[ParseVerify] parses the report `git bundle verify` writes to stdout,
+describing the refs a bundle contains and the prerequisite commits it
+requires. When verification fails because the repository lacks some of those
+prerequisites, [ParseMissing] parses the commits git reports on stderr.
+
+# Localization
+
+The verify report is written for humans, and its messages are translated
+according to the user's locale. Run git with LC_ALL=C (or LANGUAGE=C) to
+ensure the English messages this package expects.
+
+For more information, see the Git documentation for [git bundle].
+
+[git bundle]: https://git-scm.com/docs/git-bundle
+*/
+package bundle
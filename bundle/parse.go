@@ -0,0 +1,134 @@
+package bundle
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// ParseListHeads parses the output of `git bundle list-heads`.
+//
+// Each line has the form "<oid> <refname>".
+func ParseListHeads(r io.Reader) ([]Ref, error) {
+	var refs []Ref
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		ref, err := parseRef(line)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, scanner.Err()
+}
+
+// verify report section headings, which are followed by a list of objects
+const (
+	sectionNone = iota
+	sectionRefs
+	sectionPrerequisites
+)
+
+// ParseVerify parses the report written to stdout by `git bundle verify`.
+//
+// The report is only written when verification succeeds; the "is okay" line
+// git writes to stderr is not part of it. See the package documentation
+// regarding localization.
+func ParseVerify(r io.Reader) (*Verification, error) {
+	var v Verification
+	section := sectionNone
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		if rest, ok := bytes.CutPrefix(line, []byte("The bundle ")); ok {
+			switch {
+			case bytes.HasPrefix(rest, []byte("contains ")):
+				section = sectionRefs
+			case bytes.HasPrefix(rest, []byte("requires ")):
+				section = sectionPrerequisites
+			case bytes.Equal(rest, []byte("records a complete history.")):
+				section = sectionNone
+				v.Complete = true
+			case bytes.HasPrefix(rest, []byte("uses this hash algorithm: ")):
+				section = sectionNone
+				v.HashAlgorithm = string(rest[len("uses this hash algorithm: "):])
+			case bytes.HasPrefix(rest, []byte("uses this filter: ")):
+				section = sectionNone
+				v.Filter = string(rest[len("uses this filter: "):])
+			default:
+				return nil, fmt.Errorf("unrecognized bundle verify line: %q", line)
+			}
+			continue
+		}
+
+		switch section {
+		case sectionRefs:
+			ref, err := parseRef(line)
+			if err != nil {
+				return nil, err
+			}
+			v.Refs = append(v.Refs, ref)
+		case sectionPrerequisites:
+			v.Prerequisites = append(v.Prerequisites, parsePrerequisite(line))
+		default:
+			return nil, fmt.Errorf("unexpected bundle verify line: %q", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// ParseMissing parses the prerequisite commits reported missing on stderr by
+// `git bundle verify` (or `git bundle unbundle`) when it fails, in the form:
+//
+//	error: Repository lacks these prerequisite commits:
+//	error: <oid> <comment>
+//
+// Other lines are ignored. An empty result means no missing commits were
+// reported.
+func ParseMissing(r io.Reader) ([]Prerequisite, error) {
+	var missing []Prerequisite
+	inList := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, ok := bytes.CutPrefix(scanner.Bytes(), []byte("error: "))
+		if !ok {
+			inList = false
+			continue
+		}
+		if bytes.HasPrefix(line, []byte("Repository lacks these prerequisite commits")) {
+			inList = true
+			continue
+		}
+		if inList {
+			missing = append(missing, parsePrerequisite(line))
+		}
+	}
+	return missing, scanner.Err()
+}
+
+// <oid> SP <refname>
+func parseRef(line []byte) (Ref, error) {
+	oid, name, found := bytes.Cut(line, []byte{' '})
+	if !found || len(oid) == 0 || len(name) == 0 {
+		return Ref{}, fmt.Errorf("invalid bundle ref line: %q", line)
+	}
+	return Ref{OID: string(oid), Name: string(name)}, nil
+}
+
+// <oid> (SP <comment>)?
+func parsePrerequisite(line []byte) Prerequisite {
+	oid, comment, _ := bytes.Cut(line, []byte{' '})
+	return Prerequisite{OID: string(oid), Comment: string(comment)}
+}
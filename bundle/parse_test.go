@@ -0,0 +1,150 @@
+package bundle
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseListHeads(t *testing.T) {
+	testcases := []struct {
+		name    string
+		input   string
+		want    []Ref
+		wantErr bool
+	}{
+		{
+			name: "multiple refs",
+			input: "97516266cf63ca9f9c2ad45010ccbda99a87e91c refs/heads/main\n" +
+				"ac38b1b7893d9097854d1b2bf0d0ac6b261378dd refs/heads/clean1\n" +
+				"ac38b1b7893d9097854d1b2bf0d0ac6b261378dd HEAD\n",
+			want: []Ref{
+				{OID: "97516266cf63ca9f9c2ad45010ccbda99a87e91c", Name: "refs/heads/main"},
+				{OID: "ac38b1b7893d9097854d1b2bf0d0ac6b261378dd", Name: "refs/heads/clean1"},
+				{OID: "ac38b1b7893d9097854d1b2bf0d0ac6b261378dd", Name: "HEAD"},
+			},
+		},
+		{
+			name:  "empty",
+			input: "",
+		},
+		{
+			name:    "missing ref name",
+			input:   "97516266cf63ca9f9c2ad45010ccbda99a87e91c\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseListHeads(strings.NewReader(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseListHeads() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseListHeads() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseVerify(t *testing.T) {
+	testcases := []struct {
+		name    string
+		input   string
+		want    *Verification
+		wantErr bool
+	}{
+		{
+			name: "complete history",
+			input: "The bundle contains these 2 refs:\n" +
+				"97516266cf63ca9f9c2ad45010ccbda99a87e91c refs/heads/main\n" +
+				"ac38b1b7893d9097854d1b2bf0d0ac6b261378dd refs/heads/clean1\n" +
+				"The bundle records a complete history.\n" +
+				"The bundle uses this hash algorithm: sha1\n",
+			want: &Verification{
+				Refs: []Ref{
+					{OID: "97516266cf63ca9f9c2ad45010ccbda99a87e91c", Name: "refs/heads/main"},
+					{OID: "ac38b1b7893d9097854d1b2bf0d0ac6b261378dd", Name: "refs/heads/clean1"},
+				},
+				Complete:      true,
+				HashAlgorithm: "sha1",
+			},
+		},
+		{
+			name: "incremental bundle",
+			input: "The bundle contains this ref:\n" +
+				"ac38b1b7893d9097854d1b2bf0d0ac6b261378dd refs/heads/clean1\n" +
+				"The bundle requires these 2 refs:\n" +
+				"df6a620370d719a420a039a14ab14f342d3438f7 \n" +
+				"97516266cf63ca9f9c2ad45010ccbda99a87e91c commit subject\n" +
+				"The bundle uses this hash algorithm: sha256\n" +
+				"The bundle uses this filter: blob:none\n",
+			want: &Verification{
+				Refs: []Ref{
+					{OID: "ac38b1b7893d9097854d1b2bf0d0ac6b261378dd", Name: "refs/heads/clean1"},
+				},
+				Prerequisites: []Prerequisite{
+					{OID: "df6a620370d719a420a039a14ab14f342d3438f7"},
+					{OID: "97516266cf63ca9f9c2ad45010ccbda99a87e91c", Comment: "commit subject"},
+				},
+				HashAlgorithm: "sha256",
+				Filter:        "blob:none",
+			},
+		},
+		{
+			name:    "unrecognized heading",
+			input:   "The bundle is written in Klingon.\n",
+			wantErr: true,
+		},
+		{
+			name:    "object line outside of a section",
+			input:   "97516266cf63ca9f9c2ad45010ccbda99a87e91c refs/heads/main\n",
+			wantErr: true,
+		},
+		{
+			name:    "translated output",
+			input:   "Das Paket enthält diese Referenz:\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseVerify(strings.NewReader(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseVerify() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseVerify() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseMissing(t *testing.T) {
+	input := "error: Repository lacks these prerequisite commits:\n" +
+		"error: df6a620370d719a420a039a14ab14f342d3438f7 \n" +
+		"error: 97516266cf63ca9f9c2ad45010ccbda99a87e91c commit subject\n"
+
+	got, err := ParseMissing(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseMissing() error = %v", err)
+	}
+	want := []Prerequisite{
+		{OID: "df6a620370d719a420a039a14ab14f342d3438f7"},
+		{OID: "97516266cf63ca9f9c2ad45010ccbda99a87e91c", Comment: "commit subject"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseMissing() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = ParseMissing(strings.NewReader("error: could not open '../nope.bundle'\n"))
+	if err != nil {
+		t.Fatalf("ParseMissing() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("ParseMissing() = %+v, want none", got)
+	}
+}
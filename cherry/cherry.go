@@ -0,0 +1,84 @@
+package cherry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// Commit represents a single commit listed by `git cherry`.
+type Commit struct {
+	InUpstream bool   // whether an equivalent change exists upstream ("-")
+	OID        string // object name of the commit
+	Subject    string // commit subject; only present with -v
+}
+
+// Parse parses the output of `git cherry`, with or without -v.
+func Parse(r io.Reader) ([]Commit, error) {
+	var commits []Commit
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		c, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+// Lines have the following format:
+// <+|-> SP <commit> [SP <subject>]
+func parseLine(line string) (Commit, error) {
+	if len(line) < 3 || line[1] != ' ' {
+		return Commit{}, fmt.Errorf("invalid cherry line: %q", line)
+	}
+	var c Commit
+	switch line[0] {
+	case '-':
+		c.InUpstream = true
+	case '+':
+	default:
+		return Commit{}, fmt.Errorf("invalid cherry line: unknown marker %q: %q", line[0], line)
+	}
+	c.OID, c.Subject, _ = strings.Cut(line[2:], " ")
+	if c.OID == "" {
+		return Commit{}, fmt.Errorf("invalid cherry line: missing commit: %q", line)
+	}
+	return c, nil
+}
+
+// List runs `git cherry -v` to compare head against upstream, and returns the
+// commits of head not in upstream, oldest first.
+//
+// If head is empty, HEAD is used. If upstream is empty, the upstream branch
+// of head is used.
+func List(ctx context.Context, git gitexec.Runner, upstream, head string) ([]Commit, error) {
+	if upstream == "" && head != "" {
+		upstream = head + "@{upstream}"
+	}
+	args := []string{"cherry", "-v"}
+	if upstream != "" {
+		args = append(args, upstream)
+	}
+	if head != "" {
+		args = append(args, head)
+	}
+	out, err := git.Run(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(bytes.NewReader(out))
+}
@@ -0,0 +1,106 @@
+package cherry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Commit
+	}{
+		{
+			name:  "verbose",
+			input: "- 6fbeb104b0b2520129e108e7d29ca02e672d3cb5 add x (dup)\n+ bee1cb0cd7eea5277efba7e9abc166fabf5a408b add y\n",
+			want: []Commit{
+				{InUpstream: true, OID: "6fbeb104b0b2520129e108e7d29ca02e672d3cb5", Subject: "add x (dup)"},
+				{InUpstream: false, OID: "bee1cb0cd7eea5277efba7e9abc166fabf5a408b", Subject: "add y"},
+			},
+		},
+		{
+			name:  "plain",
+			input: "- 6fbeb104b0b2520129e108e7d29ca02e672d3cb5\n+ bee1cb0cd7eea5277efba7e9abc166fabf5a408b\n",
+			want: []Commit{
+				{InUpstream: true, OID: "6fbeb104b0b2520129e108e7d29ca02e672d3cb5"},
+				{InUpstream: false, OID: "bee1cb0cd7eea5277efba7e9abc166fabf5a408b"},
+			},
+		},
+		{
+			name:  "abbreviated",
+			input: "+ bee1cb0 add y\n",
+			want:  []Commit{{OID: "bee1cb0", Subject: "add y"}},
+		},
+		{
+			name:  "nothing to pick",
+			input: "",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, input := range []string{
+		"bee1cb0cd7eea5277efba7e9abc166fabf5a408b add y\n",
+		"* bee1cb0 add y\n",
+		"+\n",
+		"+  add y\n",
+	} {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", input)
+		}
+	}
+}
+
+// fakeRunner is a gitexec.Runner returning canned results, recording the
+// arguments it was called with.
+type fakeRunner struct {
+	out  string
+	err  error
+	args []string
+}
+
+func (f *fakeRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+	f.args = args
+	return []byte(f.out), f.err
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		upstream, head string
+		wantArgs       []string
+	}{
+		{"", "", []string{"cherry", "-v"}},
+		{"main", "", []string{"cherry", "-v", "main"}},
+		{"main", "topic", []string{"cherry", "-v", "main", "topic"}},
+		{"", "topic", []string{"cherry", "-v", "topic@{upstream}", "topic"}},
+	}
+	for _, tt := range tests {
+		git := &fakeRunner{out: "+ bee1cb0 add y\n"}
+		got, err := List(context.Background(), git, tt.upstream, tt.head)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if diff := cmp.Diff([]Commit{{OID: "bee1cb0", Subject: "add y"}}, got); diff != "" {
+			t.Errorf("List() mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(tt.wantArgs, git.args); diff != "" {
+			t.Errorf("List(%q, %q) args mismatch (-want +got):\n%s", tt.upstream, tt.head, diff)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Package cherry parses the output of `git cherry`.
+
+`git cherry` lists the commits of a branch, and whether an equivalent change
+(one with the same patch ID) has already been applied upstream. It is
+commonly used in release workflows to find the commits of a topic branch that
+remain to be cherry-picked or merged.
+
+# Basic Usage
+
+[Parse] takes an [io.Reader] containing `git cherry` or `git cherry -v`
+output:
+
+	r := bytes.NewReader(gitCherryOutput)
+	commits, err := cherry.Parse(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, c := range commits {
+	    if !c.InUpstream {
+	        fmt.Println("pending:", c.OID, c.Subject)
+	    }
+	}
+
+[List] runs `git cherry -v` through a [gitexec.Runner] and parses its output.
+
+# Git Cherry Format
+
+Each line has the following format, where the subject is only present with
+-v:
+
+	<+|-> SP <commit> [SP <subject>]
+
+A "-" marks commits with an equivalent change upstream, and "+" commits
+without one.
+
+For more information, see the Git documentation for [git cherry].
+
+[git cherry]: https://git-scm.com/docs/git-cherry
+*/
+package cherry
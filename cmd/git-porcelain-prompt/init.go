@@ -0,0 +1,189 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// defaultHookTimeout is the -timeout for the bash hook, which cannot update
+// the prompt asynchronously, unless another is given.
+const defaultHookTimeout = 100 * time.Millisecond
+
+// hookTemplates are the hook snippets for each shell, given the command
+// running the prompt, as a list of arguments quoted for the shell.
+var hookTemplates = map[string]*template.Template{
+	"bash": template.Must(template.New("bash").Parse(`# git-porcelain-prompt hook for bash, generated by:
+#   {{.Init}}
+# Load it from ~/.bashrc with:
+#   eval "$({{.Init}})"
+# and show the segment by including ${PORCELAIN_PROMPT} in PS1, such as:
+#   PS1='\w ${PORCELAIN_PROMPT} \$ '
+#
+# bash cannot redraw the prompt once git finishes, so the segment is gathered
+# before each prompt, showing what is known after the -timeout.
+__porcelain_prompt() {
+	local status=$?
+	PORCELAIN_PROMPT="$({{.Command}})"
+	return $status
+}
+if [[ ";${PROMPT_COMMAND[*]:-};" != *";__porcelain_prompt;"* ]]; then
+	PROMPT_COMMAND="__porcelain_prompt${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+`)),
+	"zsh": template.Must(template.New("zsh").Parse(`# git-porcelain-prompt hook for zsh, generated by:
+#   {{.Init}}
+# Load it from ~/.zshrc with:
+#   eval "$({{.Init}})"
+# and show the segment by including ${PORCELAIN_PROMPT} in PROMPT, such as:
+#   setopt prompt_subst
+#   PROMPT='%~ ${PORCELAIN_PROMPT} %# '
+#
+# The segment is gathered in the background, and the prompt redrawn once it
+# is ready, showing the previous segment meanwhile if the directory is the
+# same.
+typeset -g PORCELAIN_PROMPT=''
+typeset -g __porcelain_prompt_fd=0 __porcelain_prompt_pwd=''
+__porcelain_prompt_precmd() {
+	if (( __porcelain_prompt_fd )); then
+		zle -F $__porcelain_prompt_fd 2>/dev/null
+		exec {__porcelain_prompt_fd}<&-
+		__porcelain_prompt_fd=0
+	fi
+	[[ $PWD == $__porcelain_prompt_pwd ]] || PORCELAIN_PROMPT=''
+	__porcelain_prompt_pwd=$PWD
+	exec {__porcelain_prompt_fd}< <({{.Command}})
+	zle -F $__porcelain_prompt_fd __porcelain_prompt_done
+}
+__porcelain_prompt_done() {
+	local fd=$1 out
+	IFS= read -r -u $fd out
+	zle -F $fd
+	exec {fd}<&-
+	__porcelain_prompt_fd=0
+	PORCELAIN_PROMPT=$out
+	zle reset-prompt
+}
+autoload -Uz add-zsh-hook
+add-zsh-hook precmd __porcelain_prompt_precmd
+`)),
+	"fish": template.Must(template.New("fish").Parse(`# git-porcelain-prompt hook for fish, generated by:
+#   {{.Init}}
+# Load it from ~/.config/fish/config.fish with:
+#   {{.Init}} | source
+# and show the segment by printing $PORCELAIN_PROMPT in fish_prompt, such as:
+#   function fish_prompt; echo -n (prompt_pwd) $PORCELAIN_PROMPT '> '; end
+#
+# The segment is gathered in the background, and the prompt redrawn once it
+# is ready, showing the previous segment meanwhile if the directory is the
+# same.
+set -g PORCELAIN_PROMPT ''
+set -g __porcelain_prompt_pwd ''
+set -g __porcelain_prompt_repaint 0
+function __porcelain_prompt_start --on-event fish_prompt
+	if test $__porcelain_prompt_repaint = 1
+		set -g __porcelain_prompt_repaint 0
+		return
+	end
+	if test "$PWD" != "$__porcelain_prompt_pwd"
+		set -g PORCELAIN_PROMPT ''
+		set -g __porcelain_prompt_pwd $PWD
+	end
+	command fish --private --no-config --command 'set -U __porcelain_prompt_'$fish_pid' ('{{.QuotedCommand}}')' &
+	disown
+end
+function __porcelain_prompt_done --on-variable __porcelain_prompt_$fish_pid
+	set -l var __porcelain_prompt_$fish_pid
+	set -g PORCELAIN_PROMPT $$var
+	set -g __porcelain_prompt_repaint 1
+	commandline -f repaint
+end
+function __porcelain_prompt_exit --on-event fish_exit
+	set -eU __porcelain_prompt_$fish_pid
+end
+`)),
+}
+
+// runInit writes the hook for the shell named by the first argument, running
+// the prompt with the flags that follow it.
+func runInit(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing shell for init [bash, zsh, fish]")
+	}
+	shellName, flags := args[0], args[1:]
+	tmpl, ok := hookTemplates[shellName]
+	if !ok {
+		return fmt.Errorf("unsupported shell for init: %s", shellName)
+	}
+	if err := flag.CommandLine.Parse(flags); err != nil {
+		return err
+	}
+	if flag.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments for init: %s", strings.Join(flag.Args(), " "))
+	}
+	if *shell != "" || *printTheme {
+		return fmt.Errorf("-shell and -print-theme cannot be used with init")
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	return writeHook(os.Stdout, tmpl, exe, shellName, flags)
+}
+
+// writeHook writes the hook from tmpl for a shell, running exe with the
+// flags set on the command line, as parsed from flags.
+func writeHook(w io.Writer, tmpl *template.Template, exe, shellName string, flags []string) error {
+	quote := posixQuote
+	if shellName == "fish" {
+		quote = fishQuote
+	}
+
+	command := []string{quote(exe)}
+	flag.Visit(func(f *flag.Flag) {
+		command = append(command, quote("-"+f.Name+"="+f.Value.String()))
+	})
+	switch shellName {
+	case "bash", "zsh":
+		command = append(command, quote("-shell="+shellName))
+	}
+	if shellName == "bash" && *timeout == 0 {
+		command = append(command, quote("-timeout="+defaultHookTimeout.String()))
+	}
+
+	init := []string{"git-porcelain-prompt", "init", shellName}
+	for _, f := range flags {
+		init = append(init, quote(f))
+	}
+	return tmpl.Execute(w, struct {
+		Init          string
+		Command       string
+		QuotedCommand string
+	}{
+		Init:          strings.Join(init, " "),
+		Command:       strings.Join(command, " "),
+		QuotedCommand: fishQuote(strings.Join(command, " ")),
+	})
+}
+
+// posixQuote quotes s as a single word for bash and zsh, if needed.
+func posixQuote(s string) string {
+	if s != "" && strings.Trim(s, safeChars) == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote quotes s as a single word for fish, if needed.
+func fishQuote(s string) string {
+	if s != "" && strings.Trim(s, safeChars) == "" {
+		return s
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+const safeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_=./:,+@"
@@ -0,0 +1,228 @@
+// Command git-porcelain-prompt prints a compact summary of the status of the
+// git repository in the current directory, for use in a shell prompt.
+//
+// Install it with:
+//
+//	go install github.com/mroth/porcelain/cmd/git-porcelain-prompt@latest
+//
+// Then call it from the prompt, for example in bash:
+//
+//	PS1='\w $(git-porcelain-prompt -shell bash) \$ '
+//
+// or in zsh, with the PROMPT_SUBST option set:
+//
+//	PROMPT='%~ $(git-porcelain-prompt -shell zsh) %# '
+//
+// Rather than calling it from the prompt directly, the init subcommand writes
+// a hook for bash, zsh or fish, which runs it with any flags that follow:
+//
+//	eval "$(git-porcelain-prompt init zsh -color)"
+//
+// and sets the PORCELAIN_PROMPT variable to include in the prompt. The zsh and
+// fish hooks run it in the background, and redraw the prompt when it finishes,
+// so that the shell never waits for git. bash cannot redraw the prompt, so its
+// hook uses a -timeout of 100ms instead, unless another is given. See the
+// comments of the hook for how to use it.
+//
+// The segment shows the branch (or abbreviated commit when detached), any
+// operation in progress such as a rebase, commits ahead of and behind
+// upstream, and the number of staged, unstaged, untracked and conflicted
+// files, and stash entries, omitting any that are zero:
+//
+//	main ↑1 ●2 ✚1 …3
+//	:1a2b3c4|REBASE-i 2/5 ✖1
+//
+// Outside of a git repository, nothing is printed.
+//
+// Status is gathered with the gitprompt package, without taking optional
+// locks, so it is fast enough to run for every prompt. On very large
+// repositories, -untracked no skips the slowest part of it, or -timeout limits
+// the time spent, printing whatever was gathered by then:
+//
+//	PS1='\w $(git-porcelain-prompt -shell bash -timeout 100ms) \$ '
+//
+// The -theme flag selects the glyphs and colors: "default" uses Unicode
+// symbols, falling back to ASCII characters unless the locale supports UTF-8,
+// while "ascii" always uses ASCII characters. A customized theme is read from
+// the file given with -theme-file, or else from porcelain/theme.conf in the
+// user configuration directory, if it exists; see the theme package for its
+// format. -print-theme writes the selected theme in that format, as a starting
+// point:
+//
+//	git-porcelain-prompt -print-theme > ~/.config/porcelain/theme.conf
+//
+// Colors are enabled with -color, and -shell wraps the color escape sequences
+// so that the shell does not count them towards the width of the prompt.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/gitprompt"
+	"github.com/mroth/porcelain/gitstate"
+	"github.com/mroth/porcelain/theme"
+)
+
+var (
+	dir        = flag.String("C", "", "run git in `dir` instead of the current directory")
+	themeName  = flag.String("theme", "", "built-in `theme` [default, ascii] (default from the theme file, or default)")
+	themeFile  = flag.String("theme-file", "", "read the theme from `file` (default porcelain/theme.conf in the user config dir)")
+	printTheme = flag.Bool("print-theme", false, "print the selected theme in the theme file format and exit")
+	color      = flag.Bool("color", false, "colorize the output with ANSI escape sequences")
+	shell      = flag.String("shell", "", "wrap escape sequences for the prompt of `shell` [bash, zsh]")
+	untracked  = flag.String("untracked", "normal", "untracked files `mode` [no, normal, all]")
+	timeout    = flag.Duration("timeout", 0, "print what is known after `duration`, instead of waiting for git (0 for no limit)")
+)
+
+func main() {
+	log.SetFlags(0)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: git-porcelain-prompt [flags]\n       git-porcelain-prompt init bash|zsh|fish [flags]\n")
+		flag.PrintDefaults()
+	}
+	if len(os.Args) > 1 && os.Args[1] == "init" {
+		if err := runInit(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			flag.Usage()
+			os.Exit(2)
+		}
+		return
+	}
+	flag.Parse()
+
+	th, err := selectTheme()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *printTheme {
+		if err := theme.Encode(os.Stdout, th); err != nil {
+			log.Fatalf("git-porcelain-prompt: %v", err)
+		}
+		return
+	}
+	switch *shell {
+	case "", "bash", "zsh":
+	default:
+		fmt.Fprintf(os.Stderr, "error: unsupported -shell flag value: %s\n", *shell)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var info *gitprompt.PromptInfo
+	git, untrackedArg := gitexec.New(*dir), "--untracked-files="+*untracked
+	if *timeout > 0 {
+		info, err = gitprompt.GetWithTimeout(context.Background(), git, *timeout, untrackedArg)
+	} else {
+		info, err = gitprompt.Get(context.Background(), git, untrackedArg)
+	}
+	if errors.Is(err, gitprompt.ErrNotRepository) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("git-porcelain-prompt: %v", err)
+	}
+	r := renderer{glyphs: th.LocaleGlyphs(), colors: th.Colors, color: *color, shell: *shell}
+	fmt.Println(r.render(info))
+}
+
+// selectTheme returns the theme selected by the -theme and -theme-file flags,
+// or the theme file in the default location if it exists.
+func selectTheme() (theme.Theme, error) {
+	switch {
+	case *themeName != "" && *themeFile != "":
+		return theme.Theme{}, errors.New("-theme and -theme-file cannot be used together")
+	case *themeName != "":
+		th, ok := theme.Builtin(*themeName)
+		if !ok {
+			return theme.Theme{}, fmt.Errorf("unsupported -theme flag value: %s", *themeName)
+		}
+		return th, nil
+	case *themeFile != "":
+		return theme.Load(*themeFile)
+	}
+	if path, err := theme.DefaultPath(); err == nil {
+		th, err := theme.Load(path)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return th, err
+		}
+	}
+	return theme.Default, nil
+}
+
+// renderer renders prompt information as a prompt segment.
+type renderer struct {
+	glyphs theme.Glyphs
+	colors theme.Palette
+	color  bool
+	shell  string // "bash", "zsh" or "" for none
+}
+
+func (r renderer) render(p *gitprompt.PromptInfo) string {
+	var parts []string
+	add := func(color, glyph string, n int) {
+		if n > 0 {
+			parts = append(parts, r.paint(color, glyph+strconv.Itoa(n)))
+		}
+	}
+
+	head := r.paint(r.colors.Branch, p.Branch)
+	if p.Detached && len(p.Commit) >= 7 {
+		head = r.paint(r.colors.Detached, ":"+p.Commit[:7])
+	}
+	if p.Operation != gitstate.None {
+		op := "|" + p.Operation.String()
+		if p.Total > 0 {
+			op += fmt.Sprintf(" %d/%d", p.Step, p.Total)
+		}
+		head += r.paint(r.colors.Conflicts, op)
+	}
+	parts = append(parts, head)
+	add(r.colors.Ahead, r.glyphs.Ahead, p.Ahead)
+	add(r.colors.Behind, r.glyphs.Behind, p.Behind)
+	add(r.colors.Staged, r.glyphs.Staged, p.Staged)
+	add(r.colors.Unstaged, r.glyphs.Unstaged, p.Unstaged)
+	add(r.colors.Untracked, r.glyphs.Untracked, p.Untracked)
+	add(r.colors.Conflicts, r.glyphs.Conflicts, p.Conflicts)
+	// only claim a clean worktree once all changes have been counted
+	if !p.Dirty() && p.Incomplete&(gitprompt.FieldsChanges|gitprompt.FieldsUntracked) == 0 && r.glyphs.Clean != "" {
+		parts = append(parts, r.paint(r.colors.Clean, r.glyphs.Clean))
+	}
+	add(r.colors.Stash, r.glyphs.Stash, p.Stash)
+	return strings.Join(parts, " ")
+}
+
+// paint returns text wrapped in the escape sequences for color, if enabled.
+func (r renderer) paint(color, text string) string {
+	if r.shell == "zsh" {
+		text = strings.ReplaceAll(text, "%", "%%")
+	}
+	if !r.color || color == "" {
+		return text
+	}
+	return r.escape("\x1b["+color+"m") + text + r.escape("\x1b[0m")
+}
+
+// escape marks a non-printing escape sequence for the shell.
+func (r renderer) escape(seq string) string {
+	switch r.shell {
+	case "bash":
+		// readline's markers, as \[ and \] are not expanded in the output of
+		// command substitutions.
+		return "\x01" + seq + "\x02"
+	case "zsh":
+		return "%{" + seq + "%}"
+	default:
+		return seq
+	}
+}
@@ -0,0 +1,234 @@
+// Command porcelain-diff records snapshots of the status of a git repository,
+// and compares them, printing the entries that changed. It is useful for
+// auditing what a build step touched in the worktree:
+//
+//	porcelain-diff -record before.status
+//	make generate
+//	porcelain-diff before.status
+//
+// With one snapshot, it is compared against the live status of the
+// repository. With two, they are compared against each other:
+//
+//	porcelain-diff before.status after.status
+//
+// Each changed path is printed on its own line, prefixed with "+" if it only
+// has an entry in the newer status, "-" if it only has one in the older, or "~"
+// if its entry differs between them, followed by its XY status ("??" for
+// untracked and "!!" for ignored files):
+//
+//	~ .M -> M. main.go
+//	+ ?? generated.go
+//	- .M README.md
+//
+// Changes of branch or HEAD commit are printed first. Like diff, the exit code
+// is 0 if there are no changes, 1 if there are, and 2 on error.
+//
+// With -report, the paths entering or leaving a state, such as becoming staged
+// or conflicted, are reported instead, for bots commenting on the changes a
+// tool made. With -report summary, they are counted on a single line:
+//
+//	3 files became staged, 1 conflict resolved, 2 new untracked
+//
+// and with -report json, they are listed as JSON events, with the kind, path,
+// and XY status before and after:
+//
+//	[{"Kind":"staged","Path":"main.go","From":".M","To":"M."}]
+//
+// The exit code is then 1 only if there are events.
+//
+// # Snapshot Format
+//
+// A snapshot file is the output of:
+//
+//	git status --porcelain=v2 -z --branch --show-stash --untracked-files=all
+//
+// so snapshots can also be captured without this command, and inspected with
+// any tool that reads porcelain output, such as porcelain2go.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+var (
+	dir    = flag.String("C", "", "run git in `dir` instead of the current directory")
+	record = flag.String("record", "", "record a snapshot of the status to `file`, instead of comparing")
+	report = flag.String("report", "", "report the changes of state of paths in `format` [summary, json]")
+)
+
+// snapshotArgs are the git arguments producing a snapshot.
+var snapshotArgs = []string{"status", "--porcelain=v2", "-z", "--branch", "--show-stash", "--untracked-files=all"}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("porcelain-diff: ")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: porcelain-diff [-C dir] -record file\n       porcelain-diff [-C dir] [-report format] old [new]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *record != "" {
+		if flag.NArg() != 0 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		out, err := snapshot()
+		if err != nil {
+			fatal(err)
+		}
+		if err := os.WriteFile(*record, out, 0o644); err != nil {
+			fatal(err)
+		}
+		return
+	}
+
+	if flag.NArg() < 1 || flag.NArg() > 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *report != "" && *report != "summary" && *report != "json" {
+		fmt.Fprintf(os.Stderr, "unsupported -report flag value: %s\n", *report)
+		flag.Usage()
+		os.Exit(2)
+	}
+	old, err := readSnapshot(flag.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+	var new *statusv2.Status
+	if flag.NArg() == 2 {
+		new, err = readSnapshot(flag.Arg(1))
+	} else {
+		var out []byte
+		if out, err = snapshot(); err == nil {
+			new, err = statusv2.ParseZ(bytes.NewReader(out))
+		}
+	}
+	if err != nil {
+		fatal(err)
+	}
+
+	var changed bool
+	if *report != "" {
+		changed, err = printReport(os.Stdout, old, new, *report)
+		if err != nil {
+			fatal(err)
+		}
+	} else {
+		changed = printDiff(os.Stdout, old, new)
+	}
+	if changed {
+		os.Exit(1)
+	}
+}
+
+func fatal(err error) {
+	log.Print(err)
+	os.Exit(2)
+}
+
+// snapshot returns a snapshot of the live status.
+func snapshot() ([]byte, error) {
+	return gitexec.New(*dir).Run(context.Background(), snapshotArgs...)
+}
+
+func readSnapshot(name string) (*statusv2.Status, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	s, err := statusv2.ParseZ(f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing snapshot %s: %w", name, err)
+	}
+	return s, nil
+}
+
+// printDiff writes the differences between old and new to w, and reports
+// whether there were any.
+func printDiff(w io.Writer, old, new *statusv2.Status) bool {
+	var changed bool
+	oldBranch, newBranch := branchOrZero(old), branchOrZero(new)
+	if oldBranch.Head != newBranch.Head {
+		fmt.Fprintf(w, "branch: %s -> %s\n", oldBranch.Head, newBranch.Head)
+		changed = true
+	}
+	if oldBranch.OID != newBranch.OID {
+		fmt.Fprintf(w, "HEAD: %s -> %s\n", oldBranch.OID, newBranch.OID)
+		changed = true
+	}
+
+	for _, c := range statusv2.Diff(old, new) {
+		switch {
+		case c.Added():
+			fmt.Fprintf(w, "+ %s %s\n", state(c.New), describe(c.New))
+		case c.Removed():
+			fmt.Fprintf(w, "- %s %s\n", state(c.Old), describe(c.Old))
+		default:
+			fmt.Fprintf(w, "~ %s -> %s %s\n", state(c.Old), state(c.New), describe(c.New))
+		}
+		changed = true
+	}
+	return changed
+}
+
+// printReport writes the events between old and new to w in the given
+// format, and reports whether there were any.
+func printReport(w io.Writer, old, new *statusv2.Status, format string) (bool, error) {
+	events := statusv2.Events(statusv2.Diff(old, new))
+	if format == "json" {
+		if events == nil {
+			events = []statusv2.Event{}
+		}
+		return len(events) > 0, json.NewEncoder(w).Encode(events)
+	}
+	_, err := fmt.Fprintln(w, statusv2.Summarize(events))
+	return len(events) > 0, err
+}
+
+func branchOrZero(s *statusv2.Status) statusv2.BranchInfo {
+	if s.Branch == nil {
+		return statusv2.BranchInfo{}
+	}
+	return *s.Branch
+}
+
+// state returns the XY status of an entry, using the porcelain=v1 "??" and
+// "!!" for untracked and ignored files.
+func state(e statusv2.Entry) string {
+	switch e := e.(type) {
+	case statusv2.ChangedEntry:
+		return e.XY.String()
+	case statusv2.RenameOrCopyEntry:
+		return e.XY.String()
+	case statusv2.UnmergedEntry:
+		return e.XY.String()
+	case statusv2.UntrackedEntry:
+		return "??"
+	case statusv2.IgnoredEntry:
+		return "!!"
+	default:
+		return "  "
+	}
+}
+
+// describe returns the path of an entry, including the original path of
+// renames and copies.
+func describe(e statusv2.Entry) string {
+	if r, ok := e.(statusv2.RenameOrCopyEntry); ok {
+		return r.Orig + " -> " + r.Path
+	}
+	return e.EntryPath()
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// goldenV2 is the JSON shape of an expected porcelain=v2 parse result, which
+// is the same as that of porcelain2go: each entry has a Type field naming its
+// kind, so that it can be decoded again.
+type goldenV2 struct {
+	Branch  *statusv2.BranchInfo
+	Stash   *statusv2.StashInfo
+	Entries []goldenEntry
+}
+
+func newGoldenV2(s *statusv2.Status) goldenV2 {
+	g := goldenV2{Branch: s.Branch, Stash: s.Stash, Entries: []goldenEntry{}}
+	for _, e := range s.Entries {
+		g.Entries = append(g.Entries, goldenEntry{e})
+	}
+	return g
+}
+
+type goldenEntry struct {
+	statusv2.Entry
+}
+
+func (e goldenEntry) MarshalJSON() ([]byte, error) {
+	return statusv2.MarshalEntryJSON(e.Entry)
+}
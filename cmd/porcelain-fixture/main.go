@@ -0,0 +1,297 @@
+// Command porcelain-fixture records golden test data for porcelain parsers, by
+// running scripted scenarios in scratch repositories and capturing the
+// resulting `git status` output.
+//
+// Each scenario is a JSON file describing the steps that set up a repository:
+//
+//	{
+//	  "description": "a modified file, staged and then modified again",
+//	  "status_args": ["--branch"],
+//	  "steps": [
+//	    {"write": "a.txt", "content": "one\n"},
+//	    {"git": ["add", "a.txt"]},
+//	    {"git": ["commit", "-m", "initial"]},
+//	    {"write": "a.txt", "content": "two\n"},
+//	    {"git": ["add", "a.txt"]},
+//	    {"write": "a.txt", "content": "three\n"}
+//	  ]
+//	}
+//
+// A step either writes a file (creating parent directories as needed),
+// creates a symbolic link, removes a file or directory, or runs git with the
+// given arguments, which may be allowed to fail with "ignore_error" (as when
+// merging with conflicts). The status_args are passed to every `git status`
+// invocation.
+//
+// For a scenario file named NAME.json, the output of `git status` in the
+// porcelain=v1 and porcelain=v2 formats, with and without -z, is written to
+// NAME.v1, NAME.v1z, NAME.v2 and NAME.v2z, along with the expected parse results
+// as NAME.v1.json and NAME.v2.json. The v1 files are written to the -v1
+// directory and the v2 files to the -v2 directory:
+//
+//	porcelain-fixture -v1 v1/testdata -v2 v2/testdata scenarios/*.json
+//
+// Git runs with a fixed identity, dates and default branch name, and ignores
+// the system and global configuration, so that the recorded output (including
+// object names) is reproducible. A scenario may set "object_format" to
+// "sha256" to record the output of a SHA-256 repository, which requires git
+// 2.29.0 or later.
+//
+// # Corpus
+//
+// With -corpus, the fixtures are instead written to a directory named after
+// the version of git, such as git-2.39.5, in the given directory, as for the
+// corpus package, which holds the golden testdata of the statusv1 and statusv2
+// parsers. Use -git to record with another git binary:
+//
+//	porcelain-fixture -git ~/src/git-2.11.0/git -corpus corpus/testdata scenarios/*.json
+//
+// Scenarios requiring a newer version of git than the one used, as declared by
+// their "min_git" field, are skipped.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+var (
+	v1Dir = flag.String("v1", "testdata", "write porcelain=v1 fixtures to `dir`")
+	v2Dir = flag.String("v2", "testdata", "write porcelain=v2 fixtures to `dir`")
+	keep  = flag.Bool("keep", false, "keep the scratch repositories, printing their location")
+
+	gitPath   = flag.String("git", "", "run the git binary at `path` instead of git from PATH")
+	corpusDir = flag.String("corpus", "", "write fixtures to a directory for the git version in `dir`, instead of -v1 and -v2")
+)
+
+// Scenario describes how to set up a repository.
+type Scenario struct {
+	Description  string   `json:"description"`
+	MinGit       string   `json:"min_git"`       // oldest git version supporting the scenario, if newer than 2.11.0
+	ObjectFormat string   `json:"object_format"` // object format of the repository, e.g. "sha256", if not the default
+	StatusArgs   []string `json:"status_args"`
+	Steps        []Step   `json:"steps"`
+}
+
+// Step is a single action setting up a repository. Exactly one of Write,
+// Symlink, Remove or Git is set.
+type Step struct {
+	Write       string   `json:"write"`        // path of a file to write
+	Content     string   `json:"content"`      // content of the file to write
+	Symlink     string   `json:"symlink"`      // path of a symbolic link to create
+	Target      string   `json:"target"`       // target of the symbolic link
+	Remove      string   `json:"remove"`       // path of a file or directory to remove
+	Git         []string `json:"git"`          // arguments to run git with
+	IgnoreError bool     `json:"ignore_error"` // whether git may fail, e.g. merging with conflicts
+}
+
+// env makes git output reproducible. It is set up to work with all versions of
+// git since 2.11.0, which introduced porcelain=v2, so the global configuration
+// is ignored by pointing HOME elsewhere, and config is set in the repository.
+var env = []string{
+	"GIT_CONFIG_NOSYSTEM=1",
+	"GIT_AUTHOR_NAME=Porcelain Fixture",
+	"GIT_AUTHOR_EMAIL=fixture@example.com",
+	"GIT_AUTHOR_DATE=2016-11-29T00:00:00Z",
+	"GIT_COMMITTER_NAME=Porcelain Fixture",
+	"GIT_COMMITTER_EMAIL=fixture@example.com",
+	"GIT_COMMITTER_DATE=2016-11-29T00:00:00Z",
+}
+
+// setup are the git commands preparing a new repository.
+var setup = [][]string{
+	{"init", "--quiet"},
+	{"symbolic-ref", "HEAD", "refs/heads/main"},
+	{"config", "core.autocrlf", "false"},
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("porcelain-fixture: ")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: porcelain-fixture [flags] scenario.json...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	version, err := gitVersion(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *corpusDir != "" {
+		dir := filepath.Join(*corpusDir, "git-"+version.String())
+		*v1Dir, *v2Dir = dir, dir
+	}
+	for _, file := range flag.Args() {
+		if err := record(ctx, file, version); err != nil {
+			log.Fatalf("%s: %v", file, err)
+		}
+	}
+}
+
+// gitVersion returns the version of git used.
+func gitVersion(ctx context.Context) (version, error) {
+	out, err := (&gitexec.Git{Path: *gitPath}).Run(ctx, "version")
+	if err != nil {
+		return version{}, err
+	}
+	// e.g. "git version 2.39.5" or "git version 2.39.5.windows.1"
+	v, ok := strings.CutPrefix(strings.TrimSpace(string(out)), "git version ")
+	if !ok {
+		return version{}, fmt.Errorf("unexpected git version output: %q", out)
+	}
+	return parseVersion(v)
+}
+
+// record runs the scenario in file with the given version of git, and writes
+// its fixtures.
+func record(ctx context.Context, file string, gitVersion version) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var sc Scenario
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&sc); err != nil {
+		return fmt.Errorf("invalid scenario: %w", err)
+	}
+	name := strings.TrimSuffix(filepath.Base(file), ".json")
+	if sc.MinGit != "" {
+		min, err := parseVersion(sc.MinGit)
+		if err != nil {
+			return fmt.Errorf("invalid scenario: %w", err)
+		}
+		if gitVersion.less(min) {
+			log.Printf("%s: skipped, requires git %s", name, min)
+			return nil
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "porcelain-fixture-"+name+"-")
+	if err != nil {
+		return err
+	}
+	if *keep {
+		log.Printf("%s: repository kept in %s", name, dir)
+	} else {
+		defer os.RemoveAll(dir)
+	}
+
+	git := &gitexec.Git{Path: *gitPath, Dir: dir, Env: append(env, "HOME="+dir, "XDG_CONFIG_HOME="+dir)}
+	for _, args := range setup {
+		if args[0] == "init" && sc.ObjectFormat != "" {
+			args = append(slices.Clip(args), "--object-format="+sc.ObjectFormat)
+		}
+		if _, err := git.Run(ctx, args...); err != nil {
+			return err
+		}
+	}
+	for i, step := range sc.Steps {
+		if err := run(ctx, git, dir, step); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+
+	outputs := map[string][]byte{}
+	for _, format := range []string{"v1", "v1z", "v2", "v2z"} {
+		version, z := strings.CutSuffix(format, "z")
+		args := append([]string{"status", "--porcelain=" + version}, sc.StatusArgs...)
+		if z {
+			args = append(args, "-z")
+		}
+		out, err := git.Run(ctx, args...)
+		if err != nil {
+			return err
+		}
+		outputs[format] = out
+	}
+
+	v1, err := statusv1.ParseZ(bytes.NewReader(outputs["v1z"]))
+	if err != nil {
+		return fmt.Errorf("parsing v1z output: %w", err)
+	}
+	v2, err := statusv2.ParseZ(bytes.NewReader(outputs["v2z"]))
+	if err != nil {
+		return fmt.Errorf("parsing v2z output: %w", err)
+	}
+	v1JSON, err := marshalGolden(v1)
+	if err != nil {
+		return err
+	}
+	v2JSON, err := marshalGolden(newGoldenV2(v2))
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		dir, name string
+		data      []byte
+	}{
+		{*v1Dir, name + ".v1", outputs["v1"]},
+		{*v1Dir, name + ".v1z", outputs["v1z"]},
+		{*v1Dir, name + ".v1.json", v1JSON},
+		{*v2Dir, name + ".v2", outputs["v2"]},
+		{*v2Dir, name + ".v2z", outputs["v2z"]},
+		{*v2Dir, name + ".v2.json", v2JSON},
+	}
+	for _, f := range files {
+		if err := os.MkdirAll(f.dir, 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(f.dir, f.name), f.data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func run(ctx context.Context, git gitexec.Runner, dir string, step Step) error {
+	switch {
+	case step.Write != "":
+		path := filepath.Join(dir, filepath.FromSlash(step.Write))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(step.Content), 0o644)
+	case step.Symlink != "":
+		return os.Symlink(step.Target, filepath.Join(dir, filepath.FromSlash(step.Symlink)))
+	case step.Remove != "":
+		return os.RemoveAll(filepath.Join(dir, filepath.FromSlash(step.Remove)))
+	case len(step.Git) > 0:
+		_, err := git.Run(ctx, step.Git...)
+		var exitErr *gitexec.ExitError
+		if step.IgnoreError && errors.As(err, &exitErr) {
+			return nil
+		}
+		return err
+	default:
+		return fmt.Errorf("empty step")
+	}
+}
+
+func marshalGolden(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
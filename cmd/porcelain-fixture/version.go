@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// version is a git version, such as 2.39.5.
+type version struct {
+	major, minor, patch int
+}
+
+// parseVersion parses a version, ignoring any suffix after the patch number,
+// such as ".windows.1".
+func parseVersion(s string) (version, error) {
+	parts := strings.SplitN(s, ".", 4)
+	if len(parts) < 3 {
+		return version{}, fmt.Errorf("invalid git version: %q", s)
+	}
+	var nums [3]int
+	for i := range nums {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return version{}, fmt.Errorf("invalid git version: %q", s)
+		}
+		nums[i] = n
+	}
+	return version{nums[0], nums[1], nums[2]}, nil
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+// less reports whether v is older than w.
+func (v version) less(w version) bool {
+	if v.major != w.major {
+		return v.major < w.major
+	}
+	if v.minor != w.minor {
+		return v.minor < w.minor
+	}
+	return v.patch < w.patch
+}
@@ -0,0 +1,129 @@
+// Command porcelain-gen writes synthetic `git status` porcelain output, for
+// load-testing consumers of the format and reproducing performance issues
+// without needing a repository of the same size:
+//
+//	porcelain-gen -n 1000000 -renames 0.1 -unicode 0.2 -z > big.v2z
+//	porcelain-gen -n 1000000 | my-prompt --from-stdin
+//
+// The entries are random but valid, and are generated deterministically from
+// -seed, so the same flags always produce the same output. Without -z, paths
+// are quoted as git would with the default core.quotePath setting. Output is
+// streamed, so arbitrarily large counts can be generated in constant memory.
+//
+// The -renames, -unmerged, -untracked and -ignored flags set the fraction of
+// entries of each kind; the remaining entries are ordinary changes. See the
+// statusgen package for details.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/mroth/porcelain/statusgen"
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+var (
+	format    = flag.String("format", "v2", "porcelain `version` to write, v1 or v2")
+	zflag     = flag.Bool("z", false, "write NUL-terminated output, as from git status -z")
+	count     = flag.Int("n", 1000, "number of `entries` to write")
+	seed      = flag.Uint64("seed", 1, "random `seed`")
+	branch    = flag.Bool("branch", true, "write branch headers, as from git status --branch")
+	renames   = flag.Float64("renames", 0.05, "`fraction` of renamed or copied entries")
+	unmerged  = flag.Float64("unmerged", 0, "`fraction` of unmerged entries")
+	untracked = flag.Float64("untracked", 0.2, "`fraction` of untracked entries")
+	ignored   = flag.Float64("ignored", 0, "`fraction` of ignored entries")
+	unicode   = flag.Float64("unicode", 0, "`fraction` of paths with non-ASCII characters")
+)
+
+// batchSize is the number of entries encoded at a time.
+const batchSize = 1000
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("porcelain-gen: ")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: porcelain-gen [flags]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 0 || (*format != "v1" && *format != "v2") {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *renames+*unmerged+*untracked+*ignored > 1 {
+		log.Fatal("fractions of entry kinds must sum to at most 1")
+	}
+
+	g := statusgen.New(statusgen.Config{
+		Seed:           *seed,
+		RenameRatio:    *renames,
+		UnmergedRatio:  *unmerged,
+		UntrackedRatio: *untracked,
+		IgnoredRatio:   *ignored,
+		UnicodeRatio:   *unicode,
+		Quote:          !*zflag,
+	})
+
+	w := bufio.NewWriter(os.Stdout)
+	var err error
+	if *format == "v1" {
+		err = writeV1(w, g)
+	} else {
+		err = writeV2(w, g)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeV2(w *bufio.Writer, g *statusgen.Generator) error {
+	encode := statusv2.Encode
+	if *zflag {
+		encode = statusv2.EncodeZ
+	}
+	s := &statusv2.Status{}
+	if *branch {
+		s.Branch = g.Branch()
+	}
+	for n := 0; n < *count || s.Branch != nil; n += batchSize {
+		s.Entries = s.Entries[:0]
+		for range min(batchSize, *count-n) {
+			s.Entries = append(s.Entries, g.V2())
+		}
+		if err := encode(w, s); err != nil {
+			return err
+		}
+		s.Branch = nil // headers only precede the first batch
+	}
+	return nil
+}
+
+func writeV1(w *bufio.Writer, g *statusgen.Generator) error {
+	encode := statusv1.Encode
+	if *zflag {
+		encode = statusv1.EncodeZ
+	}
+	s := &statusv1.Status{}
+	if *branch {
+		s.Headers = g.V1Status(0).Headers
+	}
+	for n := 0; n < *count || s.Headers != nil; n += batchSize {
+		s.Entries = s.Entries[:0]
+		for range min(batchSize, *count-n) {
+			s.Entries = append(s.Entries, g.V1())
+		}
+		if err := encode(w, s); err != nil {
+			return err
+		}
+		s.Headers = nil // headers only precede the first batch
+	}
+	return nil
+}
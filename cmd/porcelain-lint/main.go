@@ -0,0 +1,119 @@
+// Command porcelain-lint strictly validates `git status` porcelain output,
+// reporting every line that git itself would never produce. It is intended for
+// people generating porcelain output from sources other than git, such as test
+// harnesses and emulators, to check that consumers will be able to read it:
+//
+//	my-emulator status --porcelain=v2 | porcelain-lint
+//	porcelain-lint -format v1 -z testdata/*.v1z
+//
+// Input is read from the named files, or standard input if there are none. It
+// is validated using the strict mode of the statusv1 and statusv2 decoders.
+// Each violation is printed as:
+//
+//	file:line: reason
+//
+// where line counts NUL-terminated records with -z. The exit code is 0 if all
+// input is valid, 1 if there are violations, and 2 on error.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+var (
+	format = flag.String("format", "v2", "porcelain `version` of the input, v1 or v2")
+	zflag  = flag.Bool("z", false, "input is NUL-terminated, as from git status -z")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("porcelain-lint: ")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: porcelain-lint [-format v1|v2] [-z] [file ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *format != "v1" && *format != "v2" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var violations int
+	if flag.NArg() == 0 {
+		violations = lint(os.Stdout, "<stdin>", os.Stdin)
+	}
+	for _, name := range flag.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			log.Print(err)
+			os.Exit(2)
+		}
+		violations += lint(os.Stdout, name, f)
+		f.Close()
+	}
+	if violations > 0 {
+		os.Exit(1)
+	}
+}
+
+// decoder is the common subset of the statusv1 and statusv2 decoders.
+type decoder struct {
+	next func() error
+	line func() int
+}
+
+func newDecoder(r io.Reader) decoder {
+	if *format == "v1" {
+		d := statusv1.NewDecoder(r)
+		if *zflag {
+			d = statusv1.NewDecoderZ(r)
+		}
+		d.Strict()
+		return decoder{
+			next: func() error { _, err := d.Next(); return err },
+			line: d.Line,
+		}
+	}
+	d := statusv2.NewDecoder(r)
+	if *zflag {
+		d = statusv2.NewDecoderZ(r)
+	}
+	d.Strict()
+	return decoder{
+		next: func() error { _, err := d.Next(); return err },
+		line: d.Line,
+	}
+}
+
+// lint writes the violations in r to w, and returns how many there were.
+func lint(w io.Writer, name string, r io.Reader) int {
+	d := newDecoder(r)
+	var violations int
+	lastLine := -1
+	for {
+		err := d.next()
+		if errors.Is(err, io.EOF) {
+			return violations
+		}
+		if err == nil {
+			continue
+		}
+		line := d.line()
+		if line == lastLine {
+			// the error did not consume any input, so is not recoverable,
+			// as with a read error or truncated input
+			return violations
+		}
+		fmt.Fprintf(w, "%s:%d: %v\n", name, line, err)
+		violations++
+		lastLine = line
+	}
+}
@@ -0,0 +1,143 @@
+// Command porcelain-scan finds the git repositories under a directory and
+// reports those with uncommitted or unpushed work, answering "are all my repos
+// pushed?":
+//
+//	$ porcelain-scan ~/src
+//	REPO          BRANCH  AHEAD  BEHIND  STAGED  UNSTAGED  UNTRACKED  CONFLICTS  STASH
+//	porcelain     main    2      0       0       1         3          0          0
+//	scmpuff       feat    0      0       1       0         0          0          1
+//
+// A repository is reported if it has any staged, unstaged, untracked or
+// conflicted files, commits ahead of its upstream, or stash entries. Use -all
+// to report every repository found. The status of each repository is gathered
+// concurrently, with up to -j git processes at once.
+//
+// With -json, the results are written as a JSON array instead, with the counts
+// of each repository, or the error encountered for it.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/mroth/porcelain/multistatus"
+)
+
+var (
+	all       = flag.Bool("all", false, "report all repositories, including clean ones")
+	workers   = flag.Int("j", 0, "maximum number of concurrent git processes (default number of CPUs)")
+	jsonOut   = flag.Bool("json", false, "write results as JSON")
+	untracked = flag.String("untracked", "normal", "untracked files `mode` [no, normal, all]")
+)
+
+// Repo is the summary of a single repository.
+type Repo struct {
+	Path      string // relative to the scanned directory
+	Branch    string `json:",omitempty"`
+	Upstream  string `json:",omitempty"`
+	Ahead     int
+	Behind    int
+	Staged    int
+	Unstaged  int
+	Untracked int
+	Conflicts int
+	Stash     int
+	Error     string `json:",omitempty"`
+}
+
+// NeedsAttention reports whether the repository has work that is not
+// committed and pushed, or failed to be scanned.
+func (r Repo) NeedsAttention() bool {
+	return r.Error != "" || r.Ahead+r.Staged+r.Unstaged+r.Untracked+r.Conflicts+r.Stash > 0
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("porcelain-scan: ")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: porcelain-scan [flags] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	root := "."
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		root = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dirs, err := multistatus.FindRepos(root)
+	if err != nil {
+		log.Fatal(err)
+	}
+	results := multistatus.Get(context.Background(), dirs, multistatus.Config{
+		Workers: *workers,
+		Args:    []string{"--untracked-files=" + *untracked},
+	})
+
+	repos := []Repo{}
+	for _, res := range results {
+		r := summarize(res)
+		if rel, err := filepath.Rel(root, res.Dir); err == nil {
+			r.Path = rel
+		}
+		if *all || r.NeedsAttention() {
+			repos = append(repos, r)
+		}
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(repos); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := writeTable(repos); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func summarize(res multistatus.Result) Repo {
+	r := Repo{Path: res.Dir}
+	if res.Err != nil {
+		r.Error = res.Err.Error()
+		return r
+	}
+	s := res.Status
+	if s.Branch != nil {
+		r.Branch, r.Upstream = s.Branch.Head, s.Branch.Upstream
+		r.Ahead, r.Behind = s.Branch.Ahead, s.Branch.Behind
+	}
+	if s.Stash != nil {
+		r.Stash = s.Stash.Count
+	}
+	sum := s.Summary()
+	r.Staged, r.Unstaged, r.Untracked, r.Conflicts = sum.Staged, sum.Unstaged, sum.Untracked, sum.Conflicted
+	return r
+}
+
+func writeTable(repos []Repo) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPO\tBRANCH\tAHEAD\tBEHIND\tSTAGED\tUNSTAGED\tUNTRACKED\tCONFLICTS\tSTASH")
+	for _, r := range repos {
+		if r.Error != "" {
+			fmt.Fprintf(tw, "%s\terror: %s\n", r.Path, r.Error)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n",
+			r.Path, r.Branch, r.Ahead, r.Behind, r.Staged, r.Unstaged, r.Untracked, r.Conflicts, r.Stash)
+	}
+	return tw.Flush()
+}
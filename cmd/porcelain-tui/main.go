@@ -0,0 +1,59 @@
+// Command porcelain-tui is an interactive terminal viewer for the status of a
+// git repository.
+//
+// It shows the conflicted, staged, unstaged and untracked files in collapsible
+// sections, updated as the status changes (by polling with the watch package)
+// to follow changes made elsewhere:
+//
+//	porcelain-tui -C path/to/repo
+//
+// Keys:
+//
+//	up/k, down/j    move the cursor
+//	enter/space     collapse or expand the section under the cursor
+//	y               copy the path under the cursor to the clipboard
+//	r               refresh now
+//	q               quit
+//
+// The clipboard is set with an OSC 52 escape sequence, which is supported by
+// most terminal emulators, including over SSH.
+//
+// This command is a separate module, so that its dependencies are not
+// required by users of the library. Install it from a checkout of the
+// repository with:
+//
+//	cd cmd/porcelain-tui && go install .
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mroth/porcelain/watch"
+)
+
+var (
+	dir       = flag.String("C", "", "show the repository in `dir` instead of the current directory")
+	interval  = flag.Duration("interval", 2*time.Second, "how often to refresh the status")
+	untracked = flag.String("untracked", "normal", "untracked files `mode` [no, normal, all]")
+	ignored   = flag.Bool("ignored", false, "also show ignored files")
+)
+
+func main() {
+	flag.Parse()
+
+	args := []string{"--untracked-files=" + *untracked}
+	if *ignored {
+		args = append(args, "--ignored")
+	}
+	w := watch.New(*dir, watch.Interval(*interval), watch.Args(args...))
+	_, err := tea.NewProgram(newModel(w), tea.WithAltScreen()).Run()
+	w.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "porcelain-tui: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	osc52 "github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mroth/porcelain/statusv2"
+	"github.com/mroth/porcelain/watch"
+)
+
+var (
+	headerStyle   = lipgloss.NewStyle().Bold(true)
+	sectionStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("4"))
+	cursorStyle   = lipgloss.NewStyle().Reverse(true)
+	messageStyle  = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	sectionColors = map[string]lipgloss.Color{
+		"Conflicts": "1",
+		"Staged":    "2",
+		"Unstaged":  "3",
+		"Untracked": "8",
+		"Ignored":   "8",
+	}
+)
+
+// section is a collapsible group of files.
+type section struct {
+	title     string
+	items     []item
+	collapsed bool
+}
+
+// item is a file in a section, with its state in that section.
+type item struct {
+	state string // e.g. "M" or "UU"
+	path  string // path, including the original path of renames
+}
+
+// row is a line of the list: a section header if item is negative, or an item
+// of the section otherwise.
+type row struct {
+	section int
+	item    int
+}
+
+type model struct {
+	watcher *watch.Watcher
+
+	status   *statusv2.Status
+	sections []section
+	cursor   int // index into rows()
+	err      error
+	message  string
+	height   int
+}
+
+type statusMsg struct {
+	status *statusv2.Status
+	err    error
+}
+
+func newModel(w *watch.Watcher) *model {
+	return &model{watcher: w}
+}
+
+func (m *model) Init() tea.Cmd {
+	return m.wait()
+}
+
+// wait returns a command that waits for the next status or error from the
+// watcher.
+func (m *model) wait() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case s, ok := <-m.watcher.Updates():
+			if ok {
+				return statusMsg{status: s}
+			}
+		case err, ok := <-m.watcher.Errors():
+			if ok {
+				return statusMsg{err: err}
+			}
+		}
+		return nil // watcher closed
+	}
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case statusMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.setStatus(msg.status)
+		}
+		return m, m.wait()
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		m.message = ""
+		rows := m.rows()
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			m.cursor = max(m.cursor-1, 0)
+		case "down", "j":
+			m.cursor = min(m.cursor+1, len(rows)-1)
+		case "enter", " ":
+			if m.cursor < len(rows) {
+				s := &m.sections[rows[m.cursor].section]
+				s.collapsed = !s.collapsed
+				// keep the cursor on the section header
+				for i, r := range m.rows() {
+					if r.section == rows[m.cursor].section && r.item < 0 {
+						m.cursor = i
+					}
+				}
+			}
+		case "y":
+			if m.cursor < len(rows) && rows[m.cursor].item >= 0 {
+				r := rows[m.cursor]
+				path := pathOf(m.sections[r.section].items[r.item])
+				osc52.New(path).WriteTo(os.Stderr)
+				m.message = "copied " + path
+			}
+		case "r":
+			m.watcher.Refresh()
+		}
+	}
+	return m, nil
+}
+
+// setStatus replaces the displayed status, keeping collapsed sections and the
+// cursor position where possible.
+func (m *model) setStatus(s *statusv2.Status) {
+	collapsed := map[string]bool{}
+	for _, sec := range m.sections {
+		collapsed[sec.title] = sec.collapsed
+	}
+	m.status = s
+	m.sections = buildSections(s)
+	for i := range m.sections {
+		m.sections[i].collapsed = collapsed[m.sections[i].title]
+	}
+	m.cursor = min(m.cursor, max(len(m.rows())-1, 0))
+}
+
+// buildSections groups the entries of s into sections, in the order of
+// urgency. Files with both staged and unstaged changes appear in both.
+func buildSections(s *statusv2.Status) []section {
+	all := []section{{title: "Conflicts"}, {title: "Staged"}, {title: "Unstaged"}, {title: "Untracked"}, {title: "Ignored"}}
+	const conflicts, staged, unstaged, untracked, ignored = 0, 1, 2, 3, 4
+	add := func(i int, state, path string) {
+		all[i].items = append(all[i].items, item{state: state, path: path})
+	}
+	for _, entry := range s.Entries {
+		switch e := entry.(type) {
+		case statusv2.ChangedEntry:
+			if e.XY.HasStaged() {
+				add(staged, string(e.XY.X), e.Path)
+			}
+			if e.XY.HasUnstaged() {
+				add(unstaged, string(e.XY.Y), e.Path)
+			}
+		case statusv2.RenameOrCopyEntry:
+			if e.XY.HasStaged() {
+				add(staged, string(e.XY.X), e.Orig+" -> "+e.Path)
+			}
+			if e.XY.HasUnstaged() {
+				add(unstaged, string(e.XY.Y), e.Path)
+			}
+		case statusv2.UnmergedEntry:
+			add(conflicts, e.XY.String(), e.Path)
+		case statusv2.UntrackedEntry:
+			add(untracked, "?", e.Path)
+		case statusv2.IgnoredEntry:
+			add(ignored, "!", e.Path)
+		}
+	}
+
+	var sections []section
+	for _, sec := range all {
+		if len(sec.items) > 0 {
+			sections = append(sections, sec)
+		}
+	}
+	return sections
+}
+
+// pathOf returns the current path of an item, without the original path of
+// renames.
+func pathOf(it item) string {
+	if _, after, ok := strings.Cut(it.path, " -> "); ok {
+		return after
+	}
+	return it.path
+}
+
+func (m *model) rows() []row {
+	var rows []row
+	for i, sec := range m.sections {
+		rows = append(rows, row{section: i, item: -1})
+		if sec.collapsed {
+			continue
+		}
+		for j := range sec.items {
+			rows = append(rows, row{section: i, item: j})
+		}
+	}
+	return rows
+}
+
+func (m *model) View() string {
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(m.header()) + "\n\n")
+
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(m.err.Error()) + "\n")
+	} else if m.status != nil && len(m.sections) == 0 {
+		b.WriteString("nothing to commit, working tree clean\n")
+	}
+
+	rows := m.rows()
+	// scroll to keep the cursor visible, leaving room for the header and footer
+	first, visible := 0, len(rows)
+	if m.height > 4 && len(rows) > m.height-4 {
+		visible = m.height - 4
+		first = min(max(m.cursor-visible/2, 0), len(rows)-visible)
+	}
+	for i := first; i < first+visible; i++ {
+		r := rows[i]
+		sec := m.sections[r.section]
+		var line string
+		if r.item < 0 {
+			marker := "▾"
+			if sec.collapsed {
+				marker = "▸"
+			}
+			line = sectionStyle.Render(fmt.Sprintf("%s %s (%d)", marker, sec.title, len(sec.items)))
+		} else {
+			it := sec.items[r.item]
+			state := lipgloss.NewStyle().Foreground(sectionColors[sec.title]).Render(fmt.Sprintf("%-2s", it.state))
+			line = "    " + state + " " + it.path
+		}
+		if i == m.cursor {
+			line = cursorStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n" + messageStyle.Render(m.footer()))
+	return b.String()
+}
+
+func (m *model) header() string {
+	if m.status == nil || m.status.Branch == nil {
+		return "git status"
+	}
+	br := m.status.Branch
+	h := "On branch " + br.Head
+	if commit, ok := br.Commit(); br.IsDetached() && ok && len(commit) >= 7 {
+		h = "HEAD detached at " + string(commit[:7])
+	}
+	if br.HasUpstream() {
+		h += fmt.Sprintf(" (%s, ahead %d, behind %d)", br.Upstream, br.Ahead, br.Behind)
+	}
+	if m.status.Stash != nil {
+		h += fmt.Sprintf(", %d stashed", m.status.Stash.Count)
+	}
+	return h
+}
+
+func (m *model) footer() string {
+	if m.message != "" {
+		return m.message
+	}
+	return "j/k move • enter toggle • y copy path • r refresh • q quit"
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// decodeJSON reads the json or jsonl output of this tool for the given
+// porcelain format from r, and writes it to w as porcelain output again.
+func decodeJSON(w io.Writer, r io.Reader, format string) error {
+	switch format {
+	case "v1", "v1z":
+		s, err := decodeV1(json.NewDecoder(r))
+		if err != nil {
+			return err
+		}
+		if format == "v1z" {
+			return statusv1.EncodeZ(w, s)
+		}
+		return statusv1.Encode(w, s)
+
+	case "v2", "v2z":
+		s, err := statusv2.NewJSONDecoder(r).Decode()
+		if err != nil {
+			return err
+		}
+		if format == "v2z" {
+			return statusv2.EncodeZ(w, s)
+		}
+		return statusv2.Encode(w, s)
+
+	default:
+		return fmt.Errorf("unsupported -format flag value: %s", format)
+	}
+}
+
+// decodeV1 reads porcelain=v1 records from dec, which is either a complete
+// status or a sequence of header and entry records.
+func decodeV1(dec *json.Decoder) (*statusv1.Status, error) {
+	var s statusv1.Status
+	for {
+		var rec struct {
+			Header   *string
+			Headers  []string
+			Entries  []statusv1.Entry
+			XY       *statusv1.XYFlag
+			Path     string
+			OrigPath string
+		}
+		if err := dec.Decode(&rec); errors.Is(err, io.EOF) {
+			return &s, nil
+		} else if err != nil {
+			return nil, err
+		}
+		switch {
+		case rec.Header != nil:
+			s.Headers = append(s.Headers, *rec.Header)
+		case rec.XY != nil:
+			s.Entries = append(s.Entries, statusv1.Entry{XY: *rec.XY, Path: rec.Path, OrigPath: rec.OrigPath})
+		default:
+			s.Headers = append(s.Headers, rec.Headers...)
+			s.Entries = append(s.Entries, rec.Entries...)
+		}
+	}
+}
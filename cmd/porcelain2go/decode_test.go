@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mroth/porcelain/corpus"
+)
+
+// TestDecodeJSON checks that decoding the json and jsonl output for porcelain
+// output with -z reproduces it byte for byte.
+func TestDecodeJSON(t *testing.T) {
+	cases, err := corpus.Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		if !strings.HasSuffix(c.Format, "z") {
+			continue // paths are quoted without -z, and kept quoted in JSON
+		}
+		for _, output := range []string{"json", "jsonl"} {
+			t.Run(c.Name()+"/"+output, func(t *testing.T) {
+				data := encodeOutput(t, c.Input, c.Format, output)
+				var buf bytes.Buffer
+				if err := decodeJSON(&buf, bytes.NewReader(data), c.Format); err != nil {
+					t.Fatalf("decodeJSON() error = %v", err)
+				}
+				if got := buf.Bytes(); !bytes.Equal(got, c.Input) {
+					t.Errorf("decodeJSON() = %q, want %q", got, c.Input)
+				}
+			})
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	input := corpusInput(t, "changes", "v2z")
+	data, code := runMain(t, input, "-format", "v2z", "-o", "jsonl")
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	got, code := runMain(t, data, "-format", "v2z", "-decode")
+	if code != 0 {
+		t.Fatalf("-decode exit code = %d, want 0", code)
+	}
+	if !bytes.Equal(got, input) {
+		t.Errorf("-decode wrote %q, want %q", got, input)
+	}
+}
+
+// TestDecodeJSON_InvalidUTF8 checks that paths that are not valid UTF-8 are
+// reproduced exactly.
+func TestDecodeJSON_InvalidUTF8(t *testing.T) {
+	input := []byte("? caf\xe9.txt\x00! \xff\xfe\x00")
+	for _, output := range []string{"json", "jsonl"} {
+		data := encodeOutput(t, input, "v2z", output)
+		var buf bytes.Buffer
+		if err := decodeJSON(&buf, bytes.NewReader(data), "v2z"); err != nil {
+			t.Fatalf("decodeJSON(%s) error = %v", output, err)
+		}
+		if got := buf.Bytes(); !bytes.Equal(got, input) {
+			t.Errorf("decodeJSON(%s) = %q, want %q", output, got, input)
+		}
+	}
+}
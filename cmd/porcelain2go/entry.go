@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// v2Status is the JSON shape of a porcelain=v2 status, which adds the type of
+// each entry so that it can be decoded again.
+type v2Status struct {
+	Branch  *statusv2.BranchInfo
+	Stash   *statusv2.StashInfo
+	Entries []v2Entry
+}
+
+func newV2Status(s *statusv2.Status) *v2Status {
+	entries := make([]v2Entry, len(s.Entries))
+	for i, e := range s.Entries {
+		entries[i] = v2Entry{e}
+	}
+	return &v2Status{Branch: s.Branch, Stash: s.Stash, Entries: entries}
+}
+
+func (s *v2Status) status() *statusv2.Status {
+	entries := make([]statusv2.Entry, len(s.Entries))
+	for i, e := range s.Entries {
+		entries[i] = e.Entry
+	}
+	return &statusv2.Status{Branch: s.Branch, Stash: s.Stash, Entries: entries}
+}
+
+// v2Entry is the JSON shape of a porcelain=v2 status entry: the fields of the
+// entry, preceded by a Type field naming the kind of entry.
+type v2Entry struct {
+	statusv2.Entry
+}
+
+func (e v2Entry) MarshalJSON() ([]byte, error) {
+	return statusv2.MarshalEntryJSON(e.Entry)
+}
+
+func (e *v2Entry) UnmarshalJSON(data []byte) error {
+	var err error
+	e.Entry, err = statusv2.UnmarshalEntryJSON(data)
+	return err
+}
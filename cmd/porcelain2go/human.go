@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+	"github.com/mroth/porcelain/theme"
+)
+
+// colorEnabled reports whether -o human output is colorized, as set by the
+// -color flag, where "auto" colorizes when stdout is a terminal and NO_COLOR
+// is not set.
+func colorEnabled(when string) (bool, error) {
+	switch when {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		fi, err := os.Stdout.Stat()
+		return err == nil && fi.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("unsupported -color flag value: %s", when)
+	}
+}
+
+// humanEntry is a status entry as written by -o human.
+type humanEntry struct {
+	x, y   byte   // status codes, as in git status --short
+	xColor string // SGR parameters of x
+	yColor string // SGR parameters of y
+	path   string
+	orig   string // original path of a rename or copy
+}
+
+// humanWriter writes status in the style of git status --short --branch, with
+// the colors of a theme, and the arrows of renames and copies aligned.
+type humanWriter struct {
+	colors theme.Palette
+	color  bool
+}
+
+// writeHuman writes v for reading by people, with colors if enabled.
+func writeHuman(w io.Writer, v any, color bool) error {
+	h := humanWriter{colors: theme.Default.Colors, color: color}
+	bw := bufio.NewWriter(w)
+	switch s := v.(type) {
+	case *statusv1.Status:
+		for _, header := range s.Headers {
+			bw.WriteString(h.paint(h.colors.Branch, header) + "\n")
+		}
+		h.writeEntries(bw, v1HumanEntries(s.Entries, h.colors))
+	case *v2Status:
+		if s.Branch != nil {
+			bw.WriteString(h.branch(s.Branch) + "\n")
+		}
+		if s.Stash != nil && s.Stash.Count > 0 {
+			bw.WriteString("## " + h.paint(h.colors.Stash, "stash "+strconv.Itoa(s.Stash.Count)) + "\n")
+		}
+		h.writeEntries(bw, v2HumanEntries(s.status().Entries, h.colors))
+	case Summary:
+		bw.WriteString(s.String() + "\n")
+	default:
+		return fmt.Errorf("cannot write %T as human output", v)
+	}
+	return bw.Flush()
+}
+
+// branch returns the branch header of a porcelain=v2 status in the form of
+// porcelain=v1, e.g. "## main...origin/main [ahead 1, behind 2]".
+func (h humanWriter) branch(b *statusv2.BranchInfo) string {
+	head := h.paint(h.colors.Branch, b.Head)
+	switch {
+	case b.IsDetached():
+		head = h.paint(h.colors.Detached, "HEAD (no branch)")
+	case b.IsUnborn():
+		head = "No commits yet on " + head
+	}
+	if !b.HasUpstream() {
+		return "## " + head
+	}
+	head += "..." + h.paint(h.colors.Branch, b.Upstream)
+	var ab []string
+	if b.Ahead > 0 {
+		ab = append(ab, h.paint(h.colors.Ahead, "ahead "+strconv.Itoa(b.Ahead)))
+	}
+	if b.Behind > 0 {
+		ab = append(ab, h.paint(h.colors.Behind, "behind "+strconv.Itoa(b.Behind)))
+	}
+	if len(ab) > 0 {
+		head += " [" + strings.Join(ab, ", ") + "]"
+	}
+	return "## " + head
+}
+
+// writeEntries writes one line per entry, padding the original paths of
+// renames and copies so that their arrows line up.
+func (h humanWriter) writeEntries(w *bufio.Writer, entries []humanEntry) {
+	width := 0
+	for _, e := range entries {
+		if e.orig != "" {
+			width = max(width, utf8.RuneCountInString(e.orig))
+		}
+	}
+	for _, e := range entries {
+		w.WriteString(h.paint(e.xColor, string(e.x)))
+		w.WriteString(h.paint(e.yColor, string(e.y)))
+		w.WriteString(" ")
+		if e.orig != "" {
+			w.WriteString(e.orig)
+			w.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(e.orig)))
+			w.WriteString(" -> ")
+		}
+		w.WriteString(e.path + "\n")
+	}
+}
+
+// paint returns text wrapped in the escape sequences for color, if enabled and
+// the text is not blank.
+func (h humanWriter) paint(color, text string) string {
+	if !h.color || color == "" || strings.TrimSpace(text) == "" {
+		return text
+	}
+	return "\x1b[" + color + "m" + text + "\x1b[0m"
+}
+
+// humanColors returns the colors of the status codes of an entry with the
+// porcelain=v1 status code x.
+func humanColors(x byte, conflict bool, colors theme.Palette) (string, string) {
+	switch {
+	case conflict:
+		return colors.Conflicts, colors.Conflicts
+	case x == '?' || x == '!':
+		return colors.Untracked, colors.Untracked
+	}
+	return colors.Staged, colors.Unstaged
+}
+
+func v1HumanEntries(entries []statusv1.Entry, colors theme.Palette) []humanEntry {
+	out := make([]humanEntry, len(entries))
+	for i, e := range entries {
+		x, y := byte(e.XY.X), byte(e.XY.Y)
+		xc, yc := humanColors(x, v1Unmerged[e.XY.String()], colors)
+		out[i] = humanEntry{x: x, y: y, xColor: xc, yColor: yc, path: e.Path, orig: e.OrigPath}
+	}
+	return out
+}
+
+func v2HumanEntries(entries []statusv2.Entry, colors theme.Palette) []humanEntry {
+	out := make([]humanEntry, 0, len(entries))
+	for _, entry := range entries {
+		var (
+			xy         statusv2.XYFlag
+			path, orig string
+			x, y       byte
+			conflict   bool
+		)
+		switch e := entry.(type) {
+		case statusv2.ChangedEntry:
+			xy, path = e.XY, e.Path
+		case statusv2.RenameOrCopyEntry:
+			xy, path, orig = e.XY, e.Path, e.Orig
+		case statusv2.UnmergedEntry:
+			xy, path, conflict = e.XY, e.Path, true
+		case statusv2.UntrackedEntry:
+			x, y, path = '?', '?', e.Path
+		case statusv2.IgnoredEntry:
+			x, y, path = '!', '!', e.Path
+		default:
+			continue
+		}
+		if x == 0 {
+			x, y = v1State(xy.X), v1State(xy.Y)
+		}
+		xc, yc := humanColors(x, conflict, colors)
+		out = append(out, humanEntry{x: x, y: y, xColor: xc, yColor: yc, path: path, orig: orig})
+	}
+	return out
+}
+
+// v1State returns the porcelain=v1 status code of a porcelain=v2 state.
+func v1State(s statusv2.State) byte {
+	if s == statusv2.Unmodified {
+		return byte(statusv1.Unmodified)
+	}
+	return byte(s)
+}
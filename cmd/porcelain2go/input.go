@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"os"
+)
+
+// openInput opens the named file for reading, or stdin for "-", decompressing
+// it if it is compressed.
+func openInput(name string) (io.Reader, error) {
+	var f *os.File = os.Stdin
+	if name != "-" {
+		var err error
+		if f, err = os.Open(name); err != nil {
+			return nil, err
+		}
+	}
+	return decompress(bufio.NewReader(f))
+}
+
+// decompress returns the contents of r, decompressed if they are gzip or
+// bzip2 compressed, as detected from their first bytes, which porcelain output
+// and JSON never start with.
+func decompress(r *bufio.Reader) (io.Reader, error) {
+	magic, _ := r.Peek(3)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return bufio.NewReader(zr), nil
+	case bytes.HasPrefix(magic, []byte("BZh")):
+		return bufio.NewReader(bzip2.NewReader(r)), nil
+	}
+	return r, nil
+}
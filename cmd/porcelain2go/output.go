@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// Encoder writes parsed results to w.
+type Encoder func(w io.Writer, v any) error
+
+func getEncoder(output string, compact, color bool) (Encoder, error) {
+	switch output {
+	case "json":
+		return func(w io.Writer, v any) error { return writeJSON(w, v, compact) }, nil
+	case "jsonl":
+		return writeJSONLines, nil
+	case "yaml":
+		return writeYAML, nil
+	case "text":
+		return writeText, nil
+	case "human":
+		return func(w io.Writer, v any) error { return writeHuman(w, v, color) }, nil
+	default:
+		return nil, fmt.Errorf("unsupported -o flag value: %s", output)
+	}
+}
+
+func writeJSON(w io.Writer, v any, compact bool) error {
+	enc := json.NewEncoder(w)
+	if !compact {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(v)
+}
+
+// writeText writes v in its single line text form, which is only available
+// for a [Summary].
+func writeText(w io.Writer, v any) error {
+	s, ok := v.(Summary)
+	if !ok {
+		return fmt.Errorf("text output requires -summary")
+	}
+	_, err := fmt.Fprintln(w, s)
+	return err
+}
+
+// writeJSONLines writes each header and entry of a status as a separate JSON
+// object on its own line, headers first. Other values are written as a single
+// line.
+func writeJSONLines(w io.Writer, v any) error {
+	var records []any
+	switch s := v.(type) {
+	case *statusv1.Status:
+		records = v1HeaderRecords(s.Headers)
+		for _, e := range s.Entries {
+			records = append(records, e)
+		}
+	case *v2Status:
+		records = v2HeaderRecords(s.Branch, s.Stash)
+		for _, e := range s.Entries {
+			records = append(records, e)
+		}
+	default:
+		records = append(records, v)
+	}
+
+	enc := json.NewEncoder(w)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// v1HeaderRecords returns the JSON Lines records for porcelain=v1 headers.
+func v1HeaderRecords(headers []string) []any {
+	var records []any
+	for _, h := range headers {
+		records = append(records, struct{ Header string }{h})
+	}
+	return records
+}
+
+// v2HeaderRecords returns the JSON Lines records for porcelain=v2 headers.
+func v2HeaderRecords(branch *statusv2.BranchInfo, stash *statusv2.StashInfo) []any {
+	var records []any
+	if branch != nil {
+		records = append(records, struct{ Branch *statusv2.BranchInfo }{branch})
+	}
+	if stash != nil {
+		records = append(records, struct{ Stash *statusv2.StashInfo }{stash})
+	}
+	return records
+}
+
+// writeYAML writes v as a YAML document, by way of its JSON encoding so that
+// field names and custom marshalers are shared with the JSON output.
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	node, err := decodeNode(dec)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	if node.kind == nodeScalar || len(node.children) == 0 {
+		bw.WriteString(node.flow() + "\n")
+	} else {
+		node.writeBlock(bw, 0)
+	}
+	return bw.Flush()
+}
+
+type nodeKind int
+
+const (
+	nodeScalar nodeKind = iota
+	nodeObject
+	nodeArray
+)
+
+// node is a JSON value, retaining the order of object keys.
+type node struct {
+	kind     nodeKind
+	scalar   string   // YAML representation, for scalars
+	keys     []string // for objects
+	children []*node  // for objects and arrays
+}
+
+func decodeNode(dec *json.Decoder) (*node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		n := &node{kind: nodeArray}
+		if t == '{' {
+			n.kind = nodeObject
+		}
+		for dec.More() {
+			if n.kind == nodeObject {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, key.(string))
+			}
+			child, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, child)
+		}
+		if _, err := dec.Token(); err != nil { // closing delimiter
+			return nil, err
+		}
+		return n, nil
+	case string:
+		return &node{scalar: yamlString(t)}, nil
+	case json.Number:
+		return &node{scalar: t.String()}, nil
+	case bool:
+		return &node{scalar: fmt.Sprint(t)}, nil
+	default:
+		return &node{scalar: "null"}, nil
+	}
+}
+
+// flow returns the single-line representation of scalars and empty
+// collections.
+func (n *node) flow() string {
+	switch {
+	case n.kind == nodeObject && len(n.children) == 0:
+		return "{}"
+	case n.kind == nodeArray && len(n.children) == 0:
+		return "[]"
+	default:
+		return n.scalar
+	}
+}
+
+// inline reports whether n is written on the same line as its key or item
+// marker.
+func (n *node) inline() bool {
+	return n.kind == nodeScalar || len(n.children) == 0
+}
+
+// writeBlock writes a non-empty collection in block style at the given
+// indentation. The first line is written without indentation, so that array
+// items can start on the line of their "- " marker.
+func (n *node) writeBlock(w *bufio.Writer, indent int) {
+	pad := strings.Repeat("  ", indent)
+	for i, child := range n.children {
+		if i > 0 {
+			w.WriteString(pad)
+		}
+		if n.kind == nodeArray {
+			w.WriteString("- ")
+			if child.inline() {
+				w.WriteString(child.flow() + "\n")
+			} else {
+				child.writeBlock(w, indent+1)
+			}
+			continue
+		}
+		w.WriteString(yamlString(n.keys[i]) + ":")
+		if child.inline() {
+			w.WriteString(" " + child.flow() + "\n")
+			continue
+		}
+		w.WriteString("\n" + pad + "  ")
+		child.writeBlock(w, indent+1)
+	}
+}
+
+var plainScalar = regexp.MustCompile(`^[A-Za-z_./(][A-Za-z0-9_./() -]*$`)
+
+// yamlString returns s as a YAML scalar, quoting it unless it can be
+// represented as a plain string that would not be read back as another type.
+func yamlString(s string) string {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~", ".inf", ".nan":
+		return quoteString(s)
+	}
+	if strings.HasPrefix(s, ".") && len(s) > 1 && s[1] >= '0' && s[1] <= '9' {
+		return quoteString(s) // e.g. ".5", a float
+	}
+	if plainScalar.MatchString(s) && !strings.HasSuffix(s, " ") {
+		return s
+	}
+	return quoteString(s)
+}
+
+// quoteString returns s as a double-quoted scalar. JSON string escapes are a
+// subset of those in YAML double-quoted scalars, but JSON leaves some
+// characters unescaped that YAML does not allow, which are escaped here.
+func quoteString(s string) string {
+	b, _ := json.Marshal(s)
+	return yamlUnprintable.ReplaceAllStringFunc(string(b), func(c string) string {
+		return fmt.Sprintf(`\u%04x`, []rune(c)[0])
+	})
+}
+
+// yamlUnprintable matches the characters YAML requires to be escaped that
+// JSON does not: DEL, the C1 control characters and the byte order mark.
+var yamlUnprintable = regexp.MustCompile(`[\x7f-\x{9f}\x{feff}]`)
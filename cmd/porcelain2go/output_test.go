@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/corpus"
+	"github.com/mroth/porcelain/statusv1"
+	"gopkg.in/yaml.v3"
+)
+
+// checkYAML checks that the YAML output of v reads back as its JSON output.
+func checkYAML(t *testing.T, v any) {
+	t.Helper()
+	var yamlOut, jsonOut bytes.Buffer
+	if err := writeYAML(&yamlOut, v); err != nil {
+		t.Fatalf("writeYAML() error = %v", err)
+	}
+	if err := writeJSON(&jsonOut, v, false); err != nil {
+		t.Fatalf("writeJSON() error = %v", err)
+	}
+
+	var fromYAML any
+	if err := yaml.Unmarshal(yamlOut.Bytes(), &fromYAML); err != nil {
+		t.Fatalf("invalid YAML: %v\n%s", err, yamlOut.Bytes())
+	}
+	// Round-trip the YAML value through JSON, for the same Go types.
+	data, err := json.Marshal(fromYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, want any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &want); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("YAML differs from JSON (-json +yaml):\n%s\nYAML:\n%s", diff, yamlOut.Bytes())
+	}
+}
+
+func TestWriteYAML_Corpus(t *testing.T) {
+	cases, err := corpus.Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		t.Run(c.Name(), func(t *testing.T) {
+			parse, err := getStatusParser(c.Format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, err := parse(bytes.NewReader(c.Input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkYAML(t, v)
+			sum, err := summarize(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkYAML(t, sum)
+		})
+	}
+}
+
+func TestWriteYAML_Strings(t *testing.T) {
+	tests := []string{
+		"",
+		"file.txt",
+		"dir/file name.txt",
+		"(initial)",
+		"true", "False", "yes", "NO", "on", "y", "null", "~",
+		"123", "1.5", "0x1F", "1e3", ".5", ".inf", "-1",
+		"- item", "key: value", "# comment", "a #b", "trailing ", " leading",
+		"&anchor", "*alias", "!tag", "%directive", "@at", "`tick", "|", ">",
+		"[a]", "{a}", "a,b", `"quoted"`, "'single'",
+		"tab\there", "new\nline", "back\\slash", "ünïcödé", "\x7f", "\u0085", "\ufeffbom",
+		"...", "---",
+	}
+	for _, s := range tests {
+		t.Run(s, func(t *testing.T) {
+			checkYAML(t, &statusv1.Status{
+				Headers: []string{s},
+				Entries: []statusv1.Entry{{XY: statusv1.XYFlag{X: statusv1.Added, Y: statusv1.Unmodified}, Path: s, OrigPath: s}},
+			})
+		})
+	}
+}
+
+func TestWriteYAML_Empty(t *testing.T) {
+	checkYAML(t, &statusv1.Status{})
+	checkYAML(t, &v2Status{})
+	checkYAML(t, Summary{})
+}
@@ -0,0 +1,355 @@
+// Command porcelain2go converts porcelain output of `git status` into JSON.
+// It reads from stdin, or a file given as argument, and writes to stdout, so
+// it can be used in a pipeline.
+// It is primarily intended for use in testing and debugging on the CLI, and in
+// scripts.
+//
+// Install it with:
+//
+//	go install github.com/mroth/porcelain/cmd/porcelain2go@latest
+//
+// Usage example:
+//
+//	git status --porcelain=v1 | porcelain2go -format v1
+//	git status --porcelain=v2 | porcelain2go -format v2
+//	git status --porcelain=v1 -z | porcelain2go -format v1z
+//	git status --porcelain=v2 -z | porcelain2go -format v2z
+//
+// Input files may be gzip or bzip2 compressed, which is detected from their
+// contents, as for saved captures:
+//
+//	porcelain2go -format v2z status.v2z.gz
+//
+// With -exec, git is run directly instead of reading from stdin. Giving a
+// directory as argument implies -exec, running git in that directory:
+//
+//	porcelain2go -exec -C path/to/repo -branch -show-stash -untracked all
+//	porcelain2go -branch path/to/repo
+//
+// Results are written as indented JSON by default. Use -compact for JSON on a
+// single line, -o yaml for YAML, or -o jsonl for JSON Lines, with one line per
+// header and entry.
+//
+// With -o human, results are written for reading by people instead, in the
+// style of `git status --short --branch` with the colors of the default theme,
+// as when pretty-printing a captured status. Colors are used when writing to a
+// terminal and NO_COLOR is not set, unless -color is always or never:
+//
+//	porcelain2go -format v2z -o human status.v2z.gz | less -R
+//
+// With -stream, JSON Lines are written as the input is parsed rather than once
+// it has all been read, for use on very large outputs or in pipelines:
+//
+//	git status --porcelain=v2 -z | porcelain2go -format v2z -stream | jq .Path
+//
+// With -summary, only aggregate counts are written, which -o text formats as a
+// single human-readable line:
+//
+//	porcelain2go -exec -branch -show-stash -summary -o text
+//
+// With -quiet, nothing is written, and the exit code reports the state of the
+// worktree, similar to `git diff --quiet`: 0 if there are no entries other
+// than ignored files, 2 if there are conflicts (see -conflict-exit), or 1
+// otherwise. Errors then exit with code 128 instead of 1, so they are not
+// mistaken for a dirty worktree:
+//
+//	if porcelain2go -exec -quiet; then echo clean; fi
+//
+// With -decode, the json or jsonl output of the tool is read from stdin and
+// written back out as porcelain output in the given -format, so that it can be
+// edited with JSON tools to produce fixtures:
+//
+//	porcelain2go -exec -format v2 | jq '.Entries |= .[:1]' | porcelain2go -decode -format v2z
+//
+// # JSON Output
+//
+// The flags described above and the JSON output are stable, and will only
+// change in backwards compatible ways, such as by adding new fields.
+//
+// For porcelain=v1, the output is an object with the header lines and entries:
+//
+//	{"Headers": ["## main"], "Entries": [{"XY": "R ", "Path": "new", "OrigPath": "old"}]}
+//
+// For porcelain=v2, the output is an object with the branch and stash headers,
+// which are null if not present, and the entries. Each entry has a Type of
+// "changed", "rename_or_copy", "unmerged", "untracked" or "ignored", followed by
+// the fields of the corresponding [statusv2.Entry] type:
+//
+//	{"Branch": {"OID": "...", "Head": "main", "Upstream": "", "Ahead": 0, "Behind": 0},
+//	 "Stash": {"Count": 1},
+//	 "Entries": [{"Type": "untracked", "Path": "file.txt"}]}
+//
+// Fields of an entry that are not valid UTF-8, such as paths in other
+// encodings, are written in base64 and listed in its Base64 field, so that
+// they can be decoded exactly:
+//
+//	{"Type": "untracked", "Path": "Y2Fm6Q==", "Base64": ["Path"]}
+//
+// With -o jsonl or -stream, each header is written as its own object, i.e.
+// {"Header": "## main"} for porcelain=v1, or {"Branch": {...}} and
+// {"Stash": {...}} for porcelain=v2, followed by one object per entry.
+//
+// With -schema, the JSON Schema of the output for the given -format is printed
+// instead, describing each line for -o jsonl and -stream, or the summary with
+// -summary. The schema is generated from the same types as the output, so can
+// be used to generate types for it in other languages, or to validate it:
+//
+//	porcelain2go -format v2 -schema > porcelain2go.schema.json
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+var (
+	porcelainVersion = flag.String("format", "v2", "porcelain version to parse [v1, v1z, v2, v2z]")
+	outputFormat     = flag.String("o", "json", "output format [json, jsonl, yaml, text (with -summary), human]")
+	compact          = flag.Bool("compact", false, "write JSON without indentation")
+	stream           = flag.Bool("stream", false, "write JSON Lines as entries are parsed, instead of after reading all input")
+	summary          = flag.Bool("summary", false, "write only aggregate counts of entries, ahead/behind and stash")
+	quiet            = flag.Bool("quiet", false, "write nothing, and exit 0 when clean, 1 when dirty, or -conflict-exit when there are conflicts")
+	decode           = flag.Bool("decode", false, "read json or jsonl output of this tool, and write it as porcelain output in -format")
+	conflictExit     = flag.Int("conflict-exit", 2, "exit `code` for conflicts (with -quiet)")
+	colorWhen        = flag.String("color", "auto", "colorize -o human output `when` [auto, always, never]")
+	printSchema      = flag.Bool("schema", false, "print the JSON Schema of the output for -format, -o and -summary, and exit")
+
+	showVersion = flag.Bool("version", false, "print the version and exit")
+
+	execGit   = flag.Bool("exec", false, "run git status directly instead of reading from stdin")
+	dir       = flag.String("C", "", "run git in `dir` (with -exec)")
+	branch    = flag.Bool("branch", false, "include branch information (with -exec)")
+	showStash = flag.Bool("show-stash", false, "include stash information (with -exec)")
+	untracked = flag.String("untracked", "", "untracked files `mode` [no, normal, all] (with -exec)")
+)
+
+// statusArgs returns the arguments for running git status to produce output
+// in the given porcelain format.
+func statusArgs(format string) []string {
+	version, z := strings.CutSuffix(format, "z")
+	args := []string{"status", "--porcelain=" + version}
+	if z {
+		args = append(args, "-z")
+	}
+	if *branch {
+		args = append(args, "--branch")
+	}
+	if *showStash {
+		args = append(args, "--show-stash")
+	}
+	if *untracked != "" {
+		args = append(args, "--untracked-files="+*untracked)
+	}
+	return args
+}
+
+type StatusParser func(io.Reader) (any, error)
+
+func getStatusParser(format string) (StatusParser, error) {
+	switch format {
+	case "v1":
+		return func(r io.Reader) (any, error) { return statusv1.Parse(r) }, nil
+	case "v1z":
+		return func(r io.Reader) (any, error) { return statusv1.ParseZ(r) }, nil
+	case "v2":
+		return func(r io.Reader) (any, error) { return statusv2.Parse(r) }, nil
+	case "v2z":
+		return func(r io.Reader) (any, error) { return statusv2.ParseZ(r) }, nil
+	default:
+		return nil, fmt.Errorf("unsupported -format flag value: %s", format)
+	}
+}
+
+// version returns the module version the binary was built from, which is
+// known when installed with `go install`.
+func version() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: porcelain2go [flags] [file | dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *showVersion {
+		fmt.Println("porcelain2go", version())
+		return
+	}
+
+	parser, err := getStatusParser(*porcelainVersion)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	color, err := colorEnabled(*colorWhen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	encode, err := getEncoder(*outputFormat, *compact, color)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *summary && *stream {
+		fmt.Fprintln(os.Stderr, "error: -summary and -stream cannot be used together")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *outputFormat == "text" && !*summary {
+		fmt.Fprintln(os.Stderr, "error: -o text requires -summary")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *printSchema {
+		if *outputFormat == "text" || *outputFormat == "human" {
+			fmt.Fprintf(os.Stderr, "error: -schema is not available for -o %s\n", *outputFormat)
+			os.Exit(2)
+		}
+		schema, err := outputSchema(*porcelainVersion, *summary, *outputFormat == "jsonl" || *stream)
+		if err != nil {
+			fatalf("fatal: %v", err)
+		}
+		if err := writeJSON(os.Stdout, schema, *compact); err != nil {
+			fatalf("fatal: error writing schema: %v", err)
+		}
+		return
+	}
+
+	input := "-"
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		input = flag.Arg(0)
+	default:
+		fmt.Fprintln(os.Stderr, "error: at most one file or directory can be given")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if input != "-" {
+		if fi, err := os.Stat(input); err == nil && fi.IsDir() {
+			if *dir != "" || *decode {
+				fmt.Fprintln(os.Stderr, "error: a directory argument cannot be used with -C or -decode")
+				flag.Usage()
+				os.Exit(2)
+			}
+			*execGit, *dir, input = true, input, "-"
+		} else if *execGit {
+			fmt.Fprintln(os.Stderr, "error: -exec cannot be used with an input file")
+			flag.Usage()
+			os.Exit(2)
+		}
+	}
+
+	var in io.Reader
+	if !*execGit {
+		if in, err = openInput(input); err != nil {
+			fatalf("fatal: error reading input: %v", err)
+		}
+	}
+	if *decode {
+		if *execGit {
+			fmt.Fprintln(os.Stderr, "error: -decode and -exec cannot be used together")
+			flag.Usage()
+			os.Exit(2)
+		}
+		out := bufio.NewWriter(os.Stdout)
+		if err := decodeJSON(out, in, *porcelainVersion); err != nil {
+			fatalf("fatal: error decoding JSON input: %v", err)
+		}
+		if err := out.Flush(); err != nil {
+			fatalf("fatal: error writing results: %v", err)
+		}
+		return
+	}
+	if *execGit {
+		out, err := gitexec.New(*dir).Run(context.Background(), statusArgs(*porcelainVersion)...)
+		if err != nil {
+			fatalf("fatal: error running git status: %v", err)
+		}
+		in = bytes.NewReader(out)
+	}
+
+	if *stream && !*quiet {
+		out := bufio.NewWriter(os.Stdout)
+		if err := streamJSONLines(out, in, *porcelainVersion); err != nil {
+			out.Flush()
+			fatalf("fatal: error parsing porcelain output: %v", err)
+		}
+		if err := out.Flush(); err != nil {
+			fatalf("fatal: error writing results: %v", err)
+		}
+		return
+	}
+
+	results, err := parser(in)
+	if err != nil {
+		fatalf("fatal: error parsing porcelain output: %v", err)
+	}
+	if *quiet {
+		sum, err := summarize(results)
+		if err != nil {
+			fatalf("fatal: %v", err)
+		}
+		os.Exit(quietExitCode(sum))
+	}
+	if *summary {
+		if results, err = summarize(results); err != nil {
+			fatalf("fatal: %v", err)
+		}
+	} else if s, ok := results.(*statusv2.Status); ok {
+		results = newV2Status(s)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	if err := encode(out, results); err != nil {
+		fatalf("fatal: error encoding results: %v", err)
+	}
+	if err := out.Flush(); err != nil {
+		fatalf("fatal: error writing results: %v", err)
+	}
+}
+
+// quietExitCode returns the exit code for -quiet mode.
+func quietExitCode(s Summary) int {
+	switch {
+	case s.Conflicts > 0:
+		return *conflictExit
+	case s.Staged+s.Unstaged+s.Untracked > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// fatalf logs a fatal error and exits, with code 128 in -quiet mode, where 1
+// reports a dirty worktree.
+func fatalf(format string, v ...any) {
+	log.Printf(format, v...)
+	if *quiet {
+		os.Exit(128)
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/mroth/porcelain/corpus"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// TestMain runs the command instead of the tests when the test binary is run
+// by runMain, so that its exit codes can be tested.
+func TestMain(m *testing.M) {
+	if os.Getenv("PORCELAIN2GO_RUN_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runMain runs the command with the given arguments and stdin, returning its
+// stdout and exit code.
+func runMain(t *testing.T, stdin []byte, args ...string) ([]byte, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "PORCELAIN2GO_RUN_MAIN=1")
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return stdout.Bytes(), exitErr.ExitCode()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stdout.Bytes(), 0
+}
+
+// corpusInput returns the input of the corpus case for a scenario and format.
+func corpusInput(t *testing.T, scenario, format string) []byte {
+	t.Helper()
+	cases, err := corpus.Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		if c.Scenario == scenario && c.Format == format {
+			return c.Input
+		}
+	}
+	t.Fatalf("no corpus case for %s.%s", scenario, format)
+	return nil
+}
+
+func TestQuiet(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		args  []string
+		want  int
+	}{
+		{"clean", nil, []string{"-format", "v2"}, 0},
+		{"ignored only", []byte("! build/\n"), []string{"-format", "v2"}, 0},
+		{"untracked", []byte("? a.txt\n"), []string{"-format", "v2"}, 1},
+		{"changes", corpusInput(t, "changes", "v2z"), []string{"-format", "v2z"}, 1},
+		{"conflicts v1z", corpusInput(t, "conflicts", "v1z"), []string{"-format", "v1z"}, 2},
+		{"conflicts v2z", corpusInput(t, "conflicts", "v2z"), []string{"-format", "v2z"}, 2},
+		{"conflict exit code", corpusInput(t, "conflicts", "v2z"), []string{"-format", "v2z", "-conflict-exit", "3"}, 3},
+		{"invalid input", []byte("1 A.\n"), []string{"-format", "v2"}, 128},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, code := runMain(t, tt.input, append(tt.args, "-quiet")...)
+			if code != tt.want {
+				t.Errorf("exit code = %d, want %d", code, tt.want)
+			}
+			if len(out) > 0 {
+				t.Errorf("wrote %q, want no output", out)
+			}
+		})
+	}
+}
+
+func Test_quietExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		sum  Summary
+		want int
+	}{
+		{"clean", Summary{}, 0},
+		{"ignored", Summary{Ignored: 1, Ahead: 2, Stash: 1}, 0},
+		{"staged", Summary{Staged: 1}, 1},
+		{"unstaged", Summary{Unstaged: 1}, 1},
+		{"untracked", Summary{Untracked: 1}, 1},
+		{"conflicts", Summary{Staged: 1, Conflicts: 1}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quietExitCode(tt.sum); got != tt.want {
+				t.Errorf("quietExitCode(%+v) = %d, want %d", tt.sum, got, tt.want)
+			}
+		})
+	}
+}
+
+// encodeOutput parses input in the given porcelain format and encodes the
+// result in the given output format, as the command does.
+func encodeOutput(t *testing.T, input []byte, format, output string) []byte {
+	t.Helper()
+	parse, err := getStatusParser(format)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := parse(bytes.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := v.(*statusv2.Status); ok {
+		v = newV2Status(s)
+	}
+	encode, err := getEncoder(output, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := encode(&buf, v); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
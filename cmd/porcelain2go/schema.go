@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// outputSchema returns the JSON Schema of the output for the given porcelain
+// format, or of a single line of it if lines is set (for -o jsonl and
+// -stream), or of the summary instead if summary is set.
+func outputSchema(format string, summary, lines bool) (map[string]any, error) {
+	g := &schemaGen{defs: map[string]any{}}
+	var root map[string]any
+	var title string
+	switch {
+	case summary:
+		title = "porcelain2go summary"
+		root = g.schemaOf(reflect.TypeFor[Summary]())
+	case format == "v1" || format == "v1z":
+		title = "porcelain2go porcelain=v1 status"
+		root = g.schemaOf(reflect.TypeFor[statusv1.Status]())
+		if lines {
+			title += " line"
+			root = map[string]any{"oneOf": []any{
+				g.schemaOf(reflect.TypeFor[struct{ Header string }]()),
+				g.schemaOf(reflect.TypeFor[statusv1.Entry]()),
+			}}
+		}
+	case format == "v2" || format == "v2z":
+		title = "porcelain2go porcelain=v2 status"
+		root = g.schemaOf(reflect.TypeFor[v2Status]())
+		if lines {
+			title += " line"
+			root = map[string]any{"oneOf": []any{
+				g.schemaOf(reflect.TypeFor[struct{ Branch *statusv2.BranchInfo }]()),
+				g.schemaOf(reflect.TypeFor[struct{ Stash *statusv2.StashInfo }]()),
+				g.schemaOf(reflect.TypeFor[v2Entry]()),
+			}}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported -format flag value: %s", format)
+	}
+
+	schema := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   title,
+	}
+	for k, v := range root {
+		schema[k] = v
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+	if len(g.defs) > 0 {
+		schema["$defs"] = g.defs
+	}
+	return schema, nil
+}
+
+// schemaGen generates JSON Schemas for Go types from their JSON encoding,
+// collecting named struct types as definitions.
+type schemaGen struct {
+	defs map[string]any
+	err  error // first unsupported type found
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// schemaOf returns the schema of values of type t, or a reference to it in
+// the definitions for named struct types. For types without a JSON Schema,
+// such as maps and functions, it records an error in g.err.
+func (g *schemaGen) schemaOf(t reflect.Type) map[string]any {
+	if t.Implements(textMarshalerType) {
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.schemaOf(t.Elem()))
+	case reflect.Slice:
+		// nil slices are encoded as null
+		return nullable(map[string]any{"type": "array", "items": g.schemaOf(t.Elem())})
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Struct:
+		if t == reflect.TypeFor[v2Entry]() {
+			return g.v2EntrySchema()
+		}
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // reserve the name, in case of recursion
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	case reflect.Interface:
+		return map[string]any{}
+	default:
+		if g.err == nil {
+			g.err = fmt.Errorf("schema: unsupported type %s", t)
+		}
+		return map[string]any{}
+	}
+}
+
+// structSchema returns the schema of an object with the exported fields of
+// struct type t, following the encoding/json rules for field names.
+func (g *schemaGen) structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = g.schemaOf(f.Type)
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// v2EntrySchema returns the schema of a porcelain=v2 entry, which is one of
+// the entry types distinguished by its Type field.
+func (g *schemaGen) v2EntrySchema() map[string]any {
+	var oneOf []any
+	for _, e := range []statusv2.Entry{
+		statusv2.ChangedEntry{},
+		statusv2.RenameOrCopyEntry{},
+		statusv2.UnmergedEntry{},
+		statusv2.UntrackedEntry{},
+		statusv2.IgnoredEntry{},
+	} {
+		t := reflect.TypeOf(e)
+		if _, ok := g.defs[t.Name()]; !ok {
+			schema := g.structSchema(t)
+			schema["properties"].(map[string]any)["Type"] = map[string]any{"const": e.Type().String()}
+			schema["properties"].(map[string]any)["Base64"] = map[string]any{
+				"description": "fields encoded in base64, as they are not valid UTF-8",
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+			}
+			schema["required"] = append([]string{"Type"}, schema["required"].([]string)...)
+			g.defs[t.Name()] = schema
+		}
+		oneOf = append(oneOf, map[string]any{"$ref": "#/$defs/" + t.Name()})
+	}
+	return map[string]any{"oneOf": oneOf}
+}
+
+func nullable(schema map[string]any) map[string]any {
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// TestOutputSchema_V2Entries checks that the schema of each porcelain=v2 entry
+// type has the fields of its JSON encoding, all required, its Type, and the
+// optional Base64 field.
+func TestOutputSchema_V2Entries(t *testing.T) {
+	for _, lines := range []bool{false, true} {
+		schema, err := outputSchema("v2", false, lines)
+		if err != nil {
+			t.Fatalf("outputSchema(lines=%v) error = %v", lines, err)
+		}
+		defs := schema["$defs"].(map[string]any)
+		for _, e := range []statusv2.Entry{
+			statusv2.ChangedEntry{},
+			statusv2.RenameOrCopyEntry{},
+			statusv2.UnmergedEntry{},
+			statusv2.UntrackedEntry{},
+			statusv2.IgnoredEntry{},
+		} {
+			name := reflect.TypeOf(e).Name()
+			t.Run(name, func(t *testing.T) {
+				def, ok := defs[name].(map[string]any)
+				if !ok {
+					t.Fatalf("no definition of %s", name)
+				}
+				data, err := statusv2.MarshalEntryJSON(e)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var fields map[string]any
+				if err := json.Unmarshal(data, &fields); err != nil {
+					t.Fatal(err)
+				}
+				want := slices.Sorted(maps.Keys(fields))
+
+				properties := def["properties"].(map[string]any)
+				if got, want := slices.Sorted(maps.Keys(properties)), slices.Sorted(slices.Values(append(want, "Base64"))); !slices.Equal(got, want) {
+					t.Errorf("properties = %q, want %q", got, want)
+				}
+				if got := slices.Sorted(slices.Values(def["required"].([]string))); !slices.Equal(got, want) {
+					t.Errorf("required = %q, want %q", got, want)
+				}
+				typ := properties["Type"].(map[string]any)
+				if typ["const"] != e.Type().String() {
+					t.Errorf("Type = %v, want const %q", typ, e.Type())
+				}
+			})
+		}
+	}
+}
+
+func TestOutputSchema(t *testing.T) {
+	tests := []struct {
+		format         string
+		summary, lines bool
+		wantTitle      string
+	}{
+		{"v1", false, false, "porcelain2go porcelain=v1 status"},
+		{"v1z", false, true, "porcelain2go porcelain=v1 status line"},
+		{"v2", false, false, "porcelain2go porcelain=v2 status"},
+		{"v2z", false, true, "porcelain2go porcelain=v2 status line"},
+		{"v2", true, false, "porcelain2go summary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantTitle, func(t *testing.T) {
+			schema, err := outputSchema(tt.format, tt.summary, tt.lines)
+			if err != nil {
+				t.Fatalf("outputSchema() error = %v", err)
+			}
+			if schema["title"] != tt.wantTitle {
+				t.Errorf("title = %v, want %q", schema["title"], tt.wantTitle)
+			}
+			if _, err := json.Marshal(schema); err != nil {
+				t.Errorf("schema does not encode as JSON: %v", err)
+			}
+		})
+	}
+	if _, err := outputSchema("v3", false, false); err == nil {
+		t.Error("outputSchema(v3) error = nil, want unsupported format")
+	}
+}
+
+// TestSchemaGen_EntryTypes walks every porcelain=v2 entry type, checking that
+// its schema can be generated and that it is one of the entry schemas.
+func TestSchemaGen_EntryTypes(t *testing.T) {
+	g := &schemaGen{defs: map[string]any{}}
+	oneOf := g.v2EntrySchema()["oneOf"].([]any)
+	var n int
+	for typ := statusv2.EntryType(0); ; typ++ {
+		name, err := typ.MarshalText()
+		if err != nil {
+			break // past the last entry type
+		}
+		n++
+		e, err := statusv2.UnmarshalEntryJSON([]byte(`{"Type":"` + string(name) + `"}`))
+		if err != nil {
+			t.Fatalf("UnmarshalEntryJSON(%s) error = %v", name, err)
+		}
+		rt := reflect.TypeOf(e)
+		g.schemaOf(rt)
+		if g.err != nil {
+			t.Fatalf("schemaOf(%s) error = %v", rt, g.err)
+		}
+		if ref := map[string]any{"$ref": "#/$defs/" + rt.Name()}; !slices.ContainsFunc(oneOf, func(s any) bool { return reflect.DeepEqual(s, ref) }) {
+			t.Errorf("entry schema does not include %s", rt)
+		}
+	}
+	if len(oneOf) != n {
+		t.Errorf("entry schema has %d types, want %d", len(oneOf), n)
+	}
+}
+
+func TestSchemaGen_Unsupported(t *testing.T) {
+	for _, rt := range []reflect.Type{
+		reflect.TypeFor[map[string]int](),
+		reflect.TypeFor[struct{ F func() }](),
+		reflect.TypeFor[[]chan int](),
+	} {
+		g := &schemaGen{defs: map[string]any{}}
+		g.schemaOf(rt)
+		if g.err == nil {
+			t.Errorf("schemaOf(%s) error = nil, want unsupported type", rt)
+		}
+	}
+}
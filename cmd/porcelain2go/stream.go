@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// streamJSONLines parses status output in the given porcelain format from r,
+// writing each header and entry to w as a JSON line as soon as it is parsed.
+// The records are the same as those of the jsonl output format.
+func streamJSONLines(w io.Writer, r io.Reader, format string) error {
+	enc := json.NewEncoder(w)
+	encodeAll := func(records []any) error {
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch format {
+	case "v1", "v1z":
+		d := statusv1.NewDecoder(r)
+		if format == "v1z" {
+			d = statusv1.NewDecoderZ(r)
+		}
+		var written int // headers written so far
+		for {
+			e, err := d.Next()
+			if headers := d.Headers(); len(headers) > written {
+				if err := encodeAll(v1HeaderRecords(headers[written:])); err != nil {
+					return err
+				}
+				written = len(headers)
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+
+	case "v2", "v2z":
+		d := statusv2.NewDecoder(r)
+		if format == "v2z" {
+			d = statusv2.NewDecoderZ(r)
+		}
+		for first := true; ; first = false {
+			e, err := d.Next()
+			if first {
+				if err := encodeAll(v2HeaderRecords(d.Branch(), d.Stash())); err != nil {
+					return err
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := enc.Encode(v2Entry{e}); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("unsupported -format flag value: %s", format)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// Summary holds aggregate counts of a status.
+type Summary struct {
+	Staged    int // entries with changes in the index
+	Unstaged  int // entries with changes in the worktree
+	Untracked int
+	Ignored   int
+	Conflicts int // unmerged entries
+	Ahead     int // commits ahead of upstream
+	Behind    int // commits behind upstream
+	Stash     int // number of stash entries (porcelain=v2 only)
+}
+
+// String returns the summary as a single line of key=value pairs.
+func (s Summary) String() string {
+	return fmt.Sprintf("staged=%d unstaged=%d untracked=%d ignored=%d conflicts=%d ahead=%d behind=%d stash=%d",
+		s.Staged, s.Unstaged, s.Untracked, s.Ignored, s.Conflicts, s.Ahead, s.Behind, s.Stash)
+}
+
+func summarize(v any) (Summary, error) {
+	switch s := v.(type) {
+	case *statusv1.Status:
+		return summarizeV1(s), nil
+	case *statusv2.Status:
+		return summarizeV2(s), nil
+	default:
+		return Summary{}, fmt.Errorf("cannot summarize %T", v)
+	}
+}
+
+// Unmerged XY combinations in porcelain=v1, as listed in git-status(1).
+var v1Unmerged = map[string]bool{
+	"DD": true, "AU": true, "UD": true, "UA": true, "DU": true, "AA": true, "UU": true,
+}
+
+// Ahead and behind counts in a porcelain=v1 branch header, e.g.
+// "## main...origin/main [ahead 1, behind 2]".
+var v1AheadBehind = regexp.MustCompile(`\[(?:ahead (\d+))?(?:, )?(?:behind (\d+))?\]$`)
+
+func summarizeV1(s *statusv1.Status) Summary {
+	var sum Summary
+	for _, h := range s.Headers {
+		if m := v1AheadBehind.FindStringSubmatch(h); m != nil {
+			sum.Ahead, _ = strconv.Atoi(m[1])
+			sum.Behind, _ = strconv.Atoi(m[2])
+		}
+	}
+	for _, e := range s.Entries {
+		switch {
+		case e.XY.X == statusv1.Untracked:
+			sum.Untracked++
+		case e.XY.X == statusv1.Ignored:
+			sum.Ignored++
+		case v1Unmerged[e.XY.String()]:
+			sum.Conflicts++
+		default:
+			if e.XY.X != statusv1.Unmodified {
+				sum.Staged++
+			}
+			if e.XY.Y != statusv1.Unmodified {
+				sum.Unstaged++
+			}
+		}
+	}
+	return sum
+}
+
+func summarizeV2(s *statusv2.Status) Summary {
+	counts := s.Summary()
+	sum := Summary{
+		Staged:    counts.Staged,
+		Unstaged:  counts.Unstaged,
+		Untracked: counts.Untracked,
+		Ignored:   counts.Ignored,
+		Conflicts: counts.Conflicted,
+	}
+	if s.Branch != nil {
+		sum.Ahead, sum.Behind = s.Branch.Ahead, s.Branch.Behind
+	}
+	if s.Stash != nil {
+		sum.Stash = s.Stash.Count
+	}
+	return sum
+}
@@ -0,0 +1,189 @@
+package codeowners
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mroth/porcelain/ignore"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// Locations are the paths of the CODEOWNERS file relative to the root of a
+// repository, in the order GitHub looks for it.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// A Rule is a line of a CODEOWNERS file.
+type Rule struct {
+	Pattern string
+	Owners  []string // none if the paths matching Pattern are unowned
+	Line    int      // line number in the file, from 1
+
+	matcher *ignore.Matcher
+}
+
+// A File is a parsed CODEOWNERS file. A nil File owns nothing.
+type File struct {
+	Rules []Rule
+}
+
+// Find reads the CODEOWNERS file of the repository at root, from the first of
+// [Locations] that exists. It returns an error matching [fs.ErrNotExist] if
+// there is none.
+func Find(root string) (*File, error) {
+	for _, loc := range Locations {
+		f, err := Load(filepath.Join(root, filepath.FromSlash(loc)))
+		if !errors.Is(err, fs.ErrNotExist) {
+			return f, err
+		}
+	}
+	return nil, fmt.Errorf("no CODEOWNERS file in %s: %w", root, fs.ErrNotExist)
+}
+
+// Load reads the CODEOWNERS file at path.
+func Load(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	owners, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return owners, nil
+}
+
+// Parse reads a CODEOWNERS file from r.
+func Parse(r io.Reader) (*File, error) {
+	f := &File{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		if i := slices.IndexFunc(fields, func(s string) bool { return strings.HasPrefix(s, "#") }); i >= 0 {
+			fields = fields[:i]
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		rule := Rule{Pattern: fields[0], Owners: fields[1:], Line: n}
+		if strings.HasPrefix(rule.Pattern, "!") {
+			return nil, fmt.Errorf("line %d: negated pattern %q", n, rule.Pattern)
+		}
+		var err error
+		if rule.matcher, err = ignore.New(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		f.Rules = append(f.Rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Match returns the last rule matching path, which decides its owners, and
+// whether there is one. Directories are given with a trailing "/".
+func (f *File) Match(path string) (Rule, bool) {
+	if f == nil {
+		return Rule{}, false
+	}
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].matcher.Match(path) {
+			return f.Rules[i], true
+		}
+	}
+	return Rule{}, false
+}
+
+// Owners returns the owners of path, or none if it is unowned.
+func (f *File) Owners(path string) []string {
+	rule, _ := f.Match(path)
+	return rule.Owners
+}
+
+// An OwnerGroup is the dirty files of an owner.
+type OwnerGroup struct {
+	Owner   string // "" for files without owners
+	Entries []statusv2.Entry
+}
+
+// ByOwner groups the entries of s by owner, in the order of the owners' names,
+// with the group of unowned files last. A file with several owners is in the
+// group of each, and the entries of each group keep their order in s.
+func (f *File) ByOwner(s *statusv2.Status) []OwnerGroup {
+	groups := make(map[string][]statusv2.Entry)
+	for _, e := range dirtyEntries(s) {
+		owners := f.Owners(e.EntryPath())
+		if len(owners) == 0 {
+			owners = []string{""}
+		}
+		for _, o := range owners {
+			groups[o] = append(groups[o], e)
+		}
+	}
+	result := make([]OwnerGroup, 0, len(groups))
+	for o, entries := range groups {
+		result = append(result, OwnerGroup{Owner: o, Entries: entries})
+	}
+	slices.SortFunc(result, func(a, b OwnerGroup) int {
+		if (a.Owner == "") != (b.Owner == "") {
+			return strings.Compare(b.Owner, a.Owner) // unowned last
+		}
+		return strings.Compare(a.Owner, b.Owner)
+	})
+	return result
+}
+
+// A RuleGroup is the dirty files whose owners a rule decides.
+type RuleGroup struct {
+	Rule    Rule // the zero Rule for files matching no rule
+	Entries []statusv2.Entry
+}
+
+// ByRule groups the entries of s by the rule deciding their owners, in the
+// order of the rules in the file, with the group of files matching no rule
+// last. The entries of each group keep their order in s.
+func (f *File) ByRule(s *statusv2.Status) []RuleGroup {
+	groups := make(map[int]*RuleGroup) // by line, 0 for no rule
+	for _, e := range dirtyEntries(s) {
+		rule, _ := f.Match(e.EntryPath())
+		g, ok := groups[rule.Line]
+		if !ok {
+			g = &RuleGroup{Rule: rule}
+			groups[rule.Line] = g
+		}
+		g.Entries = append(g.Entries, e)
+	}
+	result := make([]RuleGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, *g)
+	}
+	slices.SortFunc(result, func(a, b RuleGroup) int {
+		if (a.Rule.Line == 0) != (b.Rule.Line == 0) {
+			return b.Rule.Line - a.Rule.Line // no rule last
+		}
+		return a.Rule.Line - b.Rule.Line
+	})
+	return result
+}
+
+// dirtyEntries returns the entries of s other than ignored files.
+func dirtyEntries(s *statusv2.Status) []statusv2.Entry {
+	if s == nil {
+		return nil
+	}
+	var entries []statusv2.Entry
+	for _, e := range s.Entries {
+		if e.Type() != statusv2.EntryTypeIgnored {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
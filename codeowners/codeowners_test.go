@@ -0,0 +1,152 @@
+package codeowners
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/fuzzseed"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+func loadSeed(t *testing.T, name string) *File {
+	t.Helper()
+	data, err := fs.ReadFile(fuzzseed.FS, "codeowners/"+name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := Parse(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return f
+}
+
+func TestFile_Owners(t *testing.T) {
+	f := loadSeed(t, "basic")
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@org/platform"}},
+		{"web/app.js", []string{"@org/frontend", "@alice"}},
+		{"docs/app.js", []string{"docs@example.com"}},
+		{"docs/", []string{"docs@example.com"}},
+		{"sub/docs/index.md", []string{"@org/platform"}},
+		{"build/logs/today.log", []string{"@org/ci"}},
+		{"x/apps/main.go", []string{"@org/apps"}},
+		{"scripts/generated.sh", nil},
+	}
+	for _, tt := range tests {
+		if got := f.Owners(tt.path); !slices.Equal(got, tt.want) {
+			t.Errorf("Owners(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	rule, ok := f.Match("docs/index.md")
+	if !ok || rule.Pattern != "/docs/" || rule.Line != 8 {
+		t.Errorf("Match() = %+v, %v, want the rule of /docs/ on line 8", rule, ok)
+	}
+	if _, ok := (*File)(nil).Match("a"); ok {
+		t.Error("nil Match() = true, want false")
+	}
+}
+
+func TestFile_Globs(t *testing.T) {
+	f := loadSeed(t, "globs")
+	tests := map[string]string{
+		"a/vendor/b/c.go":            "@org/deps",
+		"services/auth/api/v1.proto": "@org/api",
+		"src/pkg/test_x.go":          "@org/qa",
+		"#notes.md":                  "@bob",
+		"services/auth/main.go":      "",
+	}
+	for path, want := range tests {
+		if got := strings.Join(f.Owners(path), " "); got != want {
+			t.Errorf("Owners(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, input := range []string{"!*.js @a", "a\n[z- @b"} {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", input)
+		}
+	}
+}
+
+var groupStatus = &statusv2.Status{Entries: []statusv2.Entry{
+	statusv2.ChangedEntry{Path: "main.go"},
+	statusv2.RenameOrCopyEntry{Path: "web/new.js", Orig: "web/old.go"},
+	statusv2.UntrackedEntry{Path: "docs/"},
+	statusv2.UnmergedEntry{Path: "style.css"},
+	statusv2.ChangedEntry{Path: "scripts/generated.sh"},
+	statusv2.IgnoredEntry{Path: "debug.log"},
+}}
+
+func TestFile_ByOwner(t *testing.T) {
+	got := loadSeed(t, "basic").ByOwner(groupStatus)
+	want := []OwnerGroup{
+		{Owner: "@alice", Entries: []statusv2.Entry{groupStatus.Entries[1]}},
+		{Owner: "@org/frontend", Entries: []statusv2.Entry{groupStatus.Entries[1], groupStatus.Entries[3]}},
+		{Owner: "@org/platform", Entries: []statusv2.Entry{groupStatus.Entries[0]}},
+		{Owner: "docs@example.com", Entries: []statusv2.Entry{groupStatus.Entries[2]}},
+		{Owner: "", Entries: []statusv2.Entry{groupStatus.Entries[4]}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByOwner() mismatch (-want +got):\n%s", diff)
+	}
+	if got := (*File)(nil).ByOwner(groupStatus); len(got) != 1 || got[0].Owner != "" || len(got[0].Entries) != 5 {
+		t.Errorf("nil ByOwner() = %v, want a single unowned group", got)
+	}
+}
+
+func TestFile_ByRule(t *testing.T) {
+	got := loadSeed(t, "basic").ByRule(groupStatus)
+	var lines []int
+	var counts []int
+	for _, g := range got {
+		lines = append(lines, g.Rule.Line)
+		counts = append(counts, len(g.Entries))
+	}
+	if want := []int{2, 5, 6, 8, 11}; !cmp.Equal(want, lines) {
+		t.Errorf("ByRule() lines = %v, want %v", lines, want)
+	}
+	if want := []int{1, 1, 1, 1, 1}; !cmp.Equal(want, counts) {
+		t.Errorf("ByRule() counts = %v, want %v", counts, want)
+	}
+
+	unowned := (&File{}).ByRule(groupStatus)
+	if len(unowned) != 1 || unowned[0].Rule.Line != 0 {
+		t.Errorf("ByRule() without rules = %v, want a single group without a rule", unowned)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	if _, err := Find(root); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Find() error = %v, want %v", err, fs.ErrNotExist)
+	}
+	for _, loc := range []string{"docs/CODEOWNERS", ".github/CODEOWNERS"} {
+		path := filepath.Join(root, filepath.FromSlash(loc))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("* @"+filepath.Dir(loc)+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := Find(root)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if got := f.Owners("a"); len(got) != 1 || got[0] != "@.github" {
+		t.Errorf("Find() owners = %q, want those of .github/CODEOWNERS", got)
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Package codeowners joins status entries against a CODEOWNERS file, to report
+the dirty files of a monorepo grouped by the teams owning them.
+
+# Basic Usage
+
+[Find] reads the CODEOWNERS file of a repository from where GitHub looks for
+it, and [Load] and [Parse] read one from a given file or [io.Reader]. The
+resulting [File] gives the owners of a path, and groups the entries of a
+status by owner or by the rule matching them:
+
+	owners, err := codeowners.Find(repo)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, g := range owners.ByOwner(status) {
+	    fmt.Printf("%s: %d dirty files\n", cmp.Or(g.Owner, "(unowned)"), len(g.Entries))
+	}
+
+# Syntax
+
+Each line holds a pattern followed by its owners, separated by whitespace,
+such as "/docs/ @org/docs-team someone@example.com". Blank lines and comments,
+starting with "#", are skipped, as is the rest of a line after an owner
+starting with "#". The last rule with a pattern matching a path decides its
+owners, so a rule without owners leaves the paths it matches unowned.
+
+Patterns are matched as in gitignore files, by [github.com/mroth/porcelain/ignore],
+including the directories above a path, so that "/build/" owns every file
+under build. As in GitHub, patterns cannot be negated with "!".
+
+# Entries
+
+A renamed or copied file is owned by the owners of its new path. Ignored
+files are not dirty, so are left out of the groups, while an untracked
+directory, listed as a single entry, is matched as the directory.
+*/
+package codeowners
@@ -0,0 +1,24 @@
+package codeowners
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// FuzzParse tests that parsing and matching arbitrary CODEOWNERS files never
+// panics.
+func FuzzParse(f *testing.F) {
+	fuzzseed.Add[[]byte](f, "codeowners")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		owners, err := Parse(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		for _, p := range []string{"a.js", "docs/index.md", "apps/web/", "vendor/x/y.go"} {
+			owners.Owners(p)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package corpus
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+// FS holds the corpus files, in a directory for each version of git.
+var FS fs.FS
+
+func init() {
+	var err error
+	if FS, err = fs.Sub(testdata, "testdata"); err != nil {
+		panic(err)
+	}
+}
+
+// Formats are the porcelain formats of the corpus: porcelain=v1 and
+// porcelain=v2, without and with -z.
+var Formats = []string{"v1", "v1z", "v2", "v2z"}
+
+// Case is the output of `git status` in a porcelain format for a scenario,
+// recorded with a version of git.
+type Case struct {
+	GitVersion string // version of git, such as "2.39.5"
+	Scenario   string // name of the scenario, such as "changes"
+	Format     string // one of Formats
+	Input      []byte // output of git status
+	Golden     []byte // expected parse result, as JSON
+}
+
+// Name returns a name for the case, such as "git-2.39.5/changes.v2z".
+func (c Case) Name() string {
+	return "git-" + c.GitVersion + "/" + c.Scenario + "." + c.Format
+}
+
+// Versions returns the versions of git in the corpus, oldest first.
+func Versions() ([]string, error) {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, e := range entries {
+		if v, ok := strings.CutPrefix(e.Name(), "git-"); ok && e.IsDir() {
+			versions = append(versions, v)
+		}
+	}
+	slices.SortFunc(versions, compareVersions)
+	return versions, nil
+}
+
+// Cases returns all cases of the corpus, ordered by version of git, scenario
+// and format.
+func Cases() ([]Case, error) {
+	versions, err := Versions()
+	if err != nil {
+		return nil, err
+	}
+	var cases []Case
+	for _, v := range versions {
+		dir := "git-" + v
+		goldens, err := fs.Glob(FS, path.Join(dir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(goldens)
+		for _, golden := range goldens {
+			// e.g. "changes.v2.json" for the v2 and v2z formats
+			base := strings.TrimSuffix(path.Base(golden), ".json")
+			i := strings.LastIndexByte(base, '.')
+			if i < 0 {
+				continue
+			}
+			scenario, version := base[:i], base[i+1:]
+			goldenData, err := fs.ReadFile(FS, golden)
+			if err != nil {
+				return nil, err
+			}
+			for _, format := range []string{version, version + "z"} {
+				input, err := fs.ReadFile(FS, path.Join(dir, scenario+"."+format))
+				if err != nil {
+					return nil, err
+				}
+				cases = append(cases, Case{
+					GitVersion: v,
+					Scenario:   scenario,
+					Format:     format,
+					Input:      input,
+					Golden:     goldenData,
+				})
+			}
+		}
+	}
+	return cases, nil
+}
+
+// compareVersions compares dotted version numbers numerically.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareNumbers(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return len(as) - len(bs)
+}
+
+// compareNumbers compares decimal numbers without leading zeros.
+func compareNumbers(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,198 @@
+package corpus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+func TestVersions(t *testing.T) {
+	versions, err := Versions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) == 0 {
+		t.Fatal("Versions() is empty")
+	}
+	if !slices.IsSortedFunc(versions, compareVersions) {
+		t.Errorf("Versions() = %v, not sorted", versions)
+	}
+}
+
+func Test_compareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.11.0", "2.11.0", 0},
+		{"2.9.5", "2.11.0", -1},
+		{"2.39.5", "2.4.0", 1},
+		{"2.40.0", "2.40.0.1", -1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); sign(got) != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want sign %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// TestCases checks that every case parses to its golden result.
+func TestCases(t *testing.T) {
+	cases, err := Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatal("Cases() is empty")
+	}
+	for _, c := range cases {
+		t.Run(c.Name(), func(t *testing.T) {
+			got, err := parse(c)
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			want, err := golden(c)
+			if err != nil {
+				t.Fatalf("invalid golden result: %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("parse mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestStability checks that each scenario parses to the same result with
+// every version of git recorded.
+func TestStability(t *testing.T) {
+	cases, err := Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := map[string]Case{}
+	for _, c := range cases {
+		latest[c.Scenario+"."+c.Format] = c // cases are ordered oldest first
+	}
+	for _, c := range cases {
+		ref := latest[c.Scenario+"."+c.Format]
+		if c.GitVersion == ref.GitVersion {
+			continue
+		}
+		t.Run(c.Name(), func(t *testing.T) {
+			got, err := parse(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := parse(ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("git %s parse differs from git %s (-%s +%s):\n%s", c.GitVersion, ref.GitVersion, ref.GitVersion, c.GitVersion, diff)
+			}
+		})
+	}
+}
+
+// parse parses the input of a case, unquoting the paths of formats without
+// -z.
+func parse(c Case) (any, error) {
+	r := bytes.NewReader(c.Input)
+	switch c.Format {
+	case "v1":
+		s, err := statusv1.Parse(r)
+		if err != nil {
+			return nil, err
+		}
+		for i, e := range s.Entries {
+			if e.Path, err = unquote(e.Path); err != nil {
+				return nil, err
+			}
+			if e.OrigPath, err = unquote(e.OrigPath); err != nil {
+				return nil, err
+			}
+			s.Entries[i] = e
+		}
+		return s, nil
+	case "v1z":
+		return statusv1.ParseZ(r)
+	case "v2":
+		s, err := statusv2.Parse(r)
+		if err != nil {
+			return nil, err
+		}
+		for i, e := range s.Entries {
+			if s.Entries[i], err = unquoteEntry(e); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case "v2z":
+		return statusv2.ParseZ(r)
+	}
+	return nil, fmt.Errorf("unknown format %q", c.Format)
+}
+
+// unquote unquotes a path as quoted by git without -z.
+func unquote(path string) (string, error) {
+	if !strings.HasPrefix(path, `"`) {
+		return path, nil
+	}
+	// git's C-style quoting is a subset of Go string literal syntax
+	return strconv.Unquote(path)
+}
+
+func unquoteEntry(e statusv2.Entry) (statusv2.Entry, error) {
+	var err error
+	switch e := e.(type) {
+	case statusv2.ChangedEntry:
+		e.Path, err = unquote(e.Path)
+		return e, err
+	case statusv2.RenameOrCopyEntry:
+		if e.Path, err = unquote(e.Path); err != nil {
+			return nil, err
+		}
+		e.Orig, err = unquote(e.Orig)
+		return e, err
+	case statusv2.UnmergedEntry:
+		e.Path, err = unquote(e.Path)
+		return e, err
+	case statusv2.UntrackedEntry:
+		e.Path, err = unquote(e.Path)
+		return e, err
+	case statusv2.IgnoredEntry:
+		e.Path, err = unquote(e.Path)
+		return e, err
+	}
+	return e, nil
+}
+
+// golden decodes the golden result of a case.
+func golden(c Case) (any, error) {
+	if strings.HasPrefix(c.Format, "v1") {
+		var s statusv1.Status
+		if err := json.Unmarshal(c.Golden, &s); err != nil {
+			return nil, err
+		}
+		return &s, nil
+	}
+	return statusv2.NewJSONDecoder(bytes.NewReader(c.Golden)).Decode()
+}
@@ -0,0 +1,44 @@
+/*
+Package corpus provides golden porcelain status output recorded from git for
+scripted repository states, along with the expected parse results. It is the
+test data of the statusv1 and statusv2 parsers, and is exported so that other
+parsers and tools can test against it.
+
+# Basic Usage
+
+[Cases] returns a [Case] for each recording, in each of the porcelain formats:
+
+	cases, err := corpus.Cases()
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, c := range cases {
+	    if c.Format == "v2z" {
+	        s, err := statusv2.ParseZ(bytes.NewReader(c.Input))
+	        // compare s with c.Golden...
+	    }
+	}
+
+The golden results are JSON in the shape written by porcelain2go: the status
+of the statusv1 package for porcelain=v1, and for porcelain=v2, the status of
+the statusv2 package with a Type field naming the kind of each entry, one of
+"changed", "rename_or_copy", "unmerged", "untracked" or "ignored". Golden
+results are shared by the formats with and without -z, so without -z, paths
+containing special characters are C-quoted in the input but not in the golden
+results, and need unquoting before comparing.
+
+# Versions
+
+The corpus holds a directory for each version of git recorded, named like
+"git-2.39.5", with the recordings of each scenario, named like "changes.v2z".
+Currently only git 2.39.5 is recorded. Recordings from other versions can be
+added alongside it, to guard the stability of the parsers across versions, in
+which case scenarios using options a version does not support are not
+recorded for it.
+
+The recordings are made with the porcelain-fixture command, from the scenarios
+in its directory. To add a version, record the corpus with that version of git:
+
+	go run ./cmd/porcelain-fixture -git path/to/git -corpus corpus/testdata cmd/porcelain-fixture/scenarios/*.json
+*/
+package corpus
@@ -0,0 +1,141 @@
+package credential
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Decode reads a credential from r, stopping at the first blank line or at
+// end of input.
+//
+// The special "url" attribute is parsed and its components are applied to
+// the credential, as git does, overriding any attributes read before it.
+func Decode(r io.Reader) (*Credential, error) {
+	var c Credential
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			break
+		}
+		key, value, found := bytes.Cut(line, []byte{'='})
+		if !found || len(key) == 0 {
+			return nil, fmt.Errorf("invalid credential attribute line: %q", line)
+		}
+		if err := c.set(string(key), string(value)); err != nil {
+			return nil, err
+		}
+	}
+	return &c, scanner.Err()
+}
+
+func (c *Credential) set(key, value string) error {
+	switch key {
+	case "protocol":
+		c.Protocol = value
+	case "host":
+		c.Host = value
+	case "path":
+		c.Path = value
+	case "username":
+		c.Username = value
+	case "password":
+		c.Password = value
+	case "password_expiry_utc":
+		sec, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid password_expiry_utc attribute: %q", value)
+		}
+		c.PasswordExpiryUTC = time.Unix(sec, 0).UTC()
+	case "oauth_refresh_token":
+		c.OAuthRefreshToken = value
+	case "wwwauth[]":
+		// an empty value resets the list, as with all multi-valued attributes
+		if value == "" {
+			c.WWWAuth = nil
+		} else {
+			c.WWWAuth = append(c.WWWAuth, value)
+		}
+	case "quit":
+		c.Quit = value == "1" || value == "true"
+	case "url":
+		return c.setURL(value)
+	default:
+		c.Extra = append(c.Extra, Attribute{Key: key, Value: value})
+	}
+	return nil
+}
+
+// setURL applies the components of a URL to the credential. As with git, a
+// component missing from the URL clears the corresponding attribute.
+func (c *Credential) setURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" {
+		return fmt.Errorf("invalid url attribute: %q", value)
+	}
+	c.Protocol = u.Scheme
+	c.Host = u.Host
+	c.Path = strings.TrimPrefix(u.Path, "/")
+	c.Username = u.User.Username()
+	c.Password, _ = u.User.Password()
+	return nil
+}
+
+// Encode writes the non-empty attributes of c to w, one per line, without a
+// terminating blank line.
+//
+// Attribute values may not contain newlines or NUL bytes, and keys may not
+// contain "=", as these cannot be represented in the protocol.
+func Encode(w io.Writer, c *Credential) error {
+	var b bytes.Buffer
+	write := func(key, value string) error {
+		if value == "" {
+			return nil
+		}
+		if strings.ContainsAny(value, "\n\x00") {
+			return fmt.Errorf("credential attribute %q: value contains newline or NUL", key)
+		}
+		if key == "" || strings.ContainsAny(key, "=\n\x00") {
+			return fmt.Errorf("invalid credential attribute key: %q", key)
+		}
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(value)
+		b.WriteByte('\n')
+		return nil
+	}
+
+	errs := []error{
+		write("protocol", c.Protocol),
+		write("host", c.Host),
+		write("path", c.Path),
+		write("username", c.Username),
+		write("password", c.Password),
+	}
+	if !c.PasswordExpiryUTC.IsZero() {
+		errs = append(errs, write("password_expiry_utc", strconv.FormatInt(c.PasswordExpiryUTC.Unix(), 10)))
+	}
+	errs = append(errs, write("oauth_refresh_token", c.OAuthRefreshToken))
+	for _, v := range c.WWWAuth {
+		errs = append(errs, write("wwwauth[]", v))
+	}
+	for _, a := range c.Extra {
+		errs = append(errs, write(a.Key, a.Value))
+	}
+	if c.Quit {
+		errs = append(errs, write("quit", "1"))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
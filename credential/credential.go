@@ -0,0 +1,29 @@
+package credential
+
+import (
+	"time"
+)
+
+// Credential represents the attributes of a credential exchanged with git.
+//
+// Attributes not represented by a dedicated field, including multi-valued
+// attributes such as "capability[]", are preserved in Extra, so that a
+// Credential read with [Decode] can be written back with [Encode] unchanged.
+type Credential struct {
+	Protocol          string      // protocol over which the credential will be used, e.g. "https"
+	Host              string      // remote hostname, including the port number if specified
+	Path              string      // path of the repository, if credential.useHttpPath is set
+	Username          string      // username, if already known
+	Password          string      // password, if already known
+	PasswordExpiryUTC time.Time   // when the password expires; zero if it does not
+	OAuthRefreshToken string      // OAuth refresh token, if any
+	WWWAuth           []string    // WWW-Authenticate response headers from the server
+	Quit              bool        // tells git to stop trying further helpers
+	Extra             []Attribute // other attributes, in the order they appeared
+}
+
+// Attribute is a single key=value attribute line.
+type Attribute struct {
+	Key   string
+	Value string
+}
@@ -0,0 +1,41 @@
+/*
+Package credential reads and writes the git credential helper protocol.
+
+Git communicates with credential helpers, and `git credential fill`, `approve`,
+and `reject` communicate with their callers, using a simple format of
+key=value attribute lines terminated by a blank line or end of input:
+
+	protocol=https
+	host=example.com
+	username=bob
+	password=secr3t
+
+# Basic Usage
+
+[Decode] reads a single [Credential] from an [io.Reader], and [Encode] writes
+one to an [io.Writer]:
+
+	c, err := credential.Decode(os.Stdin)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	c.Username, c.Password = lookup(c.Host)
+	credential.Encode(os.Stdout, c)
+
+# Writing Credential Helpers
+
+[Serve] implements the helper side of the protocol for a [Helper], dispatching
+on the action argument git passes to the helper:
+
+	func main() {
+	    err := credential.Serve(os.Args[1], os.Stdin, os.Stdout, myHelper{})
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+	}
+
+For more information, see the Git documentation for [git credential].
+
+[git credential]: https://git-scm.com/docs/git-credential#IOFMT
+*/
+package credential
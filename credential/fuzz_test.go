@@ -0,0 +1,29 @@
+package credential
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// Fuzz test for Decode function, checking that anything decoded can be
+// encoded and decoded again to the same credential.
+func FuzzDecode(f *testing.F) {
+	// Add some seed inputs
+	fuzzseed.Add[[]byte](f, "credential")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c, err := Decode(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		var b bytes.Buffer
+		if err := Encode(&b, c); err != nil {
+			return // e.g. decoded values containing NUL cannot be encoded
+		}
+		if _, err := Decode(&b); err != nil {
+			t.Errorf("Decode() of encoded credential %q failed: %v", b.String(), err)
+		}
+	})
+}
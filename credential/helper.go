@@ -0,0 +1,51 @@
+package credential
+
+import (
+	"fmt"
+	"io"
+)
+
+// Helper is implemented by git credential helpers.
+//
+// Get is called with a partially filled credential, and should return the
+// credential with any attributes it can supply (typically username and
+// password) filled in, or nil if it has nothing to offer. Store and Erase
+// are called with a complete credential after git has used it successfully
+// or unsuccessfully, respectively.
+type Helper interface {
+	Get(c *Credential) (*Credential, error)
+	Store(c *Credential) error
+	Erase(c *Credential) error
+}
+
+// Serve handles a single credential helper invocation for the given action
+// ("get", "store", or "erase"), reading the request from r and writing any
+// response to w.
+//
+// As documented for git credential helpers, unknown actions are ignored, so
+// that helpers remain compatible with future versions of git.
+func Serve(action string, r io.Reader, w io.Writer, h Helper) error {
+	switch action {
+	case "get", "store", "erase":
+	default:
+		return nil
+	}
+
+	c, err := Decode(r)
+	if err != nil {
+		return fmt.Errorf("credential %s: %w", action, err)
+	}
+
+	switch action {
+	case "get":
+		resp, err := h.Get(c)
+		if err != nil || resp == nil {
+			return err
+		}
+		return Encode(w, resp)
+	case "store":
+		return h.Store(c)
+	default:
+		return h.Erase(c)
+	}
+}
@@ -0,0 +1,82 @@
+package credential
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// memHelper is a Helper storing passwords in memory, keyed by host.
+type memHelper struct {
+	passwords map[string]string
+	erased    []string
+}
+
+func (h *memHelper) Get(c *Credential) (*Credential, error) {
+	pw, ok := h.passwords[c.Host]
+	if !ok {
+		return nil, nil
+	}
+	resp := *c
+	resp.Username, resp.Password = "bob", pw
+	return &resp, nil
+}
+
+func (h *memHelper) Store(c *Credential) error {
+	if c.Password == "" {
+		return errors.New("missing password")
+	}
+	h.passwords[c.Host] = c.Password
+	return nil
+}
+
+func (h *memHelper) Erase(c *Credential) error {
+	delete(h.passwords, c.Host)
+	h.erased = append(h.erased, c.Host)
+	return nil
+}
+
+func TestServe(t *testing.T) {
+	h := &memHelper{passwords: map[string]string{}}
+
+	var out bytes.Buffer
+	if err := Serve("store", strings.NewReader("protocol=https\nhost=example.com\nusername=bob\npassword=secr3t\n\n"), &out, h); err != nil {
+		t.Fatalf("Serve(store) error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Serve(store) wrote %q, want nothing", out.String())
+	}
+
+	if err := Serve("get", strings.NewReader("protocol=https\nhost=example.com\n\n"), &out, h); err != nil {
+		t.Fatalf("Serve(get) error = %v", err)
+	}
+	if want := "protocol=https\nhost=example.com\nusername=bob\npassword=secr3t\n"; out.String() != want {
+		t.Errorf("Serve(get) wrote %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	if err := Serve("get", strings.NewReader("protocol=https\nhost=unknown.example.com\n\n"), &out, h); err != nil {
+		t.Fatalf("Serve(get) error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Serve(get) for unknown host wrote %q, want nothing", out.String())
+	}
+
+	if err := Serve("erase", strings.NewReader("protocol=https\nhost=example.com\n\n"), &out, h); err != nil {
+		t.Fatalf("Serve(erase) error = %v", err)
+	}
+	if len(h.passwords) != 0 || len(h.erased) != 1 {
+		t.Errorf("Serve(erase) did not erase credential: %+v", h)
+	}
+
+	if err := Serve("frobnicate", strings.NewReader("garbage"), &out, h); err != nil {
+		t.Errorf("Serve() with unknown action error = %v, want nil", err)
+	}
+	if err := Serve("store", strings.NewReader("protocol=https\n\n"), &out, h); err == nil {
+		t.Errorf("Serve(store) error = nil, want helper error")
+	}
+	if err := Serve("get", strings.NewReader("garbage\n"), &out, h); err == nil {
+		t.Errorf("Serve(get) error = nil, want decode error")
+	}
+}
@@ -0,0 +1,85 @@
+package diffraw
+
+import "strconv"
+
+// Entry represents the change of a single path.
+type Entry struct {
+	Commit  string   // commit being compared, if preceded by a commit ID (see package docs)
+	Sources []Source // one per compared source; more than one for combined diffs
+	Mode    FileMode // file mode of the destination
+	Object  string   // object name of the destination; all zeros if not in the object database
+	Path    string   // path of the destination
+}
+
+// Source represents the source side of a change, i.e. what the destination
+// is compared against.
+type Source struct {
+	Mode   FileMode // file mode of the source
+	Object string   // object name of the source; all zeros for added files
+	Status Status   // kind of change from this source
+	Score  int      // similarity percentage of renames and copies, or dissimilarity of modifications (with -B)
+	Path   string   // path of the source, if different from the destination (renames, copies) or with --combined-all-paths
+}
+
+// Combined reports whether the entry is part of a combined diff, comparing a
+// merge commit against more than one parent.
+func (e Entry) Combined() bool {
+	return len(e.Sources) > 1
+}
+
+// Status returns the status of the change, which for combined diffs is the
+// status relative to each parent, e.g. "MM".
+func (e Entry) Status() string {
+	b := make([]byte, len(e.Sources))
+	for i, s := range e.Sources {
+		b[i] = byte(s.Status)
+	}
+	return string(b)
+}
+
+// SourcePath returns the path of the first source, which is the path before a
+// rename or copy, or Path if the source has no path of its own.
+func (e Entry) SourcePath() string {
+	if len(e.Sources) > 0 && e.Sources[0].Path != "" {
+		return e.Sources[0].Path
+	}
+	return e.Path
+}
+
+// Status is the kind of change between a source and the destination.
+type Status byte
+
+// Possible statuses.
+const (
+	Added       Status = 'A' // addition of a file
+	Copied      Status = 'C' // copy of a file into a new one
+	Deleted     Status = 'D' // deletion of a file
+	Modified    Status = 'M' // modification of the contents or mode of a file
+	Renamed     Status = 'R' // renaming of a file
+	TypeChanged Status = 'T' // change in the type of the file (regular file, symlink or submodule)
+	Unmerged    Status = 'U' // file is unmerged
+	Unknown     Status = 'X' // unknown change type (most probably a bug)
+)
+
+// String returns the status letter, e.g. "M".
+func (s Status) String() string {
+	return string(rune(s))
+}
+
+// A FileMode represents the kind of tree entries used by git.
+type FileMode uint32
+
+// Common FileMode values. A mode of zero is used for the missing side of an addition
+// or deletion.
+const (
+	FileModeRegular    FileMode = 0100644
+	FileModeExecutable FileMode = 0100755
+	FileModeSymlink    FileMode = 0120000
+	FileModeSubmodule  FileMode = 0160000
+)
+
+// String returns the octal string representation of the FileMode, e.g. "100644".
+// Note that this is different from Go octal formatting, which uses a leading "0".
+func (m FileMode) String() string {
+	return strconv.FormatUint(uint64(m), 8)
+}
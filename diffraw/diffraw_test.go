@@ -0,0 +1,46 @@
+package diffraw
+
+import "testing"
+
+func TestEntry(t *testing.T) {
+	tests := []struct {
+		name         string
+		entry        Entry
+		wantCombined bool
+		wantStatus   string
+		wantSrcPath  string
+	}{
+		{
+			name:        "modified",
+			entry:       Entry{Sources: []Source{{Status: Modified}}, Path: "f"},
+			wantStatus:  "M",
+			wantSrcPath: "f",
+		},
+		{
+			name:        "renamed",
+			entry:       Entry{Sources: []Source{{Status: Renamed, Score: 90, Path: "old"}}, Path: "new"},
+			wantStatus:  "R",
+			wantSrcPath: "old",
+		},
+		{
+			name:         "combined",
+			entry:        Entry{Sources: []Source{{Status: Modified}, {Status: Added}}, Path: "f"},
+			wantCombined: true,
+			wantStatus:   "MA",
+			wantSrcPath:  "f",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.Combined(); got != tt.wantCombined {
+				t.Errorf("Combined() = %v, want %v", got, tt.wantCombined)
+			}
+			if got := tt.entry.Status(); got != tt.wantStatus {
+				t.Errorf("Status() = %q, want %q", got, tt.wantStatus)
+			}
+			if got := tt.entry.SourcePath(); got != tt.wantSrcPath {
+				t.Errorf("SourcePath() = %q, want %q", got, tt.wantSrcPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Package diffraw parses the --raw and --name-status output of the git diff
+family of commands (`git diff`, `git diff-tree`, `git diff-index`,
+`git diff-files`, and `git log`), including combined diffs of merge commits.
+
+# Basic Usage
+
+[ParseRaw] takes an [io.Reader] containing --raw -z output, and
+[ParseNameStatus] one containing --name-status -z output:
+
+	r := bytes.NewReader(gitDiffOutput)
+	entries, err := diffraw.ParseRaw(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, e := range entries {
+	    fmt.Printf("%s %s\n", e.Status(), e.Path)
+	}
+
+# Combined Diffs
+
+With -c or --cc, merge commits are compared against all of their parents at
+once. Each entry then has one [Source] per parent, each with its own mode,
+object and status, and raw lines start with one colon per parent:
+
+	::100644 100644 100644 fabadb8 cc95eb0 4866510 MM NUL desc.c NUL
+
+Parsers that assume a single source mishandle these lines. The entries
+returned by this package always describe every parent; see [Entry.Combined].
+
+With --combined-all-paths, combined raw output additionally lists the path of
+the file in each parent, which is recorded in [Source.Path]. This is detected
+automatically in raw output, but not supported for --name-status output,
+where it cannot be told apart from a list of entries.
+
+# Git Raw Diff Format
+
+Only the NUL-terminated (-z) output is supported, as paths are quoted
+otherwise. In this format each entry is:
+
+	:<src mode> SP <dst mode> SP <src object> SP <dst object> SP <status> NUL <path> NUL
+	:<src mode> SP <dst mode> SP <src object> SP <dst object> SP <status><score> NUL <src path> NUL <dst path> NUL
+
+with the second form for renames and copies. With --name-status, the part
+before the first NUL is only the status.
+
+When `git diff-tree` is given a single commit, the diff is preceded by the
+commit ID, which is recorded in [Entry.Commit]. Output of `git log` must not
+include anything other than the diff and commit IDs, e.g. with --format=%H.
+
+For more information, see the "Raw output format" and "Diff format for
+merges" sections of the Git documentation for [git diff].
+
+[git diff]: https://git-scm.com/docs/git-diff#_raw_output_format
+*/
+package diffraw
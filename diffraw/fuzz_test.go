@@ -0,0 +1,38 @@
+package diffraw
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// Fuzz test checking that parsing arbitrary raw output never panics.
+func FuzzParseRaw(f *testing.F) {
+	// Add some seed inputs
+	fuzzseed.Add[[]byte](f, "diffraw-raw")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("ParseRaw panicked with input %q: %v", data, r)
+			}
+		}()
+		_, _ = ParseRaw(bytes.NewReader(data))
+	})
+}
+
+// Fuzz test checking that parsing arbitrary name-status output never panics.
+func FuzzParseNameStatus(f *testing.F) {
+	// Add some seed inputs
+	fuzzseed.Add[[]byte](f, "diffraw-name-status")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("ParseNameStatus panicked with input %q: %v", data, r)
+			}
+		}()
+		_, _ = ParseNameStatus(bytes.NewReader(data))
+	})
+}
@@ -0,0 +1,296 @@
+package diffraw
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseRaw parses the output of a git diff command run with --raw -z,
+// including combined diffs produced with -c or --cc.
+//
+// Path Handling: In -z format, Git does not quote paths containing special
+// characters, so all paths are provided as-is.
+func ParseRaw(r io.Reader) ([]Entry, error) {
+	return parse(r, true)
+}
+
+// ParseNameStatus parses the output of a git diff command run with
+// --name-status -z, including combined diffs produced with -c or --cc.
+//
+// Entries parsed from --name-status output only have their Commit, Path, and
+// source Status, Score and Path fields set.
+func ParseNameStatus(r io.Reader) ([]Entry, error) {
+	return parse(r, false)
+}
+
+func parse(r io.Reader, raw bool) ([]Entry, error) {
+	var entries []Entry
+	var commit string
+
+	tokens := newTokens(r)
+	for {
+		tok, ok := tokens.next()
+		if !ok {
+			break
+		}
+		if tok == "" {
+			continue
+		}
+		if isCommitID(tok) {
+			commit = tok
+			continue
+		}
+
+		var e Entry
+		var err error
+		if raw {
+			e, err = parseRawMeta(tok)
+		} else {
+			e, err = parseNameStatusMeta(tok)
+		}
+		if err != nil {
+			return nil, err
+		}
+		e.Commit = commit
+
+		if err := readPaths(tokens, &e, raw); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err := tokens.err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// readPaths reads the path tokens following the metadata of an entry.
+func readPaths(tokens *tokens, e *Entry, raw bool) error {
+	path, ok := tokens.next()
+	if !ok || path == "" {
+		return fmt.Errorf("invalid diff entry: missing path for %s entry", e.Status())
+	}
+
+	if !e.Combined() {
+		if s := &e.Sources[0]; s.Status == Renamed || s.Status == Copied {
+			dst, ok := tokens.next()
+			if !ok || dst == "" {
+				return fmt.Errorf("invalid diff entry: missing destination path for %s of %q", s.Status, path)
+			}
+			s.Path, path = path, dst
+		}
+		e.Path = path
+		return nil
+	}
+
+	// With --combined-all-paths, the path in each parent precedes the path in
+	// the merge result. This is only detectable in raw output, where the next
+	// entry starts with a colon.
+	if raw && parentPaths(tokens, len(e.Sources)) {
+		paths := []string{path}
+		for len(paths) < len(e.Sources)+1 {
+			p, ok := tokens.next()
+			if !ok || p == "" {
+				return fmt.Errorf("invalid combined diff entry: expected %d paths, got %d", len(e.Sources)+1, len(paths))
+			}
+			paths = append(paths, p)
+		}
+		for i := range e.Sources {
+			e.Sources[i].Path = paths[i]
+		}
+		path = paths[len(paths)-1]
+	}
+	e.Path = path
+	return nil
+}
+
+// parentPaths reports whether the first path of a combined raw entry with n
+// parents is followed by more paths, as with --combined-all-paths, rather than
+// by the next record. A token that looks like a commit ID may be a path, so it
+// is only taken to start a record if the token after the n more paths would
+// not, or if there are not n more paths.
+func parentPaths(tokens *tokens, n int) bool {
+	next, ok := tokens.peek(0)
+	if !ok || next == "" || isRawMeta(next) {
+		return false
+	}
+	if !isCommitID(next) {
+		return true
+	}
+	for i := 1; i < n; i++ {
+		if p, ok := tokens.peek(i); !ok || p == "" {
+			return false
+		}
+	}
+	after, ok := tokens.peek(n)
+	return !ok || after == "" || isRawMeta(after) || isCommitID(after)
+}
+
+// Raw metadata has one colon per source, followed by space separated source
+// modes, destination mode, source objects, destination object, and status:
+// :<mode>... <mode> <object>... <object> <status>
+func parseRawMeta(tok string) (Entry, error) {
+	var e Entry
+	n := len(tok) - len(strings.TrimLeft(tok, ":"))
+	if n == 0 {
+		return e, fmt.Errorf("invalid raw diff entry: %q", tok)
+	}
+	fields := strings.Split(tok[n:], " ")
+	if len(fields) != 2*(n+1)+1 {
+		return e, fmt.Errorf("invalid raw diff entry: expected %d fields, got %d: %q", 2*(n+1)+1, len(fields), tok)
+	}
+
+	modes := make([]FileMode, n+1)
+	for i := range modes {
+		m, err := strconv.ParseUint(fields[i], 8, 32)
+		if err != nil {
+			return e, fmt.Errorf("invalid raw diff entry mode %q: %q", fields[i], tok)
+		}
+		modes[i] = FileMode(m)
+	}
+	objects := fields[n+1 : 2*(n+1)]
+	for _, o := range objects {
+		if o == "" {
+			return e, fmt.Errorf("invalid raw diff entry: empty object: %q", tok)
+		}
+	}
+
+	sources, err := parseStatus(fields[len(fields)-1], n)
+	if err != nil {
+		return e, fmt.Errorf("invalid raw diff entry: %w: %q", err, tok)
+	}
+	for i := range sources {
+		sources[i].Mode = modes[i]
+		sources[i].Object = objects[i]
+	}
+	e.Sources = sources
+	e.Mode = modes[n]
+	e.Object = objects[n]
+	return e, nil
+}
+
+// Name-status metadata is only the status, from which the number of sources
+// is inferred: scores only follow single statuses, so a status of several
+// letters is combined.
+func parseNameStatusMeta(tok string) (Entry, error) {
+	n := len(strings.TrimRight(tok, "0123456789"))
+	if n == 0 || (n > 1 && n != len(tok)) {
+		return Entry{}, fmt.Errorf("invalid name-status diff entry: %q", tok)
+	}
+	sources, err := parseStatus(tok, n)
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid name-status diff entry: %w", err)
+	}
+	return Entry{Sources: sources}, nil
+}
+
+// parseStatus parses the status of an entry with n sources, which is either a
+// single status letter optionally followed by a score, or n status letters
+// for combined diffs.
+func parseStatus(s string, n int) ([]Source, error) {
+	sources := make([]Source, n)
+	if n == 1 {
+		if s == "" {
+			return nil, fmt.Errorf("missing status")
+		}
+		sources[0].Status = Status(s[0])
+		if score := s[1:]; score != "" {
+			v, err := strconv.Atoi(score)
+			if err != nil || v < 0 || v > 100 {
+				return nil, fmt.Errorf("invalid score %q", score)
+			}
+			sources[0].Score = v
+		}
+	} else {
+		if len(s) != n {
+			return nil, fmt.Errorf("expected %d status letters, got %q", n, s)
+		}
+		for i := range sources {
+			sources[i].Status = Status(s[i])
+		}
+	}
+	for _, src := range sources {
+		if !validStatus(src.Status) {
+			return nil, fmt.Errorf("unknown status %q", src.Status)
+		}
+	}
+	return sources, nil
+}
+
+func validStatus(s Status) bool {
+	switch s {
+	case Added, Copied, Deleted, Modified, Renamed, TypeChanged, Unmerged, Unknown:
+		return true
+	}
+	return false
+}
+
+// isRawMeta reports whether tok looks like the metadata of a raw entry, i.e.
+// colons followed by a file mode.
+func isRawMeta(tok string) bool {
+	rest := strings.TrimLeft(tok, ":")
+	if len(rest) == len(tok) || len(rest) < 7 || rest[6] != ' ' {
+		return false
+	}
+	for _, c := range rest[:6] {
+		if c < '0' || c > '7' {
+			return false
+		}
+	}
+	return true
+}
+
+// isCommitID reports whether tok is a full commit ID, as printed by
+// `git diff-tree` before the diff of a commit.
+func isCommitID(tok string) bool {
+	if len(tok) != 40 && len(tok) != 64 {
+		return false
+	}
+	for _, c := range tok {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// tokens reads NUL-terminated tokens with lookahead.
+type tokens struct {
+	scanner *bufio.Scanner
+	peeked  []string
+}
+
+func newTokens(r io.Reader) *tokens {
+	return &tokens{scanner: newZScanner(r)}
+}
+
+func (t *tokens) next() (string, bool) {
+	if len(t.peeked) > 0 {
+		tok := t.peeked[0]
+		t.peeked = t.peeked[1:]
+		return tok, true
+	}
+	if !t.scanner.Scan() {
+		return "", false
+	}
+	return t.scanner.Text(), true
+}
+
+// peek returns the token i tokens ahead of the next one, without consuming
+// any.
+func (t *tokens) peek(i int) (string, bool) {
+	for len(t.peeked) <= i {
+		if !t.scanner.Scan() {
+			return "", false
+		}
+		t.peeked = append(t.peeked, t.scanner.Text())
+	}
+	return t.peeked[i], true
+}
+
+func (t *tokens) err() error {
+	return t.scanner.Err()
+}
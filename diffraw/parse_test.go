@@ -0,0 +1,238 @@
+package diffraw
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRaw(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Entry
+	}{
+		{
+			name:  "modify, add and rename",
+			input: ":100644 100644 e952784d6ea184b32977bcc43ba488ef9d1ee9a5 1010ad3b907303666f7fb483b64e005f21f40287 M\x00f\x00:000000 100644 0000000000000000000000000000000000000000 13e7564ea3d6a2bfe5ad4ee4b0ecd2bd07a5f8a6 A\x00onlyo\x00:100644 100644 9e03d3d3fa6e8df05a5ec6e4a3b4c34fb3c1b4ee 9e03d3d3fa6e8df05a5ec6e4a3b4c34fb3c1b4ee R100\x00r\x00r2\x00",
+			want: []Entry{
+				{
+					Sources: []Source{{Mode: FileModeRegular, Object: "e952784d6ea184b32977bcc43ba488ef9d1ee9a5", Status: Modified}},
+					Mode:    FileModeRegular, Object: "1010ad3b907303666f7fb483b64e005f21f40287", Path: "f",
+				},
+				{
+					Sources: []Source{{Mode: 0, Object: "0000000000000000000000000000000000000000", Status: Added}},
+					Mode:    FileModeRegular, Object: "13e7564ea3d6a2bfe5ad4ee4b0ecd2bd07a5f8a6", Path: "onlyo",
+				},
+				{
+					Sources: []Source{{Mode: FileModeRegular, Object: "9e03d3d3fa6e8df05a5ec6e4a3b4c34fb3c1b4ee", Status: Renamed, Score: 100, Path: "r"}},
+					Mode:    FileModeRegular, Object: "9e03d3d3fa6e8df05a5ec6e4a3b4c34fb3c1b4ee", Path: "r2",
+				},
+			},
+		},
+		{
+			name:  "abbreviated objects in work tree",
+			input: ":100644 100644 5e28b27 0000000 M\x00s p a c e\x00",
+			want: []Entry{
+				{
+					Sources: []Source{{Mode: FileModeRegular, Object: "5e28b27", Status: Modified}},
+					Mode:    FileModeRegular, Object: "0000000", Path: "s p a c e",
+				},
+			},
+		},
+		{
+			name:  "combined diff with commit ID",
+			input: "7cd2d7e2a3400e2463239d071c475c09ab410c2d\x00::100644 100644 100644 e952784d6ea184b32977bcc43ba488ef9d1ee9a5 2963b58e0f4025e1f70c75fa6e53978ad3d523da 1010ad3b907303666f7fb483b64e005f21f40287 MM\x00f\x00::100644 000000 100755 e952784 0000000 1a24852 TA\x00g\x00",
+			want: []Entry{
+				{
+					Commit: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+					Sources: []Source{
+						{Mode: FileModeRegular, Object: "e952784d6ea184b32977bcc43ba488ef9d1ee9a5", Status: Modified},
+						{Mode: FileModeRegular, Object: "2963b58e0f4025e1f70c75fa6e53978ad3d523da", Status: Modified},
+					},
+					Mode: FileModeRegular, Object: "1010ad3b907303666f7fb483b64e005f21f40287", Path: "f",
+				},
+				{
+					Commit: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+					Sources: []Source{
+						{Mode: FileModeRegular, Object: "e952784", Status: TypeChanged},
+						{Mode: 0, Object: "0000000", Status: Added},
+					},
+					Mode: FileModeExecutable, Object: "1a24852", Path: "g",
+				},
+			},
+		},
+		{
+			name:  "combined all paths",
+			input: "::100644 100644 100644 e952784 2963b58 1010ad3 RM\x00old\x00f\x00f\x00::100644 100644 100644 e952784 2963b58 1010ad3 MM\x00h\x00h\x00h\x00",
+			want: []Entry{
+				{
+					Sources: []Source{
+						{Mode: FileModeRegular, Object: "e952784", Status: Renamed, Path: "old"},
+						{Mode: FileModeRegular, Object: "2963b58", Status: Modified, Path: "f"},
+					},
+					Mode: FileModeRegular, Object: "1010ad3", Path: "f",
+				},
+				{
+					Sources: []Source{
+						{Mode: FileModeRegular, Object: "e952784", Status: Modified, Path: "h"},
+						{Mode: FileModeRegular, Object: "2963b58", Status: Modified, Path: "h"},
+					},
+					Mode: FileModeRegular, Object: "1010ad3", Path: "h",
+				},
+			},
+		},
+		{
+			name:  "commit ID file names",
+			input: ":100644 100644 e952784 1010ad3 M\x000123456789abcdef0123456789abcdef01234567\x00::100644 100644 100644 e952784 2963b58 1010ad3 MM\x000123456789abcdef0123456789abcdef01234567\x000123456789abcdef0123456789abcdef01234567\x000123456789abcdef0123456789abcdef01234567\x00",
+			want: []Entry{
+				{
+					Sources: []Source{{Mode: FileModeRegular, Object: "e952784", Status: Modified}},
+					Mode:    FileModeRegular, Object: "1010ad3", Path: "0123456789abcdef0123456789abcdef01234567",
+				},
+				{
+					Sources: []Source{
+						{Mode: FileModeRegular, Object: "e952784", Status: Modified, Path: "0123456789abcdef0123456789abcdef01234567"},
+						{Mode: FileModeRegular, Object: "2963b58", Status: Modified, Path: "0123456789abcdef0123456789abcdef01234567"},
+					},
+					Mode: FileModeRegular, Object: "1010ad3", Path: "0123456789abcdef0123456789abcdef01234567",
+				},
+			},
+		},
+		{
+			name:  "combined diff followed by commit ID",
+			input: "::100644 100644 100644 e952784 2963b58 1010ad3 MM\x00f\x007cd2d7e2a3400e2463239d071c475c09ab410c2d\x00::100644 100644 100644 e952784 2963b58 1010ad3 MM\x00g\x00",
+			want: []Entry{
+				{
+					Sources: []Source{
+						{Mode: FileModeRegular, Object: "e952784", Status: Modified},
+						{Mode: FileModeRegular, Object: "2963b58", Status: Modified},
+					},
+					Mode: FileModeRegular, Object: "1010ad3", Path: "f",
+				},
+				{
+					Commit: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+					Sources: []Source{
+						{Mode: FileModeRegular, Object: "e952784", Status: Modified},
+						{Mode: FileModeRegular, Object: "2963b58", Status: Modified},
+					},
+					Mode: FileModeRegular, Object: "1010ad3", Path: "g",
+				},
+			},
+		},
+		{
+			name:  "octopus merge",
+			input: ":::100644 100644 100644 100644 a b c d MMA\x00f\x00",
+			want: []Entry{
+				{
+					Sources: []Source{
+						{Mode: FileModeRegular, Object: "a", Status: Modified},
+						{Mode: FileModeRegular, Object: "b", Status: Modified},
+						{Mode: FileModeRegular, Object: "c", Status: Added},
+					},
+					Mode: FileModeRegular, Object: "d", Path: "f",
+				},
+			},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRaw(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseRaw() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseRaw() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseRaw_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"no colon", "100644 100644 a b M\x00f\x00"},
+		{"missing fields", ":100644 100644 a M\x00f\x00"},
+		{"fields for wrong parent count", "::100644 100644 a b M\x00f\x00"},
+		{"bad mode", ":100944 100644 a b M\x00f\x00"},
+		{"unknown status", ":100644 100644 a b Q\x00f\x00"},
+		{"bad score", ":100644 100644 a b R1x\x00f\x00g\x00"},
+		{"combined status length", "::100644 100644 100644 a b c M\x00f\x00"},
+		{"missing path", ":100644 100644 a b M\x00"},
+		{"missing rename destination", ":100644 100644 a b R100\x00f\x00"},
+		{"truncated all paths", "::100644 100644 100644 a b c MM\x00f\x00f\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRaw(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("ParseRaw(%q) error = nil, want error", tt.input)
+			}
+		})
+	}
+}
+
+func TestParseNameStatus(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Entry
+	}{
+		{
+			name:  "modify, add and rename",
+			input: "M\x00f\x00A\x00onlyo\x00R100\x00r\x00r2\x00C075\x00a\x00b\x00",
+			want: []Entry{
+				{Sources: []Source{{Status: Modified}}, Path: "f"},
+				{Sources: []Source{{Status: Added}}, Path: "onlyo"},
+				{Sources: []Source{{Status: Renamed, Score: 100, Path: "r"}}, Path: "r2"},
+				{Sources: []Source{{Status: Copied, Score: 75, Path: "a"}}, Path: "b"},
+			},
+		},
+		{
+			name:  "combined diff with commit IDs",
+			input: "7cd2d7e2a3400e2463239d071c475c09ab410c2d\x00MM\x00f\x00AM\x00g\x008a3c1e5c3f1d1b0a2e6f4d7c9b8a7f6e5d4c3b2a\x00MD\x00h\x00",
+			want: []Entry{
+				{Commit: "7cd2d7e2a3400e2463239d071c475c09ab410c2d", Sources: []Source{{Status: Modified}, {Status: Modified}}, Path: "f"},
+				{Commit: "7cd2d7e2a3400e2463239d071c475c09ab410c2d", Sources: []Source{{Status: Added}, {Status: Modified}}, Path: "g"},
+				{Commit: "8a3c1e5c3f1d1b0a2e6f4d7c9b8a7f6e5d4c3b2a", Sources: []Source{{Status: Modified}, {Status: Deleted}}, Path: "h"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNameStatus(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseNameStatus() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseNameStatus() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseNameStatus_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"score only", "100\x00f\x00"},
+		{"combined with score", "MM100\x00f\x00"},
+		{"unknown status", "Z\x00f\x00"},
+		{"missing path", "M\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseNameStatus(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("ParseNameStatus(%q) error = nil, want error", tt.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Package fsck parses the output of `git fsck`.
+
+`git fsck` reports problems with the object database, along with dangling
+and unreachable objects, one per line. This package turns those lines into
+typed [Finding] values, so that maintenance tools can act on them, e.g. to
+recover dangling commits or to detect corruption.
+
+# Basic Usage
+
+[Parse] takes an [io.Reader] containing `git fsck` output:
+
+	r := bytes.NewReader(gitFsckOutput)
+	findings, err := fsck.Parse(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, f := range findings {
+	    if f.Kind == fsck.Dangling && f.Object.Type == "commit" {
+	        fmt.Println("recoverable commit:", f.Object.OID)
+	    }
+	}
+
+Git writes dangling, unreachable, missing, and broken link reports to stdout,
+but errors, warnings and notices to stderr. Pass both streams combined (e.g.
+with 2>&1) to receive all findings. Git must run with LC_ALL=C, as the
+reports are translated in other locales. Progress lines are ignored.
+
+Output produced with --unreachable, --root, --tags, --name-objects and
+--lost-found is supported. With --lost-found, git additionally writes each
+dangling object into the repository; see [Finding.LostFoundPath].
+
+Note that git exits with a non-zero status when it finds errors, which
+callers executing git themselves will want to distinguish from a failure to
+run it.
+
+For more information, see the Git documentation for [git fsck].
+
+[git fsck]: https://git-scm.com/docs/git-fsck
+*/
+package fsck
@@ -0,0 +1,58 @@
+package fsck
+
+import "path"
+
+// Kind is the kind of a fsck finding.
+type Kind string
+
+// Kinds of findings reported by git fsck.
+const (
+	Dangling    Kind = "dangling"    // object not referenced by any other unreachable object
+	Unreachable Kind = "unreachable" // object not reachable from any ref (with --unreachable)
+	Missing     Kind = "missing"     // object referenced but not present
+	BrokenLink  Kind = "broken link" // object referencing a missing or invalid object
+	Root        Kind = "root"        // root commit (with --root)
+	Tagged      Kind = "tagged"      // object pointed to by a tag (with --tags)
+	Error       Kind = "error"       // error, about an object or free-form
+	Warning     Kind = "warning"     // warning, about an object or free-form
+	Notice      Kind = "notice"      // free-form informational message
+)
+
+// Object identifies an object named in a finding.
+type Object struct {
+	Type string // object type: "commit", "tree", "blob", "tag", or "unknown"
+	OID  string // object name
+	Name string // with --name-objects, how the object is reachable, e.g. "HEAD~2:src/main.go"
+}
+
+// Finding represents a single fsck report.
+//
+// Which fields are set depends on the Kind:
+//   - Dangling, Unreachable, Missing and Root: Object.
+//   - BrokenLink: Object links to Target, which is missing or invalid.
+//   - Tagged: the tag object Target, named TagName, points to Object.
+//   - Error and Warning: Message, and for reports about an object, Object and
+//     the fsck MessageID (e.g. "missingEmail").
+//   - Notice: Message.
+type Finding struct {
+	Kind      Kind
+	Object    Object
+	Target    Object
+	TagName   string
+	MessageID string // fsck message id, usable with fsck.<msg-id> config
+	Message   string
+}
+
+// LostFoundPath returns the path, relative to the git directory, that
+// `git fsck --lost-found` writes a dangling object to, or "" if the finding is
+// not a dangling object.
+func (f Finding) LostFoundPath() string {
+	if f.Kind != Dangling {
+		return ""
+	}
+	dir := "other"
+	if f.Object.Type == "commit" {
+		dir = "commit"
+	}
+	return path.Join("lost-found", dir, f.Object.OID)
+}
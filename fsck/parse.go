@@ -0,0 +1,189 @@
+package fsck
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Parse parses the output of `git fsck`.
+//
+// Findings are returned in the order they appear in the output.
+func Parse(r io.Reader) ([]Finding, error) {
+	var findings []Finding
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "Checking ") {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "broken link from "); ok {
+			// The linked object follows on its own line, e.g.
+			// "              to    blob <oid>".
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("invalid fsck broken link: missing target: %q", line)
+			}
+			f, err := parseBrokenLink(rest, scanner.Text())
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, f)
+			continue
+		}
+
+		f, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, f)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+func parseLine(line string) (Finding, error) {
+	kind, rest, _ := strings.Cut(line, " ")
+	switch Kind(kind) {
+	case Dangling, Unreachable, Missing:
+		obj, err := parseTypedObject(rest)
+		if err != nil {
+			return Finding{}, fmt.Errorf("invalid fsck %s line: %q", kind, line)
+		}
+		return Finding{Kind: Kind(kind), Object: obj}, nil
+
+	case Root:
+		obj, err := parseObject(rest)
+		if err != nil {
+			return Finding{}, fmt.Errorf("invalid fsck root line: %q", line)
+		}
+		obj.Type = "commit"
+		return Finding{Kind: Root, Object: obj}, nil
+
+	case Tagged:
+		return parseTagged(line, rest)
+
+	case Error, Warning:
+		// "error in <type> <object>: <msg-id>: <message>"
+		if rest, ok := strings.CutPrefix(rest, "in "); ok {
+			return parseObjectMessage(line, Kind(kind), rest)
+		}
+	}
+
+	// free-form messages, e.g. "error: refs/heads/x: invalid sha1 pointer"
+	for _, k := range []Kind{Error, Warning, Notice} {
+		if msg, ok := strings.CutPrefix(line, string(k)+": "); ok {
+			return Finding{Kind: k, Message: msg}, nil
+		}
+	}
+	return Finding{}, fmt.Errorf("unrecognized fsck line: %q", line)
+}
+
+// parseBrokenLink parses the two lines of a broken link report:
+// "broken link from <type> <object>" and "to <type> <object>", where types
+// are right-aligned and the second line is indented.
+func parseBrokenLink(from, to string) (Finding, error) {
+	fromObj, err := parseTypedObject(strings.TrimSpace(from))
+	if err != nil {
+		return Finding{}, fmt.Errorf("invalid fsck broken link: %q", from)
+	}
+	to, ok := strings.CutPrefix(strings.TrimSpace(to), "to ")
+	if !ok {
+		return Finding{}, fmt.Errorf("invalid fsck broken link target: %q", to)
+	}
+	toObj, err := parseTypedObject(strings.TrimSpace(to))
+	if err != nil {
+		return Finding{}, fmt.Errorf("invalid fsck broken link target: %q", to)
+	}
+	return Finding{Kind: BrokenLink, Object: fromObj, Target: toObj}, nil
+}
+
+// parseTagged parses "tagged <type> <object> (<tag name>) in <tag object>".
+func parseTagged(line, rest string) (Finding, error) {
+	// With --name-objects, the tagged object may itself be followed by a
+	// parenthesized name, so the tag name is the last parenthesized group.
+	i := strings.LastIndex(rest, ") in ")
+	if i < 0 {
+		return Finding{}, fmt.Errorf("invalid fsck tagged line: %q", line)
+	}
+	head := rest[:i]
+	k := strings.LastIndex(head, " (")
+	if k < 0 {
+		return Finding{}, fmt.Errorf("invalid fsck tagged line: %q", line)
+	}
+	obj, err := parseTypedObject(head[:k])
+	if err != nil {
+		return Finding{}, fmt.Errorf("invalid fsck tagged line: %q", line)
+	}
+	tag, err := parseObject(rest[i+len(") in "):])
+	if err != nil {
+		return Finding{}, fmt.Errorf("invalid fsck tagged line: %q", line)
+	}
+	tag.Type = "tag"
+	return Finding{Kind: Tagged, Object: obj, Target: tag, TagName: head[k+2:]}, nil
+}
+
+// parseObjectMessage parses "<type> <object>: <msg-id>: <message>", the
+// remainder of an error or warning about an object. Errors raised by fsck
+// itself rather than by the object checks have no message id.
+func parseObjectMessage(line string, kind Kind, rest string) (Finding, error) {
+	invalid := fmt.Errorf("invalid fsck %s line: %q", kind, line)
+
+	typ, rest, _ := strings.Cut(rest, " ")
+	i := strings.IndexAny(rest, " :")
+	if typ == "" || i <= 0 {
+		return Finding{}, invalid
+	}
+	obj := Object{Type: typ, OID: rest[:i]}
+	rest = rest[i:]
+
+	if after, ok := strings.CutPrefix(rest, " ("); ok {
+		j := strings.Index(after, "): ")
+		if j < 0 {
+			return Finding{}, invalid
+		}
+		obj.Name, rest = after[:j], after[j+1:]
+	}
+	msg, ok := strings.CutPrefix(rest, ": ")
+	if !ok {
+		return Finding{}, invalid
+	}
+
+	f := Finding{Kind: kind, Object: obj, Message: msg}
+	if id, text, found := strings.Cut(msg, ": "); found && id != "" && !strings.Contains(id, " ") {
+		f.MessageID, f.Message = id, text
+	}
+	return f, nil
+}
+
+// parseTypedObject parses "<type> <object>".
+func parseTypedObject(s string) (Object, error) {
+	typ, rest, found := strings.Cut(s, " ")
+	if !found || typ == "" {
+		return Object{}, fmt.Errorf("invalid object: %q", s)
+	}
+	obj, err := parseObject(rest)
+	obj.Type = typ
+	return obj, err
+}
+
+// parseObject parses an object name, optionally followed by a name with
+// --name-objects: "<oid> (<name>)".
+func parseObject(s string) (Object, error) {
+	oid, rest, found := strings.Cut(s, " ")
+	if oid == "" {
+		return Object{}, fmt.Errorf("invalid object: %q", s)
+	}
+	if !found {
+		return Object{OID: oid}, nil
+	}
+	name, ok := strings.CutPrefix(rest, "(")
+	name, ok2 := strings.CutSuffix(name, ")")
+	if !ok || !ok2 {
+		return Object{}, fmt.Errorf("invalid object: %q", s)
+	}
+	return Object{OID: oid, Name: name}, nil
+}
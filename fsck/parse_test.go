@@ -0,0 +1,157 @@
+package fsck
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Finding
+	}{
+		{
+			name: "dangling",
+			input: "dangling commit 229fbcffed19656800896e9a6edbbf8c146318a7\n" +
+				"dangling blob f2ad6c76f0115a6ba5b00456a849810e7ec0af20\n",
+			want: []Finding{
+				{Kind: Dangling, Object: Object{Type: "commit", OID: "229fbcffed19656800896e9a6edbbf8c146318a7"}},
+				{Kind: Dangling, Object: Object{Type: "blob", OID: "f2ad6c76f0115a6ba5b00456a849810e7ec0af20"}},
+			},
+		},
+		{
+			name: "root tags and names",
+			input: "Checking object directories: 100% (256/256), done.\n" +
+				"error in commit e21a9298b19584fe0ba364c6ef5005aa1a04ad1f: missingEmail: invalid author/committer line - missing email\n" +
+				"root 7bef2b5e2a6168409d31de471ba4e5dde601e9e6\n" +
+				"tagged commit 7bef2b5e2a6168409d31de471ba4e5dde601e9e6 (v1) in bc5559067bb51c98917e32619b21be727d4d2708\n" +
+				"unreachable tree 3683f870be446c7cc05ffaef9fa06415276e1828\n" +
+				"missing blob 78981922613b2afb6025042ff6bd878ac1994e85 (:a)\n",
+			want: []Finding{
+				{
+					Kind:      Error,
+					Object:    Object{Type: "commit", OID: "e21a9298b19584fe0ba364c6ef5005aa1a04ad1f"},
+					MessageID: "missingEmail",
+					Message:   "invalid author/committer line - missing email",
+				},
+				{Kind: Root, Object: Object{Type: "commit", OID: "7bef2b5e2a6168409d31de471ba4e5dde601e9e6"}},
+				{
+					Kind:    Tagged,
+					Object:  Object{Type: "commit", OID: "7bef2b5e2a6168409d31de471ba4e5dde601e9e6"},
+					Target:  Object{Type: "tag", OID: "bc5559067bb51c98917e32619b21be727d4d2708"},
+					TagName: "v1",
+				},
+				{Kind: Unreachable, Object: Object{Type: "tree", OID: "3683f870be446c7cc05ffaef9fa06415276e1828"}},
+				{Kind: Missing, Object: Object{Type: "blob", OID: "78981922613b2afb6025042ff6bd878ac1994e85", Name: ":a"}},
+			},
+		},
+		{
+			name: "broken link",
+			input: "broken link from    tree 3683f870be446c7cc05ffaef9fa06415276e1828 (HEAD^{tree})\n" +
+				"              to    blob 78981922613b2afb6025042ff6bd878ac1994e85 (HEAD:a)\n" +
+				"broken link from  commit e21a9298b19584fe0ba364c6ef5005aa1a04ad1f\n" +
+				"              to  commit 229fbcffed19656800896e9a6edbbf8c146318a7\n",
+			want: []Finding{
+				{
+					Kind:   BrokenLink,
+					Object: Object{Type: "tree", OID: "3683f870be446c7cc05ffaef9fa06415276e1828", Name: "HEAD^{tree}"},
+					Target: Object{Type: "blob", OID: "78981922613b2afb6025042ff6bd878ac1994e85", Name: "HEAD:a"},
+				},
+				{
+					Kind:   BrokenLink,
+					Object: Object{Type: "commit", OID: "e21a9298b19584fe0ba364c6ef5005aa1a04ad1f"},
+					Target: Object{Type: "commit", OID: "229fbcffed19656800896e9a6edbbf8c146318a7"},
+				},
+			},
+		},
+		{
+			name: "messages",
+			input: "warning in tag bc5559067bb51c98917e32619b21be727d4d2708 (v1): missingTaggerEntry: invalid format - expected 'tagger' line\n" +
+				"error in tree 3683f870be446c7cc05ffaef9fa06415276e1828: broken links\n" +
+				"error: refs/heads/bad: invalid sha1 pointer 0000000000000000000000000000000000000000\n" +
+				"notice: HEAD points to an unborn branch (main)\n",
+			want: []Finding{
+				{
+					Kind:      Warning,
+					Object:    Object{Type: "tag", OID: "bc5559067bb51c98917e32619b21be727d4d2708", Name: "v1"},
+					MessageID: "missingTaggerEntry",
+					Message:   "invalid format - expected 'tagger' line",
+				},
+				{
+					Kind:    Error,
+					Object:  Object{Type: "tree", OID: "3683f870be446c7cc05ffaef9fa06415276e1828"},
+					Message: "broken links",
+				},
+				{Kind: Error, Message: "refs/heads/bad: invalid sha1 pointer 0000000000000000000000000000000000000000"},
+				{Kind: Notice, Message: "HEAD points to an unborn branch (main)"},
+			},
+		},
+		{
+			name:  "tagged with names",
+			input: "tagged commit 7bef2b5e2a6168409d31de471ba4e5dde601e9e6 (v1^0) (v1) in bc5559067bb51c98917e32619b21be727d4d2708 (v1)\n",
+			want: []Finding{{
+				Kind:    Tagged,
+				Object:  Object{Type: "commit", OID: "7bef2b5e2a6168409d31de471ba4e5dde601e9e6", Name: "v1^0"},
+				Target:  Object{Type: "tag", OID: "bc5559067bb51c98917e32619b21be727d4d2708", Name: "v1"},
+				TagName: "v1",
+			}},
+		},
+		{
+			name:  "clean",
+			input: "",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"unrecognized", "fatal: not a git repository\n"},
+		{"dangling without object", "dangling commit\n"},
+		{"broken link without target", "broken link from    tree 3683f870be446c7cc05ffaef9fa06415276e1828\n"},
+		{"broken link with invalid target", "broken link from    tree 3683f870\nmissing blob 78981922\n"},
+		{"tagged without tag", "tagged commit 7bef2b5e (v1)\n"},
+		{"error without message", "error in commit e21a9298\n"},
+		{"unterminated name", "missing blob 78981922 (:a\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.input)); err == nil {
+				t.Error("Parse() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestFinding_LostFoundPath(t *testing.T) {
+	tests := []struct {
+		finding Finding
+		want    string
+	}{
+		{Finding{Kind: Dangling, Object: Object{Type: "commit", OID: "229fbcff"}}, "lost-found/commit/229fbcff"},
+		{Finding{Kind: Dangling, Object: Object{Type: "blob", OID: "f2ad6c76"}}, "lost-found/other/f2ad6c76"},
+		{Finding{Kind: Unreachable, Object: Object{Type: "commit", OID: "229fbcff"}}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.finding.LostFoundPath(); got != tt.want {
+			t.Errorf("LostFoundPath() = %q, want %q", got, tt.want)
+		}
+	}
+}
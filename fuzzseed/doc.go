@@ -0,0 +1,42 @@
+/*
+Package fuzzseed provides the seed inputs of the fuzz tests of this module's
+parsers, including regression inputs for bugs found by fuzzing and property
+testing. It is exported so that projects embedding the parsers can seed their
+own fuzz targets with them, and so that parsers added for new formats start
+with the same shared infrastructure.
+
+# Basic Usage
+
+[Add] adds the seeds of a format to a fuzz target taking a single []byte or
+string argument:
+
+	func FuzzMyParser(f *testing.F) {
+	    fuzzseed.Add[[]byte](f, "statusv2z")
+	    f.Fuzz(func(t *testing.T, data []byte) {
+	        statusv2.ParseZ(bytes.NewReader(data))
+	    })
+	}
+
+[Seeds] returns the seeds of a format, for fuzz targets taking other
+arguments, and [Formats] lists the formats.
+
+# Formats
+
+The seeds are held in a directory for each format, named after the package
+parsing it, with a suffix for packages parsing several formats:
+
+  - "statusv1" and "statusv1z" for porcelain=v1, without and with -z
+  - "statusv2" and "statusv2z" for porcelain=v2, without and with -z
+  - "apply" and "apply-check" for the numstat and summary, and the check
+    output of git apply
+  - "diffraw-raw" and "diffraw-name-status" for git diff --raw -z and
+    --name-status -z
+
+and "branch", "credential", "fsck", "logfmt", "lstree", "mergetree",
+"rebasetodo", "reflog", "theme" and "trailers" for the other packages. Seeds
+of formats without -z, such as "statusv2", use LF line endings.
+
+Each seed is a file holding the raw input. Regression inputs are named with a
+"regress-" prefix, and are kept even if they no longer reproduce a bug.
+*/
+package fuzzseed
@@ -0,0 +1,83 @@
+package fuzzseed
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+// FS holds the seed files, in a directory for each format.
+var FS fs.FS
+
+func init() {
+	var err error
+	if FS, err = fs.Sub(testdata, "testdata"); err != nil {
+		panic(err)
+	}
+}
+
+// Seed is a seed input for a fuzz target.
+type Seed struct {
+	Format string // format of the input, such as "statusv2z"
+	Name   string // name of the seed, such as "changes"
+	Data   []byte // the raw input
+}
+
+// Regression reports whether the seed is an input that once triggered a bug.
+func (s Seed) Regression() bool {
+	return strings.HasPrefix(s.Name, "regress-")
+}
+
+// Formats returns the formats with seeds, in lexical order.
+func Formats() ([]string, error) {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		return nil, err
+	}
+	var formats []string
+	for _, e := range entries {
+		if e.IsDir() {
+			formats = append(formats, e.Name())
+		}
+	}
+	return formats, nil
+}
+
+// Seeds returns the seeds of format, ordered by name. It returns an error
+// wrapping [fs.ErrNotExist] if there are no seeds for the format.
+func Seeds(format string) ([]Seed, error) {
+	entries, err := fs.ReadDir(FS, format)
+	if err != nil {
+		return nil, err
+	}
+	var seeds []Seed
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := fs.ReadFile(FS, path.Join(format, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		seeds = append(seeds, Seed{Format: format, Name: e.Name(), Data: data})
+	}
+	return seeds, nil
+}
+
+// Add adds the seeds of format to the corpus of f, as the single argument of
+// its fuzz target. It fails f if there are no seeds for the format.
+func Add[T []byte | string](f *testing.F, format string) {
+	f.Helper()
+	seeds, err := Seeds(format)
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, s := range seeds {
+		f.Add(T(s.Data))
+	}
+}
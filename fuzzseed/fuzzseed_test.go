@@ -0,0 +1,51 @@
+package fuzzseed
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+)
+
+func TestFormats(t *testing.T) {
+	formats, err := Formats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"statusv1", "statusv1z", "statusv2", "statusv2z"} {
+		if !slices.Contains(formats, want) {
+			t.Errorf("Formats() = %q, missing %q", formats, want)
+		}
+	}
+	for _, format := range formats {
+		seeds, err := Seeds(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(seeds) == 0 {
+			t.Errorf("Seeds(%q) is empty", format)
+		}
+		for _, s := range seeds {
+			if s.Format != format || s.Name == "" {
+				t.Errorf("Seeds(%q) returned %+v", format, s)
+			}
+		}
+	}
+}
+
+func TestSeeds_NotExist(t *testing.T) {
+	if _, err := Seeds("nonexistent"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Seeds() error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestSeed_Regression(t *testing.T) {
+	seeds, err := Seeds("rebasetodo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := slices.IndexFunc(seeds, Seed.Regression)
+	if i < 0 || seeds[i].Name != "regress-exec-trailing-cr" {
+		t.Errorf("Seeds() has no regression seed regress-exec-trailing-cr")
+	}
+}
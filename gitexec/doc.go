@@ -0,0 +1,49 @@
+/*
+Package gitexec provides a minimal layer for executing git commands.
+
+The parser packages in this module operate on an [io.Reader] and never run
+git themselves. This package is the companion for callers who want to: it
+runs git as a subprocess and surfaces its output and failures consistently,
+so that higher-level helpers (such as fetching note contents) can be written
+once against the [Runner] interface.
+
+# Basic Usage
+
+	git := gitexec.New("/path/to/repo")
+	out, err := git.Run(ctx, "rev-parse", "HEAD")
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+When git exits with a non-zero status, the returned error is an [*ExitError]
+carrying the exit code and standard error. The standard output captured up to
+that point is still returned, as some commands (such as `git merge-tree`)
+report meaningful results with a non-zero exit status.
+
+# Optional Locks
+
+Git takes some locks only optionally, such as to write the index after
+`git status` refreshes it. A [*Git] runs git with GIT_OPTIONAL_LOCKS=0, so
+that commands only reading the state of a repository never write to it, and
+never make git commands run concurrently, such as by the user, fail to take
+the index lock. This matters for prompts, editors and watchers, which run git
+in the background. [Git.OptionalLocks] restores the default of git.
+
+# Testing
+
+Code written against the [Runner] interface rather than [*Git] directly can
+be exercised in tests without git installed, by substituting a fake Runner.
+
+Rather than writing the output of git by hand, a [Recorder] wraps a real
+Runner to capture each run with its output and exit status, and a [Replayer]
+serves them back, so that tests stay hermetic while exercising what git
+actually wrote:
+
+	rec := gitexec.NewRecorder(gitexec.New(dir))
+	// ... run the code under test against rec ...
+	err := rec.Save("testdata/status.json")
+
+	git, err := gitexec.LoadReplayer("testdata/status.json")
+	// ... run the code under test against git ...
+*/
+package gitexec
@@ -0,0 +1,109 @@
+package gitexec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Runner runs git commands.
+//
+// Run executes git with the given arguments and returns its standard output.
+// Implementations should return an [*ExitError] when git exits with a non-zero
+// status, along with any standard output produced.
+type Runner interface {
+	Run(ctx context.Context, args ...string) ([]byte, error)
+}
+
+// Git is a [Runner] that executes the git binary as a subprocess.
+//
+// The zero value runs "git" from PATH in the current working directory.
+//
+// Git runs with GIT_OPTIONAL_LOCKS=0 unless OptionalLocks is set, so that
+// reading the state of a repository, as with `git status`, never writes to it
+// or takes the index lock, which would make git commands run concurrently by
+// the user fail. Commands that modify the repository still take the locks they
+// need. Setting GIT_OPTIONAL_LOCKS in Env overrides OptionalLocks.
+type Git struct {
+	Path string   // path to the git executable; "git" is looked up in PATH if empty
+	Dir  string   // working directory for commands; the current directory if empty
+	Env  []string // additional environment variables, in "KEY=value" form
+
+	// OptionalLocks lets git take optional locks, as it does by default, such
+	// as to write the index refreshed by `git status`, saving the work on the
+	// next run at the cost of interfering with concurrent git commands.
+	OptionalLocks bool
+}
+
+// New returns a Git that runs commands in the repository at dir.
+func New(dir string) *Git {
+	return &Git{Dir: dir}
+}
+
+// Run executes git with args and returns its standard output.
+//
+// If ctx is done before the command completes, the process is killed and
+// ctx.Err() is returned.
+func (g *Git) Run(ctx context.Context, args ...string) ([]byte, error) {
+	path := g.Path
+	if path == "" {
+		path = "git"
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Dir = g.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if !g.OptionalLocks || len(g.Env) > 0 {
+		cmd.Env = os.Environ()
+		if !g.OptionalLocks {
+			cmd.Env = append(cmd.Env, "GIT_OPTIONAL_LOCKS=0")
+		}
+		cmd.Env = append(cmd.Env, g.Env...)
+	}
+
+	err := cmd.Run()
+	if err == nil {
+		return stdout.Bytes(), nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return stdout.Bytes(), ctxErr
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return stdout.Bytes(), &ExitError{
+			Args:     args,
+			ExitCode: exitErr.ExitCode(),
+			Stderr:   stderr.Bytes(),
+		}
+	}
+	return stdout.Bytes(), fmt.Errorf("gitexec: %w", err)
+}
+
+// ExitError is returned when git exits with a non-zero status.
+type ExitError struct {
+	Args     []string // arguments git was invoked with
+	ExitCode int      // process exit code
+	Stderr   []byte   // standard error output
+}
+
+// Error returns a message including the git subcommand, exit code, and the
+// first line of standard error, if any.
+func (e *ExitError) Error() string {
+	var b strings.Builder
+	b.WriteString("git")
+	if len(e.Args) > 0 {
+		b.WriteString(" " + e.Args[0])
+	}
+	fmt.Fprintf(&b, ": exit status %d", e.ExitCode)
+	if line, _, _ := bytes.Cut(bytes.TrimSpace(e.Stderr), []byte{'\n'}); len(line) > 0 {
+		b.WriteString(": ")
+		b.Write(line)
+	}
+	return b.String()
+}
@@ -0,0 +1,163 @@
+package gitexec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGit_Run(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+	dir := t.TempDir()
+	git := New(dir)
+	ctx := context.Background()
+
+	if _, err := git.Run(ctx, "init", "--quiet"); err != nil {
+		t.Fatalf("Run(init) error = %v", err)
+	}
+	out, err := git.Run(ctx, "rev-parse", "--is-inside-work-tree")
+	if err != nil {
+		t.Fatalf("Run(rev-parse) error = %v", err)
+	}
+	if got := string(bytes.TrimSpace(out)); got != "true" {
+		t.Errorf("Run(rev-parse) = %q, want %q", got, "true")
+	}
+
+	_, err = git.Run(ctx, "rev-parse", "--verify", "refs/heads/does-not-exist")
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("Run() error = %v, want *ExitError", err)
+	}
+	if exitErr.ExitCode == 0 {
+		t.Errorf("ExitError.ExitCode = 0, want non-zero")
+	}
+	if len(exitErr.Stderr) == 0 {
+		t.Errorf("ExitError.Stderr is empty")
+	}
+}
+
+func TestGit_Run_Env(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+	git := &Git{Dir: t.TempDir(), Env: []string{"GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=porcelain.test", "GIT_CONFIG_VALUE_0=yes"}}
+	out, err := git.Run(context.Background(), "config", "porcelain.test")
+	if err != nil {
+		t.Fatalf("Run(config) error = %v", err)
+	}
+	if got := string(bytes.TrimSpace(out)); got != "yes" {
+		t.Errorf("Run(config) = %q, want %q", got, "yes")
+	}
+}
+
+// TestGit_Run_OptionalLocks checks that git status leaves a stale index
+// alone, rather than locking it to write it refreshed, unless OptionalLocks
+// is set.
+func TestGit_Run_OptionalLocks(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+	dir := t.TempDir()
+	ctx := context.Background()
+	git := New(dir)
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, []byte("content\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"init", "--quiet"}, {"add", "file.txt"}} {
+		if _, err := git.Run(ctx, args...); err != nil {
+			t.Fatalf("Run(%s) error = %v", args[0], err)
+		}
+	}
+	// Make the stat information in the index stale, without changing the
+	// content of the file, so that git status would refresh the index.
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(file, old, old); err != nil {
+		t.Fatal(err)
+	}
+	index := filepath.Join(dir, ".git", "index")
+	before, err := os.ReadFile(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, g := range []*Git{git, {Dir: dir, Env: []string{"GIT_TRACE=0"}}} {
+		if _, err := g.Run(ctx, "status", "--porcelain=v2"); err != nil {
+			t.Fatalf("Run(status) error = %v", err)
+		}
+		if after, err := os.ReadFile(index); err != nil || !bytes.Equal(before, after) {
+			t.Errorf("Run(status) with Env %q wrote the index", g.Env)
+		}
+	}
+
+	withLocks := &Git{Dir: dir, OptionalLocks: true}
+	if _, err := withLocks.Run(ctx, "status", "--porcelain=v2"); err != nil {
+		t.Fatalf("Run(status) error = %v", err)
+	}
+	if after, err := os.ReadFile(index); err != nil || bytes.Equal(before, after) {
+		t.Error("Run(status) with OptionalLocks did not refresh the index")
+	}
+}
+
+func TestGit_Run_Canceled(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := New(t.TempDir()).Run(ctx, "version")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestGit_Run_NotFound(t *testing.T) {
+	git := &Git{Path: "/nonexistent/git"}
+	_, err := git.Run(context.Background(), "version")
+	if err == nil {
+		t.Fatal("Run() error = nil, want error")
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		t.Errorf("Run() error = %v, want non-ExitError", err)
+	}
+}
+
+func TestExitError_Error(t *testing.T) {
+	testcases := []struct {
+		name string
+		err  ExitError
+		want string
+	}{
+		{
+			name: "with stderr",
+			err:  ExitError{Args: []string{"notes", "show"}, ExitCode: 1, Stderr: []byte("error: no note found for object abc.\nmore\n")},
+			want: "git notes: exit status 1: error: no note found for object abc.",
+		},
+		{
+			name: "without stderr",
+			err:  ExitError{Args: []string{"diff", "--quiet"}, ExitCode: 1},
+			want: "git diff: exit status 1",
+		},
+		{
+			name: "without args",
+			err:  ExitError{ExitCode: 129},
+			want: "git: exit status 129",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.err.Error(); got != tc.want {
+				t.Errorf("Error() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
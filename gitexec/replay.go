@@ -0,0 +1,175 @@
+package gitexec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"unicode/utf8"
+)
+
+// An Interaction is a recorded run of git.
+type Interaction struct {
+	Args     []string
+	Stdout   Output
+	Stderr   Output `json:",omitempty"` // only captured when git exits with a non-zero status
+	ExitCode int    `json:",omitempty"`
+	Err      string `json:",omitempty"` // message of any other error, such as git not being found
+}
+
+// err returns the error of running git the interaction recorded.
+func (i Interaction) err() error {
+	switch {
+	case i.ExitCode != 0:
+		return &ExitError{Args: i.Args, ExitCode: i.ExitCode, Stderr: i.Stderr}
+	case i.Err != "":
+		return errors.New(i.Err)
+	}
+	return nil
+}
+
+// Output is the output of git. It encodes to JSON as a string if it is valid
+// UTF-8, so that recordings are readable, and otherwise as an object with a
+// Base64 field.
+type Output []byte
+
+func (o Output) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(o) {
+		return json.Marshal(string(o))
+	}
+	return json.Marshal(struct{ Base64 []byte }{o})
+}
+
+func (o *Output) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*o = Output(s)
+		return nil
+	}
+	var b struct{ Base64 []byte }
+	if err := json.Unmarshal(data, &b); err != nil {
+		return err
+	}
+	*o = b.Base64
+	return nil
+}
+
+// A Recorder is a [Runner] recording the runs of git by another Runner, to be
+// saved and served back by a [Replayer] in tests without git. It is safe for
+// concurrent use.
+type Recorder struct {
+	runner Runner
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder returns a Recorder running git with r.
+func NewRecorder(r Runner) *Recorder {
+	return &Recorder{runner: r}
+}
+
+// Run runs git with the Runner of r, recording the result unless it is an
+// error of ctx, which is not a result of git.
+func (r *Recorder) Run(ctx context.Context, args ...string) ([]byte, error) {
+	out, err := r.runner.Run(ctx, args...)
+	if err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return out, err
+	}
+
+	i := Interaction{Args: slices.Clone(args), Stdout: slices.Clone(out)}
+	var exitErr *ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		i.ExitCode, i.Stderr = exitErr.ExitCode, slices.Clone(exitErr.Stderr)
+	case err != nil:
+		i.Err = err.Error()
+	}
+	r.mu.Lock()
+	r.interactions = append(r.interactions, i)
+	r.mu.Unlock()
+	return out, err
+}
+
+// Interactions returns the interactions recorded so far, in the order git
+// finished running.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.interactions)
+}
+
+// Save writes the interactions recorded so far to the file at path, as JSON,
+// for [LoadReplayer].
+func (r *Recorder) Save(path string) error {
+	data, err := json.MarshalIndent(r.Interactions(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// A Replayer is a [Runner] serving back recorded interactions instead of
+// running git. It is safe for concurrent use.
+//
+// Each run is served by the first interaction with the same arguments that
+// has not been served yet, so that a command run repeatedly gets each of its
+// results in turn, while different commands may run in any order.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	served       []bool
+}
+
+// NewReplayer returns a Replayer serving the given interactions.
+func NewReplayer(interactions []Interaction) *Replayer {
+	return &Replayer{interactions: interactions, served: make([]bool, len(interactions))}
+}
+
+// LoadReplayer returns a Replayer serving the interactions saved to the file
+// at path by [Recorder.Save].
+func LoadReplayer(path string) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var interactions []Interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, fmt.Errorf("gitexec: %s: %w", path, err)
+	}
+	return NewReplayer(interactions), nil
+}
+
+// Run returns the output and error recorded for args, or an error if there is
+// none left to serve. It returns ctx.Err() if ctx is done.
+func (r *Replayer) Run(ctx context.Context, args ...string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for n, i := range r.interactions {
+		if !r.served[n] && slices.Equal(i.Args, args) {
+			r.served[n] = true
+			return slices.Clone(i.Stdout), i.err()
+		}
+	}
+	return nil, fmt.Errorf("gitexec: no recorded interaction for git %q", args)
+}
+
+// Unserved returns the interactions not served yet, in order, so that tests
+// can check that the code under test ran every command expected.
+func (r *Replayer) Unserved() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unserved []Interaction
+	for n, i := range r.interactions {
+		if !r.served[n] {
+			unserved = append(unserved, i)
+		}
+	}
+	return unserved
+}
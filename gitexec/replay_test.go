@@ -0,0 +1,141 @@
+package gitexec
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// scriptedRunner returns a fixed result for each command, by its first
+// argument.
+type scriptedRunner map[string]struct {
+	out string
+	err error
+}
+
+func (s scriptedRunner) Run(ctx context.Context, args ...string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r := s[args[0]]
+	return []byte(r.out), r.err
+}
+
+func TestRecorderReplayer(t *testing.T) {
+	git := scriptedRunner{
+		"status":    {out: "? new.txt\x00? caf\xc3\xa9\x00"},
+		"cat-file":  {out: "\xff\xfe binary"},
+		"rev-parse": {err: &ExitError{Args: []string{"rev-parse"}, ExitCode: 128, Stderr: []byte("fatal: not a git repository\n")}},
+		"missing":   {err: errors.New("gitexec: exec: \"git\": executable file not found in $PATH")},
+	}
+	rec := NewRecorder(git)
+	ctx := context.Background()
+	commands := [][]string{
+		{"status", "--porcelain=v2", "-z"},
+		{"cat-file", "blob", "HEAD:image.png"},
+		{"rev-parse", "HEAD"},
+		{"missing"},
+		{"status", "--porcelain=v2", "-z"},
+	}
+	type result struct {
+		Out string
+		Err string
+	}
+	var want []result
+	for _, args := range commands {
+		out, err := rec.Run(ctx, args...)
+		r := result{Out: string(out)}
+		if err != nil {
+			r.Err = err.Error()
+		}
+		want = append(want, r)
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := rec.Run(canceled, "status"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() with canceled context error = %v", err)
+	}
+	if got := len(rec.Interactions()); got != len(commands) {
+		t.Errorf("recorded %d interactions, want %d, without the canceled one", got, len(commands))
+	}
+
+	path := filepath.Join(t.TempDir(), "git.json")
+	if err := rec.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"Stdout": "? new.txt\u0000? café\u0000"`) {
+		t.Errorf("saved UTF-8 output is not readable:\n%s", data)
+	}
+
+	rep, err := LoadReplayer(path)
+	if err != nil {
+		t.Fatalf("LoadReplayer() error = %v", err)
+	}
+	// Replay in a different order, which only matters for repeated commands.
+	var got []result
+	for _, n := range []int{4, 3, 2, 1, 0} {
+		out, err := rep.Run(ctx, commands[n]...)
+		r := result{Out: string(out)}
+		if err != nil {
+			r.Err = err.Error()
+		}
+		got = append([]result{r}, got...)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("replayed results mismatch (-want +got):\n%s", diff)
+	}
+	if unserved := rep.Unserved(); len(unserved) != 0 {
+		t.Errorf("Unserved() = %v, want none", unserved)
+	}
+
+	_, err = rep.Run(ctx, "status", "--porcelain=v2", "-z")
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("Run() beyond the recording error = %v, want no recorded interaction", err)
+	}
+}
+
+func TestReplayer_ExitError(t *testing.T) {
+	rep := NewReplayer([]Interaction{
+		{Args: []string{"merge-tree"}, Stdout: Output("tree\x00"), ExitCode: 1},
+		{Args: []string{"log"}, Stdout: Output("a\n")},
+	})
+	if got := rep.Unserved(); len(got) != 2 {
+		t.Errorf("Unserved() = %d interactions, want 2", len(got))
+	}
+	out, err := rep.Run(context.Background(), "merge-tree")
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode != 1 || string(out) != "tree\x00" {
+		t.Errorf("Run() = %q, %v, want output with exit status 1", out, err)
+	}
+	if got := rep.Unserved(); len(got) != 1 || got[0].Args[0] != "log" {
+		t.Errorf("Unserved() = %v, want the log interaction", got)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := rep.Run(canceled, "log"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() with canceled context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestLoadReplayer_Errors(t *testing.T) {
+	if _, err := LoadReplayer(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadReplayer() of a missing file error = %v, want %v", err, os.ErrNotExist)
+	}
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(path, []byte(`[{"Stdout": 42}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadReplayer(path); err == nil {
+		t.Error("LoadReplayer() of invalid JSON error = nil, want error")
+	}
+}
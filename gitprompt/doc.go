@@ -0,0 +1,43 @@
+/*
+Package gitprompt gathers everything a shell prompt typically shows about a
+git repository into a single [PromptInfo]: the branch, ahead/behind counts,
+any operation in progress, counts of changed files, and stash entries.
+
+# Basic Usage
+
+[Get] runs git in the repository, without taking optional locks, and
+combines the results of [statusv2.Get] and [gitstate.Get]:
+
+	git := gitexec.New(".")
+	info, err := gitprompt.Get(ctx, git)
+	if errors.Is(err, gitprompt.ErrNotRepository) {
+	    return // not in a repository, so nothing to show
+	}
+	if err != nil {
+	    log.Fatal(err)
+	}
+	fmt.Printf("%s ↑%d ↓%d\n", info.Branch, info.Ahead, info.Behind)
+
+Additional arguments for `git status` can be passed to Get, such as
+"--untracked-files=no" to skip the slowest part of it on large repositories.
+
+# Time Budget
+
+On large repositories or cold caches, `git status` can take long enough to
+noticeably delay the shell. [GetWithTimeout] instead returns within a time
+budget, with whatever was gathered by then. The branch and any operation in
+progress are found quickly, and the tracked changes are counted separately from
+the untracked files, so that a slow scan for untracked files does not hold back
+the rest. Fields that were not gathered in time are flagged in Incomplete:
+
+	info, err := gitprompt.GetWithTimeout(ctx, git, 100*time.Millisecond)
+	if err != nil {
+	    return
+	}
+	if info.Incomplete&gitprompt.FieldsUntracked != 0 {
+	    fmt.Print("?") // untracked files not counted yet
+	}
+
+[New] builds a PromptInfo from an already gathered status and state.
+*/
+package gitprompt
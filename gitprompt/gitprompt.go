@@ -0,0 +1,124 @@
+package gitprompt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/gitstate"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// ErrNotRepository is returned by [Get] when git is not run in a repository.
+var ErrNotRepository = errors.New("gitprompt: not a git repository")
+
+// PromptInfo is the information shown by a typical git prompt.
+type PromptInfo struct {
+	Branch   string // current branch, or "" when detached
+	Detached bool   // HEAD is detached
+	Commit   string // HEAD commit, or "" before the first commit
+	Upstream string // upstream branch, or "" if none
+	Ahead    int    // commits ahead of upstream
+	Behind   int    // commits behind upstream
+
+	Operation   gitstate.Operation // operation in progress, such as a rebase
+	Step, Total int                // progress of a rebase or am, if known
+
+	Staged    int // files with changes in the index
+	Unstaged  int // files with changes in the worktree
+	Untracked int // untracked files
+	Conflicts int // unmerged files
+	Stash     int // stash entries
+
+	// Incomplete flags the fields that could not be gathered in time by
+	// GetWithTimeout, which are left zero. It is zero when all are complete.
+	Incomplete Fields
+}
+
+// Dirty reports whether there are any staged, unstaged, untracked or
+// conflicted files.
+func (p *PromptInfo) Dirty() bool {
+	return p.Staged+p.Unstaged+p.Untracked+p.Conflicts > 0
+}
+
+// Get gathers the prompt information for the repository git runs in, running
+// `git status` with any additional args.
+//
+// A [*gitexec.Git] runs without taking optional locks, unless configured
+// otherwise, so that prompts do not interfere with other git commands.
+func Get(ctx context.Context, git gitexec.Runner, args ...string) (*PromptInfo, error) {
+	status, err := statusv2.Get(ctx, git, append([]string{"--branch", "--show-stash"}, args...)...)
+	if err != nil {
+		return nil, notRepository(err)
+	}
+	state, err := gitstate.Get(ctx, git)
+	if err != nil {
+		return nil, notRepository(err)
+	}
+	return New(status, state), nil
+}
+
+// notRepository returns ErrNotRepository if err is git failing outside of a
+// repository, or err otherwise.
+func notRepository(err error) error {
+	var exitErr *gitexec.ExitError
+	if errors.As(err, &exitErr) && bytes.Contains(exitErr.Stderr, []byte("not a git repository")) {
+		return ErrNotRepository
+	}
+	return err
+}
+
+// New returns the prompt information for a status, gathered with --branch and
+// --show-stash, and state.
+func New(s *statusv2.Status, state gitstate.State) *PromptInfo {
+	p := &PromptInfo{Incomplete: allFields}
+	p.setState(state)
+	p.setStatus(s, allFields)
+	return p
+}
+
+func (p *PromptInfo) setState(state gitstate.State) {
+	if p.Incomplete&FieldsOperation == 0 {
+		return
+	}
+	p.Operation, p.Step, p.Total = state.Operation, state.Step, state.Total
+	p.Incomplete &^= FieldsOperation
+}
+
+// setStatus sets the incomplete fields among the given groups from a status,
+// which must have been gathered with the options providing them.
+func (p *PromptInfo) setStatus(s *statusv2.Status, fields Fields) {
+	fields &= p.Incomplete
+	if b := s.Branch; b != nil {
+		if fields&FieldsBranch != 0 {
+			p.Detached = b.IsDetached()
+			p.Branch = ""
+			if !p.Detached {
+				p.Branch = b.Head
+			}
+		}
+		if fields&FieldsCommit != 0 {
+			commit, _ := b.Commit()
+			p.Commit = string(commit)
+		}
+		if fields&FieldsUpstream != 0 {
+			p.Upstream, p.Ahead, p.Behind = b.Upstream, b.Ahead, b.Behind
+		}
+	}
+	if fields&FieldsStash != 0 {
+		p.Stash = 0
+		if s.Stash != nil {
+			p.Stash = s.Stash.Count
+		}
+	}
+
+	sum := s.Summary()
+	if fields&FieldsChanges != 0 {
+		p.Staged, p.Unstaged, p.Conflicts = sum.Staged, sum.Unstaged, sum.Conflicted
+	}
+	if fields&FieldsUntracked != 0 {
+		p.Untracked = sum.Untracked
+	}
+	p.Incomplete &^= fields
+}
@@ -0,0 +1,143 @@
+package gitprompt
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/gitstate"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+func TestNew(t *testing.T) {
+	const hash = "ce013625030ba8dba906f756967f9e9ca394464a"
+	tests := []struct {
+		name   string
+		status *statusv2.Status
+		state  gitstate.State
+		want   *PromptInfo
+	}{
+		{
+			name:   "empty",
+			status: &statusv2.Status{},
+			want:   &PromptInfo{},
+		},
+		{
+			name:   "initial",
+			status: &statusv2.Status{Branch: &statusv2.BranchInfo{OID: "(initial)", Head: "main"}},
+			want:   &PromptInfo{Branch: "main"},
+		},
+		{
+			name: "branch with upstream and stash",
+			status: &statusv2.Status{
+				Branch: &statusv2.BranchInfo{OID: hash, Head: "main", Upstream: "origin/main", Ahead: 1, Behind: 2},
+				Stash:  &statusv2.StashInfo{Count: 3},
+			},
+			want: &PromptInfo{Branch: "main", Commit: hash, Upstream: "origin/main", Ahead: 1, Behind: 2, Stash: 3},
+		},
+		{
+			name:   "detached during rebase",
+			status: &statusv2.Status{Branch: &statusv2.BranchInfo{OID: hash, Head: "(detached)"}},
+			state:  gitstate.State{Operation: gitstate.RebaseInteractive, Step: 2, Total: 5},
+			want:   &PromptInfo{Detached: true, Commit: hash, Operation: gitstate.RebaseInteractive, Step: 2, Total: 5},
+		},
+		{
+			name: "entries",
+			status: &statusv2.Status{Entries: []statusv2.Entry{
+				statusv2.ChangedEntry{XY: statusv2.XYFlag{X: 'M', Y: 'M'}, Path: "a"},
+				statusv2.ChangedEntry{XY: statusv2.XYFlag{X: '.', Y: 'M'}, Path: "b"},
+				statusv2.RenameOrCopyEntry{XY: statusv2.XYFlag{X: 'R', Y: '.'}, Path: "c", Orig: "d"},
+				statusv2.UnmergedEntry{XY: statusv2.XYFlag{X: 'U', Y: 'U'}, Path: "e"},
+				statusv2.UntrackedEntry{Path: "f"},
+				statusv2.UntrackedEntry{Path: "g"},
+				statusv2.IgnoredEntry{Path: "h"},
+			}},
+			want: &PromptInfo{Staged: 2, Unstaged: 2, Untracked: 2, Conflicts: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.status, tt.state)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("New() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPromptInfo_Dirty(t *testing.T) {
+	if (&PromptInfo{Stash: 1, Ahead: 1}).Dirty() {
+		t.Error("Dirty() = true for a clean worktree")
+	}
+	if !(&PromptInfo{Untracked: 1}).Dirty() {
+		t.Error("Dirty() = false with untracked files")
+	}
+}
+
+func TestGet(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+	dir := t.TempDir()
+	git := gitexec.New(dir)
+	ctx := context.Background()
+	if _, err := git.Run(ctx, "init", "-q", "-b", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".git", "MERGE_HEAD"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := Get(ctx, git)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	want := &PromptInfo{Branch: "main", Untracked: 1, Operation: gitstate.Merge}
+	if diff := cmp.Diff(want, info); diff != "" {
+		t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	info, err = Get(ctx, git, "--untracked-files=no")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if info.Untracked != 0 {
+		t.Errorf("Get() with --untracked-files=no counted %d untracked files", info.Untracked)
+	}
+}
+
+func TestGet_NotRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+	git := gitexec.New(t.TempDir())
+	git.Env = []string{"GIT_CEILING_DIRECTORIES=" + filepath.Dir(git.Dir)}
+	_, err := Get(context.Background(), git)
+	if !errors.Is(err, ErrNotRepository) {
+		t.Errorf("Get() error = %v, want ErrNotRepository", err)
+	}
+}
+
+type fakeRunner struct {
+	err error
+}
+
+func (f *fakeRunner) Run(ctx context.Context, args ...string) ([]byte, error) {
+	return nil, f.err
+}
+
+func TestGet_Error(t *testing.T) {
+	want := &gitexec.ExitError{Args: []string{"status"}, ExitCode: 128, Stderr: []byte("fatal: bad config")}
+	_, err := Get(context.Background(), &fakeRunner{err: want})
+	if err != want {
+		t.Errorf("Get() error = %v, want %v", err, want)
+	}
+}
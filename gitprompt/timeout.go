@@ -0,0 +1,117 @@
+package gitprompt
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/gitstate"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// Fields is a set of groups of [PromptInfo] fields.
+type Fields uint
+
+const (
+	FieldsBranch    Fields = 1 << iota // Branch and Detached
+	FieldsCommit                       // Commit
+	FieldsUpstream                     // Upstream, Ahead and Behind
+	FieldsOperation                    // Operation, Step and Total
+	FieldsChanges                      // Staged, Unstaged and Conflicts
+	FieldsUntracked                    // Untracked
+	FieldsStash                        // Stash
+
+	allFields = FieldsBranch | FieldsCommit | FieldsUpstream | FieldsOperation |
+		FieldsChanges | FieldsUntracked | FieldsStash
+)
+
+// GetWithTimeout is like [Get], but returns within budget with whatever
+// information was gathered by then, so that a prompt never blocks the shell.
+// Fields that could not be gathered in time are left zero and flagged in
+// Incomplete.
+//
+// The cheaper parts of the information are gathered concurrently with the full
+// `git status`, so that, for example, the branch is known even when counting
+// untracked files takes too long on a cold cache. Git commands still running
+// when the budget expires are killed.
+func GetWithTimeout(ctx context.Context, git gitexec.Runner, budget time.Duration, args ...string) (*PromptInfo, error) {
+	budgetCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	type result struct {
+		set func(p *PromptInfo)
+		err error
+	}
+	tasks := []func() result{
+		func() result {
+			branch, detached, err := headBranch(budgetCtx, git)
+			return result{func(p *PromptInfo) {
+				if p.Incomplete&FieldsBranch != 0 {
+					p.Branch, p.Detached = branch, detached
+					p.Incomplete &^= FieldsBranch
+				}
+			}, err}
+		},
+		func() result {
+			state, err := gitstate.Get(budgetCtx, git)
+			return result{func(p *PromptInfo) { p.setState(state) }, err}
+		},
+		func() result {
+			status, err := statusv2.Get(budgetCtx, git, "--branch", "--show-stash", "--untracked-files=no")
+			return result{func(p *PromptInfo) {
+				p.setStatus(status, allFields&^(FieldsOperation|FieldsUntracked))
+			}, err}
+		},
+		func() result {
+			status, err := statusv2.Get(budgetCtx, git, append([]string{"--branch", "--show-stash"}, args...)...)
+			return result{func(p *PromptInfo) { p.setStatus(status, allFields&^FieldsOperation) }, err}
+		},
+	}
+	results := make(chan result, len(tasks))
+	for _, task := range tasks {
+		go func() { results <- task() }()
+	}
+
+	p := &PromptInfo{Incomplete: allFields}
+	for range tasks {
+		var r result
+		select {
+		case r = <-results:
+		case <-budgetCtx.Done():
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return p, nil
+		}
+		if r.err != nil {
+			if budgetCtx.Err() != nil {
+				continue // killed when the budget expired
+			}
+			return nil, notRepository(r.err)
+		}
+		r.set(p)
+		if p.Incomplete == 0 {
+			break
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// headBranch returns the current branch, which is known even before the first
+// commit, or reports that HEAD is detached.
+func headBranch(ctx context.Context, git gitexec.Runner) (branch string, detached bool, err error) {
+	out, err := git.Run(ctx, "symbolic-ref", "--quiet", "--short", "HEAD")
+	var exitErr *gitexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode == 1 {
+		return "", true, nil // not a symbolic ref
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimSpace(string(out)), false, nil
+}
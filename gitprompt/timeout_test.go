@@ -0,0 +1,124 @@
+package gitprompt
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// scriptRunner responds to git commands by the first of their args that
+// identifies them, blocking until the context is done for those that are slow.
+type scriptRunner struct {
+	gitDir string
+	out    map[string]string
+	errs   map[string]error
+	slow   map[string]bool
+}
+
+func (r *scriptRunner) Run(ctx context.Context, args ...string) ([]byte, error) {
+	key := args[0]
+	if key == "status" && !strings.Contains(strings.Join(args, " "), "--untracked-files=no") {
+		key = "status-full"
+	}
+	if r.slow[key] {
+		<-ctx.Done()
+		return nil, &gitexec.ExitError{Args: args, ExitCode: -1}
+	}
+	if key == "rev-parse" {
+		return []byte(r.gitDir + "\n"), nil
+	}
+	return []byte(r.out[key]), r.errs[key]
+}
+
+func TestGetWithTimeout(t *testing.T) {
+	const hash = "ce013625030ba8dba906f756967f9e9ca394464a"
+	tracked := "# branch.oid " + hash + "\x00# branch.head main\x00# stash 2\x00" +
+		"1 .M N... 100644 100644 100644 " + hash + " " + hash + " a.txt\x00"
+	full := tracked + "? b.txt\x00"
+
+	tests := []struct {
+		name string
+		slow []string
+		want *PromptInfo
+	}{
+		{
+			name: "complete",
+			want: &PromptInfo{Branch: "main", Commit: hash, Unstaged: 1, Untracked: 1, Stash: 2},
+		},
+		{
+			name: "untracked too slow",
+			slow: []string{"status-full"},
+			want: &PromptInfo{Branch: "main", Commit: hash, Unstaged: 1, Stash: 2, Incomplete: FieldsUntracked},
+		},
+		{
+			name: "only branch in time",
+			slow: []string{"status-full", "status", "rev-parse"},
+			want: &PromptInfo{Branch: "main", Incomplete: allFields &^ FieldsBranch},
+		},
+		{
+			name: "nothing in time",
+			slow: []string{"status-full", "status", "rev-parse", "symbolic-ref"},
+			want: &PromptInfo{Incomplete: allFields},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			git := &scriptRunner{
+				gitDir: t.TempDir(),
+				out:    map[string]string{"status-full": full, "status": tracked, "symbolic-ref": "main\n"},
+				slow:   map[string]bool{},
+			}
+			for _, s := range tt.slow {
+				git.slow[s] = true
+			}
+			got, err := GetWithTimeout(context.Background(), git, 50*time.Millisecond)
+			if err != nil {
+				t.Fatalf("GetWithTimeout() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetWithTimeout() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetWithTimeout_Detached(t *testing.T) {
+	git := &scriptRunner{
+		gitDir: t.TempDir(),
+		errs:   map[string]error{"symbolic-ref": &gitexec.ExitError{Args: []string{"symbolic-ref"}, ExitCode: 1}},
+		slow:   map[string]bool{"status": true, "status-full": true},
+	}
+	got, err := GetWithTimeout(context.Background(), git, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("GetWithTimeout() error = %v", err)
+	}
+	if !got.Detached || got.Incomplete&FieldsBranch != 0 {
+		t.Errorf("GetWithTimeout() = %+v, want detached with complete branch", got)
+	}
+}
+
+func TestGetWithTimeout_NotRepository(t *testing.T) {
+	notRepo := &gitexec.ExitError{ExitCode: 128, Stderr: []byte("fatal: not a git repository (or any of the parent directories): .git")}
+	git := &scriptRunner{
+		errs: map[string]error{"status": notRepo, "status-full": notRepo, "symbolic-ref": notRepo},
+		slow: map[string]bool{"rev-parse": true},
+	}
+	_, err := GetWithTimeout(context.Background(), git, time.Second)
+	if !errors.Is(err, ErrNotRepository) {
+		t.Errorf("GetWithTimeout() error = %v, want ErrNotRepository", err)
+	}
+}
+
+func TestGetWithTimeout_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	git := &scriptRunner{slow: map[string]bool{"status": true, "status-full": true, "rev-parse": true, "symbolic-ref": true}}
+	if _, err := GetWithTimeout(ctx, git, time.Second); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetWithTimeout() error = %v, want context.Canceled", err)
+	}
+}
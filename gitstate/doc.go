@@ -0,0 +1,22 @@
+/*
+Package gitstate detects operations in progress in a git repository, such as
+a merge or an interactive rebase, which `git status --porcelain` does not
+report.
+
+# Basic Usage
+
+[Read] inspects the state files in a git directory, as found with
+`git rev-parse --absolute-git-dir`. [Get] finds the git directory itself:
+
+	st, err := gitstate.Get(ctx, gitexec.New("/path/to/repo"))
+	if err != nil {
+	    log.Fatal(err)
+	}
+	if st.Operation != gitstate.None {
+	    fmt.Printf("%s in progress (%d/%d)\n", st.Operation, st.Step, st.Total)
+	}
+
+Detection follows the rules of git's own prompt script, contrib/completion/git-prompt.sh,
+so that prompts built on this package agree with it.
+*/
+package gitstate
@@ -0,0 +1,157 @@
+package gitstate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// Operation is a multi-step git operation that can be in progress.
+type Operation int
+
+const (
+	None              Operation = iota // no operation in progress
+	Merge                              // git merge stopped, e.g. for conflicts
+	Rebase                             // non-interactive git rebase
+	RebaseInteractive                  // git rebase -i
+	AM                                 // git am
+	AMOrRebase                         // git am or an old-style git rebase, which can't be told apart
+	CherryPick                         // git cherry-pick
+	Revert                             // git revert
+	Bisect                             // git bisect
+)
+
+var operationNames = [...]string{
+	None:              "",
+	Merge:             "MERGING",
+	Rebase:            "REBASE",
+	RebaseInteractive: "REBASE-i",
+	AM:                "AM",
+	AMOrRebase:        "AM/REBASE",
+	CherryPick:        "CHERRY-PICKING",
+	Revert:            "REVERTING",
+	Bisect:            "BISECTING",
+}
+
+// String returns the name of the operation as shown by git's prompt script,
+// e.g. "MERGING" or "REBASE-i", or "" for None.
+func (o Operation) String() string {
+	if o < 0 || int(o) >= len(operationNames) {
+		return "Operation(" + strconv.Itoa(int(o)) + ")"
+	}
+	return operationNames[o]
+}
+
+// State is the state of a repository.
+type State struct {
+	Operation Operation
+
+	// Step and Total are the progress of a rebase or am, i.e. the number of
+	// the current patch and the number of patches, or zero if unknown or not
+	// applicable.
+	Step, Total int
+
+	// HeadName is the branch being rebased, e.g. "refs/heads/feature", or ""
+	// if not rebasing or the rebase started from a detached HEAD.
+	HeadName string
+}
+
+// Read returns the state of the repository with the given git directory.
+func Read(gitDir string) (State, error) {
+	var st State
+	switch {
+	case isDir(filepath.Join(gitDir, "rebase-merge")):
+		dir := filepath.Join(gitDir, "rebase-merge")
+		st.Operation = Rebase
+		if exists(filepath.Join(dir, "interactive")) {
+			st.Operation = RebaseInteractive
+		}
+		st.Step = readInt(filepath.Join(dir, "msgnum"))
+		st.Total = readInt(filepath.Join(dir, "end"))
+		st.HeadName = readHeadName(filepath.Join(dir, "head-name"))
+	case isDir(filepath.Join(gitDir, "rebase-apply")):
+		dir := filepath.Join(gitDir, "rebase-apply")
+		switch {
+		case exists(filepath.Join(dir, "rebasing")):
+			st.Operation = Rebase
+			st.HeadName = readHeadName(filepath.Join(dir, "head-name"))
+		case exists(filepath.Join(dir, "applying")):
+			st.Operation = AM
+		default:
+			st.Operation = AMOrRebase
+		}
+		st.Step = readInt(filepath.Join(dir, "next"))
+		st.Total = readInt(filepath.Join(dir, "last"))
+	case exists(filepath.Join(gitDir, "MERGE_HEAD")):
+		st.Operation = Merge
+	case exists(filepath.Join(gitDir, "CHERRY_PICK_HEAD")):
+		st.Operation = CherryPick
+	case exists(filepath.Join(gitDir, "REVERT_HEAD")):
+		st.Operation = Revert
+	case exists(filepath.Join(gitDir, "BISECT_LOG")):
+		st.Operation = Bisect
+	}
+
+	// distinguish a missing git directory from one with no operation
+	if _, err := os.Stat(gitDir); err != nil {
+		return State{}, err
+	}
+	return st, nil
+}
+
+// Get finds the git directory of the repository git runs in, and returns its
+// state.
+func Get(ctx context.Context, git gitexec.Runner) (State, error) {
+	out, err := git.Run(ctx, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return State{}, err
+	}
+	gitDir := string(bytes.TrimSpace(out))
+	if gitDir == "" {
+		return State{}, errors.New("gitstate: empty git directory")
+	}
+	return Read(gitDir)
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+func isDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+// readInt returns the integer in the file at path, or zero if there is none.
+func readInt(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// readHeadName returns the ref in a head-name file, or "" if there is none or
+// the rebase started from a detached HEAD.
+func readHeadName(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	name := strings.TrimSpace(string(data))
+	if name == "detached HEAD" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,158 @@
+package gitstate
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string // path in the git directory to contents; "/" suffix for directories
+		want  State
+	}{
+		{"none", nil, State{}},
+		{"merge", map[string]string{"MERGE_HEAD": "abc\n"}, State{Operation: Merge}},
+		{"cherry-pick", map[string]string{"CHERRY_PICK_HEAD": "abc\n"}, State{Operation: CherryPick}},
+		{"revert", map[string]string{"REVERT_HEAD": "abc\n"}, State{Operation: Revert}},
+		{"bisect", map[string]string{"BISECT_LOG": "# bad\n"}, State{Operation: Bisect}},
+		{
+			"rebase interactive",
+			map[string]string{
+				"rebase-merge/interactive": "",
+				"rebase-merge/msgnum":      "2\n",
+				"rebase-merge/end":         "5\n",
+				"rebase-merge/head-name":   "refs/heads/feature\n",
+			},
+			State{Operation: RebaseInteractive, Step: 2, Total: 5, HeadName: "refs/heads/feature"},
+		},
+		{
+			"rebase merge backend",
+			map[string]string{
+				"rebase-merge/msgnum":    "1\n",
+				"rebase-merge/end":       "3\n",
+				"rebase-merge/head-name": "detached HEAD\n",
+			},
+			State{Operation: Rebase, Step: 1, Total: 3},
+		},
+		{
+			"rebase apply backend",
+			map[string]string{
+				"rebase-apply/rebasing":  "",
+				"rebase-apply/next":      "4\n",
+				"rebase-apply/last":      "4\n",
+				"rebase-apply/head-name": "refs/heads/main\n",
+			},
+			State{Operation: Rebase, Step: 4, Total: 4, HeadName: "refs/heads/main"},
+		},
+		{
+			"am",
+			map[string]string{"rebase-apply/applying": "", "rebase-apply/next": "1\n", "rebase-apply/last": "2\n"},
+			State{Operation: AM, Step: 1, Total: 2},
+		},
+		{"am or rebase", map[string]string{"rebase-apply/": ""}, State{Operation: AMOrRebase}},
+		{
+			"rebase takes precedence over merge",
+			map[string]string{"rebase-merge/": "", "MERGE_HEAD": "abc\n"},
+			State{Operation: Rebase},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitDir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(gitDir, name)
+				if name[len(name)-1] == '/' {
+					if err := os.MkdirAll(path, 0o755); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := Read(gitDir)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Read() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRead_Missing(t *testing.T) {
+	if _, err := Read(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Read() of a missing directory succeeded")
+	}
+}
+
+func TestOperation_String(t *testing.T) {
+	if got := RebaseInteractive.String(); got != "REBASE-i" {
+		t.Errorf("String() = %q, want %q", got, "REBASE-i")
+	}
+	if got := None.String(); got != "" {
+		t.Errorf("String() = %q, want empty", got)
+	}
+	if got := Operation(99).String(); got != "Operation(99)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+type fakeRunner struct {
+	out  string
+	err  error
+	args []string
+}
+
+func (f *fakeRunner) Run(ctx context.Context, args ...string) ([]byte, error) {
+	f.args = args
+	return []byte(f.out), f.err
+}
+
+func TestGet(t *testing.T) {
+	gitDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(gitDir, "MERGE_HEAD"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git := &fakeRunner{out: gitDir + "\n"}
+	st, err := Get(context.Background(), git)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if st.Operation != Merge {
+		t.Errorf("Get() = %+v, want merge", st)
+	}
+	if want := []string{"rev-parse", "--absolute-git-dir"}; !slices.Equal(git.args, want) {
+		t.Errorf("git args = %q, want %q", git.args, want)
+	}
+}
+
+func TestGet_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+	dir := t.TempDir()
+	git := gitexec.New(dir)
+	if _, err := git.Run(context.Background(), "init", "-q"); err != nil {
+		t.Fatal(err)
+	}
+	st, err := Get(context.Background(), git)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if st != (State{}) {
+		t.Errorf("Get() = %+v, want no operation", st)
+	}
+}
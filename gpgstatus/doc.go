@@ -0,0 +1,41 @@
+/*
+Package gpgstatus parses the GnuPG status lines printed by
+`git verify-commit --raw` and `git verify-tag --raw`.
+
+With --raw, git prints the machine-readable status output of gpg
+(--status-fd) instead of its human-readable messages. Each status line starts
+with "[GNUPG:]" followed by a keyword, such as GOODSIG or TRUST_FULLY, and
+its arguments. This package interprets those lines the way git itself does,
+producing a [Verification] equivalent to the %G? family of pretty format
+placeholders.
+
+# Basic Usage
+
+[Parse] takes an [io.Reader] containing the status output, which git writes
+to stderr:
+
+	// git verify-commit --raw HEAD 2>&1
+	r := bytes.NewReader(gitVerifyOutput)
+	v, err := gpgstatus.Parse(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	if v.Status == gpgstatus.Good && v.Trust >= gpgstatus.TrustFully {
+	    fmt.Printf("signed by %s (%s)\n", v.Signer, v.Fingerprint)
+	}
+
+Lines other than status lines, such as "gpg:" messages, are ignored, so
+stdout and stderr may be passed combined. Git exits with a non-zero status
+whenever the signature is not good, including when the object is unsigned
+(in which case it prints nothing).
+
+Only OpenPGP signatures are supported. Objects signed using SSH or X.509
+keys produce different output.
+
+For more information, see the Git documentation for [git verify-commit], and
+the GnuPG documentation of [status lines].
+
+[git verify-commit]: https://git-scm.com/docs/git-verify-commit
+[status lines]: https://github.com/gpg/gnupg/blob/master/doc/DETAILS#format-of-the-status-fd-output
+*/
+package gpgstatus
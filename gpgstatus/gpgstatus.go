@@ -0,0 +1,91 @@
+package gpgstatus
+
+import "time"
+
+// Verification represents the result of verifying a signature.
+type Verification struct {
+	Status                Status    // verification status
+	KeyID                 string    // long ID of the signing key
+	Signer                string    // user ID of the signing key, e.g. "Name <email>"
+	Fingerprint           string    // fingerprint of the signing key
+	PrimaryKeyFingerprint string    // fingerprint of the primary key, if the signing key is a subkey
+	Created               time.Time // signature creation time
+	Expires               time.Time // signature expiration time; zero if it does not expire
+	Trust                 Trust     // trust level of the signing key
+}
+
+// Code returns the single letter git uses to describe the verification with
+// the %G? pretty format placeholder: the Status, or 'U' for good signatures
+// from keys of undefined or no trust.
+func (v Verification) Code() byte {
+	if v.Status == Good && v.Trust < TrustMarginal {
+		return 'U'
+	}
+	return byte(v.Status)
+}
+
+// Status is the status of a signature verification. Values are the letters
+// used by git's %G? pretty format placeholder.
+type Status byte
+
+// Verification statuses.
+const (
+	None       Status = 'N' // no signature
+	Good       Status = 'G' // good signature
+	Bad        Status = 'B' // bad signature
+	ExpiredSig Status = 'X' // good signature that has expired
+	ExpiredKey Status = 'Y' // good signature made by an expired key
+	RevokedKey Status = 'R' // good signature made by a revoked key
+	Error      Status = 'E' // signature cannot be checked, e.g. missing key or multiple signatures
+)
+
+// String returns a human readable description of the status.
+func (s Status) String() string {
+	switch s {
+	case None:
+		return "no signature"
+	case Good:
+		return "good signature"
+	case Bad:
+		return "bad signature"
+	case ExpiredSig:
+		return "expired signature"
+	case ExpiredKey:
+		return "signature by expired key"
+	case RevokedKey:
+		return "signature by revoked key"
+	case Error:
+		return "signature cannot be checked"
+	default:
+		return "unknown"
+	}
+}
+
+// Trust is the trust level of a signing key, as reported by gpg.
+type Trust int
+
+// Trust levels, in increasing order.
+const (
+	TrustUndefined Trust = iota
+	TrustNever
+	TrustMarginal
+	TrustFully
+	TrustUltimate
+)
+
+var trustNames = [...]string{
+	TrustUndefined: "undefined",
+	TrustNever:     "never",
+	TrustMarginal:  "marginal",
+	TrustFully:     "fully",
+	TrustUltimate:  "ultimate",
+}
+
+// String returns the name of the trust level, as printed by git's %GT pretty
+// format placeholder, e.g. "fully".
+func (t Trust) String() string {
+	if t < 0 || int(t) >= len(trustNames) {
+		return "unknown"
+	}
+	return trustNames[t]
+}
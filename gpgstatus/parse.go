@@ -0,0 +1,128 @@
+package gpgstatus
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const statusPrefix = "[GNUPG:] "
+
+// exclusive maps the status keywords describing the overall result of a
+// verification to their status. A signature has exactly one of them.
+var exclusive = map[string]Status{
+	"GOODSIG":   Good,
+	"BADSIG":    Bad,
+	"ERRSIG":    Error,
+	"EXPSIG":    ExpiredSig,
+	"EXPKEYSIG": ExpiredKey,
+	"REVKEYSIG": RevokedKey,
+}
+
+var trustLevels = map[string]Trust{
+	"TRUST_UNDEFINED": TrustUndefined,
+	"TRUST_NEVER":     TrustNever,
+	"TRUST_MARGINAL":  TrustMarginal,
+	"TRUST_FULLY":     TrustFully,
+	"TRUST_ULTIMATE":  TrustUltimate,
+}
+
+// Parse parses the GnuPG status output of `git verify-commit --raw` or
+// `git verify-tag --raw`.
+//
+// As git does, Parse reports an [Error] status for objects with more than one
+// signature, and a [None] status when there are no status lines.
+func Parse(r io.Reader) (Verification, error) {
+	v := Verification{Status: None}
+	seenExclusive := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, ok := strings.CutPrefix(scanner.Text(), statusPrefix)
+		if !ok {
+			continue
+		}
+		keyword, args, _ := strings.Cut(line, " ")
+
+		if status, ok := exclusive[keyword]; ok {
+			if seenExclusive {
+				// multiple signatures are not supported
+				return Verification{Status: Error}, nil
+			}
+			seenExclusive = true
+			v.Status = status
+			parseSignature(&v, status, args)
+			continue
+		}
+		if trust, ok := trustLevels[keyword]; ok {
+			v.Trust = trust
+			continue
+		}
+		if keyword == "VALIDSIG" {
+			parseValidSig(&v, args)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Verification{}, err
+	}
+	return v, nil
+}
+
+// parseSignature parses the arguments of an exclusive status line, which are
+// "<keyid> <user id>", except for ERRSIG:
+// <keyid> <pkalgo> <hashalgo> <sig class> <time> <rc> [<fingerprint>]
+func parseSignature(v *Verification, status Status, args string) {
+	if status != Error {
+		v.KeyID, v.Signer, _ = strings.Cut(args, " ")
+		return
+	}
+	fields := strings.Fields(args)
+	if len(fields) > 0 {
+		v.KeyID = fields[0]
+	}
+	if len(fields) > 4 {
+		v.Created = parseTime(fields[4])
+	}
+	if len(fields) > 6 {
+		v.Fingerprint = fields[6]
+	}
+}
+
+// VALIDSIG lines have the following arguments:
+// <fingerprint> <creation date> <creation time> <expire time> <version>
+// <reserved> <pkalgo> <hashalgo> <sig class> [<primary key fingerprint>]
+func parseValidSig(v *Verification, args string) {
+	fields := strings.Fields(args)
+	if len(fields) > 0 {
+		v.Fingerprint = fields[0]
+	}
+	if len(fields) > 2 {
+		v.Created = parseTime(fields[2])
+	}
+	if len(fields) > 3 {
+		v.Expires = parseTime(fields[3])
+	}
+	if len(fields) > 9 {
+		v.PrimaryKeyFingerprint = fields[9]
+	}
+}
+
+// parseTime parses a gpg timestamp, which is either seconds since the epoch
+// or an ISO 8601 time such as "20250102T030405". Zero (meaning "none") and
+// invalid timestamps result in the zero time.
+func parseTime(s string) time.Time {
+	if strings.Contains(s, "T") {
+		t, err := time.Parse("20060102T150405", s)
+		if err != nil {
+			return time.Time{}
+		}
+		return t
+	}
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC()
+}
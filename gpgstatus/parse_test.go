@@ -0,0 +1,136 @@
+package gpgstatus
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const fpr = "E587D5AEDAF9D5D955153A9E5AAA9AB9291E30C5"
+
+func TestParse(t *testing.T) {
+	created := time.Unix(1792263901, 0).UTC()
+	tests := []struct {
+		name     string
+		input    string
+		want     Verification
+		wantCode byte
+	}{
+		{
+			name: "good",
+			input: "[GNUPG:] NEWSIG\n" +
+				"[GNUPG:] KEY_CONSIDERED E587D5AEDAF9D5D955153A9E5AAA9AB9291E30C5 0\n" +
+				"[GNUPG:] SIG_ID Zicx/1iJbTuOreCs0PusksiDF8M 2026-10-17 1792263901\n" +
+				"[GNUPG:] KEY_CONSIDERED E587D5AEDAF9D5D955153A9E5AAA9AB9291E30C5 0\n" +
+				"[GNUPG:] GOODSIG 5AAA9AB9291E30C5 Test Signer <signer@example.com>\n" +
+				"[GNUPG:] VALIDSIG E587D5AEDAF9D5D955153A9E5AAA9AB9291E30C5 2026-10-17 1792263901 0 4 0 22 8 00 E587D5AEDAF9D5D955153A9E5AAA9AB9291E30C5\n" +
+				"[GNUPG:] KEY_CONSIDERED E587D5AEDAF9D5D955153A9E5AAA9AB9291E30C5 0\n" +
+				"[GNUPG:] TRUST_ULTIMATE 0 pgp\n",
+			want: Verification{
+				Status:                Good,
+				KeyID:                 "5AAA9AB9291E30C5",
+				Signer:                "Test Signer <signer@example.com>",
+				Fingerprint:           fpr,
+				PrimaryKeyFingerprint: fpr,
+				Created:               created,
+				Trust:                 TrustUltimate,
+			},
+			wantCode: 'G',
+		},
+		{
+			name: "good with undefined trust and expiry",
+			input: "gpg: Signature made Sat Oct 17 18:25:01 2026 UTC\n" +
+				"[GNUPG:] GOODSIG 5AAA9AB9291E30C5 Test Signer <signer@example.com>\n" +
+				"[GNUPG:] VALIDSIG E587D5AEDAF9D5D955153A9E5AAA9AB9291E30C5 2026-10-17 20261017T182501 20271017T182501 4 0 22 8 00\n" +
+				"[GNUPG:] TRUST_UNDEFINED 0 pgp\n",
+			want: Verification{
+				Status:      Good,
+				KeyID:       "5AAA9AB9291E30C5",
+				Signer:      "Test Signer <signer@example.com>",
+				Fingerprint: fpr,
+				Created:     time.Date(2026, 10, 17, 18, 25, 1, 0, time.UTC),
+				Expires:     time.Date(2027, 10, 17, 18, 25, 1, 0, time.UTC),
+				Trust:       TrustUndefined,
+			},
+			wantCode: 'U',
+		},
+		{
+			name: "bad",
+			input: "[GNUPG:] NEWSIG\n" +
+				"[GNUPG:] KEY_CONSIDERED E587D5AEDAF9D5D955153A9E5AAA9AB9291E30C5 0\n" +
+				"[GNUPG:] BADSIG 5AAA9AB9291E30C5 Test Signer <signer@example.com>\n",
+			want: Verification{
+				Status: Bad,
+				KeyID:  "5AAA9AB9291E30C5",
+				Signer: "Test Signer <signer@example.com>",
+			},
+			wantCode: 'B',
+		},
+		{
+			name: "missing key",
+			input: "[GNUPG:] NEWSIG\n" +
+				"[GNUPG:] ERRSIG 5AAA9AB9291E30C5 22 8 00 1792263901 9 E587D5AEDAF9D5D955153A9E5AAA9AB9291E30C5\n" +
+				"[GNUPG:] NO_PUBKEY 5AAA9AB9291E30C5\n",
+			want: Verification{
+				Status:      Error,
+				KeyID:       "5AAA9AB9291E30C5",
+				Fingerprint: fpr,
+				Created:     created,
+			},
+			wantCode: 'E',
+		},
+		{
+			name:     "expired key",
+			input:    "[GNUPG:] EXPKEYSIG 5AAA9AB9291E30C5 Test Signer <signer@example.com>\n[GNUPG:] TRUST_FULLY 0 pgp\n",
+			want:     Verification{Status: ExpiredKey, KeyID: "5AAA9AB9291E30C5", Signer: "Test Signer <signer@example.com>", Trust: TrustFully},
+			wantCode: 'Y',
+		},
+		{
+			name: "multiple signatures",
+			input: "[GNUPG:] GOODSIG 5AAA9AB9291E30C5 Test Signer <signer@example.com>\n" +
+				"[GNUPG:] GOODSIG 1234567890ABCDEF Other Signer <other@example.com>\n",
+			want:     Verification{Status: Error},
+			wantCode: 'E',
+		},
+		{
+			name:     "unsigned",
+			input:    "",
+			want:     Verification{Status: None},
+			wantCode: 'N',
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+			if code := got.Code(); code != tt.wantCode {
+				t.Errorf("Code() = %q, want %q", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestStatus_String(t *testing.T) {
+	if got, want := RevokedKey.String(), "signature by revoked key"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := Status('?').String(), "unknown"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestTrust_String(t *testing.T) {
+	if got, want := TrustMarginal.String(), "marginal"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := Trust(9).String(), "unknown"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
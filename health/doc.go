@@ -0,0 +1,36 @@
+/*
+Package health scores how far a repository is from a clean, up to date state,
+so that dashboards and prompts can color-code repositories consistently.
+
+# Basic Usage
+
+[Score] weighs the counts of files in each state of a [statusv2.Status], with
+the commits ahead of and behind the upstream and the stash entries, into a
+single number, and classifies the status into a [Level]:
+
+	s, err := statusv2.Get(ctx, git, "--branch", "--show-stash")
+	if err != nil {
+	    log.Fatal(err)
+	}
+	r := health.Score(s, health.DefaultWeights)
+	fmt.Printf("%s (%.1f)\n", r.Level, r.Score) // dirty (4.5)
+
+The level depends only on the status, while the score depends on the
+[Weights], which tools can tune to what matters to them, such as ignoring
+untracked files by giving them no weight.
+
+# Levels
+
+Levels are ordered by severity, so that the worst of many repositories is
+their maximum:
+
+  - [Clean]: no changes, other than ignored files
+  - [Dirty]: changes staged, unstaged or untracked
+  - [Diverged]: the branch and its upstream both have commits the other does
+    not, needing a merge or rebase, whether or not there are changes
+  - [Conflicted]: files unmerged, as during a merge or rebase
+
+Ahead and behind counts need the --branch flag, and stash entries the
+--show-stash flag, without which they are not scored.
+*/
+package health
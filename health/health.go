@@ -0,0 +1,116 @@
+package health
+
+import (
+	"fmt"
+
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// Level classifies a status by the most severe state it is in.
+type Level int
+
+// Levels, in increasing order of severity.
+const (
+	Clean Level = iota
+	Dirty
+	Diverged
+	Conflicted
+)
+
+var levelNames = [...]string{
+	Clean:      "clean",
+	Dirty:      "dirty",
+	Diverged:   "diverged",
+	Conflicted: "conflicted",
+}
+
+// String returns the name of the level, such as "dirty".
+func (l Level) String() string {
+	if l < 0 || int(l) >= len(levelNames) {
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// MarshalText returns the name of the level, as for String.
+func (l Level) MarshalText() ([]byte, error) {
+	if l < 0 || int(l) >= len(levelNames) {
+		return nil, fmt.Errorf("invalid level %d", int(l))
+	}
+	return []byte(levelNames[l]), nil
+}
+
+// UnmarshalText sets the level from its name, as returned by String.
+func (l *Level) UnmarshalText(text []byte) error {
+	for i, name := range levelNames {
+		if string(text) == name {
+			*l = Level(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown level %q", text)
+}
+
+// Weights are the amounts each file, commit or stash entry adds to a score.
+type Weights struct {
+	Staged     float64 // per file with changes in the index
+	Unstaged   float64 // per tracked file with changes in the worktree not staged
+	Untracked  float64 // per untracked file or directory
+	Conflicted float64 // per unmerged file
+	Ahead      float64 // per commit ahead of the upstream
+	Behind     float64 // per commit behind the upstream
+	Stash      float64 // per stash entry
+}
+
+// DefaultWeights count unstaged changes, which are most at risk of being
+// lost, above staged ones, and conflicts far above either.
+var DefaultWeights = Weights{
+	Staged:     1,
+	Unstaged:   2,
+	Untracked:  0.5,
+	Conflicted: 10,
+	Ahead:      0.5,
+	Behind:     1,
+	Stash:      0.25,
+}
+
+// A Report is the health of a status.
+type Report struct {
+	Score float64 // zero for a clean status, up to date with its upstream and without stash entries
+	Level Level
+}
+
+// Score returns the health of s, with its score weighted by w. A nil s is
+// clean.
+func Score(s *statusv2.Status, w Weights) Report {
+	if s == nil {
+		return Report{}
+	}
+	sum := s.Summary()
+	score := w.Staged*float64(sum.Staged) +
+		w.Unstaged*float64(sum.Unstaged) +
+		w.Untracked*float64(sum.Untracked) +
+		w.Conflicted*float64(sum.Conflicted)
+	if s.Branch != nil {
+		score += w.Ahead*float64(s.Branch.Ahead) + w.Behind*float64(s.Branch.Behind)
+	}
+	if s.Stash != nil {
+		score += w.Stash * float64(s.Stash.Count)
+	}
+	return Report{Score: score, Level: LevelOf(s)}
+}
+
+// LevelOf returns the level of s, which does not depend on any weights.
+func LevelOf(s *statusv2.Status) Level {
+	switch {
+	case s == nil:
+		return Clean
+	case len(s.Conflicts()) > 0:
+		return Conflicted
+	case s.Branch != nil && s.Branch.Ahead > 0 && s.Branch.Behind > 0:
+		return Diverged
+	case !s.IsClean():
+		return Dirty
+	}
+	return Clean
+}
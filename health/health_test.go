@@ -0,0 +1,81 @@
+package health
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mroth/porcelain/statusv2"
+)
+
+func parse(t *testing.T, input string) *statusv2.Status {
+	t.Helper()
+	s, err := statusv2.Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+const (
+	header   = "# branch.oid 7cd2d7e2a3400e2463239d071c475c09ab410c2d\n# branch.head main\n# branch.upstream origin/main\n"
+	staged   = "1 M. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 8ab686eafeb1f44702738c8b0f24f2567c36da6d staged.txt\n"
+	both     = "1 MM N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 8ab686eafeb1f44702738c8b0f24f2567c36da6d both.txt\n"
+	conflict = "u UU N... 100644 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 8ab686eafeb1f44702738c8b0f24f2567c36da6d 7cd2d7e2a3400e2463239d071c475c09ab410c2d conflict.txt\n"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Report
+	}{
+		{"empty", "", Report{Score: 0, Level: Clean}},
+		{"ignored only", header + "# branch.ab +0 -0\n! build/\n", Report{Score: 0, Level: Clean}},
+		{"ahead", header + "# branch.ab +3 -0\n", Report{Score: 1.5, Level: Clean}},
+		{"stash", header + "# branch.ab +0 -0\n# stash 4\n", Report{Score: 1, Level: Clean}},
+		{"dirty", header + "# branch.ab +0 -0\n" + staged + both + "? new.txt\n", Report{Score: 1 + 3 + 0.5, Level: Dirty}},
+		{"diverged", header + "# branch.ab +1 -2\n", Report{Score: 2.5, Level: Diverged}},
+		{"diverged and dirty", header + "# branch.ab +1 -2\n" + staged, Report{Score: 3.5, Level: Diverged}},
+		{"conflicted", header + "# branch.ab +1 -2\n" + conflict + staged, Report{Score: 13.5, Level: Conflicted}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(parse(t, tt.input), DefaultWeights); got != tt.want {
+				t.Errorf("Score() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := Score(nil, DefaultWeights); got != (Report{}) {
+		t.Errorf("Score(nil) = %+v, want zero", got)
+	}
+}
+
+func TestScore_Weights(t *testing.T) {
+	s := parse(t, header+"# branch.ab +2 -0\n"+both+"? new.txt\n")
+	w := Weights{Unstaged: 1, Ahead: 10}
+	if got, want := Score(s, w), (Report{Score: 21, Level: Dirty}); got != want {
+		t.Errorf("Score() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLevel_Text(t *testing.T) {
+	for l := Clean; l <= Conflicted; l++ {
+		data, err := json.Marshal(l)
+		if err != nil {
+			t.Fatalf("Marshal(%v) error = %v", l, err)
+		}
+		var got Level
+		if err := json.Unmarshal(data, &got); err != nil || got != l {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", data, got, err, l)
+		}
+	}
+	if got := Level(7).String(); got != "Level(7)" {
+		t.Errorf("String() = %q, want %q", got, "Level(7)")
+	}
+	var l Level
+	if err := l.UnmarshalText([]byte("filthy")); err == nil {
+		t.Error("UnmarshalText() error = nil, want error for an unknown name")
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Package ignore filters status entries by path with gitignore-style patterns,
+so that tools can share one configuration of paths to leave out of their
+reports, in a .porcelainignore file.
+
+# Basic Usage
+
+[Load] reads patterns from a file, [Parse] from any [io.Reader], and [New]
+takes them directly. The resulting [Matcher] reports whether a path is
+ignored, and provides a [statusv2.EntryTransformer] dropping the entries of
+ignored paths as they are decoded:
+
+	m, err := ignore.Load(filepath.Join(repo, ignore.FileName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+	    log.Fatal(err)
+	}
+	d := statusv2.NewDecoderZ(r)
+	d.Transform(m.Filter())
+
+A nil Matcher ignores nothing, so the error for a missing file can be ignored
+as above.
+
+# Patterns
+
+Patterns follow the syntax of gitignore files, described in gitignore(5):
+
+  - blank lines and lines starting with "#" are skipped, as are trailing
+    spaces unless escaped with a backslash
+  - a leading "!" negates a pattern, re-including paths an earlier pattern
+    ignored, unless a parent directory of the path is ignored
+  - a trailing "/" matches only directories
+  - a pattern containing a "/" other than at its end is relative to the root
+    of the repository, and otherwise matches a name at any depth
+  - "*" matches anything except "/", "?" any one character except "/", and
+    "[a-z]" a range of characters, negated by "[!a-z]"
+  - a segment of just "**" matches any number of directories, so that a
+    pattern starting with it matches at any depth, one ending with it
+    matches everything inside a directory, and one with it in between
+    matches across directories
+
+Paths are matched as git writes them in status output, relative to the root
+of the repository and separated by "/", with a trailing "/" for directories.
+Paths that git quotes, without -z, should first be unquoted, such as with
+[statusv2.UnquotePaths].
+*/
+package ignore
@@ -0,0 +1,23 @@
+package ignore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// FuzzParse tests that parsing and matching arbitrary patterns never panics.
+func FuzzParse(f *testing.F) {
+	fuzzseed.Add[[]byte](f, "ignore")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := Parse(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		for _, p := range []string{"a", "a/b/c.o", "build/", "vendor/keep.go", "#hash"} {
+			m.Match(p)
+		}
+	})
+}
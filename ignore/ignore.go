@@ -0,0 +1,186 @@
+package ignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// FileName is the conventional name of the file of patterns, at the root of
+// a repository.
+const FileName = ".porcelainignore"
+
+// A Matcher reports whether paths are ignored by its patterns. A nil Matcher
+// ignores nothing.
+type Matcher struct {
+	patterns []pattern
+}
+
+// pattern is a compiled line of a pattern file.
+type pattern struct {
+	segments []string // separated by "/", with "**" for any directories
+	negate   bool
+	dirOnly  bool
+}
+
+// New returns a Matcher for the given patterns, each in the syntax of a line
+// of a pattern file.
+func New(patterns ...string) (*Matcher, error) {
+	m := &Matcher{}
+	for _, line := range patterns {
+		if err := m.add(line); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Load reads a Matcher from the pattern file at path.
+func Load(path string) (*Matcher, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	m, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Parse reads a Matcher from the patterns in r, one per line.
+func Parse(r io.Reader) (*Matcher, error) {
+	m := &Matcher{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		if err := m.add(scanner.Text()); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// add compiles a line of a pattern file, skipping blank lines and comments.
+func (m *Matcher) add(line string) error {
+	line = strings.TrimSuffix(line, "\r")
+	line = trimTrailingSpaces(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	var p pattern
+	if strings.HasPrefix(line, "!") {
+		p.negate, line = true, line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly, line = true, strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return fmt.Errorf("invalid pattern: matches nothing")
+	}
+	if !strings.Contains(line, "/") {
+		line = "**/" + line
+	}
+	line = strings.TrimPrefix(line, "/")
+
+	for _, seg := range strings.Split(line, "/") {
+		if seg == "" {
+			continue // repeated slashes
+		}
+		if seg != "**" {
+			seg = strings.ReplaceAll(seg, "[!", "[^")
+			if _, err := path.Match(seg, ""); err != nil {
+				return fmt.Errorf("invalid pattern: %q", line)
+			}
+		}
+		p.segments = append(p.segments, seg)
+	}
+	m.patterns = append(m.patterns, p)
+	return nil
+}
+
+// trimTrailingSpaces removes trailing spaces from line, except one escaped
+// with a backslash.
+func trimTrailingSpaces(line string) string {
+	trimmed := strings.TrimRight(line, " ")
+	if len(trimmed) < len(line) && strings.HasSuffix(trimmed, `\`) && !strings.HasSuffix(trimmed, `\\`) {
+		return trimmed + " "
+	}
+	return trimmed
+}
+
+// Match reports whether path is ignored, either by the last of the patterns
+// matching it, or because a parent directory of it is ignored. Directories
+// are given with a trailing "/".
+func (m *Matcher) Match(path string) bool {
+	if m == nil || path == "" {
+		return false
+	}
+	dir := strings.HasSuffix(path, "/")
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		if m.match(segments[:i], true) {
+			return true
+		}
+	}
+	return m.match(segments, dir)
+}
+
+// match returns the result of the last pattern matching the path segments.
+func (m *Matcher) match(segments []string, dir bool) bool {
+	ignored := false
+	for _, p := range m.patterns {
+		if p.dirOnly && !dir {
+			continue
+		}
+		if matchSegments(p.segments, segments) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+// matchSegments reports whether the path segments match the pattern segments,
+// in which "**" matches any number of segments, or at least one when last.
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			if len(pattern) == 1 {
+				return len(segments) > 0
+			}
+			for i := range len(segments) + 1 {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// Filter returns a transformer dropping the entries of ignored paths. Renames
+// and copies are dropped when their new path is ignored.
+func (m *Matcher) Filter() statusv2.EntryTransformer {
+	return statusv2.Filter(func(e statusv2.Entry) bool {
+		return !m.Match(e.EntryPath())
+	})
+}
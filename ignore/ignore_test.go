@@ -0,0 +1,155 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		ignored  []string
+		kept     []string
+	}{
+		{
+			name:     "name at any depth",
+			patterns: []string{"*.log"},
+			ignored:  []string{"debug.log", "a/b/debug.log", "logs.log/", "logs.log/x.txt"},
+			kept:     []string{"debug.log.txt", "log", "a/log/x"},
+		},
+		{
+			name:     "anchored",
+			patterns: []string{"/build", "docs/*.md"},
+			ignored:  []string{"build", "build/", "build/out.o", "docs/a.md"},
+			kept:     []string{"src/build", "docs/sub/a.md", "sub/docs/a.md"},
+		},
+		{
+			name:     "directory only",
+			patterns: []string{"tmp/"},
+			ignored:  []string{"tmp/", "tmp/x", "a/tmp/x", "a/tmp/"},
+			kept:     []string{"tmp", "a/tmp"},
+		},
+		{
+			name:     "negation",
+			patterns: []string{"*.log", "!keep.log"},
+			ignored:  []string{"debug.log"},
+			kept:     []string{"keep.log", "a/keep.log"},
+		},
+		{
+			name:     "negation under ignored directory",
+			patterns: []string{"vendor/", "!vendor/keep.go"},
+			ignored:  []string{"vendor/keep.go"},
+		},
+		{
+			name:     "double star",
+			patterns: []string{"**/gen/*.go", "out/**", "a/**/z"},
+			ignored:  []string{"gen/x.go", "p/q/gen/x.go", "out/x", "out/a/b", "a/z", "a/b/c/z"},
+			kept:     []string{"gen/x.txt", "out", "a/zz"},
+		},
+		{
+			name:     "character classes",
+			patterns: []string{"file[0-9].txt", "x[!a].c", "?.o"},
+			ignored:  []string{"file1.txt", "xb.c", "a.o"},
+			kept:     []string{"filea.txt", "xa.c", "ab.o", "/.o"},
+		},
+		{
+			name:     "escapes",
+			patterns: []string{`\#hash`, `\!bang`, `trailing\ `, "spaces   "},
+			ignored:  []string{"#hash", "!bang", "trailing ", "spaces"},
+			kept:     []string{"trailing", "spaces "},
+		},
+		{
+			name:     "comments and blank lines",
+			patterns: []string{"# *.go", "", "   "},
+			kept:     []string{"main.go", "# *.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.patterns...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			for _, p := range tt.ignored {
+				if !m.Match(p) {
+					t.Errorf("Match(%q) = false, want true", p)
+				}
+			}
+			for _, p := range tt.kept {
+				if m.Match(p) {
+					t.Errorf("Match(%q) = true, want false", p)
+				}
+			}
+		})
+	}
+}
+
+func TestMatcher_Nil(t *testing.T) {
+	var m *Matcher
+	if m.Match("any") {
+		t.Error("nil Match() = true, want false")
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	for _, p := range []string{"[", "/", "!"} {
+		if _, err := New(p); err == nil {
+			t.Errorf("New(%q) error = nil, want error", p)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	m, err := Parse(strings.NewReader("# generated\n*.tmp\r\n!keep.tmp\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !m.Match("x.tmp") || m.Match("keep.tmp") {
+		t.Errorf("Parse() patterns not applied")
+	}
+	if _, err := Parse(strings.NewReader("ok\nbad[\n")); err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("Parse() error = %v, want error on line 2", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("*.log\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !m.Match("x.log") {
+		t.Error("Load() patterns not applied")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), FileName)); !os.IsNotExist(err) {
+		t.Errorf("Load() of missing file error = %v, want not exist", err)
+	}
+}
+
+func TestMatcher_Filter(t *testing.T) {
+	m, err := New("*.log", "vendor/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := "? debug.log\x00? main.go\x00? vendor/\x00" +
+		"2 R. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d R100 new.log\x00old.txt\x00"
+	d := statusv2.NewDecoderZ(strings.NewReader(input))
+	d.Transform(m.Filter())
+	got, err := d.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want := []statusv2.Entry{statusv2.UntrackedEntry{Path: "main.go"}}
+	if diff := cmp.Diff(want, got.Entries); diff != "" {
+		t.Errorf("entries mismatch (-want +got):\n%s", diff)
+	}
+}
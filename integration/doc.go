@@ -0,0 +1,9 @@
+// Package integration holds the end-to-end tests of the parsers against the
+// git binary, in real repositories covering renames, copies, submodules,
+// conflicts, detached HEAD, initial commits and SHA-256 object names.
+//
+// The tests are only built with the integration build tag, and require git
+// 2.29.0 or later for SHA-256 repositories:
+//
+//	go test -tags integration ./integration
+package integration
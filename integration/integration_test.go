@@ -0,0 +1,661 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/plan"
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+func TestMain(m *testing.M) {
+	if _, err := exec.LookPath("git"); err != nil {
+		fmt.Fprintln(os.Stderr, "integration tests require git in PATH")
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// repo is a scratch repository.
+type repo struct {
+	t   *testing.T
+	dir string
+	git *gitexec.Git
+}
+
+// newRepo creates a repository in a temporary directory, with git isolated
+// from the user's configuration, and any extra arguments to git init.
+func newRepo(t *testing.T, initArgs ...string) *repo {
+	t.Helper()
+	home := t.TempDir()
+	dir := filepath.Join(home, "repo")
+	r := &repo{t: t, dir: dir, git: &gitexec.Git{Dir: home, Env: []string{
+		"HOME=" + home,
+		"XDG_CONFIG_HOME=" + home,
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_AUTHOR_NAME=Porcelain Integration",
+		"GIT_AUTHOR_EMAIL=integration@example.com",
+		"GIT_COMMITTER_NAME=Porcelain Integration",
+		"GIT_COMMITTER_EMAIL=integration@example.com",
+	}}}
+	r.run(append([]string{"init", "--quiet"}, append(initArgs, dir)...)...)
+	r.git.Dir = dir
+	r.run("symbolic-ref", "HEAD", "refs/heads/main")
+	return r
+}
+
+// run runs git in the repository, failing the test if it fails.
+func (r *repo) run(args ...string) string {
+	r.t.Helper()
+	out, err := r.git.Run(context.Background(), args...)
+	if err != nil {
+		r.t.Fatalf("git %v: %v", args, err)
+	}
+	return string(out)
+}
+
+// write writes a file in the repository, creating its directory as needed.
+func (r *repo) write(name, content string) {
+	r.t.Helper()
+	path := filepath.Join(r.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		r.t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+// commit stages everything and commits it.
+func (r *repo) commit(msg string) {
+	r.t.Helper()
+	r.run("add", "--all")
+	r.run("commit", "--quiet", "--allow-empty", "-m", msg)
+}
+
+// statusV2 runs git status in the porcelain=v2 formats with and without -z,
+// with any additional args, checks that the strict decoder accepts both, and
+// returns the -z status, checking that the other matches it.
+func (r *repo) statusV2(args ...string) *statusv2.Status {
+	r.t.Helper()
+	z := r.run(append([]string{"status", "--porcelain=v2", "-z", "--branch", "--show-stash"}, args...)...)
+	lf := r.run(append([]string{"status", "--porcelain=v2", "--branch", "--show-stash"}, args...)...)
+
+	s, err := statusv2.ParseZ(bytes.NewReader([]byte(z)))
+	if err != nil {
+		r.t.Fatalf("ParseZ() error = %v\n%q", err, z)
+	}
+	lfStatus, err := statusv2.Parse(bytes.NewReader([]byte(lf)))
+	if err != nil {
+		r.t.Fatalf("Parse() error = %v\n%q", err, lf)
+	}
+	for i, e := range lfStatus.Entries {
+		if lfStatus.Entries[i], err = statusv2.UnquotePaths().TransformEntry(e); err != nil {
+			r.t.Fatalf("unquoting paths: %v", err)
+		}
+	}
+	if !s.Equal(lfStatus) {
+		r.t.Errorf("Parse() = %+v, differs from ParseZ() = %+v", lfStatus, s)
+	}
+	strictV2(r.t, statusv2.NewDecoderZ(bytes.NewReader([]byte(z))))
+	strictV2(r.t, statusv2.NewDecoder(bytes.NewReader([]byte(lf))))
+	return s
+}
+
+// unquote reverses the C-style quoting git applies to unusual paths outside
+// of -z output, which the parsers preserve as given.
+func unquote(t *testing.T, path string) string {
+	t.Helper()
+	if !strings.HasPrefix(path, `"`) {
+		return path
+	}
+	s, err := strconv.Unquote(path)
+	if err != nil {
+		t.Fatalf("unquote %s: %v", path, err)
+	}
+	return s
+}
+
+func strictV2(t *testing.T, d *statusv2.Decoder) {
+	t.Helper()
+	d.Strict()
+	for {
+		if _, err := d.Next(); err == io.EOF {
+			return
+		} else if err != nil {
+			t.Errorf("strict Next() error = %v", err)
+			return
+		}
+	}
+}
+
+// statusV1 runs git status in the porcelain=v1 formats, as for statusV2.
+func (r *repo) statusV1(args ...string) *statusv1.Status {
+	r.t.Helper()
+	z := r.run(append([]string{"status", "--porcelain=v1", "-z", "--branch"}, args...)...)
+	lf := r.run(append([]string{"status", "--porcelain=v1", "--branch"}, args...)...)
+
+	s, err := statusv1.ParseZ(bytes.NewReader([]byte(z)))
+	if err != nil {
+		r.t.Fatalf("ParseZ() error = %v\n%q", err, z)
+	}
+	lfStatus, err := statusv1.Parse(bytes.NewReader([]byte(lf)))
+	if err != nil {
+		r.t.Fatalf("Parse() error = %v\n%q", err, lf)
+	}
+	for i, e := range lfStatus.Entries {
+		lfStatus.Entries[i].Path = unquote(r.t, e.Path)
+		if e.OrigPath != "" {
+			lfStatus.Entries[i].OrigPath = unquote(r.t, e.OrigPath)
+		}
+	}
+	if !slices.Equal(s.Entries, lfStatus.Entries) || !slices.Equal(s.Headers, lfStatus.Headers) {
+		r.t.Errorf("Parse() = %+v, differs from ParseZ() = %+v", lfStatus, s)
+	}
+	for _, d := range []*statusv1.Decoder{
+		statusv1.NewDecoderZ(bytes.NewReader([]byte(z))),
+		statusv1.NewDecoder(bytes.NewReader([]byte(lf))),
+	} {
+		d.Strict()
+		for {
+			if _, err := d.Next(); err == io.EOF {
+				break
+			} else if err != nil {
+				r.t.Errorf("strict Next() error = %v", err)
+				break
+			}
+		}
+	}
+	return s
+}
+
+// only returns the single entry of s, failing the test if there is not
+// exactly one.
+func only[E any](t *testing.T, entries []E) E {
+	t.Helper()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1: %+v", len(entries), entries)
+	}
+	return entries[0]
+}
+
+func TestInitial(t *testing.T) {
+	r := newRepo(t)
+	r.write("staged.txt", "staged\n")
+	r.run("add", "staged.txt")
+	r.write("untracked.txt", "untracked\n")
+
+	s := r.statusV2()
+	if s.Branch == nil || s.Branch.OID != "(initial)" || s.Branch.Head != "main" {
+		t.Errorf("Branch = %+v, want initial commit on main", s.Branch)
+	}
+	want := []statusv2.Entry{
+		statusv2.ChangedEntry{
+			XY:    statusv2.XYFlag{X: statusv2.Added, Y: statusv2.Unmodified},
+			ModeI: statusv2.FileModeRegular, ModeW: statusv2.FileModeRegular,
+			HashH: "0000000000000000000000000000000000000000",
+			HashI: r.run("rev-parse", ":staged.txt")[:40],
+			Path:  "staged.txt",
+		},
+		statusv2.UntrackedEntry{Path: "untracked.txt"},
+	}
+	if !slices.Equal(s.Entries, want) {
+		t.Errorf("Entries = %+v, want %+v", s.Entries, want)
+	}
+
+	v1 := r.statusV1()
+	if want := []string{"## No commits yet on main"}; !slices.Equal(v1.Headers, want) {
+		t.Errorf("v1 Headers = %q, want %q", v1.Headers, want)
+	}
+}
+
+func TestRename(t *testing.T) {
+	r := newRepo(t)
+	r.write("old name.txt", "a file with enough content\nto be detected as renamed\n")
+	r.commit("initial")
+	r.run("mv", "old name.txt", "new\tname.txt")
+
+	e, ok := only(t, r.statusV2().Entries).(statusv2.RenameOrCopyEntry)
+	if !ok || e.XY.X != statusv2.Renamed || e.Score != "R100" || e.Path != "new\tname.txt" || e.Orig != "old name.txt" {
+		t.Errorf("entry = %+v, want rename of %q to %q", e, "old name.txt", "new\tname.txt")
+	}
+	v1 := only(t, r.statusV1().Entries)
+	if v1.XY.X != statusv1.Renamed || v1.Path != "new\tname.txt" || v1.OrigPath != "old name.txt" {
+		t.Errorf("v1 entry = %+v, want rename", v1)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	r := newRepo(t)
+	r.run("config", "status.renames", "copies")
+	var content string
+	for i := range 50 {
+		content += fmt.Sprintln(i)
+	}
+	r.write("source.txt", content)
+	r.commit("initial")
+	// copies are only detected from files modified in the same change
+	r.write("copy.txt", content)
+	r.write("source.txt", content+"more\n")
+	r.run("add", "--all")
+
+	var copies []statusv2.RenameOrCopyEntry
+	for _, e := range r.statusV2().Entries {
+		if rc, ok := e.(statusv2.RenameOrCopyEntry); ok {
+			copies = append(copies, rc)
+		}
+	}
+	c := only(t, copies)
+	if c.XY.X != statusv2.Copied || c.Score != "C100" || c.Path != "copy.txt" || c.Orig != "source.txt" {
+		t.Errorf("entry = %+v, want copy of source.txt", c)
+	}
+
+	var v1Copies []statusv1.Entry
+	for _, e := range r.statusV1().Entries {
+		if e.XY.X == statusv1.Copied {
+			v1Copies = append(v1Copies, e)
+		}
+	}
+	if c := only(t, v1Copies); c.Path != "copy.txt" || c.OrigPath != "source.txt" {
+		t.Errorf("v1 entry = %+v, want copy of source.txt", c)
+	}
+}
+
+func TestSubmodule(t *testing.T) {
+	sub := newRepo(t)
+	sub.commit("sub")
+	r := newRepo(t)
+	r.run("-c", "protocol.file.allow=always", "submodule", "--quiet", "add", sub.dir, "sub")
+	r.commit("add submodule")
+
+	// untracked files in the submodule
+	r.write("sub/untracked.txt", "untracked\n")
+	e := only(t, r.statusV2().Entries).(statusv2.ChangedEntry)
+	want := statusv2.SubmoduleStatus{IsSubmodule: true, HasUntracked: true}
+	if e.Sub != want || e.ModeH != statusv2.FileModeSubmodule || e.Path != "sub" {
+		t.Errorf("entry = %+v, want submodule with untracked files", e)
+	}
+
+	// a new commit, and modified tracked files, in the submodule
+	subRepo := &repo{t: t, dir: filepath.Join(r.dir, "sub"), git: &gitexec.Git{Dir: filepath.Join(r.dir, "sub"), Env: r.git.Env}}
+	subRepo.commit("advance")
+	subRepo.write("tracked.txt", "tracked\n")
+	subRepo.commit("tracked")
+	subRepo.write("tracked.txt", "modified\n")
+	e = only(t, r.statusV2().Entries).(statusv2.ChangedEntry)
+	want = statusv2.SubmoduleStatus{IsSubmodule: true, CommitChanged: true, HasModifications: true}
+	if e.Sub != want {
+		t.Errorf("Sub = %+v, want %+v", e.Sub, want)
+	}
+	r.statusV1()
+}
+
+func TestConflicts(t *testing.T) {
+	r := newRepo(t)
+	r.write("both.txt", "base\n")
+	r.write("deleted.txt", "base\n")
+	r.commit("base")
+	r.run("checkout", "--quiet", "-b", "other")
+	r.write("both.txt", "theirs\n")
+	r.write("deleted.txt", "theirs\n")
+	r.write("added.txt", "theirs\n")
+	r.commit("theirs")
+	r.run("checkout", "--quiet", "main")
+	r.write("both.txt", "ours\n")
+	r.run("rm", "--quiet", "deleted.txt")
+	r.write("added.txt", "ours\n")
+	r.commit("ours")
+	if _, err := r.git.Run(context.Background(), "merge", "--quiet", "other"); err == nil {
+		t.Fatal("merge succeeded, want conflicts")
+	}
+
+	got := map[string]string{}
+	for _, e := range r.statusV2().Entries {
+		u, ok := e.(statusv2.UnmergedEntry)
+		if !ok {
+			t.Errorf("entry = %+v, want only unmerged entries", e)
+			continue
+		}
+		got[u.Path] = u.XY.String()
+	}
+	want := map[string]string{"added.txt": "AA", "both.txt": "UU", "deleted.txt": "DU"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("unmerged entries = %v, want %v", got, want)
+	}
+
+	for _, e := range r.statusV1().Entries {
+		if e.XY.String() != want[e.Path] {
+			t.Errorf("v1 entry %s = %q, want %q", e.Path, e.XY, want[e.Path])
+		}
+	}
+}
+
+func TestDetached(t *testing.T) {
+	r := newRepo(t)
+	r.commit("one")
+	r.commit("two")
+	r.run("checkout", "--quiet", "--detach", "HEAD~1")
+
+	s := r.statusV2()
+	oid := r.run("rev-parse", "HEAD")[:40]
+	if s.Branch == nil || s.Branch.Head != "(detached)" || s.Branch.OID != oid {
+		t.Errorf("Branch = %+v, want detached at %s", s.Branch, oid)
+	}
+	if want := []string{"## HEAD (no branch)"}; !slices.Equal(r.statusV1().Headers, want) {
+		t.Errorf("v1 Headers = %q, want %q", r.statusV1().Headers, want)
+	}
+}
+
+func TestUpstreamAndStash(t *testing.T) {
+	r := newRepo(t)
+	r.commit("one")
+	r.run("update-ref", "refs/remotes/origin/main", "HEAD")
+	r.run("config", "remote.origin.url", r.dir)
+	r.run("config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")
+	r.run("config", "branch.main.remote", "origin")
+	r.run("config", "branch.main.merge", "refs/heads/main")
+	r.commit("two")
+	r.write("stashed.txt", "stashed\n")
+	r.run("add", "stashed.txt")
+	r.run("stash", "--quiet")
+
+	s := r.statusV2()
+	want := statusv2.BranchInfo{OID: r.run("rev-parse", "HEAD")[:40], Head: "main", Upstream: "origin/main", Ahead: 1}
+	if s.Branch == nil || *s.Branch != want {
+		t.Errorf("Branch = %+v, want %+v", s.Branch, want)
+	}
+	if s.Stash == nil || s.Stash.Count != 1 {
+		t.Errorf("Stash = %+v, want 1 entry", s.Stash)
+	}
+}
+
+func TestSHA256(t *testing.T) {
+	r := newRepo(t, "--object-format=sha256")
+	r.write("committed.txt", "one\n")
+	r.commit("initial")
+	r.write("committed.txt", "two\n")
+	r.run("add", "committed.txt")
+
+	s := r.statusV2()
+	if s.Branch == nil || statusv2.Hash(s.Branch.OID).Algorithm() != statusv2.SHA256 {
+		t.Errorf("Branch = %+v, want a SHA-256 OID", s.Branch)
+	}
+	e := only(t, s.Entries).(statusv2.ChangedEntry)
+	_, head := e.Head()
+	_, index := e.Index()
+	if head.Algorithm() != statusv2.SHA256 || index.Algorithm() != statusv2.SHA256 || head == index {
+		t.Errorf("entry = %+v, want distinct SHA-256 object names", e)
+	}
+	r.statusV1()
+}
+
+func TestRefreshPaths(t *testing.T) {
+	r := newRepo(t)
+	r.write("a.txt", "a\n")
+	r.write("dir/b.txt", "b\n")
+	r.write("dir/c.txt", "c\n")
+	r.write("d.txt", "d\n")
+	r.commit("initial")
+	r.write("a.txt", "modified\n")
+	r.write("dir/b.txt", "modified\n")
+	r.write("d.txt", "modified\n")
+
+	ctx := context.Background()
+	s, err := statusv2.Get(ctx, r.git, "--untracked-files=all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a.txt is reverted, the change to dir/b.txt staged, a file added under
+	// dir, and d.txt changed again without being refreshed
+	r.write("a.txt", "a\n")
+	r.run("add", "dir/b.txt")
+	r.write("dir/new.txt", "new\n")
+	r.write("d.txt", "modified again\n")
+	if err := statusv2.RefreshPaths(ctx, r.git, s, "a.txt", "dir/"); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := statusv2.Get(ctx, r.git, "--untracked-files=all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(want) {
+		t.Errorf("refreshed status = %+v, want %+v", s.Entries, want.Entries)
+	}
+}
+
+// TestRefreshPaths_Rename checks that refreshing either path of a staged
+// rename gives the same status as a full one, after the renamed file is
+// changed and after the rename is undone.
+func TestRefreshPaths_Rename(t *testing.T) {
+	for _, path := range []string{"a.txt", "b.txt"} {
+		t.Run(path, func(t *testing.T) {
+			r := newRepo(t)
+			r.write("a.txt", "a\n")
+			r.commit("initial")
+			r.run("mv", "a.txt", "b.txt")
+
+			ctx := context.Background()
+			s, err := statusv2.Get(ctx, r.git, "--untracked-files=all")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, change := range []func(){
+				func() { r.write("b.txt", "a\nmodified\n") },
+				func() { r.run("mv", "b.txt", "a.txt") },
+			} {
+				change()
+				if err := statusv2.RefreshPaths(ctx, r.git, s, path); err != nil {
+					t.Fatal(err)
+				}
+				want, err := statusv2.Get(ctx, r.git, "--untracked-files=all")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !s.Equal(want) {
+					t.Errorf("refreshed status = %+v, want %+v", s.Entries, want.Entries)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPaths(t *testing.T) {
+	r := newRepo(t)
+	r.write("a.txt", "a\n")
+	r.write("dir/b.txt", "b\n")
+	r.write("dir/c.txt", "c\n")
+	r.write(".gitignore", "*.log\n")
+	r.commit("initial")
+	r.write("dir/b.txt", "modified\n")
+	r.write("new/d.txt", "d\n")
+	r.write("debug.log", "log\n")
+
+	paths := []string{"a.txt", "dir", "dir/c.txt", "new", "new/d.txt", "debug.log", "missing.txt"}
+	got, err := statusv2.GetPaths(context.Background(), r.git, paths, "--ignored")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &statusv2.PathsStatus{
+		Status:    got.Status,
+		Dirty:     []string{"dir"},
+		Untracked: []string{"new", "new/d.txt"},
+		Ignored:   []string{"debug.log"},
+		Clean:     []string{"a.txt", "dir/c.txt", "missing.txt"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetPaths() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetWithRenames(t *testing.T) {
+	r := newRepo(t)
+	var content string
+	for i := range 20 {
+		content += fmt.Sprintln(i)
+	}
+	other := strings.Repeat("other\n", 20)
+	r.write("source.txt", content)
+	r.write("old.txt", other)
+	r.commit("initial")
+	r.run("config", "status.renames", "copies")
+	r.write("copy.txt", content)
+	r.write("source.txt", content+"more\n")
+	r.run("mv", "old.txt", "new.txt")
+	r.write("new.txt", other+"new\n")
+	r.run("add", "--all")
+
+	tests := []struct {
+		detection statusv2.RenameDetection
+		want      []string
+	}{
+		{statusv2.RenameDetection{}, []string{"C. copy.txt", "R. new.txt"}},
+		{statusv2.RenameDetection{Mode: statusv2.Copies}, []string{"C. copy.txt", "R. new.txt"}},
+		{statusv2.RenameDetection{Mode: statusv2.Renames}, []string{"R. new.txt"}},
+		{statusv2.RenameDetection{Mode: statusv2.Copies, Threshold: 100}, []string{"C. copy.txt"}},
+		{statusv2.RenameDetection{Mode: statusv2.NoRenames}, nil},
+	}
+	for _, tt := range tests {
+		s, err := statusv2.GetWithRenames(context.Background(), r.git, tt.detection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, e := range s.Entries {
+			if rc, ok := e.(statusv2.RenameOrCopyEntry); ok {
+				got = append(got, rc.XY.String()+" "+rc.Path)
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%+v: renames and copies = %q, want %q", tt.detection, got, tt.want)
+		}
+	}
+}
+
+func TestIgnoreDirtySubmodules(t *testing.T) {
+	sub := newRepo(t)
+	sub.write("tracked.txt", "tracked\n")
+	sub.commit("sub")
+	r := newRepo(t)
+	for _, name := range []string{"dirty", "untracked", "advanced"} {
+		r.run("-c", "protocol.file.allow=always", "submodule", "--quiet", "add", sub.dir, name)
+	}
+	r.commit("add submodules")
+
+	r.write("dirty/tracked.txt", "modified\n")
+	r.write("untracked/new.txt", "new\n")
+	advanced := &repo{t: t, dir: filepath.Join(r.dir, "advanced"), git: &gitexec.Git{Dir: filepath.Join(r.dir, "advanced"), Env: r.git.Env}}
+	advanced.commit("advance")
+	advanced.write("tracked.txt", "modified\n")
+
+	got := r.statusV2()
+	if err := got.Transform(statusv2.IgnoreDirtySubmodules()); err != nil {
+		t.Fatal(err)
+	}
+	want, err := statusv2.ParseZ(strings.NewReader(r.run("status", "--porcelain=v2", "-z", "--branch", "--show-stash", "--ignore-submodules=dirty")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("transformed status = %+v, want %+v", got.Entries, want.Entries)
+	}
+	if len(want.Entries) != 1 {
+		t.Errorf("git status --ignore-submodules=dirty has %d entries, want 1", len(want.Entries))
+	}
+}
+
+func TestVerify(t *testing.T) {
+	r := newRepo(t)
+	for _, name := range []string{"kept.txt", "deleted.txt", "unstaged.txt", "old.txt", "dir/nested.txt"} {
+		r.write(name, name+"\n")
+	}
+	if err := os.Symlink("nowhere", filepath.Join(r.dir, "dangling")); err != nil {
+		t.Fatal(err)
+	}
+	r.commit("initial")
+	r.run("rm", "--quiet", "deleted.txt")
+	r.run("rm", "--quiet", "--cached", "kept.txt")
+	r.run("mv", "old.txt", "new.txt")
+	r.write("dir/nested.txt", "modified\n")
+	r.write("untracked/file.txt", "new\n")
+	if err := os.Remove(filepath.Join(r.dir, "unstaged.txt")); err != nil {
+		t.Fatal(err)
+	}
+	r.run("mv", "dangling", "still-dangling")
+
+	s := r.statusV2()
+	fsys := os.DirFS(r.dir)
+	if got, err := s.Verify(fsys); err != nil || len(got) != 0 {
+		t.Fatalf("Verify() = %v, %v, want no discrepancies", got, err)
+	}
+
+	r.write("deleted.txt", "restored\n")
+	if err := os.RemoveAll(filepath.Join(r.dir, "untracked")); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Verify(fsys)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	var paths []string
+	for _, d := range got {
+		paths = append(paths, d.Path)
+	}
+	if want := []string{"deleted.txt", "untracked"}; !slices.Equal(want, paths) {
+		t.Errorf("Verify() = %v, want discrepancies for %q", got, want)
+	}
+}
+
+func TestPlanStage(t *testing.T) {
+	r := newRepo(t)
+	for _, name := range []string{"modified.txt", "deleted.txt", "[glob].txt"} {
+		r.write(name, name+"\n")
+	}
+	r.write("old.txt", strings.Repeat("line\n", 10))
+	r.commit("initial")
+	r.write("modified.txt", "modified\n")
+	r.write("[glob].txt", "modified\n")
+	r.write("gtxt", "matched by the glob, if taken as one\n")
+	r.run("mv", "old.txt", "new.txt")
+	r.write("new.txt", strings.Repeat("line\n", 11))
+	r.write("untracked/file.txt", "new\n")
+	if err := os.Remove(filepath.Join(r.dir, "deleted.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	s := r.statusV2()
+	p := plan.Stage(s, plan.Config{})
+	if err := p.Run(context.Background(), r.git); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	got := map[string]string{}
+	for path, xy := range r.statusV2().XYMap() {
+		got[path] = xy.String()
+	}
+	want := map[string]string{
+		"modified.txt": "M.",
+		"deleted.txt":  "D.",
+		"new.txt":      "R.",
+		"old.txt":      "R.",
+		"[glob].txt":   "M.",
+		"gtxt":         "??",
+		"untracked/":   "??",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("status after Run() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Package logfmt builds `git log` format strings from a list of fields and
+parses the resulting output into typed commit records.
+
+Tools reading commit history commonly invent their own --format string and a
+matching ad hoc parser. This package does both from a single declaration: a
+[Format] lists the [Field] values wanted, and produces the format string git
+should be run with and a parser for its output. Fields are NUL-terminated, and
+git is run with -z, so free-form fields such as the commit body can contain
+any text.
+
+# Basic Usage
+
+Declare the fields you need, then run git through a [gitexec.Runner]:
+
+	format := logfmt.Format{logfmt.Hash, logfmt.AuthorName, logfmt.AuthorDate, logfmt.Subject}
+	commits, err := format.Log(ctx, gitexec.New("."), "-n", "10")
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, c := range commits {
+	    fmt.Printf("%s %s %s %s\n", c.Hash, c.Author.Name, c.Author.Time, c.Subject)
+	}
+
+Fields not included in the format are left as zero values in each [Commit].
+
+To run git yourself, or to parse previously captured output, use the
+arguments returned by [Format.Args] and pass the output to [Format.Parse], or
+to a [Decoder] to stream commits one at a time for long histories:
+
+	cmd := exec.Command("git", format.Args("main..topic")...)
+	out, _ := cmd.StdoutPipe()
+	cmd.Start()
+
+	dec := format.NewDecoder(out)
+	for {
+	    c, err := dec.Next()
+	    if err == io.EOF {
+	        break
+	    } else if err != nil {
+	        log.Fatal(err)
+	    }
+	    fmt.Println(c.Hash, c.Subject)
+	}
+
+For more information, see the "PRETTY FORMATS" section of the Git
+documentation for [git log].
+
+[git log]: https://git-scm.com/docs/git-log#_pretty_formats
+*/
+package logfmt
@@ -0,0 +1,25 @@
+package logfmt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// Fuzz test checking that parsing arbitrary output never panics.
+func FuzzParse(f *testing.F) {
+	// Add some seed inputs
+	f.Add(sampleOutput)
+	fuzzseed.Add[string](f, "logfmt")
+
+	format := Format{Hash, ShortHash, Parents, AuthorName, AuthorEmail, AuthorDate, CommitterDate, Subject, Body, Refs}
+	f.Fuzz(func(t *testing.T, data string) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("Parse panicked with input %q: %v", data, r)
+			}
+		}()
+		_, _ = format.Parse(strings.NewReader(data))
+	})
+}
@@ -0,0 +1,130 @@
+package logfmt
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// Commit represents a single commit record read from `git log`.
+//
+// Only the fields requested in the [Format] used to read the commit are set.
+type Commit struct {
+	Hash      string    // full commit object name
+	ShortHash string    // abbreviated commit object name
+	Tree      string    // full tree object name
+	Parents   []string  // full parent object names; empty for root commits
+	Author    Signature // author of the changes
+	Committer Signature // creator of the commit object
+	Subject   string    // first paragraph of the message, joined into a single line
+	Body      string    // message after the subject, without trailing newlines
+	Message   string    // full raw message (subject and body), without trailing newlines
+	Refs      []string  // ref names pointing at the commit, e.g. "HEAD -> main", "tag: v1.0"
+}
+
+// Signature represents the name, email and timestamp of an author or
+// committer.
+type Signature struct {
+	Name  string
+	Email string
+	Time  time.Time // in the signer's time zone
+}
+
+// Field identifies a piece of information to read about each commit.
+type Field int
+
+// Fields that can be included in a [Format].
+const (
+	Hash           Field = iota // %H, sets Commit.Hash
+	ShortHash                   // %h, sets Commit.ShortHash
+	Tree                        // %T, sets Commit.Tree
+	Parents                     // %P, sets Commit.Parents
+	AuthorName                  // %an, sets Commit.Author.Name
+	AuthorEmail                 // %ae, sets Commit.Author.Email
+	AuthorDate                  // %aI, sets Commit.Author.Time
+	CommitterName               // %cn, sets Commit.Committer.Name
+	CommitterEmail              // %ce, sets Commit.Committer.Email
+	CommitterDate               // %cI, sets Commit.Committer.Time
+	Subject                     // %s, sets Commit.Subject
+	Body                        // %b, sets Commit.Body
+	Message                     // %B, sets Commit.Message
+	Refs                        // %D, sets Commit.Refs
+)
+
+var fieldInfo = [...]struct {
+	name        string
+	placeholder string
+}{
+	Hash:           {"hash", "%H"},
+	ShortHash:      {"short hash", "%h"},
+	Tree:           {"tree", "%T"},
+	Parents:        {"parents", "%P"},
+	AuthorName:     {"author name", "%an"},
+	AuthorEmail:    {"author email", "%ae"},
+	AuthorDate:     {"author date", "%aI"},
+	CommitterName:  {"committer name", "%cn"},
+	CommitterEmail: {"committer email", "%ce"},
+	CommitterDate:  {"committer date", "%cI"},
+	Subject:        {"subject", "%s"},
+	Body:           {"body", "%b"},
+	Message:        {"message", "%B"},
+	Refs:           {"refs", "%D"},
+}
+
+func (f Field) valid() bool {
+	return f >= 0 && int(f) < len(fieldInfo)
+}
+
+// String returns a human readable name for the field, e.g. "author date".
+func (f Field) String() string {
+	if !f.valid() {
+		return "unknown"
+	}
+	return fieldInfo[f].name
+}
+
+// Placeholder returns the `git log --format` placeholder for the field,
+// e.g. "%aI".
+func (f Field) Placeholder() string {
+	if !f.valid() {
+		return ""
+	}
+	return fieldInfo[f].placeholder
+}
+
+// Format is an ordered list of fields to read about each commit.
+type Format []Field
+
+// String returns the `git log --format` string for the fields, separated by
+// NUL bytes.
+//
+// Git must also be run with -z, which terminates each commit with a NUL byte,
+// for the output to be parsed; see [Format.Args].
+func (f Format) String() string {
+	placeholders := make([]string, len(f))
+	for i, field := range f {
+		placeholders[i] = field.Placeholder()
+	}
+	return strings.Join(placeholders, "%x00")
+}
+
+// Args returns the git arguments for reading commits in the format, followed
+// by args, which may contain revisions, paths and other `git log` options.
+func (f Format) Args(args ...string) []string {
+	return append([]string{"log", "-z", "--format=tformat:" + f.String()}, args...)
+}
+
+// Log runs `git log` with the format and args, and returns the commits read.
+func (f Format) Log(ctx context.Context, git gitexec.Runner, args ...string) ([]Commit, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	out, err := git.Run(ctx, f.Args(args...)...)
+	if err != nil {
+		return nil, err
+	}
+	return f.Parse(bytes.NewReader(out))
+}
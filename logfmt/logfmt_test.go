@@ -0,0 +1,83 @@
+package logfmt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormat_String(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{Format{Hash}, "%H"},
+		{Format{Hash, AuthorDate, Subject, Body}, "%H%x00%aI%x00%s%x00%b"},
+		{Format{Parents, Refs, Message}, "%P%x00%D%x00%B"},
+	}
+	for _, tt := range tests {
+		if got := tt.format.String(); got != tt.want {
+			t.Errorf("Format%v.String() = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestFormat_Args(t *testing.T) {
+	got := Format{Hash, Subject}.Args("-n", "5", "main")
+	want := []string{"log", "-z", "--format=tformat:%H%x00%s", "-n", "5", "main"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Args() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestField_String(t *testing.T) {
+	if got, want := CommitterEmail.String(), "committer email"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := Field(-1).String(), "unknown"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// fakeRunner is a gitexec.Runner returning canned results, recording the
+// arguments it was called with.
+type fakeRunner struct {
+	out  string
+	err  error
+	args []string
+}
+
+func (f *fakeRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+	f.args = args
+	return []byte(f.out), f.err
+}
+
+func TestFormat_Log(t *testing.T) {
+	git := &fakeRunner{out: "840f036c019297c7c4cef9942e09308f098c6ffa\x00Second\x00e7817be5f8c72c206d0de5bab5b0ae6da3e69c51\x00Topic\x00"}
+	format := Format{Hash, Subject}
+	got, err := format.Log(context.Background(), git, "--all")
+	if err != nil {
+		t.Fatalf("Log() error = %v", err)
+	}
+	want := []Commit{
+		{Hash: "840f036c019297c7c4cef9942e09308f098c6ffa", Subject: "Second"},
+		{Hash: "e7817be5f8c72c206d0de5bab5b0ae6da3e69c51", Subject: "Topic"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Log() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(format.Args("--all"), git.args); diff != "" {
+		t.Errorf("Log() args mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormat_Log_Empty(t *testing.T) {
+	git := &fakeRunner{}
+	if _, err := (Format{}).Log(context.Background(), git); err == nil {
+		t.Error("Log() with empty format succeeded, want error")
+	}
+	if git.args != nil {
+		t.Errorf("Log() with empty format ran git with %q", git.args)
+	}
+}
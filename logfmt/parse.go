@@ -0,0 +1,146 @@
+package logfmt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Parse parses all commits of `git log` output produced with [Format.Args].
+func (f Format) Parse(r io.Reader) ([]Commit, error) {
+	var commits []Commit
+	dec := f.NewDecoder(r)
+	for {
+		c, err := dec.Next()
+		if err == io.EOF {
+			return commits, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, c)
+	}
+}
+
+// A Decoder reads commits from a stream of `git log` output produced with
+// [Format.Args].
+type Decoder struct {
+	format Format
+	reader *bufio.Reader
+	err    error
+}
+
+// NewDecoder returns a new Decoder reading commits in the format from r.
+func (f Format) NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{format: f, reader: bufio.NewReader(r)}
+}
+
+// Next returns the next commit, or [io.EOF] when no commits remain.
+func (d *Decoder) Next() (Commit, error) {
+	if d.err != nil {
+		return Commit{}, d.err
+	}
+	c, err := d.read()
+	if err != nil {
+		d.err = err
+		return Commit{}, err
+	}
+	return c, nil
+}
+
+func (d *Decoder) read() (Commit, error) {
+	var c Commit
+	if err := d.format.validate(); err != nil {
+		return c, err
+	}
+	for i, field := range d.format {
+		value, err := d.reader.ReadString(0)
+		switch {
+		case err == io.EOF && i == 0 && value == "":
+			return c, io.EOF
+		case err == io.EOF && i == len(d.format)-1 && value != "":
+			// final record without terminator, e.g. from --format=format:
+		case err == io.EOF:
+			return c, fmt.Errorf("truncated log record: expected %d fields, got %d", len(d.format), i)
+		case err != nil:
+			return c, err
+		default:
+			value = value[:len(value)-1]
+		}
+		if err := setField(&c, field, value); err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
+
+func (f Format) validate() error {
+	if len(f) == 0 {
+		return errors.New("empty log format")
+	}
+	for _, field := range f {
+		if !field.valid() {
+			return fmt.Errorf("invalid log format field: %d", field)
+		}
+	}
+	return nil
+}
+
+func setField(c *Commit, field Field, value string) error {
+	switch field {
+	case Hash:
+		c.Hash = value
+	case ShortHash:
+		c.ShortHash = value
+	case Tree:
+		c.Tree = value
+	case Parents:
+		if value != "" {
+			c.Parents = strings.Fields(value)
+		}
+	case AuthorName:
+		c.Author.Name = value
+	case AuthorEmail:
+		c.Author.Email = value
+	case AuthorDate:
+		t, err := parseDate(field, value)
+		if err != nil {
+			return err
+		}
+		c.Author.Time = t
+	case CommitterName:
+		c.Committer.Name = value
+	case CommitterEmail:
+		c.Committer.Email = value
+	case CommitterDate:
+		t, err := parseDate(field, value)
+		if err != nil {
+			return err
+		}
+		c.Committer.Time = t
+	case Subject:
+		c.Subject = value
+	case Body:
+		c.Body = strings.TrimRight(value, "\n")
+	case Message:
+		c.Message = strings.TrimRight(value, "\n")
+	case Refs:
+		if value != "" {
+			c.Refs = strings.Split(value, ", ")
+		}
+	}
+	return nil
+}
+
+// parseDate parses a date in strict ISO 8601 format, e.g.
+// "2025-10-17T18:56:58+02:00".
+func parseDate(field Field, value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %q", field, value)
+	}
+	return t, nil
+}
@@ -0,0 +1,137 @@
+package logfmt
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Output of `git log` for a small history with a merge, a tag and a multiline
+// message, with fixed identities and dates.
+const sampleFormat = "%H%x00%h%x00%P%x00%an%x00%ae%x00%aI%x00%cI%x00%s%x00%b%x00%D"
+
+var sampleOutput = "7d9d2bcfca1f7b9a64a7d58ecb372d5b9d288317\x007d9d2bc\x00840f036c019297c7c4cef9942e09308f098c6ffa e7817be5f8c72c206d0de5bab5b0ae6da3e69c51\x00A U Thor\x00author@example.com\x002025-01-02T03:04:05+02:00\x002025-01-02T04:00:00-05:00\x00Merge branch 'topic'\x00\x00HEAD -> master\x00" +
+	"840f036c019297c7c4cef9942e09308f098c6ffa\x00840f036\x007ea692c6beb49bfdeff3865fdd772e036d71f31c\x00A U Thor\x00author@example.com\x002025-01-02T03:04:05+02:00\x002025-01-02T04:00:00-05:00\x00Second\x00\x00\x00" +
+	"e7817be5f8c72c206d0de5bab5b0ae6da3e69c51\x00e7817be\x007ea692c6beb49bfdeff3865fdd772e036d71f31c\x00A U Thor\x00author@example.com\x002025-01-02T03:04:05+02:00\x002025-01-02T04:00:00-05:00\x00Topic\x00\x00topic\x00" +
+	"7ea692c6beb49bfdeff3865fdd772e036d71f31c\x007ea692c\x00\x00A U Thor\x00author@example.com\x002025-01-02T03:04:05+02:00\x002025-01-02T04:00:00-05:00\x00First\x00Body line\n\nmore\n\x00tag: v1\x00"
+
+func TestFormat_Parse(t *testing.T) {
+	format := Format{Hash, ShortHash, Parents, AuthorName, AuthorEmail, AuthorDate, CommitterDate, Subject, Body, Refs}
+	if got := format.String(); got != sampleFormat {
+		t.Fatalf("format.String() = %q, want %q", got, sampleFormat)
+	}
+
+	author := Signature{
+		Name:  "A U Thor",
+		Email: "author@example.com",
+		Time:  time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("", 2*60*60)),
+	}
+	committed := Signature{Time: time.Date(2025, 1, 2, 4, 0, 0, 0, time.FixedZone("", -5*60*60))}
+	want := []Commit{
+		{
+			Hash:      "7d9d2bcfca1f7b9a64a7d58ecb372d5b9d288317",
+			ShortHash: "7d9d2bc",
+			Parents:   []string{"840f036c019297c7c4cef9942e09308f098c6ffa", "e7817be5f8c72c206d0de5bab5b0ae6da3e69c51"},
+			Author:    author,
+			Committer: committed,
+			Subject:   "Merge branch 'topic'",
+			Refs:      []string{"HEAD -> master"},
+		},
+		{
+			Hash:      "840f036c019297c7c4cef9942e09308f098c6ffa",
+			ShortHash: "840f036",
+			Parents:   []string{"7ea692c6beb49bfdeff3865fdd772e036d71f31c"},
+			Author:    author,
+			Committer: committed,
+			Subject:   "Second",
+		},
+		{
+			Hash:      "e7817be5f8c72c206d0de5bab5b0ae6da3e69c51",
+			ShortHash: "e7817be",
+			Parents:   []string{"7ea692c6beb49bfdeff3865fdd772e036d71f31c"},
+			Author:    author,
+			Committer: committed,
+			Subject:   "Topic",
+			Refs:      []string{"topic"},
+		},
+		{
+			Hash:      "7ea692c6beb49bfdeff3865fdd772e036d71f31c",
+			ShortHash: "7ea692c",
+			Author:    author,
+			Committer: committed,
+			Subject:   "First",
+			Body:      "Body line\n\nmore",
+			Refs:      []string{"tag: v1"},
+		},
+	}
+
+	got, err := format.Parse(strings.NewReader(sampleOutput))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormat_Parse_Unterminated(t *testing.T) {
+	// Output of --format=format:, which separates rather than terminates
+	// records, lacks a final NUL.
+	got, err := Format{Hash, Message}.Parse(strings.NewReader("abc\x00one\x00def\x00two\n\nbody"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []Commit{
+		{Hash: "abc", Message: "one"},
+		{Hash: "def", Message: "two\n\nbody"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormat_Parse_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+	}{
+		{"truncated record", Format{Hash, Subject, Body}, "abc\x00subject\x00body\x00def\x00"},
+		{"invalid date", Format{Hash, AuthorDate}, "abc\x002025-01-02 03:04:05 +0200\x00"},
+		{"empty format", Format{}, "abc\x00"},
+		{"unknown field", Format{Hash, Field(100)}, "abc\x00def\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.format.Parse(strings.NewReader(tt.input)); err == nil {
+				t.Error("Parse() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestDecoder_Empty(t *testing.T) {
+	dec := Format{Hash}.NewDecoder(strings.NewReader(""))
+	if _, err := dec.Next(); err != io.EOF {
+		t.Errorf("Next() error = %v, want io.EOF", err)
+	}
+}
+
+func TestDecoder_ErrorAfterValidCommit(t *testing.T) {
+	dec := Format{Hash, CommitterDate}.NewDecoder(strings.NewReader("abc\x002025-01-02T04:00:00Z\x00def\x00yesterday\x00"))
+	c, err := dec.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if c.Hash != "abc" {
+		t.Errorf("Next() Hash = %q, want %q", c.Hash, "abc")
+	}
+	for range 2 {
+		if _, err := dec.Next(); err == nil || err == io.EOF {
+			t.Errorf("Next() error = %v, want parse error", err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Package lsp translates status entries and diffs into the payloads of the
+Language Server Protocol and of editor decoration APIs, so that language
+servers and editor extensions can show the status of files without a protocol
+layer of their own.
+
+# File Events
+
+[FileEvents] classifies the changes between two statuses, as returned by
+[statusv2.Diff], as created, changed or deleted files, in the shape of the
+workspace/didChangeWatchedFiles notification:
+
+	params := lsp.DidChangeWatchedFilesParams{
+	    Changes: lsp.FileEvents(root, statusv2.Diff(old, new)),
+	}
+
+A status only shows files that differ from HEAD, so a file that was clean in
+one status has no entry there, and whether it exists on disk is inferred from
+the other: an untracked file appearing from nowhere was created, and one
+disappearing was deleted, while a file deleted in the worktree and then clean
+is only known to have changed, as it may have been restored or its deletion
+committed.
+
+# Decorations
+
+[Decorations] returns a badge letter, tooltip and color for each entry of a
+status, following the conventions of the git integration of VS Code: "M" for
+modified, "A" for added, "D" for deleted, "R" for renamed, "U" for untracked,
+"I" for ignored and "!" for conflicts. Colors are the identifiers of theme
+colors, such as "gitDecoration.modifiedResourceForeground", which editors
+other than VS Code can map to their own.
+
+# URIs
+
+Both identify files by URI, built by [URI] from the absolute path of the root
+of the repository, where git ran, and the path of an entry. Paths must be
+unquoted, as from [statusv2.ParseZ] or [statusv2.UnquotePaths].
+*/
+package lsp
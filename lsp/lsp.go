@@ -0,0 +1,222 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// FileChangeType is the kind of a [FileEvent], with the values of the
+// protocol.
+type FileChangeType int
+
+// File change types.
+const (
+	Created FileChangeType = 1 // file was created
+	Changed FileChangeType = 2 // file was changed
+	Deleted FileChangeType = 3 // file was deleted
+)
+
+// String returns the name of the type, such as "created".
+func (t FileChangeType) String() string {
+	switch t {
+	case Created:
+		return "created"
+	case Changed:
+		return "changed"
+	case Deleted:
+		return "deleted"
+	}
+	return "unknown"
+}
+
+// A FileEvent is an event of a file of the workspace, as in the
+// workspace/didChangeWatchedFiles notification.
+type FileEvent struct {
+	URI  string         `json:"uri"`
+	Type FileChangeType `json:"type"`
+}
+
+// DidChangeWatchedFilesParams are the parameters of the
+// workspace/didChangeWatchedFiles notification.
+type DidChangeWatchedFilesParams struct {
+	Changes []FileEvent `json:"changes"`
+}
+
+// FileEvents returns an event for each of changes, in the same order, with
+// paths relative to the repository at root.
+func FileEvents(root string, changes []statusv2.Change) []FileEvent {
+	events := make([]FileEvent, len(changes))
+	for i, c := range changes {
+		events[i] = FileEvent{URI: URI(root, c.Path), Type: changeType(c)}
+	}
+	return events
+}
+
+// changeType classifies a change by whether its file is in the worktree
+// before and after, as far as its entries tell.
+func changeType(c statusv2.Change) FileChangeType {
+	before, beforeKnown := inWorktree(c.Old)
+	after, afterKnown := inWorktree(c.New)
+	if !beforeKnown && c.New != nil {
+		// The file was clean, so existed, unless new.
+		before, beforeKnown = !isNew(c.New), true
+	}
+	if !afterKnown && c.Old != nil && (before || isUntracked(c.Old)) {
+		// The file is clean, so exists, unless it was untracked. A file
+		// deleted before may have been restored, or its deletion committed.
+		after, afterKnown = !isUntracked(c.Old), true
+	}
+	switch {
+	case !beforeKnown || !afterKnown || before == after:
+		return Changed
+	case after:
+		return Created
+	}
+	return Deleted
+}
+
+// inWorktree reports whether the file of e is in the worktree, and whether
+// that is known, which it is not for a nil entry: the file is either clean or
+// does not exist.
+func inWorktree(e statusv2.Entry) (present, known bool) {
+	switch e := e.(type) {
+	case statusv2.ChangedEntry:
+		return e.ModeW != statusv2.FileModeEmpty, true
+	case statusv2.RenameOrCopyEntry:
+		return e.ModeW != statusv2.FileModeEmpty, true
+	case statusv2.UnmergedEntry:
+		return e.ModeW != statusv2.FileModeEmpty, true
+	case statusv2.UntrackedEntry, statusv2.IgnoredEntry:
+		return true, true
+	}
+	return false, false
+}
+
+// isNew reports whether e is for a file not in HEAD, so that it did not exist
+// if it had no entry before.
+func isNew(e statusv2.Entry) bool {
+	switch e := e.(type) {
+	case statusv2.ChangedEntry:
+		return e.IsNewFile()
+	case statusv2.RenameOrCopyEntry:
+		return true
+	}
+	return isUntracked(e)
+}
+
+// isUntracked reports whether e is for an untracked or ignored file, so that
+// it no longer exists if it has no entry after.
+func isUntracked(e statusv2.Entry) bool {
+	switch e.(type) {
+	case statusv2.UntrackedEntry, statusv2.IgnoredEntry:
+		return true
+	}
+	return false
+}
+
+// A Decoration describes how an editor shows the status of a file, as a badge
+// next to its name.
+type Decoration struct {
+	URI     string `json:"uri"`
+	Badge   string `json:"badge"`           // single letter, such as "M"
+	Tooltip string `json:"tooltip"`         // description, such as "Index Added"
+	Color   string `json:"color,omitempty"` // theme color identifier
+}
+
+// Theme color identifiers of decorations, as defined by VS Code.
+const (
+	ColorAdded         = "gitDecoration.addedResourceForeground"
+	ColorModified      = "gitDecoration.modifiedResourceForeground"
+	ColorDeleted       = "gitDecoration.deletedResourceForeground"
+	ColorRenamed       = "gitDecoration.renamedResourceForeground"
+	ColorUntracked     = "gitDecoration.untrackedResourceForeground"
+	ColorIgnored       = "gitDecoration.ignoredResourceForeground"
+	ColorConflicting   = "gitDecoration.conflictingResourceForeground"
+	ColorStageModified = "gitDecoration.stageModifiedResourceForeground"
+	ColorStageDeleted  = "gitDecoration.stageDeletedResourceForeground"
+)
+
+// Decorations returns the decoration of each entry of s, in order, with paths
+// relative to the repository at root.
+func Decorations(root string, s *statusv2.Status) []Decoration {
+	if s == nil {
+		return nil
+	}
+	decorations := make([]Decoration, 0, len(s.Entries))
+	for _, e := range s.Entries {
+		d, path := decorate(e)
+		d.URI = URI(root, path)
+		decorations = append(decorations, d)
+	}
+	return decorations
+}
+
+// decorate returns the decoration of e, without its URI, and its path.
+func decorate(e statusv2.Entry) (Decoration, string) {
+	switch e := e.(type) {
+	case statusv2.ChangedEntry:
+		return decorateXY(e.XY), e.Path
+	case statusv2.RenameOrCopyEntry:
+		return decorateXY(e.XY), e.Path
+	case statusv2.UnmergedEntry:
+		return Decoration{Badge: "!", Tooltip: "Conflict: " + e.Kind().String(), Color: ColorConflicting}, e.Path
+	case statusv2.UntrackedEntry:
+		return Decoration{Badge: "U", Tooltip: "Untracked", Color: ColorUntracked}, e.Path
+	case statusv2.IgnoredEntry:
+		return Decoration{Badge: "I", Tooltip: "Ignored", Color: ColorIgnored}, e.Path
+	}
+	return Decoration{}, ""
+}
+
+// decorateXY returns the decoration of a tracked file, for its change in the
+// worktree if it has one, as that is what the editor shows, or else for its
+// change in the index.
+func decorateXY(xy statusv2.XYFlag) Decoration {
+	if xy.Y != statusv2.Unmodified {
+		return decorateState(xy.Y, false)
+	}
+	return decorateState(xy.X, true)
+}
+
+func decorateState(st statusv2.State, index bool) Decoration {
+	d := Decoration{Badge: string(st)}
+	switch st {
+	case statusv2.Modified:
+		d.Tooltip, d.Color = "Modified", ColorModified
+	case statusv2.TypeChanged:
+		d.Tooltip, d.Color = "Type Changed", ColorModified
+	case statusv2.Added:
+		d.Tooltip, d.Color = "Added", ColorAdded
+	case statusv2.Deleted:
+		d.Tooltip, d.Color = "Deleted", ColorDeleted
+	case statusv2.Renamed:
+		d.Tooltip, d.Color = "Renamed", ColorRenamed
+	case statusv2.Copied:
+		d.Tooltip, d.Color = "Copied", ColorAdded
+	default:
+		d.Tooltip = "Changed"
+	}
+	if index {
+		d.Tooltip = "Index " + d.Tooltip
+		switch d.Color {
+		case ColorModified:
+			d.Color = ColorStageModified
+		case ColorDeleted:
+			d.Color = ColorStageDeleted
+		}
+	}
+	return d
+}
+
+// URI returns the file URI of path, relative to the repository at root, which
+// must be absolute.
+func URI(root, path string) string {
+	p := filepath.ToSlash(filepath.Join(root, filepath.FromSlash(path)))
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p // a Windows path, starting with a drive letter
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
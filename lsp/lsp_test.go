@@ -0,0 +1,102 @@
+package lsp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+func TestURI(t *testing.T) {
+	tests := []struct {
+		root, path, want string
+	}{
+		{"/home/me/repo", "main.go", "file:///home/me/repo/main.go"},
+		{"/home/me/repo", "dir/sub file.go", "file:///home/me/repo/dir/sub%20file.go"},
+		{"/home/me/repo", "new/", "file:///home/me/repo/new"},
+		{"/home/me/repo/", "café.txt", "file:///home/me/repo/caf%C3%A9.txt"},
+	}
+	for _, tt := range tests {
+		if got := URI(tt.root, tt.path); got != tt.want {
+			t.Errorf("URI(%q, %q) = %q, want %q", tt.root, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestFileEvents(t *testing.T) {
+	modified := statusv2.ChangedEntry{XY: statusv2.XYFlag{X: statusv2.Unmodified, Y: statusv2.Modified}, ModeW: statusv2.FileModeRegular, Path: "f"}
+	deleted := statusv2.ChangedEntry{XY: statusv2.XYFlag{X: statusv2.Unmodified, Y: statusv2.Deleted}, Path: "f"}
+	staged := statusv2.ChangedEntry{XY: statusv2.XYFlag{X: statusv2.Modified, Y: statusv2.Unmodified}, ModeW: statusv2.FileModeRegular, Path: "f"}
+	added := statusv2.ChangedEntry{XY: statusv2.XYFlag{X: statusv2.Added, Y: statusv2.Unmodified}, ModeW: statusv2.FileModeRegular, Path: "f"}
+	untracked := statusv2.UntrackedEntry{Path: "f"}
+	ignored := statusv2.IgnoredEntry{Path: "f"}
+
+	tests := []struct {
+		name     string
+		old, new statusv2.Entry
+		want     FileChangeType
+	}{
+		{"new untracked", nil, untracked, Created},
+		{"new ignored", nil, ignored, Created},
+		{"new and added", nil, added, Created},
+		{"untracked removed", untracked, nil, Deleted},
+		{"deleted", modified, deleted, Deleted},
+		{"deleted clean", nil, deleted, Deleted},
+		{"restored", deleted, modified, Created},
+		{"modified", nil, modified, Changed},
+		{"committed", modified, nil, Changed},
+		{"restored clean", deleted, nil, Changed},
+		{"staged", modified, staged, Changed},
+		{"untracked added", untracked, added, Changed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := FileEvents("/repo", []statusv2.Change{{Path: "f", Old: tt.old, New: tt.new}})
+			want := []FileEvent{{URI: "file:///repo/f", Type: tt.want}}
+			if diff := cmp.Diff(want, events); diff != "" {
+				t.Errorf("FileEvents() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecorations(t *testing.T) {
+	s := &statusv2.Status{Entries: []statusv2.Entry{
+		statusv2.ChangedEntry{XY: statusv2.XYFlag{X: statusv2.Modified, Y: statusv2.Unmodified}, Path: "staged.go"},
+		statusv2.ChangedEntry{XY: statusv2.XYFlag{X: statusv2.Added, Y: statusv2.Modified}, Path: "both.go"},
+		statusv2.ChangedEntry{XY: statusv2.XYFlag{X: statusv2.Unmodified, Y: statusv2.Deleted}, Path: "gone.go"},
+		statusv2.ChangedEntry{XY: statusv2.XYFlag{X: statusv2.Deleted, Y: statusv2.Unmodified}, Path: "removed.go"},
+		statusv2.RenameOrCopyEntry{XY: statusv2.XYFlag{X: statusv2.Renamed, Y: statusv2.Unmodified}, Path: "new.go", Orig: "old.go"},
+		statusv2.UnmergedEntry{XY: statusv2.XYFlag{X: statusv2.UpdatedUnmerged, Y: statusv2.UpdatedUnmerged}, Path: "conflict.go"},
+		statusv2.UntrackedEntry{Path: "scratch/"},
+		statusv2.IgnoredEntry{Path: "debug.log"},
+	}}
+	want := []Decoration{
+		{URI: "file:///repo/staged.go", Badge: "M", Tooltip: "Index Modified", Color: ColorStageModified},
+		{URI: "file:///repo/both.go", Badge: "M", Tooltip: "Modified", Color: ColorModified},
+		{URI: "file:///repo/gone.go", Badge: "D", Tooltip: "Deleted", Color: ColorDeleted},
+		{URI: "file:///repo/removed.go", Badge: "D", Tooltip: "Index Deleted", Color: ColorStageDeleted},
+		{URI: "file:///repo/new.go", Badge: "R", Tooltip: "Index Renamed", Color: ColorRenamed},
+		{URI: "file:///repo/conflict.go", Badge: "!", Tooltip: "Conflict: both modified", Color: ColorConflicting},
+		{URI: "file:///repo/scratch", Badge: "U", Tooltip: "Untracked", Color: ColorUntracked},
+		{URI: "file:///repo/debug.log", Badge: "I", Tooltip: "Ignored", Color: ColorIgnored},
+	}
+	if diff := cmp.Diff(want, Decorations("/repo", s)); diff != "" {
+		t.Errorf("Decorations() mismatch (-want +got):\n%s", diff)
+	}
+	if got := Decorations("/repo", nil); got != nil {
+		t.Errorf("Decorations(nil) = %v, want nil", got)
+	}
+}
+
+func TestDidChangeWatchedFilesParams_JSON(t *testing.T) {
+	params := DidChangeWatchedFilesParams{Changes: []FileEvent{{URI: "file:///repo/a", Type: Deleted}}}
+	b, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"changes":[{"uri":"file:///repo/a","type":3}]}`; string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+}
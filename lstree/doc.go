@@ -0,0 +1,41 @@
+/*
+Package lstree parses the output of `git ls-tree -z`.
+
+`git ls-tree` lists the contents of a tree object, which makes it the basic
+building block for browsing a repository at any commit without a working
+tree.
+
+# Basic Usage
+
+[Parse] takes an [io.Reader] containing `git ls-tree -z` output:
+
+	r := bytes.NewReader(gitLsTreeOutput)
+	entries, err := lstree.Parse(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, e := range entries {
+	    fmt.Printf("%s %s %s\n", e.Type, e.Object, e.Path)
+	}
+
+Output from recursive listings (-r, optionally with -t to include the trees
+being recursed into) and from long listings (-l, --long, which include object
+sizes) is supported. Paths are always relative to the root of the listed tree,
+or to the current directory when run from a subdirectory without --full-name.
+
+# Git Ls-Tree Format
+
+Only the NUL-terminated (-z) output is supported, as paths are quoted
+otherwise. In this format each entry is:
+
+	<mode> SP <type> SP <object> TAB <path> NUL
+	<mode> SP <type> SP <object> SP+ <size> TAB <path> NUL   (with -l)
+
+where size is right-aligned and "-" for trees and submodules. Output from
+--name-only, --object-only or a custom --format is not supported.
+
+For more information, see the Git documentation for [git ls-tree].
+
+[git ls-tree]: https://git-scm.com/docs/git-ls-tree#_output_format
+*/
+package lstree
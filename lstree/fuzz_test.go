@@ -0,0 +1,23 @@
+package lstree
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// Fuzz test checking that parsing arbitrary output never panics.
+func FuzzParse(f *testing.F) {
+	// Add some seed inputs
+	fuzzseed.Add[[]byte](f, "lstree")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("Parse panicked with input %q: %v", data, r)
+			}
+		}()
+		_, _ = Parse(bytes.NewReader(data))
+	})
+}
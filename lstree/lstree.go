@@ -0,0 +1,48 @@
+package lstree
+
+import "strconv"
+
+// Entry represents a single entry of a tree listing.
+//
+// The Size of an entry is only listed with -l, and only for blobs. For trees,
+// submodule commits, and all entries of listings without -l, it is -1.
+type Entry struct {
+	Mode   FileMode   // file mode of the entry
+	Type   ObjectType // type of the object the entry points to
+	Object string     // object name
+	Size   int64      // object size in bytes, or -1 if not listed
+	Path   string     // path of the entry
+}
+
+// IsDir reports whether the entry is a tree (directory).
+func (e Entry) IsDir() bool {
+	return e.Type == TypeTree
+}
+
+// ObjectType is the type of the object a tree entry points to.
+type ObjectType string
+
+// Object types found in trees.
+const (
+	TypeBlob   ObjectType = "blob"   // file or symlink
+	TypeTree   ObjectType = "tree"   // directory
+	TypeCommit ObjectType = "commit" // submodule
+)
+
+// A FileMode represents the kind of tree entries used by git.
+type FileMode uint32
+
+// Common FileMode values.
+const (
+	FileModeDir        FileMode = 0040000
+	FileModeRegular    FileMode = 0100644
+	FileModeExecutable FileMode = 0100755
+	FileModeSymlink    FileMode = 0120000
+	FileModeSubmodule  FileMode = 0160000
+)
+
+// String returns the octal string representation of the FileMode, e.g. "100644".
+// Note that this is different from Go octal formatting, which uses a leading "0".
+func (m FileMode) String() string {
+	return strconv.FormatUint(uint64(m), 8)
+}
@@ -0,0 +1,64 @@
+package lstree
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Parse parses the output of `git ls-tree -z`, with or without the -r, -t and
+// -l flags.
+//
+// Path Handling: In -z format, Git does not quote paths containing special
+// characters, so all paths are provided as-is.
+func Parse(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := newZScanner(r)
+	for scanner.Scan() {
+		e, err := parseEntry(scanner.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Entries have the following format:
+// <mode> SP <type> SP <object> [SP+ <size>] TAB <path>
+func parseEntry(field []byte) (Entry, error) {
+	var zero Entry
+	meta, path, found := bytes.Cut(field, []byte{'\t'})
+	if !found || len(path) == 0 {
+		return zero, fmt.Errorf("invalid ls-tree entry: %q", field)
+	}
+	parts := bytes.Fields(meta)
+	if len(parts) != 3 && len(parts) != 4 {
+		return zero, fmt.Errorf("invalid ls-tree entry: expected 3 or 4 fields before path, got %d: %q", len(parts), field)
+	}
+
+	mode, err := strconv.ParseUint(string(parts[0]), 8, 32)
+	if err != nil {
+		return zero, fmt.Errorf("invalid ls-tree entry mode %q: %w", parts[0], err)
+	}
+
+	size := int64(-1)
+	if len(parts) == 4 && !bytes.Equal(parts[3], []byte{'-'}) {
+		size, err = strconv.ParseInt(string(parts[3]), 10, 64)
+		if err != nil || size < 0 {
+			return zero, fmt.Errorf("invalid ls-tree entry size: %q", parts[3])
+		}
+	}
+
+	return Entry{
+		Mode:   FileMode(mode),
+		Type:   ObjectType(parts[1]),
+		Object: string(parts[2]),
+		Size:   size,
+		Path:   string(path),
+	}, nil
+}
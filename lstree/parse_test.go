@@ -0,0 +1,108 @@
+package lstree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Entry
+	}{
+		{
+			name:  "top level",
+			input: "040000 tree 76f79c34ac7ecb422730276d7a9b330c02d2ff2c\tdir\x00120000 blob e0e63473c2593040d7d1c67637864821b28cef4b\tlink\x00160000 commit 7ea692c6beb49bfdeff3865fdd772e036d71f31c\tmod\x00100755 blob 1a2485251c33a70432394c93fb89330ef214bfc9\trun.sh\x00100644 blob ce013625030ba8dba906f756967f9e9ca394464a\ttab\tname.txt\x00",
+			want: []Entry{
+				{Mode: FileModeDir, Type: TypeTree, Object: "76f79c34ac7ecb422730276d7a9b330c02d2ff2c", Size: -1, Path: "dir"},
+				{Mode: FileModeSymlink, Type: TypeBlob, Object: "e0e63473c2593040d7d1c67637864821b28cef4b", Size: -1, Path: "link"},
+				{Mode: FileModeSubmodule, Type: TypeCommit, Object: "7ea692c6beb49bfdeff3865fdd772e036d71f31c", Size: -1, Path: "mod"},
+				{Mode: FileModeExecutable, Type: TypeBlob, Object: "1a2485251c33a70432394c93fb89330ef214bfc9", Size: -1, Path: "run.sh"},
+				{Mode: FileModeRegular, Type: TypeBlob, Object: "ce013625030ba8dba906f756967f9e9ca394464a", Size: -1, Path: "tab\tname.txt"},
+			},
+		},
+		{
+			name:  "recursive long listing with trees",
+			input: "040000 tree 76f79c34ac7ecb422730276d7a9b330c02d2ff2c       -\tdir\x00040000 tree a1dffc7a64c0b2d395484bf452e9aeb1da3a18f2       -\tdir/sub\x00100644 blob 587be6b4c3f93f93c489c0111bba5596147a26cb       2\tdir/sub/f\x00120000 blob e0e63473c2593040d7d1c67637864821b28cef4b       6\tlink\x00160000 commit 7ea692c6beb49bfdeff3865fdd772e036d71f31c       -\tmod\x00100755 blob 1a2485251c33a70432394c93fb89330ef214bfc9      10\trun.sh\x00100644 blob ce013625030ba8dba906f756967f9e9ca394464a       6\ttab\tname.txt\x00",
+			want: []Entry{
+				{Mode: FileModeDir, Type: TypeTree, Object: "76f79c34ac7ecb422730276d7a9b330c02d2ff2c", Size: -1, Path: "dir"},
+				{Mode: FileModeDir, Type: TypeTree, Object: "a1dffc7a64c0b2d395484bf452e9aeb1da3a18f2", Size: -1, Path: "dir/sub"},
+				{Mode: FileModeRegular, Type: TypeBlob, Object: "587be6b4c3f93f93c489c0111bba5596147a26cb", Size: 2, Path: "dir/sub/f"},
+				{Mode: FileModeSymlink, Type: TypeBlob, Object: "e0e63473c2593040d7d1c67637864821b28cef4b", Size: 6, Path: "link"},
+				{Mode: FileModeSubmodule, Type: TypeCommit, Object: "7ea692c6beb49bfdeff3865fdd772e036d71f31c", Size: -1, Path: "mod"},
+				{Mode: FileModeExecutable, Type: TypeBlob, Object: "1a2485251c33a70432394c93fb89330ef214bfc9", Size: 10, Path: "run.sh"},
+				{Mode: FileModeRegular, Type: TypeBlob, Object: "ce013625030ba8dba906f756967f9e9ca394464a", Size: 6, Path: "tab\tname.txt"},
+			},
+		},
+		{
+			name:  "large size",
+			input: "100644 blob 587be6b4c3f93f93c489c0111bba5596147a26cb 123456789012\tbig.bin\x00",
+			want: []Entry{
+				{Mode: FileModeRegular, Type: TypeBlob, Object: "587be6b4c3f93f93c489c0111bba5596147a26cb", Size: 123456789012, Path: "big.bin"},
+			},
+		},
+		{
+			name:  "missing final terminator",
+			input: "100644 blob 587be6b4c3f93f93c489c0111bba5596147a26cb\tf",
+			want: []Entry{
+				{Mode: FileModeRegular, Type: TypeBlob, Object: "587be6b4c3f93f93c489c0111bba5596147a26cb", Size: -1, Path: "f"},
+			},
+		},
+		{
+			name:  "empty tree",
+			input: "",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"name only", "dir\x00link\x00"},
+		{"missing path", "100644 blob 587be6b4c3f93f93c489c0111bba5596147a26cb\t\x00"},
+		{"missing object", "100644 blob\tf\x00"},
+		{"too many fields", "100644 blob 587be6b4 2 3\tf\x00"},
+		{"invalid mode", "100x44 blob 587be6b4c3f93f93c489c0111bba5596147a26cb\tf\x00"},
+		{"invalid size", "100644 blob 587be6b4c3f93f93c489c0111bba5596147a26cb two\tf\x00"},
+		{"negative size", "100644 blob 587be6b4c3f93f93c489c0111bba5596147a26cb -2\tf\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.input)); err == nil {
+				t.Error("Parse() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestEntry_IsDir(t *testing.T) {
+	if !(Entry{Type: TypeTree}).IsDir() {
+		t.Error("tree IsDir() = false, want true")
+	}
+	if (Entry{Type: TypeCommit}).IsDir() {
+		t.Error("commit IsDir() = true, want false")
+	}
+}
+
+func TestFileMode_String(t *testing.T) {
+	if got, want := FileModeDir.String(), "40000"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
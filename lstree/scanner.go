@@ -0,0 +1,16 @@
+package lstree
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/mroth/porcelain/zscan"
+)
+
+// newZScanner creates a scanner that tokenizes NUL-terminated output,
+// returning each entry as a token, omitting the NUL terminator.
+func newZScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(zscan.ScanNUL)
+	return scanner
+}
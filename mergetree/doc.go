@@ -0,0 +1,56 @@
+/*
+Package mergetree parses the output of `git merge-tree --write-tree -z`.
+
+Since Git v2.38.0, `git merge-tree --write-tree` performs a real merge of two
+commits without touching the index or working tree, writing the resulting
+tree to the object database and reporting any conflicts in a machine-readable
+format. This makes it well suited for server-side merge services.
+
+# Basic Usage
+
+[Parse] takes an [io.Reader] containing `git merge-tree --write-tree -z`
+output:
+
+	r := bytes.NewReader(gitMergeTreeOutput)
+	result, err := mergetree.Parse(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+[ParseNameOnly] provides a variant that will work with output from the
+--name-only flag, where conflicted files are listed by path alone.
+
+# Working with Results
+
+The [Result] struct contains the OID of the resulting toplevel tree, the list
+of conflicted index entries (one per path and stage), and the informational
+messages emitted by the merge machinery:
+
+	if !result.Clean() {
+	    for _, c := range result.Conflicts {
+	        fmt.Printf("%s stage %d: %s\n", c.Path, c.Stage, c.Object)
+	    }
+	}
+	for _, m := range result.Messages {
+	    fmt.Printf("%s %v\n", m.Type, m.Paths)
+	}
+
+Note that git exits with status 1 when the merge has conflicts, which callers
+executing git themselves will want to distinguish from a real failure.
+
+# Git Merge-Tree Format
+
+Only the NUL-terminated (-z) output is supported, as the informational
+messages section is not machine-parseable without it. In this format the
+output is:
+
+	<OID of toplevel tree> NUL
+	<mode> SP <object> SP <stage> TAB <path> NUL   (repeated, per conflicted stage)
+	NUL                                            (if messages are shown)
+	<count> NUL <path>... NUL <type> NUL <message> NUL   (repeated)
+
+For more information, see the Git documentation for [git merge-tree].
+
+[git merge-tree]: https://git-scm.com/docs/git-merge-tree#OUTPUT
+*/
+package mergetree
@@ -0,0 +1,27 @@
+package mergetree
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// Fuzz test for Parse and ParseNameOnly functions
+func FuzzParse(f *testing.F) {
+	// Add some seed inputs
+	f.Add([]byte(sampleMergeTreeOutput))
+	f.Add([]byte(sampleMergeTreeNameOnlyOutput))
+	fuzzseed.Add[[]byte](f, "mergetree")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Parser should never panic, only return an error for invalid input
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("Parse panicked with input %q: %v", data, r)
+			}
+		}()
+		Parse(bytes.NewReader(data))
+		ParseNameOnly(bytes.NewReader(data))
+	})
+}
@@ -0,0 +1,91 @@
+package mergetree
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Result represents parsed git merge-tree --write-tree -z output.
+//
+// Tree is always present. Conflicts and Messages are empty for a clean merge,
+// unless --messages was explicitly requested, in which case informational
+// messages such as "Auto-merging" may be present even without conflicts.
+type Result struct {
+	Tree      string           // OID of the toplevel tree written by the merge
+	Conflicts []ConflictedFile // conflicted index entries, in the order they appeared
+	Messages  []Message        // informational messages, in the order they appeared
+}
+
+// Clean reports whether the merge completed without conflicted files.
+func (r *Result) Clean() bool { return len(r.Conflicts) == 0 }
+
+// ConflictedPaths returns the unique paths of all conflicted files, in the
+// order they first appeared.
+func (r *Result) ConflictedPaths() []string {
+	var paths []string
+	for i, c := range r.Conflicts {
+		// entries for the same path are emitted adjacently, one per stage
+		if i > 0 && r.Conflicts[i-1].Path == c.Path {
+			continue
+		}
+		paths = append(paths, c.Path)
+	}
+	return paths
+}
+
+// Stage identifies the index stage of a conflicted file entry.
+type Stage int
+
+// Index stages for conflicted entries.
+const (
+	StageBase   Stage = 1 // common ancestor version
+	StageOurs   Stage = 2 // version from the first commit
+	StageTheirs Stage = 3 // version from the second commit
+)
+
+// A FileMode represents the kind of tree entries used by git.
+type FileMode uint32
+
+// String returns the octal string representation of the FileMode, e.g. "100644".
+func (m FileMode) String() string {
+	return strconv.FormatUint(uint64(m), 8)
+}
+
+// ConflictedFile represents a single index entry for a conflicted file.
+//
+// A conflicted path usually appears up to three times, once for each stage in
+// which it exists. When parsed with [ParseNameOnly], only the Path is set and
+// each path appears exactly once.
+type ConflictedFile struct {
+	Mode   FileMode // file mode of the entry at this stage
+	Object string   // object hash of the entry at this stage
+	Stage  Stage    // index stage (1, 2, or 3)
+	Path   string   // file path relative to repository root
+}
+
+// Well-known message types emitted by the merge machinery. This is not an
+// exhaustive list; Git documents the set as stable but may add new types.
+const (
+	TypeAutoMerging     = "Auto-merging"
+	TypeContents        = "CONFLICT (contents)"
+	TypeBinary          = "CONFLICT (binary)"
+	TypeFileDirectory   = "CONFLICT (file/directory)"
+	TypeDistinctModes   = "CONFLICT (distinct modes)"
+	TypeModifyDelete    = "CONFLICT (modify/delete)"
+	TypeRenameRename    = "CONFLICT (rename/rename)"
+	TypeRenameDelete    = "CONFLICT (rename/delete)"
+	TypeRenameCollision = "CONFLICT (rename involved in collision)"
+)
+
+// Message represents an informational message about the merge.
+type Message struct {
+	Paths []string // paths (or branch names) affected by the message
+	Type  string   // stable short description, e.g. "CONFLICT (contents)"
+	Text  string   // human-readable message, without trailing newline
+}
+
+// IsConflict reports whether the message describes a conflict, rather than
+// being purely informational.
+func (m Message) IsConflict() bool {
+	return strings.HasPrefix(m.Type, "CONFLICT")
+}
@@ -0,0 +1,63 @@
+package mergetree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestResult_Clean(t *testing.T) {
+	clean := Result{Tree: "40c8625c41bf829e588d6bb11d2f369b829cf84a"}
+	if !clean.Clean() {
+		t.Errorf("Clean() = false for result without conflicts")
+	}
+
+	conflicted := Result{
+		Tree:      "40c8625c41bf829e588d6bb11d2f369b829cf84a",
+		Conflicts: []ConflictedFile{{Path: "greeting"}},
+	}
+	if conflicted.Clean() {
+		t.Errorf("Clean() = true for result with conflicts")
+	}
+}
+
+func TestResult_ConflictedPaths(t *testing.T) {
+	r := Result{
+		Conflicts: []ConflictedFile{
+			{Stage: StageBase, Path: "del"},
+			{Stage: StageTheirs, Path: "del"},
+			{Stage: StageBase, Path: "greeting"},
+			{Stage: StageOurs, Path: "greeting"},
+			{Stage: StageTheirs, Path: "greeting"},
+			{Stage: StageOurs, Path: "z"},
+		},
+	}
+	want := []string{"del", "greeting", "z"}
+	if got := r.ConflictedPaths(); !slices.Equal(got, want) {
+		t.Errorf("ConflictedPaths() = %q, want %q", got, want)
+	}
+}
+
+func TestMessage_IsConflict(t *testing.T) {
+	testcases := []struct {
+		typ  string
+		want bool
+	}{
+		{TypeAutoMerging, false},
+		{TypeContents, true},
+		{TypeModifyDelete, true},
+		{"CONFLICT(directory rename collision)", true},
+		{"Path updated due to directory rename", false},
+	}
+
+	for _, tc := range testcases {
+		if got := (Message{Type: tc.typ}).IsConflict(); got != tc.want {
+			t.Errorf("Message{Type: %q}.IsConflict() = %v, want %v", tc.typ, got, tc.want)
+		}
+	}
+}
+
+func TestFileMode_String(t *testing.T) {
+	if got := FileMode(0100644).String(); got != "100644" {
+		t.Errorf("String() = %q, want %q", got, "100644")
+	}
+}
@@ -0,0 +1,158 @@
+package mergetree
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Parse parses the output of `git merge-tree --write-tree -z`.
+//
+// The conflicted file info section is expected to contain full entries of the
+// form `<mode> <object> <stage>\t<path>`. For output produced with the
+// --name-only flag, use [ParseNameOnly] instead.
+//
+// Path Handling: In -z format, Git does not quote paths containing special
+// characters, so all paths are provided as-is.
+func Parse(r io.Reader) (*Result, error) {
+	return parse(newZScanner(r), parseConflictedFile)
+}
+
+// ParseNameOnly parses the output of `git merge-tree --write-tree -z --name-only`.
+//
+// The returned conflicted files only have their Path field set.
+func ParseNameOnly(r io.Reader) (*Result, error) {
+	return parse(newZScanner(r), parseConflictedName)
+}
+
+// Core parsing function that reads NUL-terminated fields from the provided
+// scanner and constructs the Result struct. The provided parseConflict func is
+// used to parse each field of the conflicted file info section.
+func parse(scanner *bufio.Scanner, parseConflict func([]byte) (ConflictedFile, error)) (*Result, error) {
+	var res Result
+
+	// The toplevel tree OID is always the first field.
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("missing toplevel tree OID")
+	}
+	res.Tree = string(scanner.Bytes())
+	if res.Tree == "" {
+		return nil, errors.New("missing toplevel tree OID")
+	}
+
+	// Conflicted file info follows, until an empty field marks the start of
+	// the informational messages section (or the output ends).
+	for scanner.Scan() {
+		field := scanner.Bytes()
+		if len(field) == 0 {
+			return parseMessages(scanner, &res)
+		}
+		c, err := parseConflict(field)
+		if err != nil {
+			return nil, err
+		}
+		res.Conflicts = append(res.Conflicts, c)
+	}
+	return &res, scanner.Err()
+}
+
+// Each message record has the following format, where every field is
+// terminated by NUL:
+// <count> <path1> ... <pathN> <type> <message>
+func parseMessages(scanner *bufio.Scanner, res *Result) (*Result, error) {
+	// next returns the next field, or an error if the output ends mid-record.
+	next := func() ([]byte, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.ErrUnexpectedEOF
+		}
+		return scanner.Bytes(), nil
+	}
+
+	for scanner.Scan() {
+		countField := scanner.Bytes()
+		if len(countField) == 0 {
+			continue
+		}
+		count, err := strconv.ParseUint(string(countField), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message path count: %q", countField)
+		}
+
+		var m Message
+		for range count {
+			path, err := next()
+			if err != nil {
+				return nil, fmt.Errorf("truncated message record: %w", err)
+			}
+			m.Paths = append(m.Paths, string(path))
+		}
+		typ, err := next()
+		if err != nil {
+			return nil, fmt.Errorf("truncated message record: %w", err)
+		}
+		m.Type = string(typ)
+		text, err := next()
+		if err != nil {
+			return nil, fmt.Errorf("truncated message record: %w", err)
+		}
+		// Messages are written with puts(), so always carry a newline before
+		// the NUL terminator, which is not part of the message itself.
+		m.Text = string(bytes.TrimSuffix(text, []byte{'\n'}))
+
+		res.Messages = append(res.Messages, m)
+	}
+	return res, scanner.Err()
+}
+
+// Conflicted file entries have the following format:
+// <mode> <object> <stage>\t<path>
+func parseConflictedFile(field []byte) (ConflictedFile, error) {
+	var zero ConflictedFile
+	info, path, found := bytes.Cut(field, []byte{'\t'})
+	if !found {
+		return zero, fmt.Errorf("invalid conflicted file entry: %q", field)
+	}
+
+	fields := bytes.Split(info, []byte{' '})
+	if len(fields) != 3 {
+		return zero, fmt.Errorf("invalid conflicted file entry: %q", field)
+	}
+
+	mode, err := strconv.ParseUint(string(fields[0]), 8, 32)
+	if err != nil {
+		return zero, fmt.Errorf("invalid file mode field: %w", err)
+	}
+
+	stage, err := parseStage(fields[2])
+	if err != nil {
+		return zero, err
+	}
+
+	return ConflictedFile{
+		Mode:   FileMode(mode),
+		Object: string(fields[1]),
+		Stage:  stage,
+		Path:   string(path),
+	}, nil
+}
+
+// With --name-only, conflicted file entries are just the path.
+func parseConflictedName(field []byte) (ConflictedFile, error) {
+	return ConflictedFile{Path: string(field)}, nil
+}
+
+func parseStage(field []byte) (Stage, error) {
+	if len(field) != 1 || field[0] < '1' || field[0] > '3' {
+		return 0, fmt.Errorf("invalid stage field: %q", field)
+	}
+	return Stage(field[0] - '0'), nil
+}
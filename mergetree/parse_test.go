@@ -0,0 +1,216 @@
+package mergetree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// sampleMergeTreeOutput is the output of `git merge-tree --write-tree -z` for
+// a merge with a modify/delete conflict and a content conflict.
+var sampleMergeTreeOutput = "3df65dc4496c822cbf0e2ea01bca15e59624db39\x00" +
+	"100644 2fa992c0b8b5c6acd2bdd4fa31de29d29799bdd5 1\tdel\x00" +
+	"100644 2680cfddbd9fa03c059ac60d2bec5e59a1c34281 3\tdel\x00" +
+	"100644 de980441c3ab03a8c07dda1ad27b8a11f39deb1e 1\tgreeting\x00" +
+	"100644 3b6f40af131104cca3a84e7a760c3c3475377106 2\tgreeting\x00" +
+	"100644 f4ea702d479ef1388dde60e3430791a9c6eb8d4f 3\tgreeting\x00" +
+	"\x00" +
+	"1\x00del\x00CONFLICT (modify/delete)\x00CONFLICT (modify/delete): del deleted in main and modified in side1.  Version side1 of del left in tree.\n\x00" +
+	"1\x00greeting\x00Auto-merging\x00Auto-merging greeting\n\x00" +
+	"1\x00greeting\x00CONFLICT (contents)\x00CONFLICT (content): Merge conflict in greeting\n\x00"
+
+// sampleMergeTreeNameOnlyOutput is the same merge as sampleMergeTreeOutput,
+// with the --name-only flag.
+var sampleMergeTreeNameOnlyOutput = "3df65dc4496c822cbf0e2ea01bca15e59624db39\x00" +
+	"del\x00" +
+	"greeting\x00" +
+	"\x00" +
+	"1\x00del\x00CONFLICT (modify/delete)\x00CONFLICT (modify/delete): del deleted in main and modified in side1.  Version side1 of del left in tree.\n\x00" +
+	"1\x00greeting\x00Auto-merging\x00Auto-merging greeting\n\x00" +
+	"1\x00greeting\x00CONFLICT (contents)\x00CONFLICT (content): Merge conflict in greeting\n\x00"
+
+var sampleMessages = []Message{
+	{
+		Paths: []string{"del"},
+		Type:  TypeModifyDelete,
+		Text:  "CONFLICT (modify/delete): del deleted in main and modified in side1.  Version side1 of del left in tree.",
+	},
+	{
+		Paths: []string{"greeting"},
+		Type:  TypeAutoMerging,
+		Text:  "Auto-merging greeting",
+	},
+	{
+		Paths: []string{"greeting"},
+		Type:  TypeContents,
+		Text:  "CONFLICT (content): Merge conflict in greeting",
+	},
+}
+
+func TestParse(t *testing.T) {
+	testcases := []struct {
+		name    string
+		input   string
+		want    *Result
+		wantErr bool
+	}{
+		{
+			name:  "clean merge",
+			input: "40c8625c41bf829e588d6bb11d2f369b829cf84a\x00",
+			want:  &Result{Tree: "40c8625c41bf829e588d6bb11d2f369b829cf84a"},
+		},
+		{
+			name:  "clean merge with --messages",
+			input: "40c8625c41bf829e588d6bb11d2f369b829cf84a\x00\x00",
+			want:  &Result{Tree: "40c8625c41bf829e588d6bb11d2f369b829cf84a"},
+		},
+		{
+			name:  "conflicted merge",
+			input: sampleMergeTreeOutput,
+			want: &Result{
+				Tree: "3df65dc4496c822cbf0e2ea01bca15e59624db39",
+				Conflicts: []ConflictedFile{
+					{Mode: 0100644, Object: "2fa992c0b8b5c6acd2bdd4fa31de29d29799bdd5", Stage: StageBase, Path: "del"},
+					{Mode: 0100644, Object: "2680cfddbd9fa03c059ac60d2bec5e59a1c34281", Stage: StageTheirs, Path: "del"},
+					{Mode: 0100644, Object: "de980441c3ab03a8c07dda1ad27b8a11f39deb1e", Stage: StageBase, Path: "greeting"},
+					{Mode: 0100644, Object: "3b6f40af131104cca3a84e7a760c3c3475377106", Stage: StageOurs, Path: "greeting"},
+					{Mode: 0100644, Object: "f4ea702d479ef1388dde60e3430791a9c6eb8d4f", Stage: StageTheirs, Path: "greeting"},
+				},
+				Messages: sampleMessages,
+			},
+		},
+		{
+			name: "message with multiple paths",
+			input: "40c8625c41bf829e588d6bb11d2f369b829cf84a\x00\x00" +
+				"3\x00a\x00b\x00c\x00CONFLICT (rename/rename)\x00CONFLICT (rename/rename): a renamed to b in HEAD and to c in side.\n\x00",
+			want: &Result{
+				Tree: "40c8625c41bf829e588d6bb11d2f369b829cf84a",
+				Messages: []Message{
+					{
+						Paths: []string{"a", "b", "c"},
+						Type:  TypeRenameRename,
+						Text:  "CONFLICT (rename/rename): a renamed to b in HEAD and to c in side.",
+					},
+				},
+			},
+		},
+		{
+			name:  "path with special characters",
+			input: "40c8625c41bf829e588d6bb11d2f369b829cf84a\x00100644 de980441c3ab03a8c07dda1ad27b8a11f39deb1e 1\tdir/with space\tand tab\x00",
+			want: &Result{
+				Tree: "40c8625c41bf829e588d6bb11d2f369b829cf84a",
+				Conflicts: []ConflictedFile{
+					{Mode: 0100644, Object: "de980441c3ab03a8c07dda1ad27b8a11f39deb1e", Stage: StageBase, Path: "dir/with space\tand tab"},
+				},
+			},
+		},
+		{
+			name:    "empty input",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "missing tree",
+			input:   "\x00",
+			wantErr: true,
+		},
+		{
+			name:    "malformed conflicted file entry",
+			input:   "40c8625c41bf829e588d6bb11d2f369b829cf84a\x00greeting\x00",
+			wantErr: true,
+		},
+		{
+			name:    "invalid stage",
+			input:   "40c8625c41bf829e588d6bb11d2f369b829cf84a\x00100644 de980441c3ab03a8c07dda1ad27b8a11f39deb1e 0\tgreeting\x00",
+			wantErr: true,
+		},
+		{
+			name:    "invalid message count",
+			input:   "40c8625c41bf829e588d6bb11d2f369b829cf84a\x00\x00x\x00greeting\x00Auto-merging\x00Auto-merging greeting\n\x00",
+			wantErr: true,
+		},
+		{
+			name:    "truncated message record",
+			input:   "40c8625c41bf829e588d6bb11d2f369b829cf84a\x00\x002\x00greeting\x00",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseNameOnly(t *testing.T) {
+	got, err := ParseNameOnly(strings.NewReader(sampleMergeTreeNameOnlyOutput))
+	if err != nil {
+		t.Fatalf("ParseNameOnly() error = %v", err)
+	}
+	want := &Result{
+		Tree: "3df65dc4496c822cbf0e2ea01bca15e59624db39",
+		Conflicts: []ConflictedFile{
+			{Path: "del"},
+			{Path: "greeting"},
+		},
+		Messages: sampleMessages,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseNameOnly() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_parseConflictedFile(t *testing.T) {
+	testcases := []struct {
+		name    string
+		input   string
+		want    ConflictedFile
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			input: "100755 de980441c3ab03a8c07dda1ad27b8a11f39deb1e 2\tbin/run",
+			want:  ConflictedFile{Mode: 0100755, Object: "de980441c3ab03a8c07dda1ad27b8a11f39deb1e", Stage: StageOurs, Path: "bin/run"},
+		},
+		{
+			name:    "missing tab",
+			input:   "100644 de980441c3ab03a8c07dda1ad27b8a11f39deb1e 1 greeting",
+			wantErr: true,
+		},
+		{
+			name:    "too few fields",
+			input:   "100644 1\tgreeting",
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode",
+			input:   "100844 de980441c3ab03a8c07dda1ad27b8a11f39deb1e 1\tgreeting",
+			wantErr: true,
+		},
+		{
+			name:    "multi digit stage",
+			input:   "100644 de980441c3ab03a8c07dda1ad27b8a11f39deb1e 12\tgreeting",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseConflictedFile([]byte(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseConflictedFile() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("parseConflictedFile() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package mergetree
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/mroth/porcelain/zscan"
+)
+
+// newZScanner creates a scanner that tokenizes NUL-terminated output,
+// returning each field as a token, omitting the NUL terminator. Unlike the
+// status parsers, empty tokens are significant here, as an empty field marks
+// the start of the messages section.
+func newZScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(zscan.ScanNUL)
+	return scanner
+}
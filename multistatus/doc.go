@@ -0,0 +1,24 @@
+/*
+Package multistatus gathers the status of many git repositories concurrently.
+
+# Basic Usage
+
+[FindRepos] walks a directory tree to find git repositories, and [Get] runs
+`git status` in each of them with a bounded pool of workers:
+
+	dirs, err := multistatus.FindRepos("/home/me/src")
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, r := range multistatus.Get(ctx, dirs, multistatus.Config{}) {
+	    if r.Err != nil {
+	        log.Printf("%s: %v", r.Dir, r.Err)
+	        continue
+	    }
+	    fmt.Printf("%s: %d entries\n", r.Dir, len(r.Status.Entries))
+	}
+
+Statuses are gathered with branch and stash information, as with
+`git status --porcelain=v2 --branch --show-stash`.
+*/
+package multistatus
@@ -0,0 +1,105 @@
+package multistatus
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// Result is the status of a single repository.
+type Result struct {
+	Dir    string           // repository directory, as passed to Get
+	Status *statusv2.Status // nil if Err is set
+	Err    error
+}
+
+// Config configures how statuses are gathered. The zero value is ready to
+// use.
+type Config struct {
+	// Workers is the maximum number of git processes to run at once. If zero,
+	// it defaults to the number of CPUs.
+	Workers int
+
+	// Args are additional arguments for `git status`, such as
+	// "--untracked-files=no".
+	Args []string
+
+	// NewRunner returns the Runner used for the repository at dir. If nil,
+	// [gitexec.New] is used.
+	NewRunner func(dir string) gitexec.Runner
+}
+
+// Get gathers the status of the repository in each of dirs concurrently,
+// returning the results in the same order as dirs.
+//
+// Failures are reported per repository in [Result.Err]. If ctx is canceled,
+// the remaining repositories fail with ctx.Err().
+func Get(ctx context.Context, dirs []string, cfg Config) []Result {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	newRunner := cfg.NewRunner
+	if newRunner == nil {
+		newRunner = func(dir string) gitexec.Runner { return gitexec.New(dir) }
+	}
+	args := append([]string{"--branch", "--show-stash"}, cfg.Args...)
+
+	results := make([]Result, len(dirs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(dirs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				r := Result{Dir: dirs[i]}
+				if err := ctx.Err(); err != nil {
+					r.Err = err
+				} else {
+					r.Status, r.Err = statusv2.Get(ctx, newRunner(dirs[i]), args...)
+				}
+				results[i] = r
+			}
+		}()
+	}
+	for i := range dirs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// FindRepos walks the directory tree rooted at root, returning the
+// directories containing a git repository, i.e. with a ".git" directory or
+// file (as used by linked worktrees and submodules).
+//
+// The contents of repositories are not searched for further repositories, and
+// directories that cannot be read are skipped.
+func FindRepos(root string) ([]string, error) {
+	var repos []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return fs.SkipDir
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if _, err := os.Lstat(filepath.Join(path, ".git")); err == nil {
+			repos = append(repos, path)
+			return fs.SkipDir
+		}
+		return nil
+	})
+	return repos, err
+}
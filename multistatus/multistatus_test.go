@@ -0,0 +1,116 @@
+package multistatus
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// fakeRunner is a gitexec.Runner returning canned results, recording the
+// arguments it was called with.
+type fakeRunner struct {
+	out  string
+	err  error
+	args []string
+}
+
+func (f *fakeRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+	f.args = args
+	return []byte(f.out), f.err
+}
+
+func TestGet(t *testing.T) {
+	errFailed := errors.New("failed")
+	var mu sync.Mutex
+	runners := map[string]*fakeRunner{}
+	cfg := Config{
+		Workers: 2,
+		Args:    []string{"--untracked-files=no"},
+		NewRunner: func(dir string) gitexec.Runner {
+			r := &fakeRunner{out: "# branch.oid (initial)\x00# branch.head " + dir + "\x00"}
+			if dir == "bad" {
+				r = &fakeRunner{err: errFailed}
+			}
+			mu.Lock()
+			runners[dir] = r
+			mu.Unlock()
+			return r
+		},
+	}
+
+	dirs := []string{"a", "bad", "c", "d", "e"}
+	results := Get(context.Background(), dirs, cfg)
+	if len(results) != len(dirs) {
+		t.Fatalf("Get() returned %d results, want %d", len(results), len(dirs))
+	}
+	for i, r := range results {
+		if r.Dir != dirs[i] {
+			t.Errorf("results[%d].Dir = %q, want %q", i, r.Dir, dirs[i])
+		}
+		if r.Dir == "bad" {
+			if !errors.Is(r.Err, errFailed) {
+				t.Errorf("results[%d].Err = %v, want %v", i, r.Err, errFailed)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Errorf("results[%d].Err = %v", i, r.Err)
+			continue
+		}
+		if r.Status.Branch.Head != r.Dir {
+			t.Errorf("results[%d].Status.Branch.Head = %q, want %q", i, r.Status.Branch.Head, r.Dir)
+		}
+	}
+
+	want := []string{"status", "--porcelain=v2", "-z", "--branch", "--show-stash", "--untracked-files=no"}
+	if got := runners["a"].args; !slices.Equal(got, want) {
+		t.Errorf("Get() ran git %q, want %q", got, want)
+	}
+}
+
+func TestGet_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg := Config{NewRunner: func(string) gitexec.Runner { return &fakeRunner{} }}
+	for _, r := range Get(ctx, []string{"a", "b"}, cfg) {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("Get() result %q error = %v, want context.Canceled", r.Dir, r.Err)
+		}
+	}
+}
+
+func TestFindRepos(t *testing.T) {
+	root := t.TempDir()
+	mkdir := func(path string) {
+		if err := os.MkdirAll(filepath.Join(root, path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkdir("a/.git")
+	mkdir("a/nested/.git") // inside a repository, not searched
+	mkdir("b/c/.git")
+	mkdir("d/empty")
+	mkdir("e")
+	if err := os.WriteFile(filepath.Join(root, "e/.git"), []byte("gitdir: ../a/.git/worktrees/e\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FindRepos(root)
+	if err != nil {
+		t.Fatalf("FindRepos() error = %v", err)
+	}
+	want := []string{filepath.Join(root, "a"), filepath.Join(root, "b/c"), filepath.Join(root, "e")}
+	if !slices.Equal(got, want) {
+		t.Errorf("FindRepos() = %q, want %q", got, want)
+	}
+
+	if _, err := FindRepos(filepath.Join(root, "missing")); err == nil {
+		t.Error("FindRepos(missing) error = nil, want error")
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Package notes parses `git notes list` output and fetches note contents.
+
+Git notes attach arbitrary metadata to objects without changing them, which
+makes them a convenient store for review or build information.
+
+# Basic Usage
+
+[ParseList] takes an [io.Reader] containing `git notes list` output:
+
+	r := bytes.NewReader(gitNotesListOutput)
+	notes, err := notes.ParseList(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+[List] and [Show] run git via a [gitexec.Runner] to list notes and fetch the
+contents of a single note, respectively:
+
+	git := gitexec.New("/path/to/repo")
+	msg, err := notes.Show(ctx, git, "refs/notes/ci", "HEAD")
+	if errors.Is(err, notes.ErrNotFound) {
+	    // object has no note
+	}
+
+For more information, see the Git documentation for [git notes].
+
+[git notes]: https://git-scm.com/docs/git-notes
+*/
+package notes
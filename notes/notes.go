@@ -0,0 +1,77 @@
+package notes
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// Note represents a single note, pairing the note blob with the object it
+// annotates.
+type Note struct {
+	Object    string // object name of the note blob
+	Annotated string // object name of the annotated object
+}
+
+// ErrNotFound is returned by [Show] when the object has no note.
+var ErrNotFound = errors.New("no note found")
+
+// ParseList parses the output of `git notes list`.
+//
+// Each line has the form "<note object> <annotated object>".
+func ParseList(r io.Reader) ([]Note, error) {
+	var notes []Note
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		object, annotated, found := bytes.Cut(line, []byte{' '})
+		if !found || len(object) == 0 || len(annotated) == 0 || bytes.IndexByte(annotated, ' ') >= 0 {
+			return nil, fmt.Errorf("invalid notes list line: %q", line)
+		}
+		notes = append(notes, Note{Object: string(object), Annotated: string(annotated)})
+	}
+	return notes, scanner.Err()
+}
+
+// List runs `git notes list` for the notes ref (or the default notes ref, if
+// empty) and returns all notes.
+func List(ctx context.Context, git gitexec.Runner, ref string) ([]Note, error) {
+	out, err := git.Run(ctx, notesArgs(ref, "list")...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseList(bytes.NewReader(out))
+}
+
+// Show runs `git notes show` for the notes ref (or the default notes ref, if
+// empty) and returns the contents of the note attached to object.
+//
+// If the object has no note, the returned error wraps [ErrNotFound].
+func Show(ctx context.Context, git gitexec.Runner, ref, object string) ([]byte, error) {
+	out, err := git.Run(ctx, notesArgs(ref, "show", object)...)
+	if err != nil {
+		// git exits with status 1 and "error: no note found for object <oid>."
+		var exitErr *gitexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode == 1 && bytes.Contains(exitErr.Stderr, []byte("no note found")) {
+			return nil, fmt.Errorf("%w for object %s", ErrNotFound, object)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func notesArgs(ref string, args ...string) []string {
+	cmd := []string{"notes"}
+	if ref != "" {
+		cmd = append(cmd, "--ref="+ref)
+	}
+	return append(cmd, args...)
+}
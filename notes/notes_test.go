@@ -0,0 +1,118 @@
+package notes
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/gitexec"
+)
+
+func TestParseList(t *testing.T) {
+	testcases := []struct {
+		name    string
+		input   string
+		want    []Note
+		wantErr bool
+	}{
+		{
+			name: "multiple notes",
+			input: "bbe1430e84b3fdb70ce588463a8922095cebd6ce ac38b1b7893d9097854d1b2bf0d0ac6b261378dd\n" +
+				"5df55025fb1865322dc28463cbb4a1a1ea171e00 df6a620370d719a420a039a14ab14f342d3438f7\n",
+			want: []Note{
+				{Object: "bbe1430e84b3fdb70ce588463a8922095cebd6ce", Annotated: "ac38b1b7893d9097854d1b2bf0d0ac6b261378dd"},
+				{Object: "5df55025fb1865322dc28463cbb4a1a1ea171e00", Annotated: "df6a620370d719a420a039a14ab14f342d3438f7"},
+			},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:    "single object (git notes list <object>)",
+			input:   "bbe1430e84b3fdb70ce588463a8922095cebd6ce\n",
+			wantErr: true,
+		},
+		{
+			name:    "too many fields",
+			input:   "a b c\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseList(strings.NewReader(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseList() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseList() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeRunner is a gitexec.Runner returning canned results, recording the
+// arguments it was called with.
+type fakeRunner struct {
+	out  string
+	err  error
+	args []string
+}
+
+func (f *fakeRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+	f.args = args
+	return []byte(f.out), f.err
+}
+
+func TestList(t *testing.T) {
+	git := &fakeRunner{out: "bbe1430e84b3fdb70ce588463a8922095cebd6ce ac38b1b7893d9097854d1b2bf0d0ac6b261378dd\n"}
+	got, err := List(context.Background(), git, "refs/notes/ci")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if want := []string{"notes", "--ref=refs/notes/ci", "list"}; !slices.Equal(git.args, want) {
+		t.Errorf("List() ran git %q, want %q", git.args, want)
+	}
+	if len(got) != 1 || got[0].Annotated != "ac38b1b7893d9097854d1b2bf0d0ac6b261378dd" {
+		t.Errorf("List() = %+v", got)
+	}
+}
+
+func TestShow(t *testing.T) {
+	git := &fakeRunner{out: "build: ok\n"}
+	got, err := Show(context.Background(), git, "", "HEAD")
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if want := []string{"notes", "show", "HEAD"}; !slices.Equal(git.args, want) {
+		t.Errorf("Show() ran git %q, want %q", git.args, want)
+	}
+	if string(got) != "build: ok\n" {
+		t.Errorf("Show() = %q, want %q", got, "build: ok\n")
+	}
+}
+
+func TestShow_NotFound(t *testing.T) {
+	git := &fakeRunner{err: &gitexec.ExitError{
+		Args:     []string{"notes", "show", "HEAD"},
+		ExitCode: 1,
+		Stderr:   []byte("error: no note found for object 97516266cf63ca9f9c2ad45010ccbda99a87e91c.\n"),
+	}}
+	_, err := Show(context.Background(), git, "", "HEAD")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Show() error = %v, want ErrNotFound", err)
+	}
+
+	// Other failures are passed through unchanged.
+	git.err = &gitexec.ExitError{ExitCode: 128, Stderr: []byte("fatal: failed to resolve 'nope' as a valid ref.\n")}
+	_, err = Show(context.Background(), git, "", "nope")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Show() error = %v, want non-ErrNotFound error", err)
+	}
+}
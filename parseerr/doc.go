@@ -0,0 +1,43 @@
+/*
+Package parseerr defines the errors of the parsers in this module for git
+output, each with a [Code] identifying its kind and the parameters of its
+message, so that tools can match on them and translate their messages, rather
+than relying on the English text.
+
+# Matching Errors
+
+Parse errors are of type [*Error], which [errors.As] finds even when wrapped:
+
+	_, err := statusv2.Parse(r)
+	var perr *parseerr.Error
+	if errors.As(err, &perr) && perr.Code == parseerr.InvalidXY {
+	    fmt.Println("bad XY flag:", perr.Params["xy"])
+	}
+
+Codes and the names of parameters are stable, while the English messages in
+[Messages] may be reworded.
+
+# Translating Messages
+
+A message template names parameters in braces, inserted as by the %v verb of
+package fmt, or as by %q with a ":q" suffix:
+
+	"invalid XY flag: {xy:q}"
+
+[Error.Localize] formats an error with a catalog of templates in another
+language, which [CheckMessages] verifies against [Messages]:
+
+	german := map[parseerr.Code]string{
+	    parseerr.InvalidXY: "ungültiges XY-Feld: {xy:q}",
+	    // ...
+	}
+	if err := parseerr.CheckMessages(german); err != nil {
+	    log.Fatal(err)
+	}
+	fmt.Println(perr.Localize(german))
+
+Errors from the statusv1 and statusv2 parsers, including their strict modes,
+and from the zscan package use this package. I/O errors, and errors from
+functions that are not parsers, such as those unmarshaling JSON, do not.
+*/
+package parseerr
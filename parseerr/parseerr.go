@@ -0,0 +1,210 @@
+package parseerr
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// A Code identifies a kind of parse error.
+type Code string
+
+// Codes of parse errors, with the parameters of their messages.
+const (
+	EmptyLine          Code = "empty_line"           // unit: "line", "entry" or "record"
+	EmptyPath          Code = "empty_path"           //
+	HeaderAfterEntries Code = "header_after_entries" // line
+	UnknownLine        Code = "unknown_line"         // line
+	TooShort           Code = "too_short"            // unit, line
+	MissingSpace       Code = "missing_space"        // got: the byte found instead
+	MissingField       Code = "missing_field"        //
+	InvalidEntry       Code = "invalid_entry"        // kind: "changed", "rename or copy", "unmerged", "untracked" or "ignored"; line
+	ParseFailed        Code = "parse_failed"         // unit, line; wraps the error parsing the record
+	InvalidRecord      Code = "invalid_record"       // unit, line; wraps the error in the record
+	InvalidHeader      Code = "invalid_header"       // line
+	InvalidHeaderValue Code = "invalid_header_value" // key, value
+	InvalidXYLength    Code = "invalid_xy_length"    // length
+	InvalidXY          Code = "invalid_xy"           // xy
+	InvalidXYForEntry  Code = "invalid_xy_for_entry" // kind, xy
+	InvalidSubmodule   Code = "invalid_submodule"    // field
+	InvalidFileMode    Code = "invalid_file_mode"    // mode
+	InvalidObjectName  Code = "invalid_object_name"  // name
+	InvalidScore       Code = "invalid_score"        // score
+	InvalidRenamePaths Code = "invalid_rename_paths" // paths
+	MissingOrigPath    Code = "missing_orig_path"    // xy
+	UnexpectedOrigPath Code = "unexpected_orig_path" // xy
+	DuplicatePath      Code = "duplicate_path"       // path
+	FormatMismatch     Code = "format_mismatch"      // format: the format the input looks like; parser: the package parsing it
+)
+
+// Messages are the English templates of the messages of each code, used by
+// [Error.Error].
+var Messages = map[Code]string{
+	EmptyLine:          "invalid empty {unit}",
+	EmptyPath:          "invalid empty path",
+	HeaderAfterEntries: "header after entries: {line:q}",
+	UnknownLine:        "invalid line: {line:q}",
+	TooShort:           "{unit} too short: {line:q}",
+	MissingSpace:       "expected space after XY status, got {got:q}",
+	MissingField:       "malformed record: missing field after embedded NUL",
+	InvalidEntry:       "invalid {kind} entry line: {line:q}",
+	ParseFailed:        "failed to parse {unit} {line:q}",
+	InvalidRecord:      "invalid {unit} {line:q}",
+	InvalidHeader:      "invalid header line: {line:q}",
+	InvalidHeaderValue: "invalid {key} header: {value:q}",
+	InvalidXYLength:    "invalid XY field: expected 2 characters, got {length}",
+	InvalidXY:          "invalid XY flag: {xy:q}",
+	InvalidXYForEntry:  "invalid XY flag for {kind} entry: {xy:q}",
+	InvalidSubmodule:   "invalid submodule status field: {field:q}",
+	InvalidFileMode:    "invalid file mode: {mode:q}",
+	InvalidObjectName:  "invalid object name: {name:q}",
+	InvalidScore:       "invalid rename or copy score: {score:q}",
+	InvalidRenamePaths: "invalid rename or copy paths: {paths:q}",
+	MissingOrigPath:    "missing original path for {xy:q} entry",
+	UnexpectedOrigPath: "unexpected original path for {xy:q} entry",
+	DuplicatePath:      "duplicate entry for path {path:q}",
+	FormatMismatch:     "input looks like {format} output, which package {parser} parses",
+}
+
+// An Error is an error parsing git output.
+type Error struct {
+	Code   Code
+	Params map[string]any // parameters of the message, by name; strings for the text of the input
+	Err    error          // the underlying error, whose message follows, if any
+}
+
+// New returns an error with the code and params, given as alternating names
+// and values, as for [log/slog]. Values of type []byte are converted to
+// strings, copying them, as parsers often reuse their buffers.
+func New(code Code, params ...any) *Error {
+	e := &Error{Code: code}
+	for i := 0; i+1 < len(params); i += 2 {
+		name, _ := params[i].(string)
+		value := params[i+1]
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		if e.Params == nil {
+			e.Params = make(map[string]any, len(params)/2)
+		}
+		e.Params[name] = value
+	}
+	return e
+}
+
+// Wrap returns an error with the code and params, as for [New], wrapping err.
+func Wrap(err error, code Code, params ...any) *Error {
+	e := New(code, params...)
+	e.Err = err
+	return e
+}
+
+// Error returns the message of e in English, from [Messages].
+func (e *Error) Error() string {
+	return e.Localize(Messages)
+}
+
+// Unwrap returns the underlying error, if any.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an *Error with the same code, so that
+// errors.Is matches errors by code, regardless of their parameters.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Localize returns the message of e from its template in messages, falling
+// back to [Messages] for codes missing from it. The message of any underlying
+// error follows after a colon, localized as well if it is an *Error.
+func (e *Error) Localize(messages map[Code]string) string {
+	tmpl, ok := messages[e.Code]
+	if !ok {
+		tmpl, ok = Messages[e.Code]
+	}
+	var msg string
+	if ok {
+		msg = expand(tmpl, e.Params)
+	} else {
+		msg = string(e.Code)
+	}
+	if e.Err != nil {
+		if inner, ok := e.Err.(*Error); ok {
+			return msg + ": " + inner.Localize(messages)
+		}
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// expand replaces the placeholders of tmpl with the values of params.
+// Placeholders without a value are left as they are.
+func expand(tmpl string, params map[string]any) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		end := -1
+		if start >= 0 {
+			end = strings.IndexByte(tmpl[start:], '}')
+		}
+		if end < 0 {
+			b.WriteString(tmpl)
+			return b.String()
+		}
+		end += start
+		b.WriteString(tmpl[:start])
+		name, verb := tmpl[start+1:end], "%v"
+		if n, ok := strings.CutSuffix(name, ":q"); ok {
+			name, verb = n, "%q"
+		}
+		if value, ok := params[name]; ok {
+			fmt.Fprintf(&b, verb, value)
+		} else {
+			b.WriteString(tmpl[start : end+1])
+		}
+		tmpl = tmpl[end+1:]
+	}
+}
+
+// Placeholders returns the names of the parameters in tmpl, in order.
+func Placeholders(tmpl string) []string {
+	var names []string
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			return names
+		}
+		end := strings.IndexByte(tmpl[start+1:], '}')
+		if end < 0 {
+			return names
+		}
+		name := tmpl[start+1 : start+1+end]
+		names = append(names, strings.TrimSuffix(name, ":q"))
+		tmpl = tmpl[start+end+2:]
+	}
+}
+
+// CheckMessages reports whether messages, a translation of [Messages], has a
+// template for every code, using only the parameters of the English template
+// of the code.
+func CheckMessages(messages map[Code]string) error {
+	var errs []error
+	for _, code := range slices.Sorted(maps.Keys(Messages)) {
+		tmpl, ok := messages[code]
+		if !ok {
+			errs = append(errs, fmt.Errorf("no message for %s", code))
+			continue
+		}
+		known := Placeholders(Messages[code])
+		for _, name := range Placeholders(tmpl) {
+			if !slices.Contains(known, name) {
+				errs = append(errs, fmt.Errorf("message for %s has unknown parameter %q", code, name))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,135 @@
+package parseerr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestMessages(t *testing.T) {
+	if err := CheckMessages(Messages); err != nil {
+		t.Errorf("CheckMessages(Messages) = %v", err)
+	}
+	for code, tmpl := range Messages {
+		if code == "" || tmpl == "" {
+			t.Errorf("Messages[%q] = %q", code, tmpl)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	line := []byte("1 XY")
+	err := New(InvalidXY, "xy", line[2:])
+	line[2] = 'Z' // as a scanner reusing its buffer
+	if got, want := err.Params["xy"], any("XY"); got != want {
+		t.Errorf("Params[xy] = %#v, want %#v", got, want)
+	}
+	if got, want := err.Error(), `invalid XY flag: "XY"`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if New(EmptyPath).Params != nil {
+		t.Error("New(EmptyPath).Params != nil")
+	}
+}
+
+func TestError_Localize(t *testing.T) {
+	messages := map[Code]string{
+		InvalidRecord: "{unit} ungültig: {line:q}",
+		InvalidXY:     "ungültiges XY-Feld {xy}",
+	}
+	tests := []struct {
+		name string
+		err  *Error
+		want string
+	}{
+		{
+			name: "translated",
+			err:  New(InvalidXY, "xy", "ZZ"),
+			want: "ungültiges XY-Feld ZZ",
+		},
+		{
+			name: "fallback to English",
+			err:  New(EmptyLine, "unit", "Zeile"),
+			want: "invalid empty Zeile",
+		},
+		{
+			name: "unknown code",
+			err:  New("future_code"),
+			want: "future_code",
+		},
+		{
+			name: "missing param",
+			err:  New(InvalidXY),
+			want: "ungültiges XY-Feld {xy}",
+		},
+		{
+			name: "wrapped error",
+			err:  Wrap(New(InvalidXY, "xy", "ZZ"), InvalidRecord, "unit", "Zeile", "line", "ZZ a"),
+			want: `Zeile ungültig: "ZZ a": ungültiges XY-Feld ZZ`,
+		},
+		{
+			name: "wrapped other error",
+			err:  Wrap(io.ErrUnexpectedEOF, InvalidRecord, "unit", "Zeile", "line", "{x}"),
+			want: `Zeile ungültig: "{x}": unexpected EOF`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Localize(messages); got != tt.want {
+				t.Errorf("Localize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError_Is(t *testing.T) {
+	err := fmt.Errorf("decoding: %w", Wrap(New(InvalidXY, "xy", "ZZ"), InvalidRecord))
+	if !errors.Is(err, New(InvalidRecord)) {
+		t.Error("errors.Is(err, InvalidRecord) = false")
+	}
+	if !errors.Is(err, New(InvalidXY)) {
+		t.Error("errors.Is(err, InvalidXY) = false")
+	}
+	if errors.Is(err, New(EmptyPath)) {
+		t.Error("errors.Is(err, EmptyPath) = true")
+	}
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Code != InvalidRecord {
+		t.Errorf("errors.As(err) = %v, want code %s", perr, InvalidRecord)
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		want []string
+	}{
+		{"no params", nil},
+		{"{unit} too short: {line:q}", []string{"unit", "line"}},
+		{"unterminated {unit", nil},
+	}
+	for _, tt := range tests {
+		if got := Placeholders(tt.tmpl); !slices.Equal(got, tt.want) {
+			t.Errorf("Placeholders(%q) = %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+}
+
+func TestCheckMessages(t *testing.T) {
+	if CheckMessages(map[Code]string{}) == nil {
+		t.Error("CheckMessages(empty) = nil, want error")
+	}
+	messages := make(map[Code]string, len(Messages))
+	for code := range Messages {
+		messages[code] = string(code)
+	}
+	if err := CheckMessages(messages); err != nil {
+		t.Errorf("CheckMessages(without params) = %v", err)
+	}
+	messages[InvalidXY] = "{xy} {line}"
+	if CheckMessages(messages) == nil {
+		t.Error("CheckMessages(unknown param) = nil, want error")
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Package patchid parses the output of `git patch-id`.
+
+`git patch-id` reads patches on stdin, such as the output of `git log -p`, and
+prints a patch ID for each: a hash of the changes that ignores whitespace and
+line numbers. Commits introducing the same change have the same patch ID,
+which makes it the basis for detecting duplicate and cherry-picked patches.
+
+# Basic Usage
+
+[Parse] takes an [io.Reader] containing `git patch-id` output:
+
+	// git log -p main..topic | git patch-id --stable
+	r := bytes.NewReader(gitPatchIDOutput)
+	results, err := patchid.Parse(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for id, commits := range patchid.Duplicates(results) {
+	    fmt.Printf("patch %s introduced by %v\n", id, commits)
+	}
+
+Output produced with --stable, --unstable and --verbatim is supported alike,
+but patch IDs are only comparable between invocations using the same mode.
+
+# Git Patch-ID Format
+
+Each line has the following format:
+
+	<patch id> SP <commit id>
+
+When a patch is not preceded by a commit header, as with the output of
+`git diff`, the commit ID is all zeros.
+
+For more information, see the Git documentation for [git patch-id].
+
+[git patch-id]: https://git-scm.com/docs/git-patch-id
+*/
+package patchid
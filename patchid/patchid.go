@@ -0,0 +1,57 @@
+package patchid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Result represents the patch ID computed for a single patch.
+type Result struct {
+	PatchID  string // patch ID of the changes
+	CommitID string // commit the patch came from; all zeros if unknown
+}
+
+// HasCommit reports whether the patch was preceded by a commit header, so
+// that CommitID identifies the commit it came from.
+func (r Result) HasCommit() bool {
+	return strings.Trim(r.CommitID, "0") != ""
+}
+
+// Parse parses the output of `git patch-id`.
+func Parse(r io.Reader) ([]Result, error) {
+	var results []Result
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		patchID, commitID, found := strings.Cut(line, " ")
+		if !found || patchID == "" || commitID == "" || strings.Contains(commitID, " ") {
+			return nil, fmt.Errorf("invalid patch-id line: %q", line)
+		}
+		results = append(results, Result{PatchID: patchID, CommitID: commitID})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Duplicates groups the commit IDs of results by patch ID, returning only the
+// patch IDs shared by more than one result. Commit IDs are listed in the order
+// they appear in results.
+func Duplicates(results []Result) map[string][]string {
+	groups := make(map[string][]string)
+	for _, r := range results {
+		groups[r.PatchID] = append(groups[r.PatchID], r.CommitID)
+	}
+	for id, commits := range groups {
+		if len(commits) < 2 {
+			delete(groups, id)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,57 @@
+package patchid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	input := "a8ee2b0a8700696b1eadc7a22b2e0151d3abc257 bee1cb0cd7eea5277efba7e9abc166fabf5a408b\n" +
+		"79ed70593d268d3f0874fdbe0cb7c978334a78d9 6fbeb104b0b2520129e108e7d29ca02e672d3cb5\n" +
+		"a8ee2b0a8700696b1eadc7a22b2e0151d3abc257 0000000000000000000000000000000000000000\n"
+	want := []Result{
+		{PatchID: "a8ee2b0a8700696b1eadc7a22b2e0151d3abc257", CommitID: "bee1cb0cd7eea5277efba7e9abc166fabf5a408b"},
+		{PatchID: "79ed70593d268d3f0874fdbe0cb7c978334a78d9", CommitID: "6fbeb104b0b2520129e108e7d29ca02e672d3cb5"},
+		{PatchID: "a8ee2b0a8700696b1eadc7a22b2e0151d3abc257", CommitID: "0000000000000000000000000000000000000000"},
+	}
+
+	got, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+	if !got[0].HasCommit() || got[2].HasCommit() {
+		t.Errorf("HasCommit() = %v, %v; want true, false", got[0].HasCommit(), got[2].HasCommit())
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, input := range []string{
+		"a8ee2b0a8700696b1eadc7a22b2e0151d3abc257\n",
+		"a8ee2b0a a b\n",
+		" bee1cb0c\n",
+	} {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", input)
+		}
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	results := []Result{
+		{PatchID: "a8ee2b0a", CommitID: "bee1cb0c"},
+		{PatchID: "79ed7059", CommitID: "6fbeb104"},
+		{PatchID: "a8ee2b0a", CommitID: "1f2e3d4c"},
+		{PatchID: "a8ee2b0a", CommitID: "00000000"},
+	}
+	want := map[string][]string{
+		"a8ee2b0a": {"bee1cb0c", "1f2e3d4c", "00000000"},
+	}
+	if diff := cmp.Diff(want, Duplicates(results)); diff != "" {
+		t.Errorf("Duplicates() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Package plan computes the git operations that follow from a status, as a
+typed plan that callers can inspect, show to a user, or run with their own
+exec layer: the bridge from a parsed status to what to run next.
+
+# Basic Usage
+
+[Stage] plans staging every change of a [statusv2.Status], as `git add --all`
+would, but without touching any path the status does not list:
+
+	p := plan.Stage(status, plan.Config{Untracked: true})
+	if len(p.Conflicts) > 0 {
+	    fmt.Println("resolve first:", p.Conflicts)
+	}
+	for _, args := range p.Commands() {
+	    fmt.Println("git", strings.Join(args, " "))
+	}
+
+The paths of the plan are grouped by operation: those to stage with git add,
+those deleted in the worktree to stage with git rm --cached, and the
+conflicts, which need resolving by hand and have no command. [Plan.Commands]
+returns the argument lists of git for the first two, with literal pathspecs
+so that paths are never taken as globs, and [Plan.Run] runs them with a
+[gitexec.Runner].
+
+Paths must be unquoted, as from [statusv2.ParseZ] or [statusv2.UnquotePaths].
+*/
+package plan
@@ -0,0 +1,109 @@
+package plan
+
+import (
+	"context"
+
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// A Plan is the git operations staging the changes of a status.
+type Plan struct {
+	Add       []string // paths with changes in the worktree, to stage with git add
+	Remove    []string // paths deleted in the worktree, to stage with git rm --cached
+	Conflicts []string // unmerged paths, to resolve before they can be staged
+}
+
+// Config configures what a Plan stages. The zero value is ready to use.
+type Config struct {
+	// Untracked adds untracked files to the plan, as with git add --all.
+	// Otherwise only tracked files are staged, as with git add --update.
+	Untracked bool
+}
+
+// Stage returns the plan staging the changes of s, with paths in the order
+// of its entries.
+//
+// A submodule with only modified or untracked content is left out, as git
+// add can only stage a new commit of it. Renamed and copied files are staged
+// by their new path, as the original path is already staged.
+func Stage(s *statusv2.Status, cfg Config) *Plan {
+	p := &Plan{}
+	if s == nil {
+		return p
+	}
+	for _, e := range s.Entries {
+		switch e := e.(type) {
+		case statusv2.ChangedEntry:
+			p.stage(e.Path, e.XY, e.Sub)
+		case statusv2.RenameOrCopyEntry:
+			p.stage(e.Path, e.XY, e.Sub)
+		case statusv2.UnmergedEntry:
+			p.Conflicts = append(p.Conflicts, e.Path)
+		case statusv2.UntrackedEntry:
+			if cfg.Untracked {
+				p.Add = append(p.Add, e.Path)
+			}
+		}
+	}
+	return p
+}
+
+// stage adds the path of a tracked file to the plan, if it has changes in the
+// worktree.
+func (p *Plan) stage(path string, xy statusv2.XYFlag, sub statusv2.SubmoduleStatus) {
+	switch {
+	case xy.Y == statusv2.Unmodified:
+	case xy.Y == statusv2.Deleted:
+		p.Remove = append(p.Remove, path)
+	case sub.IsSubmodule && !sub.CommitChanged:
+	default:
+		p.Add = append(p.Add, path)
+	}
+}
+
+// Empty reports whether the plan has nothing to stage or resolve.
+func (p *Plan) Empty() bool {
+	return len(p.Add) == 0 && len(p.Remove) == 0 && len(p.Conflicts) == 0
+}
+
+// maxArgBytes is the most bytes of paths in a single command, keeping the
+// command lines well within the limits of operating systems.
+const maxArgBytes = 16 << 10
+
+// Commands returns the arguments of the git commands carrying out the plan,
+// without those of conflicts, which git cannot resolve by itself. Long lists
+// of paths are split across several commands.
+func (p *Plan) Commands() [][]string {
+	var cmds [][]string
+	cmds = appendCommands(cmds, []string{"--literal-pathspecs", "add", "--"}, p.Add)
+	cmds = appendCommands(cmds, []string{"--literal-pathspecs", "rm", "--cached", "--quiet", "--"}, p.Remove)
+	return cmds
+}
+
+// appendCommands appends to cmds the commands of prefix followed by paths,
+// splitting paths as needed.
+func appendCommands(cmds [][]string, prefix, paths []string) [][]string {
+	for len(paths) > 0 {
+		n, size := 0, 0
+		for n < len(paths) && (n == 0 || size+len(paths[n]) <= maxArgBytes) {
+			size += len(paths[n])
+			n++
+		}
+		cmd := append(append([]string(nil), prefix...), paths[:n]...)
+		cmds = append(cmds, cmd)
+		paths = paths[n:]
+	}
+	return cmds
+}
+
+// Run runs the commands of the plan with git, in the repository at the root
+// of the paths, stopping at the first error.
+func (p *Plan) Run(ctx context.Context, git gitexec.Runner) error {
+	for _, args := range p.Commands() {
+		if _, err := git.Run(ctx, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+type fakeRunner struct {
+	calls [][]string
+	err   error
+}
+
+func (f *fakeRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	return nil, f.err
+}
+
+const stageInput = "1 .M N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d modified.txt\n" +
+	"1 M. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d staged.txt\n" +
+	"1 .D N... 100644 100644 000000 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d deleted.txt\n" +
+	"1 .T N... 100644 100644 120000 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d link\n" +
+	"1 .M S.M. 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 dirty-sub\n" +
+	"1 .M SC.. 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 moved-sub\n" +
+	"2 RM N... 100644 100644 100644 587be6b4c3f93f93c489c0111bba5596147a26cb 587be6b4c3f93f93c489c0111bba5596147a26cb R100 new.txt\told.txt\n" +
+	"u UU N... 100644 100644 100644 100644 78981922613b2afb6025042ff6bd878ac1994e85 c1827f07e114c20547dc6a7296588870a4b5b62c e6bfff5c1d0f0ecd501552b43a1e13d8008abc31 conflict.txt\n" +
+	"? untracked/\n" +
+	"! debug.log\n"
+
+func parseStatus(t *testing.T) *statusv2.Status {
+	t.Helper()
+	s, err := statusv2.Parse(strings.NewReader(stageInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestStage(t *testing.T) {
+	s := parseStatus(t)
+	want := &Plan{
+		Add:       []string{"modified.txt", "link", "moved-sub", "new.txt"},
+		Remove:    []string{"deleted.txt"},
+		Conflicts: []string{"conflict.txt"},
+	}
+	if diff := cmp.Diff(want, Stage(s, Config{})); diff != "" {
+		t.Errorf("Stage() mismatch (-want +got):\n%s", diff)
+	}
+
+	want.Add = append(want.Add, "untracked/")
+	if diff := cmp.Diff(want, Stage(s, Config{Untracked: true})); diff != "" {
+		t.Errorf("Stage(Untracked) mismatch (-want +got):\n%s", diff)
+	}
+
+	if p := Stage(nil, Config{}); !p.Empty() {
+		t.Errorf("Stage(nil) = %+v, want an empty plan", p)
+	}
+}
+
+func TestPlan_Commands(t *testing.T) {
+	p := Stage(parseStatus(t), Config{})
+	want := [][]string{
+		{"--literal-pathspecs", "add", "--", "modified.txt", "link", "moved-sub", "new.txt"},
+		{"--literal-pathspecs", "rm", "--cached", "--quiet", "--", "deleted.txt"},
+	}
+	if diff := cmp.Diff(want, p.Commands()); diff != "" {
+		t.Errorf("Commands() mismatch (-want +got):\n%s", diff)
+	}
+	if got := (&Plan{Conflicts: []string{"c"}}).Commands(); len(got) != 0 {
+		t.Errorf("Commands() of only conflicts = %q, want none", got)
+	}
+}
+
+func TestPlan_CommandsSplit(t *testing.T) {
+	long := strings.Repeat("x", maxArgBytes/2)
+	p := &Plan{Add: []string{long + "1", long + "2", long + "3", "short"}}
+	var sizes []int
+	for _, cmd := range p.Commands() {
+		sizes = append(sizes, len(cmd)-3)
+	}
+	if want := []int{1, 1, 2}; !cmp.Equal(want, sizes) {
+		t.Errorf("Commands() paths per command = %v, want %v", sizes, want)
+	}
+
+	huge := &Plan{Remove: []string{strings.Repeat("y", 2*maxArgBytes)}}
+	if got := huge.Commands(); len(got) != 1 {
+		t.Errorf("Commands() of a huge path = %d commands, want 1", len(got))
+	}
+}
+
+func TestPlan_Run(t *testing.T) {
+	p := Stage(parseStatus(t), Config{})
+	git := &fakeRunner{}
+	if err := p.Run(context.Background(), git); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if diff := cmp.Diff(p.Commands(), git.calls); diff != "" {
+		t.Errorf("Run() calls mismatch (-want +got):\n%s", diff)
+	}
+
+	wantErr := errors.New("boom")
+	git = &fakeRunner{err: wantErr}
+	if err := p.Run(context.Background(), git); !errors.Is(err, wantErr) {
+		t.Errorf("Run() error = %v, want %v", err, wantErr)
+	}
+	if len(git.calls) != 1 {
+		t.Errorf("Run() ran %d commands after an error, want 1", len(git.calls))
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Package rebasetodo parses and serializes git's interactive rebase todo list.
+
+During `git rebase -i`, git writes the planned steps of the rebase to a todo
+file and opens it in the sequence editor. Tools can set sequence.editor (or
+GIT_SEQUENCE_EDITOR) to a program that uses this package to rewrite the plan
+programmatically, e.g. to automatically squash fixup commits or insert exec
+steps.
+
+# Basic Usage
+
+[Parse] reads a todo list into a slice of [Instruction] values, and [Encode]
+writes it back:
+
+	todo, err := rebasetodo.Parse(f)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for i, insn := range todo {
+	    if insn.Command == rebasetodo.Pick && strings.HasPrefix(insn.Message, "WIP") {
+	        todo[i].Command = rebasetodo.Drop
+	    }
+	}
+	rebasetodo.Encode(w, todo)
+
+Comment and blank lines are preserved as instructions of their own, so a todo
+list written with the canonical (long) command names round-trips exactly.
+Abbreviated commands such as "p" or "f" are accepted, but are always written
+back in their long form.
+
+For more information, see the Git documentation for [git rebase].
+
+[git rebase]: https://git-scm.com/docs/git-rebase#_interactive_mode
+*/
+package rebasetodo
@@ -0,0 +1,38 @@
+package rebasetodo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// Fuzz test checking that any parsed todo list re-parses to the same
+// instructions after being encoded.
+func FuzzParse(f *testing.F) {
+	// Add some seed inputs
+	fuzzseed.Add[[]byte](f, "rebasetodo")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		todo, err := Parse(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		var b bytes.Buffer
+		if err := Encode(&b, todo); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		again, err := Parse(&b)
+		if err != nil {
+			t.Fatalf("Parse() of encoded todo %q failed: %v", b.String(), err)
+		}
+		if len(again) != len(todo) {
+			t.Fatalf("round trip changed instruction count from %d to %d", len(todo), len(again))
+		}
+		for i := range todo {
+			if todo[i].String() != again[i].String() {
+				t.Errorf("round trip changed instruction %d from %q to %q", i, todo[i].String(), again[i].String())
+			}
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package rebasetodo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Parse parses a todo list, using "#" as the comment character.
+func Parse(r io.Reader) ([]Instruction, error) {
+	return ParseCommentChar(r, '#')
+}
+
+// ParseCommentChar parses a todo list whose comment lines start with the
+// given character, for repositories configured with a custom core.commentChar.
+func ParseCommentChar(r io.Reader, commentChar byte) ([]Instruction, error) {
+	var todo []Instruction
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		in, err := parseLine(scanner.Text(), commentChar)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		todo = append(todo, in)
+	}
+	return todo, scanner.Err()
+}
+
+// Encode writes the todo list to w, one instruction per line.
+func Encode(w io.Writer, todo []Instruction) error {
+	bw := bufio.NewWriter(w)
+	for _, in := range todo {
+		bw.WriteString(in.String())
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+func parseLine(line string, commentChar byte) (Instruction, error) {
+	// The scanner removes the CR of a CRLF line ending, but not any others
+	// before it, which could not be encoded again.
+	line = strings.TrimRight(line, "\r")
+	trimmed := strings.TrimLeft(line, " \t")
+	if trimmed == "" {
+		return Instruction{Command: Blank}, nil
+	}
+	if trimmed[0] == commentChar {
+		return Instruction{Command: Comment, Message: line}, nil
+	}
+
+	word, rest := cutField(trimmed)
+	cmd := Command(word)
+	if abbr, ok := abbreviations[word]; ok {
+		cmd = abbr
+	}
+
+	switch cmd {
+	case Pick, Reword, Edit, Squash, Drop, Fixup:
+		in := Instruction{Command: cmd}
+		if cmd == Fixup {
+			in.Flag, rest = cutFlag(rest)
+		}
+		in.Commit, in.Message = cutField(rest)
+		if in.Commit == "" {
+			return Instruction{}, fmt.Errorf("missing commit for %s", cmd)
+		}
+		return in, nil
+	case Exec:
+		if rest == "" {
+			return Instruction{}, fmt.Errorf("missing command for exec")
+		}
+		return Instruction{Command: Exec, Message: rest}, nil
+	case Break, Noop:
+		return Instruction{Command: cmd}, nil
+	case Label, UpdateRef:
+		arg, _ := cutField(rest)
+		if arg == "" {
+			return Instruction{}, fmt.Errorf("missing argument for %s", cmd)
+		}
+		return Instruction{Command: cmd, Args: []string{arg}}, nil
+	case Reset:
+		return parseReset(rest)
+	case Merge:
+		return parseMerge(rest)
+	}
+	return Instruction{}, fmt.Errorf("unknown command: %q", word)
+}
+
+// reset <label> [# <comment>]
+func parseReset(rest string) (Instruction, error) {
+	args, msg := cutComment(rest)
+	// The root commit placeholder is the only label containing a space.
+	if args != "[new root]" {
+		args, _ = cutField(args)
+	}
+	if args == "" {
+		return Instruction{}, fmt.Errorf("missing label for reset")
+	}
+	return Instruction{Command: Reset, Args: []string{args}, Message: msg}, nil
+}
+
+// merge [-C <commit> | -c <commit>] <label>... [# <oneline>]
+func parseMerge(rest string) (Instruction, error) {
+	in := Instruction{Command: Merge}
+	if in.Flag, rest = cutFlag(rest); in.Flag != "" {
+		in.Commit, rest = cutField(rest)
+	}
+	args, msg := cutComment(rest)
+	in.Args = strings.FieldsFunc(args, func(r rune) bool { return strings.ContainsRune(fieldSpace, r) })
+	in.Message = msg
+	if len(in.Args) == 0 {
+		return Instruction{}, fmt.Errorf("missing label for merge")
+	}
+	if in.Args[0] == "-C" || in.Args[0] == "-c" {
+		// as in "merge -C# oneline", where the flag is not followed by a
+		// commit, and which could not be encoded again
+		return Instruction{}, fmt.Errorf("missing commit for merge %s", in.Args[0])
+	}
+	return in, nil
+}
+
+// cutField returns the first whitespace-delimited field of s, and the
+// remainder with leading whitespace removed. A CR counts as whitespace, as a
+// field ending in one could not be encoded again: a CR at the end of a line
+// is removed with the line ending.
+func cutField(s string) (field, rest string) {
+	if i := strings.IndexAny(s, fieldSpace); i >= 0 {
+		return s[:i], strings.TrimLeft(s[i:], fieldSpace)
+	}
+	return s, ""
+}
+
+// fieldSpace are the characters separating fields.
+const fieldSpace = " \t\r"
+
+// cutFlag returns the -C or -c flag of a fixup or merge command at the start
+// of s, if any, and the remainder.
+func cutFlag(s string) (flag, rest string) {
+	if field, rest := cutField(s); field == "-C" || field == "-c" {
+		return field, rest
+	}
+	return "", s
+}
+
+// cutComment splits a trailing "# comment" from the arguments of a reset or
+// merge command.
+func cutComment(s string) (args, comment string) {
+	if s, comment, found := strings.Cut(s, "#"); found {
+		return strings.TrimRight(s, fieldSpace), strings.TrimLeft(comment, " ")
+	}
+	return strings.TrimRight(s, fieldSpace), ""
+}
@@ -0,0 +1,193 @@
+package rebasetodo
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	input := "label onto\n" +
+		"\n" +
+		"# Branch topic\n" +
+		"reset onto\n" +
+		"pick 11702c8 c2\n" +
+		"label branch-point\n" +
+		"pick 17b2b5b topic work\n" +
+		"update-ref refs/heads/topic\n" +
+		"reset branch-point # c2\n" +
+		"merge -C 7bc370c topic # Merge branch 'topic'\n" +
+		"fixup -C 8baa052 fixup! c3\n" +
+		"exec make test && echo ok\n" +
+		"break\n"
+
+	got, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []Instruction{
+		{Command: Label, Args: []string{"onto"}},
+		{Command: Blank},
+		{Command: Comment, Message: "# Branch topic"},
+		{Command: Reset, Args: []string{"onto"}},
+		{Command: Pick, Commit: "11702c8", Message: "c2"},
+		{Command: Label, Args: []string{"branch-point"}},
+		{Command: Pick, Commit: "17b2b5b", Message: "topic work"},
+		{Command: UpdateRef, Args: []string{"refs/heads/topic"}},
+		{Command: Reset, Args: []string{"branch-point"}, Message: "c2"},
+		{Command: Merge, Flag: "-C", Commit: "7bc370c", Args: []string{"topic"}, Message: "Merge branch 'topic'"},
+		{Command: Fixup, Flag: "-C", Commit: "8baa052", Message: "fixup! c3"},
+		{Command: Exec, Message: "make test && echo ok"},
+		{Command: Break},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	// testdata/rebase-merges.todo was written by `git rebase -i --rebase-merges
+	// --update-refs`, including git's trailing help comment.
+	input, err := os.ReadFile("testdata/rebase-merges.todo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	todo, err := Parse(bytes.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	var b bytes.Buffer
+	if err := Encode(&b, todo); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if diff := cmp.Diff(string(input), b.String()); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseCommentChar(t *testing.T) {
+	input := "; a comment\npick abc123 # not a comment\n"
+	got, err := ParseCommentChar(strings.NewReader(input), ';')
+	if err != nil {
+		t.Fatalf("ParseCommentChar() error = %v", err)
+	}
+	want := []Instruction{
+		{Command: Comment, Message: "; a comment"},
+		{Command: Pick, Commit: "abc123", Message: "# not a comment"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseCommentChar() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_parseLine(t *testing.T) {
+	testcases := []struct {
+		name    string
+		input   string
+		want    Instruction
+		wantErr bool
+	}{
+		{
+			name:  "abbreviated pick",
+			input: "p abc123 subject line",
+			want:  Instruction{Command: Pick, Commit: "abc123", Message: "subject line"},
+		},
+		{
+			name:  "abbreviated fixup with flag",
+			input: "f -c abc123",
+			want:  Instruction{Command: Fixup, Flag: "-c", Commit: "abc123"},
+		},
+		{
+			name:  "indented with tabs",
+			input: "\t drop\tabc123\tsubject",
+			want:  Instruction{Command: Drop, Commit: "abc123", Message: "subject"},
+		},
+		{
+			name:  "squash without subject",
+			input: "squash abc123",
+			want:  Instruction{Command: Squash, Commit: "abc123"},
+		},
+		{
+			name:  "reset new root",
+			input: "reset [new root]",
+			want:  Instruction{Command: Reset, Args: []string{"[new root]"}},
+		},
+		{
+			name:  "octopus merge without original commit",
+			input: "m a b c",
+			want:  Instruction{Command: Merge, Args: []string{"a", "b", "c"}},
+		},
+		{
+			name:  "noop",
+			input: "noop",
+			want:  Instruction{Command: Noop},
+		},
+		{
+			name:  "exec with carriage returns",
+			input: "x make\r\r",
+			want:  Instruction{Command: Exec, Message: "make"},
+		},
+		{
+			name:  "whitespace only",
+			input: "   ",
+			want:  Instruction{Command: Blank},
+		},
+		{
+			name:    "unknown command",
+			input:   "yolo abc123",
+			wantErr: true,
+		},
+		{
+			name:    "pick without commit",
+			input:   "pick",
+			wantErr: true,
+		},
+		{
+			name:    "exec without command",
+			input:   "x",
+			wantErr: true,
+		},
+		{
+			name:    "merge without label",
+			input:   "merge -C abc123 # oneline",
+			wantErr: true,
+		},
+		{
+			name:    "pick with only a carriage return for commit",
+			input:   "p \r ",
+			wantErr: true,
+		},
+		{
+			name:  "pick with a carriage return after the commit",
+			input: "pick abc123\r subject",
+			want:  Instruction{Command: Pick, Commit: "abc123", Message: "subject"},
+		},
+		{
+			name:    "merge flag followed by a carriage return",
+			input:   "m -C\r0",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseLine(tc.input, '#')
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseLine() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("parseLine() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse_ErrorLineNumber(t *testing.T) {
+	_, err := Parse(strings.NewReader("pick abc123 ok\n# comment\nbogus\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Errorf("Parse() error = %v, want error for line 3", err)
+	}
+}
@@ -0,0 +1,111 @@
+package rebasetodo
+
+import (
+	"strings"
+)
+
+// Command identifies a todo list command by its canonical name.
+type Command string
+
+// Todo list commands. [Comment] and [Blank] are pseudo-commands representing
+// lines that are not instructions.
+const (
+	Pick      Command = "pick"       // use commit
+	Reword    Command = "reword"     // use commit, but edit the commit message
+	Edit      Command = "edit"       // use commit, but stop for amending
+	Squash    Command = "squash"     // use commit, but meld into previous commit
+	Fixup     Command = "fixup"      // like squash, but keep only the previous commit's message
+	Exec      Command = "exec"       // run command using shell
+	Break     Command = "break"      // stop here
+	Drop      Command = "drop"       // remove commit
+	Label     Command = "label"      // label current HEAD with a name
+	Reset     Command = "reset"      // reset HEAD to a label
+	Merge     Command = "merge"      // create a merge commit
+	UpdateRef Command = "update-ref" // track a ref to be updated to this position
+	Noop      Command = "noop"       // do nothing
+
+	Comment Command = "#" // comment line
+	Blank   Command = ""  // blank line
+)
+
+// abbreviations maps the single-letter forms accepted by git to commands.
+var abbreviations = map[string]Command{
+	"p": Pick,
+	"r": Reword,
+	"e": Edit,
+	"s": Squash,
+	"f": Fixup,
+	"x": Exec,
+	"b": Break,
+	"d": Drop,
+	"l": Label,
+	"t": Reset,
+	"m": Merge,
+	"u": UpdateRef,
+}
+
+// TakesCommit reports whether the command operates on a single commit: pick,
+// reword, edit, squash, fixup, and drop.
+func (c Command) TakesCommit() bool {
+	switch c {
+	case Pick, Reword, Edit, Squash, Fixup, Drop:
+		return true
+	}
+	return false
+}
+
+// Instruction represents a single line of a todo list.
+//
+// Which fields are set depends on the Command:
+//   - Pick, Reword, Edit, Squash, Drop: Commit, and Message (the subject)
+//   - Fixup: Commit, Message, and Flag ("-C" or "-c") if given
+//   - Exec: Message (the shell command)
+//   - Label, Reset, UpdateRef: Args (the label or ref), and for Reset, an
+//     optional Message (the comment following "#")
+//   - Merge: Args (the labels or commits to merge), Flag and Commit if the
+//     original merge commit was given with "-C" or "-c", and an optional
+//     Message (the oneline following "#")
+//   - Comment: Message (the full line, including the comment character)
+//   - Break, Noop, Blank: nothing
+type Instruction struct {
+	Command Command
+	Flag    string   `json:",omitempty"` // "-C" or "-c" (fixup and merge only)
+	Commit  string   `json:",omitempty"` // commit the command operates on
+	Args    []string `json:",omitempty"` // label, ref, or merge parent arguments
+	Message string   `json:",omitempty"` // subject, shell command, or comment text
+}
+
+// String returns the instruction formatted as a todo list line, without a
+// trailing newline.
+func (in Instruction) String() string {
+	var b strings.Builder
+	switch {
+	case in.Command == Comment:
+		return in.Message
+	case in.Command == Blank:
+		return ""
+	case in.Command == Exec:
+		return string(Exec) + " " + in.Message
+	case in.Command.TakesCommit():
+		b.WriteString(string(in.Command))
+		if in.Flag != "" {
+			b.WriteString(" " + in.Flag)
+		}
+		b.WriteString(" " + in.Commit)
+		if in.Message != "" {
+			b.WriteString(" " + in.Message)
+		}
+	default:
+		b.WriteString(string(in.Command))
+		if in.Command == Merge && in.Flag != "" {
+			b.WriteString(" " + in.Flag + " " + in.Commit)
+		}
+		for _, arg := range in.Args {
+			b.WriteString(" " + arg)
+		}
+		if in.Message != "" {
+			b.WriteString(" # " + in.Message)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package rebasetodo
+
+import "testing"
+
+func TestInstruction_String(t *testing.T) {
+	testcases := []struct {
+		in   Instruction
+		want string
+	}{
+		{Instruction{Command: Pick, Commit: "abc123", Message: "subject"}, "pick abc123 subject"},
+		{Instruction{Command: Reword, Commit: "abc123"}, "reword abc123"},
+		{Instruction{Command: Fixup, Flag: "-C", Commit: "abc123", Message: "subject"}, "fixup -C abc123 subject"},
+		{Instruction{Command: Exec, Message: "go test ./..."}, "exec go test ./..."},
+		{Instruction{Command: Break}, "break"},
+		{Instruction{Command: Label, Args: []string{"onto"}}, "label onto"},
+		{Instruction{Command: Reset, Args: []string{"onto"}, Message: "c2"}, "reset onto # c2"},
+		{Instruction{Command: UpdateRef, Args: []string{"refs/heads/topic"}}, "update-ref refs/heads/topic"},
+		{Instruction{Command: Merge, Flag: "-c", Commit: "abc123", Args: []string{"topic"}, Message: "Merge"}, "merge -c abc123 topic # Merge"},
+		{Instruction{Command: Merge, Args: []string{"a", "b"}}, "merge a b"},
+		{Instruction{Command: Comment, Message: "# hello"}, "# hello"},
+		{Instruction{Command: Blank}, ""},
+	}
+
+	for _, tc := range testcases {
+		if got := tc.in.String(); got != tc.want {
+			t.Errorf("%+v.String() = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestCommand_TakesCommit(t *testing.T) {
+	for _, c := range []Command{Pick, Reword, Edit, Squash, Fixup, Drop} {
+		if !c.TakesCommit() {
+			t.Errorf("%q.TakesCommit() = false, want true", c)
+		}
+	}
+	for _, c := range []Command{Exec, Break, Label, Reset, Merge, UpdateRef, Noop, Comment, Blank} {
+		if c.TakesCommit() {
+			t.Errorf("%q.TakesCommit() = true, want false", c)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Package reflog parses `git reflog` output produced with a package-defined format.
+
+The default output of `git reflog` is intended for humans and omits most of
+the information recorded for each entry. This package instead defines a
+[Format] for `git reflog show --format` that captures the ref, timestamp,
+new object name, actor, and message of every entry in a form that can be
+parsed unambiguously.
+
+# Basic Usage
+
+Run git with the arguments returned by [Args], and pass its output to a
+[Decoder], which streams entries one at a time for long histories:
+
+	cmd := exec.Command("git", reflog.Args("HEAD")...)
+	out, _ := cmd.StdoutPipe()
+	cmd.Start()
+
+	dec := reflog.NewDecoder(out)
+	for {
+	    e, err := dec.Next()
+	    if err == io.EOF {
+	        break
+	    } else if err != nil {
+	        log.Fatal(err)
+	    }
+	    fmt.Printf("%s %s %s\n", e.Selector(), e.Action, e.Message)
+	}
+
+[Parse] reads all entries at once.
+
+# Old Object Names
+
+Git's pretty formats do not expose the previous value recorded in a reflog
+entry. Since every entry's old value is the new value of the entry before it,
+the [Decoder] fills in [Entry.OldOID] from the next (older) entry of the same
+ref, reading one entry ahead to do so. The oldest entry read has no older
+entry to consult, so its OldOID is left empty.
+
+For more information, see the Git documentation for [git reflog].
+
+[git reflog]: https://git-scm.com/docs/git-reflog
+*/
+package reflog
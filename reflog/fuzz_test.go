@@ -0,0 +1,25 @@
+package reflog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// Fuzz test for Parse function
+func FuzzParse(f *testing.F) {
+	// Add some seed inputs
+	f.Add([]byte(sampleReflogOutput))
+	fuzzseed.Add[[]byte](f, "reflog")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Parser should never panic, only return an error for invalid input
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("Parse panicked with input %q: %v", data, r)
+			}
+		}()
+		Parse(bytes.NewReader(data))
+	})
+}
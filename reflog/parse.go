@@ -0,0 +1,173 @@
+package reflog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is the `git reflog show --format` string understood by this package.
+//
+// Fields are NUL-separated, and each entry is terminated by LF. Git collapses
+// reflog messages to a single line, so no field can contain either byte. The
+// selector (%gD) must be formatted with --date=raw for the entry timestamp to
+// be included.
+const Format = "%gD%x00%H%x00%gn%x00%ge%x00%gs"
+
+// Args returns the git arguments for listing the reflog of ref in [Format].
+func Args(ref string) []string {
+	return []string{"reflog", "show", "--date=raw", "--format=" + Format, ref, "--"}
+}
+
+// Parse parses all entries of `git reflog` output produced with [Args].
+func Parse(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	dec := NewDecoder(r)
+	for {
+		e, err := dec.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+}
+
+// A Decoder reads reflog entries from a stream of `git reflog` output
+// produced with [Args].
+type Decoder struct {
+	scanner *bufio.Scanner
+	next    *Entry         // entry read ahead to determine the old OID
+	indexes map[string]int // next index per ref
+	err     error
+}
+
+// NewDecoder returns a new Decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		scanner: bufio.NewScanner(r),
+		indexes: make(map[string]int),
+	}
+}
+
+// Next returns the next reflog entry, or [io.EOF] when no entries remain.
+func (d *Decoder) Next() (Entry, error) {
+	if d.next == nil {
+		e, err := d.read()
+		if err != nil {
+			return Entry{}, err
+		}
+		d.next = &e
+	}
+
+	cur := *d.next
+	d.next = nil
+	if next, err := d.read(); err == nil {
+		if next.Ref == cur.Ref {
+			cur.OldOID = next.NewOID
+		}
+		d.next = &next
+	} else if err != io.EOF {
+		// Hold the error until the current entry has been returned.
+		d.err = err
+	}
+	return cur, nil
+}
+
+// read parses the next line into an entry, assigning its index.
+func (d *Decoder) read() (Entry, error) {
+	if d.err != nil {
+		return Entry{}, d.err
+	}
+	for d.scanner.Scan() {
+		line := d.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		e, err := parseEntry(line)
+		if err != nil {
+			d.err = err
+			return Entry{}, err
+		}
+		e.Index = d.indexes[e.Ref]
+		d.indexes[e.Ref]++
+		return e, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		d.err = err
+		return Entry{}, err
+	}
+	d.err = io.EOF
+	return Entry{}, io.EOF
+}
+
+// Entries have the following format, where fields are separated by NUL:
+// <ref>@{<unix> <tz>} <new> <name> <email> <action>: <message>
+func parseEntry(line []byte) (Entry, error) {
+	var zero Entry
+	fields := bytes.Split(line, []byte{'\x00'})
+	if len(fields) != 5 {
+		return zero, fmt.Errorf("invalid reflog entry: expected 5 fields, got %d: %q", len(fields), line)
+	}
+
+	ref, when, err := parseSelector(fields[0])
+	if err != nil {
+		return zero, err
+	}
+
+	action, message, _ := strings.Cut(string(fields[4]), ": ")
+
+	return Entry{
+		Ref:     ref,
+		NewOID:  string(fields[1]),
+		Actor:   Identity{Name: string(fields[2]), Email: string(fields[3])},
+		Time:    when,
+		Action:  action,
+		Message: message,
+	}, nil
+}
+
+// Selectors formatted with --date=raw have the following format:
+// <ref>@{<unix timestamp> <+/-hhmm>}
+func parseSelector(field []byte) (string, time.Time, error) {
+	i := bytes.LastIndex(field, []byte("@{"))
+	if i <= 0 || !bytes.HasSuffix(field, []byte{'}'}) {
+		return "", time.Time{}, fmt.Errorf("invalid reflog selector: %q", field)
+	}
+	when, err := parseRawDate(field[i+2 : len(field)-1])
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid reflog selector %q: %w", field, err)
+	}
+	return string(field[:i]), when, nil
+}
+
+// parseRawDate parses a date in git's raw format, e.g. "1792262309 +0200".
+func parseRawDate(field []byte) (time.Time, error) {
+	secField, tzField, found := bytes.Cut(field, []byte{' '})
+	if !found {
+		return time.Time{}, fmt.Errorf("invalid raw date %q: missing time zone (was --date=raw used?)", field)
+	}
+	sec, err := strconv.ParseInt(string(secField), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid raw date %q: %w", field, err)
+	}
+	if len(tzField) != 5 || (tzField[0] != '+' && tzField[0] != '-') {
+		return time.Time{}, fmt.Errorf("invalid raw date time zone: %q", tzField)
+	}
+	hh, errH := strconv.Atoi(string(tzField[1:3]))
+	mm, errM := strconv.Atoi(string(tzField[3:5]))
+	if errH != nil || errM != nil || hh < 0 || mm < 0 {
+		return time.Time{}, fmt.Errorf("invalid raw date time zone: %q", tzField)
+	}
+	offset := hh*3600 + mm*60
+	if tzField[0] == '-' {
+		offset = -offset
+	}
+	return time.Unix(sec, 0).In(time.FixedZone("", offset)), nil
+}
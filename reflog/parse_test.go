@@ -0,0 +1,199 @@
+package reflog
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// sampleReflogOutput is the output of `git reflog show --date=raw
+// --format=<Format> HEAD` for a short history.
+var sampleReflogOutput = "HEAD@{1792262313 +0200}\x00ac38b1b7893d9097854d1b2bf0d0ac6b261378dd\x00A U Thor\x00author@example.com\x00commit: n\n" +
+	"HEAD@{1792262309 +0000}\x00df6a620370d719a420a039a14ab14f342d3438f7\x00A U Thor\x00author@example.com\x00checkout: moving from main to clean1\n" +
+	"HEAD@{1792262309 -0430}\x0097516266cf63ca9f9c2ad45010ccbda99a87e91c\x00A U Thor\x00author@example.com\x00commit: s2\n" +
+	"HEAD@{1792262300 +0000}\x00df6a620370d719a420a039a14ab14f342d3438f7\x00A U Thor\x00author@example.com\x00commit (initial): base\n"
+
+func TestParse(t *testing.T) {
+	got, err := Parse(strings.NewReader(sampleReflogOutput))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	thor := Identity{Name: "A U Thor", Email: "author@example.com"}
+	want := []Entry{
+		{
+			Ref:     "HEAD",
+			Index:   0,
+			OldOID:  "df6a620370d719a420a039a14ab14f342d3438f7",
+			NewOID:  "ac38b1b7893d9097854d1b2bf0d0ac6b261378dd",
+			Actor:   thor,
+			Time:    time.Unix(1792262313, 0).In(time.FixedZone("", 2*3600)),
+			Action:  "commit",
+			Message: "n",
+		},
+		{
+			Ref:     "HEAD",
+			Index:   1,
+			OldOID:  "97516266cf63ca9f9c2ad45010ccbda99a87e91c",
+			NewOID:  "df6a620370d719a420a039a14ab14f342d3438f7",
+			Actor:   thor,
+			Time:    time.Unix(1792262309, 0).In(time.FixedZone("", 0)),
+			Action:  "checkout",
+			Message: "moving from main to clean1",
+		},
+		{
+			Ref:     "HEAD",
+			Index:   2,
+			OldOID:  "df6a620370d719a420a039a14ab14f342d3438f7",
+			NewOID:  "97516266cf63ca9f9c2ad45010ccbda99a87e91c",
+			Actor:   thor,
+			Time:    time.Unix(1792262309, 0).In(time.FixedZone("", -(4*3600 + 30*60))),
+			Action:  "commit",
+			Message: "s2",
+		},
+		{
+			Ref:     "HEAD",
+			Index:   3,
+			NewOID:  "df6a620370d719a420a039a14ab14f342d3438f7",
+			Actor:   thor,
+			Time:    time.Unix(1792262300, 0).In(time.FixedZone("", 0)),
+			Action:  "commit (initial)",
+			Message: "base",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+	if sel := got[1].Selector(); sel != "HEAD@{1}" {
+		t.Errorf("Selector() = %q, want %q", sel, "HEAD@{1}")
+	}
+	if _, offset := got[2].Time.Zone(); offset != -(4*3600 + 30*60) {
+		t.Errorf("Time zone offset = %d, want %d", offset, -(4*3600 + 30*60))
+	}
+}
+
+func TestDecoder_MultipleRefs(t *testing.T) {
+	input := "refs/heads/a@{1700000002 +0000}\x00aaaa\x00n\x00e\x00commit: a2\n" +
+		"refs/heads/b@{1700000001 +0000}\x00bbbb\x00n\x00e\x00commit: b1\n" +
+		"refs/heads/a@{1700000000 +0000}\x00cccc\x00n\x00e\x00branch: Created from HEAD\n"
+
+	got, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	var selectors, olds []string
+	for _, e := range got {
+		selectors = append(selectors, e.Selector())
+		olds = append(olds, e.OldOID)
+	}
+	if diff := cmp.Diff([]string{"refs/heads/a@{0}", "refs/heads/b@{0}", "refs/heads/a@{1}"}, selectors); diff != "" {
+		t.Errorf("selectors mismatch (-want +got):\n%s", diff)
+	}
+	// Old OIDs are only filled in from an adjacent entry of the same ref.
+	if diff := cmp.Diff([]string{"", "", ""}, olds); diff != "" {
+		t.Errorf("old OIDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecoder_ErrorAfterValidEntry(t *testing.T) {
+	input := "HEAD@{1700000000 +0000}\x00aaaa\x00n\x00e\x00commit: ok\n" +
+		"not a reflog entry\n"
+
+	dec := NewDecoder(strings.NewReader(input))
+	e, err := dec.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v, want valid first entry", err)
+	}
+	if e.Message != "ok" {
+		t.Errorf("Next().Message = %q, want %q", e.Message, "ok")
+	}
+	if _, err := dec.Next(); err == nil || errors.Is(err, io.EOF) {
+		t.Errorf("Next() error = %v, want parse error", err)
+	}
+	if _, err := dec.Next(); err == nil || errors.Is(err, io.EOF) {
+		t.Errorf("Next() after error = %v, want sticky parse error", err)
+	}
+}
+
+func TestDecoder_Empty(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(""))
+	if _, err := dec.Next(); err != io.EOF {
+		t.Errorf("Next() error = %v, want io.EOF", err)
+	}
+}
+
+func Test_parseEntry(t *testing.T) {
+	testcases := []struct {
+		name    string
+		input   string
+		want    Entry
+		wantErr bool
+	}{
+		{
+			name:  "message without colon",
+			input: "HEAD@{1700000000 +0000}\x00aaaa\x00n\x00e\x00manual update",
+			want: Entry{
+				Ref:    "HEAD",
+				NewOID: "aaaa",
+				Actor:  Identity{Name: "n", Email: "e"},
+				Time:   time.Unix(1700000000, 0).In(time.FixedZone("", 0)),
+				Action: "manual update",
+			},
+		},
+		{
+			name:  "ref containing @",
+			input: "refs/heads/me@work@{1700000000 +0000}\x00aaaa\x00n\x00e\x00reset: moving to HEAD~1",
+			want: Entry{
+				Ref:     "refs/heads/me@work",
+				NewOID:  "aaaa",
+				Actor:   Identity{Name: "n", Email: "e"},
+				Time:    time.Unix(1700000000, 0).In(time.FixedZone("", 0)),
+				Action:  "reset",
+				Message: "moving to HEAD~1",
+			},
+		},
+		{
+			name:    "missing fields",
+			input:   "HEAD@{1700000000 +0000}\x00aaaa\x00n",
+			wantErr: true,
+		},
+		{
+			name:    "index selector (no --date=raw)",
+			input:   "HEAD@{0}\x00aaaa\x00n\x00e\x00commit: x",
+			wantErr: true,
+		},
+		{
+			name:    "invalid time zone",
+			input:   "HEAD@{1700000000 +-100}\x00aaaa\x00n\x00e\x00commit: x",
+			wantErr: true,
+		},
+		{
+			name:    "missing selector",
+			input:   "\x00aaaa\x00n\x00e\x00commit: x",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseEntry([]byte(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseEntry() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("parseEntry() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIdentity_String(t *testing.T) {
+	id := Identity{Name: "A U Thor", Email: "author@example.com"}
+	if got, want := id.String(), "A U Thor <author@example.com>"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+package reflog
+
+import (
+	"strconv"
+	"time"
+)
+
+// Entry represents a single reflog entry.
+type Entry struct {
+	Ref     string    // full ref name, e.g. "HEAD" or "refs/heads/main"
+	Index   int       // position in the reflog, newest first, as in <ref>@{<index>}
+	OldOID  string    // object name before the update (see package docs); empty if unknown
+	NewOID  string    // object name after the update
+	Actor   Identity  // identity that performed the update
+	Time    time.Time // when the update happened, in the actor's time zone
+	Action  string    // kind of update, e.g. "commit", "checkout", "reset", "commit (amend)"
+	Message string    // remainder of the reflog message, e.g. "moving from main to topic"
+}
+
+// Selector returns the reflog selector for the entry, e.g. "HEAD@{2}".
+func (e Entry) Selector() string {
+	return e.Ref + "@{" + strconv.Itoa(e.Index) + "}"
+}
+
+// Identity represents the name and email of a git user.
+type Identity struct {
+	Name  string
+	Email string
+}
+
+// String returns the identity in the conventional "Name <email>" form.
+func (id Identity) String() string {
+	return id.Name + " <" + id.Email + ">"
+}
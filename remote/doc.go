@@ -0,0 +1,40 @@
+/*
+Package remote parses the output of `git remote -v` and `git remote get-url`.
+
+# Basic Usage
+
+[Parse] takes an [io.Reader] containing `git remote -v` output, and returns
+the configured remotes with their fetch and push URLs:
+
+	r := bytes.NewReader(gitRemoteOutput)
+	remotes, err := remote.Parse(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	if origin, ok := remotes.Get("origin"); ok {
+	    fmt.Println(origin.FetchURL(), origin.PushURL())
+	}
+
+Note that `git remote -v` lists only the first fetch URL of a remote with
+several. [ParseURLs] parses the output of `git remote get-url --all`, which
+lists them all.
+
+[List] and [GetURLs] run the commands through a [gitexec.Runner] and parse
+their output.
+
+# Git Remote Format
+
+Each line of `git remote -v` has the following format, listing first the
+fetch URL and then the push URLs of each remote:
+
+	<name> TAB <url> SP "(fetch)" [SP "[" <filter> "]"]
+	<name> TAB <url> SP "(push)"
+
+where filter is the partial clone filter of promisor remotes. The push URLs
+are the remote's pushurl values, or its url values if it has none.
+
+For more information, see the Git documentation for [git remote].
+
+[git remote]: https://git-scm.com/docs/git-remote
+*/
+package remote
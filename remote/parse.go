@@ -0,0 +1,89 @@
+package remote
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Parse parses the output of `git remote -v`.
+//
+// Remotes are returned in the order they are listed, which git sorts by name.
+func Parse(r io.Reader) (Remotes, error) {
+	var remotes Remotes
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		name, url, push, filter, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(remotes) == 0 || remotes[len(remotes)-1].Name != name {
+			remotes = append(remotes, Remote{Name: name})
+		}
+		rem := &remotes[len(remotes)-1]
+		if push {
+			rem.PushURLs = append(rem.PushURLs, url)
+		} else {
+			rem.FetchURLs = append(rem.FetchURLs, url)
+			if filter != "" {
+				rem.Filter = filter
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return remotes, nil
+}
+
+// Lines have the following format:
+// <name> TAB <url> SP (fetch|push) [SP [<filter>]]
+func parseLine(line string) (name, url string, push bool, filter string, err error) {
+	name, rest, found := strings.Cut(line, "\t")
+	if !found || name == "" {
+		return "", "", false, "", fmt.Errorf("invalid remote line: %q", line)
+	}
+
+	if strings.HasSuffix(rest, "]") {
+		if i := strings.LastIndex(rest, " ["); i >= 0 {
+			filter = rest[i+2 : len(rest)-1]
+			rest = rest[:i]
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(rest, " (fetch)"):
+		url = strings.TrimSuffix(rest, " (fetch)")
+	case strings.HasSuffix(rest, " (push)"):
+		url = strings.TrimSuffix(rest, " (push)")
+		push = true
+	default:
+		return "", "", false, "", fmt.Errorf("invalid remote line: missing direction: %q", line)
+	}
+	if url == "" {
+		return "", "", false, "", fmt.Errorf("invalid remote line: missing URL: %q", line)
+	}
+	return name, url, push, filter, nil
+}
+
+// ParseURLs parses the output of `git remote get-url`, which lists one URL per
+// line.
+func ParseURLs(r io.Reader) ([]string, error) {
+	var urls []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			urls = append(urls, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
@@ -0,0 +1,102 @@
+package remote
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Remotes
+	}{
+		{
+			name:  "single",
+			input: "origin\tgit@github.com:mroth/porcelain.git (fetch)\norigin\tgit@github.com:mroth/porcelain.git (push)\n",
+			want: Remotes{{
+				Name:      "origin",
+				FetchURLs: []string{"git@github.com:mroth/porcelain.git"},
+				PushURLs:  []string{"git@github.com:mroth/porcelain.git"},
+			}},
+		},
+		{
+			name: "push URLs and filter",
+			input: "multi\thttps://a.example/r.git (fetch)\n" +
+				"multi\tssh://push.example/r.git (push)\n" +
+				"multi\tssh://mirror.example/r.git (push)\n" +
+				"origin\t/tmp/br (fetch) [blob:none]\n" +
+				"origin\t/tmp/br (push)\n",
+			want: Remotes{
+				{
+					Name:      "multi",
+					FetchURLs: []string{"https://a.example/r.git"},
+					PushURLs:  []string{"ssh://push.example/r.git", "ssh://mirror.example/r.git"},
+				},
+				{
+					Name:      "origin",
+					FetchURLs: []string{"/tmp/br"},
+					PushURLs:  []string{"/tmp/br"},
+					Filter:    "blob:none",
+				},
+			},
+		},
+		{
+			name:  "path with spaces and parentheses",
+			input: "local\t/srv/my repo (old) (fetch)\nlocal\t/srv/my repo (old) (push)\n",
+			want: Remotes{{
+				Name:      "local",
+				FetchURLs: []string{"/srv/my repo (old)"},
+				PushURLs:  []string{"/srv/my repo (old)"},
+			}},
+		},
+		{
+			name:  "no remotes",
+			input: "",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"names only", "origin\nupstream\n"},
+		{"missing direction", "origin\thttps://example.com/r.git\n"},
+		{"missing URL", "origin\t (fetch)\n"},
+		{"missing name", "\thttps://example.com/r.git (fetch)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.input)); err == nil {
+				t.Error("Parse() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestParseURLs(t *testing.T) {
+	got, err := ParseURLs(strings.NewReader("https://a.example/r.git\nhttps://b.example/r.git\n"))
+	if err != nil {
+		t.Fatalf("ParseURLs() error = %v", err)
+	}
+	want := []string{"https://a.example/r.git", "https://b.example/r.git"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseURLs() mismatch (-want +got):\n%s", diff)
+	}
+}
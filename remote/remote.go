@@ -0,0 +1,89 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// Remote represents a configured remote repository.
+type Remote struct {
+	Name      string   // name of the remote, e.g. "origin"
+	FetchURLs []string // URLs fetched from
+	PushURLs  []string // URLs pushed to
+	Filter    string   // partial clone filter, e.g. "blob:none"; empty if none
+}
+
+// FetchURL returns the first fetch URL of the remote, or "" if it has none.
+func (r Remote) FetchURL() string {
+	if len(r.FetchURLs) == 0 {
+		return ""
+	}
+	return r.FetchURLs[0]
+}
+
+// PushURL returns the first push URL of the remote, or "" if it has none.
+func (r Remote) PushURL() string {
+	if len(r.PushURLs) == 0 {
+		return ""
+	}
+	return r.PushURLs[0]
+}
+
+// Remotes is a list of remotes, in the order git lists them.
+type Remotes []Remote
+
+// Get returns the remote with the given name, and whether it was found.
+func (rs Remotes) Get(name string) (Remote, bool) {
+	for _, r := range rs {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Remote{}, false
+}
+
+// Names returns the names of all remotes.
+func (rs Remotes) Names() []string {
+	names := make([]string, len(rs))
+	for i, r := range rs {
+		names[i] = r.Name
+	}
+	return names
+}
+
+// ErrNotFound is returned by [GetURLs] when no remote has the given name.
+var ErrNotFound = errors.New("no such remote")
+
+// List runs `git remote -v` and returns all remotes.
+func List(ctx context.Context, git gitexec.Runner) (Remotes, error) {
+	out, err := git.Run(ctx, "remote", "-v")
+	if err != nil {
+		return nil, err
+	}
+	return Parse(bytes.NewReader(out))
+}
+
+// GetURLs runs `git remote get-url --all` and returns all fetch URLs of the
+// named remote, or all push URLs if push is set.
+//
+// If there is no such remote, the returned error wraps [ErrNotFound].
+func GetURLs(ctx context.Context, git gitexec.Runner, name string, push bool) ([]string, error) {
+	args := []string{"remote", "get-url", "--all"}
+	if push {
+		args = append(args, "--push")
+	}
+	out, err := git.Run(ctx, append(args, name)...)
+	if err != nil {
+		// git exits with status 2 and "error: No such remote '<name>'"
+		var exitErr *gitexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode == 2 {
+			return nil, fmt.Errorf("%w: %q", ErrNotFound, name)
+		}
+		return nil, err
+	}
+	return ParseURLs(bytes.NewReader(out))
+}
@@ -0,0 +1,93 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/gitexec"
+)
+
+func TestRemotes(t *testing.T) {
+	remotes := Remotes{
+		{Name: "origin", FetchURLs: []string{"https://a.example/r.git"}, PushURLs: []string{"ssh://a.example/r.git"}},
+		{Name: "upstream"},
+	}
+	if diff := cmp.Diff([]string{"origin", "upstream"}, remotes.Names()); diff != "" {
+		t.Errorf("Names() mismatch (-want +got):\n%s", diff)
+	}
+
+	origin, ok := remotes.Get("origin")
+	if !ok {
+		t.Fatal("Get(origin) not found")
+	}
+	if got, want := origin.FetchURL(), "https://a.example/r.git"; got != want {
+		t.Errorf("FetchURL() = %q, want %q", got, want)
+	}
+	if got, want := origin.PushURL(), "ssh://a.example/r.git"; got != want {
+		t.Errorf("PushURL() = %q, want %q", got, want)
+	}
+
+	upstream, _ := remotes.Get("upstream")
+	if upstream.FetchURL() != "" || upstream.PushURL() != "" {
+		t.Errorf("URLs of remote without URLs = %q, %q; want empty", upstream.FetchURL(), upstream.PushURL())
+	}
+	if _, ok := remotes.Get("fork"); ok {
+		t.Error("Get(fork) found, want not found")
+	}
+}
+
+// fakeRunner is a gitexec.Runner returning canned results, recording the
+// arguments it was called with.
+type fakeRunner struct {
+	out  string
+	err  error
+	args []string
+}
+
+func (f *fakeRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+	f.args = args
+	return []byte(f.out), f.err
+}
+
+func TestList(t *testing.T) {
+	git := &fakeRunner{out: "origin\thttps://a.example/r.git (fetch)\norigin\thttps://a.example/r.git (push)\n"}
+	got, err := List(context.Background(), git)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := Remotes{{Name: "origin", FetchURLs: []string{"https://a.example/r.git"}, PushURLs: []string{"https://a.example/r.git"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("List() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"remote", "-v"}, git.args); diff != "" {
+		t.Errorf("List() args mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetURLs(t *testing.T) {
+	git := &fakeRunner{out: "ssh://push.example/r.git\n"}
+	got, err := GetURLs(context.Background(), git, "multi", true)
+	if err != nil {
+		t.Fatalf("GetURLs() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"ssh://push.example/r.git"}, got); diff != "" {
+		t.Errorf("GetURLs() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"remote", "get-url", "--all", "--push", "multi"}, git.args); diff != "" {
+		t.Errorf("GetURLs() args mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetURLs_NotFound(t *testing.T) {
+	git := &fakeRunner{err: &gitexec.ExitError{
+		Args:     []string{"remote", "get-url", "--all", "nope"},
+		ExitCode: 2,
+		Stderr:   []byte("error: No such remote 'nope'\n"),
+	}}
+	_, err := GetURLs(context.Background(), git, "nope", false)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetURLs() error = %v, want ErrNotFound", err)
+	}
+}
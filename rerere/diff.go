@@ -0,0 +1,153 @@
+package rerere
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// FileDiff represents the diff of a single path, from its conflicted
+// preimage to its current contents.
+type FileDiff struct {
+	Path  string
+	Hunks []Hunk
+}
+
+// Hunk represents a single hunk of a diff.
+type Hunk struct {
+	OldStart, OldLines int // range of lines in the preimage
+	NewStart, NewLines int // range of lines in the current contents
+
+	// Lines of the hunk, each prefixed with ' ', '-' or '+' (or '\' for
+	// "\ No newline at end of file" markers), without line terminators.
+	Lines []string
+}
+
+// ParseDiff parses the output of `git rerere diff`.
+//
+// Each path's diff starts with a "--- a/<path>" and "+++ b/<path>" preamble,
+// followed by its hunks.
+func ParseDiff(r io.Reader) ([]FileDiff, error) {
+	var diffs []FileDiff
+	var hunk *Hunk
+	var oldLeft, newLeft int // lines remaining in the current hunk
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Hunk lines are consumed by count, as removed lines may themselves
+		// start with "--".
+		if hunk != nil && (oldLeft > 0 || newLeft > 0 || strings.HasPrefix(line, `\`)) {
+			switch {
+			case strings.HasPrefix(line, " "):
+				oldLeft--
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+			case strings.HasPrefix(line, `\`):
+			case line == "":
+				// context line of an empty line, with trailing space stripped
+				oldLeft--
+				newLeft--
+				line = " "
+			default:
+				return nil, fmt.Errorf("invalid rerere diff hunk line: %q", line)
+			}
+			if oldLeft < 0 || newLeft < 0 {
+				return nil, fmt.Errorf("invalid rerere diff hunk: too many lines: %q", line)
+			}
+			hunk.Lines = append(hunk.Lines, line)
+			continue
+		}
+		hunk = nil
+
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			path, err := parsePreamblePath(line, "--- a/")
+			if err != nil {
+				return nil, err
+			}
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("invalid rerere diff: missing +++ line after %q", line)
+			}
+			if _, err := parsePreamblePath(scanner.Text(), "+++ b/"); err != nil {
+				return nil, err
+			}
+			diffs = append(diffs, FileDiff{Path: path})
+
+		case strings.HasPrefix(line, "@@ "):
+			if len(diffs) == 0 {
+				return nil, fmt.Errorf("invalid rerere diff: hunk before preamble: %q", line)
+			}
+			h, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			d := &diffs[len(diffs)-1]
+			d.Hunks = append(d.Hunks, h)
+			hunk = &d.Hunks[len(d.Hunks)-1]
+			oldLeft, newLeft = h.OldLines, h.NewLines
+
+		case line == "":
+			continue
+
+		default:
+			return nil, fmt.Errorf("invalid rerere diff line: %q", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+		return nil, fmt.Errorf("invalid rerere diff: truncated hunk")
+	}
+	return diffs, nil
+}
+
+func parsePreamblePath(line, prefix string) (string, error) {
+	path, ok := strings.CutPrefix(line, prefix)
+	if !ok || path == "" {
+		return "", fmt.Errorf("invalid rerere diff preamble: %q", line)
+	}
+	return path, nil
+}
+
+// Hunk headers have the following format, where a count of 1 may be omitted:
+// @@ -<old start>[,<old lines>] +<new start>[,<new lines>] @@
+func parseHunkHeader(line string) (Hunk, error) {
+	var h Hunk
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[0] != "@@" || fields[3] != "@@" {
+		return h, fmt.Errorf("invalid rerere diff hunk header: %q", line)
+	}
+	var err1, err2 error
+	h.OldStart, h.OldLines, err1 = parseRange(fields[1], "-")
+	h.NewStart, h.NewLines, err2 = parseRange(fields[2], "+")
+	if err1 != nil || err2 != nil {
+		return h, fmt.Errorf("invalid rerere diff hunk header: %q", line)
+	}
+	return h, nil
+}
+
+func parseRange(s, sign string) (start, lines int, err error) {
+	s, ok := strings.CutPrefix(s, sign)
+	if !ok {
+		return 0, 0, fmt.Errorf("missing %q", sign)
+	}
+	startStr, linesStr, found := strings.Cut(s, ",")
+	if start, err = strconv.Atoi(startStr); err != nil || start < 0 {
+		return 0, 0, fmt.Errorf("invalid start: %q", startStr)
+	}
+	lines = 1
+	if found {
+		if lines, err = strconv.Atoi(linesStr); err != nil || lines < 0 {
+			return 0, 0, fmt.Errorf("invalid line count: %q", linesStr)
+		}
+	}
+	return start, lines, nil
+}
@@ -0,0 +1,95 @@
+package rerere
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDiff(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []FileDiff
+	}{
+		{
+			name: "resolved and unresolved",
+			input: "--- a/f\n+++ b/f\n@@ -1,7 +1,3 @@\n a\n-<<<<<<<\n-B1\n-=======\n-B2\n->>>>>>>\n+B\n c\n" +
+				"--- a/g\n+++ b/g\n@@ -1,5 +1,5 @@\n-<<<<<<<\n-x1\n-=======\n+<<<<<<< HEAD\n x2\n->>>>>>>\n+=======\n+x1\n+>>>>>>> o\n",
+			want: []FileDiff{
+				{
+					Path: "f",
+					Hunks: []Hunk{{
+						OldStart: 1, OldLines: 7, NewStart: 1, NewLines: 3,
+						Lines: []string{" a", "-<<<<<<<", "-B1", "-=======", "-B2", "->>>>>>>", "+B", " c"},
+					}},
+				},
+				{
+					Path: "g",
+					Hunks: []Hunk{{
+						OldStart: 1, OldLines: 5, NewStart: 1, NewLines: 5,
+						Lines: []string{"-<<<<<<<", "-x1", "-=======", "+<<<<<<< HEAD", " x2", "->>>>>>>", "+=======", "+x1", "+>>>>>>> o"},
+					}},
+				},
+			},
+		},
+		{
+			name:  "lines resembling preamble",
+			input: "--- a/f\n+++ b/f\n@@ -1 +1 @@\n--- a/x\n+++ b/y\n",
+			want: []FileDiff{{
+				Path:  "f",
+				Hunks: []Hunk{{OldStart: 1, OldLines: 1, NewStart: 1, NewLines: 1, Lines: []string{"--- a/x", "+++ b/y"}}},
+			}},
+		},
+		{
+			name:  "multiple hunks and no newline",
+			input: "--- a/sp ä\"x\n+++ b/sp ä\"x\n@@ -1 +1 @@\n-a\n+b\n@@ -10,0 +11,2 @@\n+c\n+d\n\\ No newline at end of file\n",
+			want: []FileDiff{{
+				Path: "sp ä\"x",
+				Hunks: []Hunk{
+					{OldStart: 1, OldLines: 1, NewStart: 1, NewLines: 1, Lines: []string{"-a", "+b"}},
+					{OldStart: 10, OldLines: 0, NewStart: 11, NewLines: 2, Lines: []string{"+c", "+d", `\ No newline at end of file`}},
+				},
+			}},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDiff(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseDiff() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseDiff() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseDiff_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing +++", "--- a/f\n"},
+		{"invalid preamble", "--- f\n+++ f\n"},
+		{"hunk before preamble", "@@ -1 +1 @@\n-a\n+b\n"},
+		{"invalid hunk header", "--- a/f\n+++ b/f\n@@ -x +1 @@\n"},
+		{"truncated hunk", "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n a\n"},
+		{"too many lines", "--- a/f\n+++ b/f\n@@ -1 +1 @@\n-a\n-b\n"},
+		{"garbage", "Resolved 'f' using previous resolution.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseDiff(strings.NewReader(tt.input)); err == nil {
+				t.Error("ParseDiff() succeeded, want error")
+			}
+		})
+	}
+}
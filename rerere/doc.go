@@ -0,0 +1,41 @@
+/*
+Package rerere parses the output of `git rerere status`, `git rerere remaining`
+and `git rerere diff`.
+
+When rerere ("reuse recorded resolution") is enabled, git records how
+conflicts were resolved and resolves identical conflicts automatically the
+next time they occur. The rerere subcommands report on the conflicts of an
+ongoing merge:
+
+  - `git rerere status` lists the paths whose conflicts rerere has recorded,
+    and will remember the resolution of.
+  - `git rerere remaining` lists the paths with conflicts rerere did not
+    resolve automatically.
+  - `git rerere diff` shows the current state of each recorded resolution,
+    as a diff against the conflicted preimage.
+
+# Basic Usage
+
+[ParsePaths] parses the path listings of status and remaining:
+
+	r := bytes.NewReader(gitRerereRemainingOutput)
+	paths, err := rerere.ParsePaths(r)
+
+[ParseDiff] parses the diff, returning one [FileDiff] per path:
+
+	diffs, err := rerere.ParseDiff(r)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	for _, d := range diffs {
+	    fmt.Printf("%s: %d hunks\n", d.Path, len(d.Hunks))
+	}
+
+[Status], [Remaining] and [Diff] run the commands through a
+[gitexec.Runner] and parse their output.
+
+For more information, see the Git documentation for [git rerere].
+
+[git rerere]: https://git-scm.com/docs/git-rerere
+*/
+package rerere
@@ -0,0 +1,56 @@
+package rerere
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// ParsePaths parses the output of `git rerere status` or
+// `git rerere remaining`, which list one path per line.
+func ParsePaths(r io.Reader) ([]string, error) {
+	var paths []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			paths = append(paths, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// Status runs `git rerere status` and returns the paths whose conflicts
+// rerere has recorded.
+func Status(ctx context.Context, git gitexec.Runner) ([]string, error) {
+	return runPaths(ctx, git, "status")
+}
+
+// Remaining runs `git rerere remaining` and returns the paths with conflicts
+// rerere did not resolve automatically.
+func Remaining(ctx context.Context, git gitexec.Runner) ([]string, error) {
+	return runPaths(ctx, git, "remaining")
+}
+
+// Diff runs `git rerere diff` and returns the current state of each recorded
+// resolution.
+func Diff(ctx context.Context, git gitexec.Runner) ([]FileDiff, error) {
+	out, err := git.Run(ctx, "rerere", "diff")
+	if err != nil {
+		return nil, err
+	}
+	return ParseDiff(bytes.NewReader(out))
+}
+
+func runPaths(ctx context.Context, git gitexec.Runner, subcommand string) ([]string, error) {
+	out, err := git.Run(ctx, "rerere", subcommand)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePaths(bytes.NewReader(out))
+}
@@ -0,0 +1,75 @@
+package rerere
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePaths(t *testing.T) {
+	got, err := ParsePaths(strings.NewReader("f\nsp ä\"x\n"))
+	if err != nil {
+		t.Fatalf("ParsePaths() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"f", "sp ä\"x"}, got); diff != "" {
+		t.Errorf("ParsePaths() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// fakeRunner is a gitexec.Runner returning canned results, recording the
+// arguments it was called with.
+type fakeRunner struct {
+	out  string
+	err  error
+	args []string
+}
+
+func (f *fakeRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+	f.args = args
+	return []byte(f.out), f.err
+}
+
+func TestStatus(t *testing.T) {
+	git := &fakeRunner{out: "f\ng\n"}
+	got, err := Status(context.Background(), git)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"f", "g"}, got); diff != "" {
+		t.Errorf("Status() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"rerere", "status"}, git.args); diff != "" {
+		t.Errorf("Status() args mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	git := &fakeRunner{out: "g\n"}
+	got, err := Remaining(context.Background(), git)
+	if err != nil {
+		t.Fatalf("Remaining() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"g"}, got); diff != "" {
+		t.Errorf("Remaining() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"rerere", "remaining"}, git.args); diff != "" {
+		t.Errorf("Remaining() args mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	git := &fakeRunner{out: "--- a/f\n+++ b/f\n@@ -1 +1 @@\n-x\n+y\n"}
+	got, err := Diff(context.Background(), git)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	want := []FileDiff{{Path: "f", Hunks: []Hunk{{OldStart: 1, OldLines: 1, NewStart: 1, NewLines: 1, Lines: []string{"-x", "+y"}}}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Diff() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"rerere", "diff"}, git.args); diff != "" {
+		t.Errorf("Diff() args mismatch (-want +got):\n%s", diff)
+	}
+}
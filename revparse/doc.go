@@ -0,0 +1,36 @@
+/*
+Package revparse gathers information about a repository with a single
+`git rev-parse` call.
+
+Tools commonly need several facts about the repository they run in: where its
+work tree and git directory are, whether it is bare or shallow, and which
+branch is checked out. `git rev-parse` can answer all of these at once, but
+prints the answers positionally, one per line, and stops at the first query
+that fails, e.g. --show-toplevel in a bare repository. This package issues
+the queries in an order that keeps the output unambiguous, and returns a
+typed [RepoInfo].
+
+# Basic Usage
+
+[Get] runs git through a [gitexec.Runner]:
+
+	info, err := revparse.Get(ctx, gitexec.New("."))
+	if err != nil {
+	    log.Fatal(err)
+	}
+	if !info.Bare {
+	    fmt.Printf("work tree %s on branch %s\n", info.TopLevel, info.Branch)
+	}
+
+To run git yourself, use the arguments returned by [Args] and pass the output
+to [Parse]. Note that git exits with status 1 when HEAD is an unborn branch,
+and fails outright outside of a work tree; [Get] handles both.
+
+Absolute paths are requested with --path-format=absolute, which requires Git
+v2.31.0 or later.
+
+For more information, see the Git documentation for [git rev-parse].
+
+[git rev-parse]: https://git-scm.com/docs/git-rev-parse
+*/
+package revparse
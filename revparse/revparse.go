@@ -0,0 +1,166 @@
+package revparse
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// RepoInfo describes a repository, as seen from the directory git runs in.
+type RepoInfo struct {
+	GitDir         string // absolute path of the git directory
+	CommonDir      string // absolute path of the common git directory, shared by all worktrees
+	Bare           bool   // whether the repository is bare
+	InsideWorkTree bool   // whether the directory is inside a work tree
+	InsideGitDir   bool   // whether the directory is inside the git directory
+	Shallow        bool   // whether the repository is a shallow clone
+	ObjectFormat   string // hash algorithm, e.g. "sha1" or "sha256"
+	Prefix         string // path of the directory relative to the top level, e.g. "sub/"; empty at the top level
+	TopLevel       string // absolute path of the top level of the work tree; empty outside a work tree
+	HeadRef        string // full ref name HEAD points to, e.g. "refs/heads/main"; empty if detached or unborn
+	Detached       bool   // whether HEAD is detached
+	Unborn         bool   // whether HEAD is a branch without commits
+}
+
+// Branch returns the short name of the checked out branch, e.g. "main", or ""
+// if HEAD is detached or unborn.
+func (info RepoInfo) Branch() string {
+	return strings.TrimPrefix(info.HeadRef, "refs/heads/")
+}
+
+// queries are the `git rev-parse` options whose answers fill a RepoInfo, in
+// the order they are printed. Each prints exactly one line.
+var queries = []string{
+	"--git-dir",
+	"--git-common-dir",
+	"--is-bare-repository",
+	"--is-inside-work-tree",
+	"--is-inside-git-dir",
+	"--is-shallow-repository",
+	"--show-object-format",
+	"--show-prefix",
+}
+
+// topLevelQuery fails outside of a work tree, so it follows all queries that
+// always succeed.
+const topLevelQuery = "--show-toplevel"
+
+// headQuery is printed last, once all other options are processed. It prints
+// nothing and exits with status 1 if HEAD is unborn.
+var headQuery = []string{"--symbolic-full-name", "--verify", "-q", "HEAD"}
+
+// Args returns the git arguments for gathering a [RepoInfo].
+func Args() []string {
+	args := []string{"rev-parse", "--path-format=absolute"}
+	args = append(args, queries...)
+	args = append(args, topLevelQuery)
+	return append(args, headQuery...)
+}
+
+// Parse parses the output of `git rev-parse` run with [Args].
+//
+// If the output ends before the final line describing HEAD, as happens when
+// git exits with status 1 for an unborn HEAD, the returned RepoInfo is marked
+// as Unborn.
+func Parse(r io.Reader) (RepoInfo, error) {
+	var info RepoInfo
+	lines, err := readLines(r)
+	if err != nil {
+		return info, err
+	}
+	n := len(queries) + 1 // including top level
+	if len(lines) != n && len(lines) != n+1 {
+		return info, fmt.Errorf("invalid rev-parse output: expected %d or %d lines, got %d", n, n+1, len(lines))
+	}
+	if err := parseQueries(&info, lines[:len(queries)]); err != nil {
+		return info, err
+	}
+	info.TopLevel = lines[len(queries)]
+	if len(lines) == n {
+		info.Unborn = true
+	} else {
+		parseHead(&info, lines[n])
+	}
+	return info, nil
+}
+
+// Get runs `git rev-parse` and returns information about the repository.
+//
+// When run outside of a work tree, where git cannot show the top level and
+// stops before describing HEAD, a second call describes HEAD.
+func Get(ctx context.Context, git gitexec.Runner) (RepoInfo, error) {
+	out, err := git.Run(ctx, Args()...)
+	var exitErr *gitexec.ExitError
+	switch {
+	case err == nil:
+		return Parse(bytes.NewReader(out))
+	case !errors.As(err, &exitErr):
+		return RepoInfo{}, err
+	case exitErr.ExitCode == 1:
+		// unborn HEAD; all other lines are present
+		return Parse(bytes.NewReader(out))
+	}
+
+	// Outside a work tree, git fails at the top level query, having printed
+	// the answers to all preceding queries.
+	var info RepoInfo
+	lines, lerr := readLines(bytes.NewReader(out))
+	if lerr != nil || len(lines) != len(queries) {
+		return info, err
+	}
+	if perr := parseQueries(&info, lines); perr != nil || info.InsideWorkTree {
+		return info, err
+	}
+
+	head, err := git.Run(ctx, append([]string{"rev-parse"}, headQuery...)...)
+	if errors.As(err, &exitErr) && exitErr.ExitCode == 1 {
+		info.Unborn = true
+		return info, nil
+	}
+	if err != nil {
+		return info, err
+	}
+	parseHead(&info, strings.TrimSuffix(string(head), "\n"))
+	return info, nil
+}
+
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+func parseQueries(info *RepoInfo, lines []string) error {
+	info.GitDir = lines[0]
+	info.CommonDir = lines[1]
+	bools := []*bool{&info.Bare, &info.InsideWorkTree, &info.InsideGitDir, &info.Shallow}
+	for i, b := range bools {
+		switch lines[2+i] {
+		case "true":
+			*b = true
+		case "false":
+		default:
+			return fmt.Errorf("invalid rev-parse output for %s: %q", queries[2+i], lines[2+i])
+		}
+	}
+	info.ObjectFormat = lines[6]
+	info.Prefix = lines[7]
+	return nil
+}
+
+func parseHead(info *RepoInfo, line string) {
+	if line == "HEAD" {
+		info.Detached = true
+		return
+	}
+	info.HeadRef = line
+}
@@ -0,0 +1,173 @@
+package revparse
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/gitexec"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  RepoInfo
+	}{
+		{
+			name:  "subdirectory",
+			input: "/tmp/br/.git\n/tmp/br/.git\nfalse\ntrue\nfalse\nfalse\nsha1\nsub/\n/tmp/br\nrefs/heads/topic2\n",
+			want: RepoInfo{
+				GitDir:         "/tmp/br/.git",
+				CommonDir:      "/tmp/br/.git",
+				InsideWorkTree: true,
+				ObjectFormat:   "sha1",
+				Prefix:         "sub/",
+				TopLevel:       "/tmp/br",
+				HeadRef:        "refs/heads/topic2",
+			},
+		},
+		{
+			name:  "linked worktree detached shallow",
+			input: "/src/repo/.git/worktrees/wt\n/src/repo/.git\nfalse\ntrue\nfalse\ntrue\nsha256\n\n/src/wt\nHEAD\n",
+			want: RepoInfo{
+				GitDir:         "/src/repo/.git/worktrees/wt",
+				CommonDir:      "/src/repo/.git",
+				InsideWorkTree: true,
+				Shallow:        true,
+				ObjectFormat:   "sha256",
+				TopLevel:       "/src/wt",
+				Detached:       true,
+			},
+		},
+		{
+			name:  "unborn",
+			input: "/tmp/ub/.git\n/tmp/ub/.git\nfalse\ntrue\nfalse\nfalse\nsha1\n\n/tmp/ub\n",
+			want: RepoInfo{
+				GitDir:         "/tmp/ub/.git",
+				CommonDir:      "/tmp/ub/.git",
+				InsideWorkTree: true,
+				ObjectFormat:   "sha1",
+				TopLevel:       "/tmp/ub",
+				Unborn:         true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"too few lines", "/tmp/br/.git\n/tmp/br/.git\nfalse\n"},
+		{"too many lines", "a\nb\nfalse\ntrue\nfalse\nfalse\nsha1\n\n/tmp\nHEAD\nextra\n"},
+		{"invalid boolean", "a\nb\nno\ntrue\nfalse\nfalse\nsha1\n\n/tmp\nHEAD\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.input)); err == nil {
+				t.Error("Parse() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestRepoInfo_Branch(t *testing.T) {
+	if got := (RepoInfo{HeadRef: "refs/heads/feature/x"}).Branch(); got != "feature/x" {
+		t.Errorf("Branch() = %q, want %q", got, "feature/x")
+	}
+	if got := (RepoInfo{Detached: true}).Branch(); got != "" {
+		t.Errorf("Branch() = %q, want empty", got)
+	}
+}
+
+func TestGet(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+	ctx := context.Background()
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	work := filepath.Join(dir, "work")
+	bare := filepath.Join(dir, "bare.git")
+	git := gitexec.New(dir)
+	git.Env = []string{
+		"GIT_AUTHOR_NAME=A", "GIT_AUTHOR_EMAIL=a@example.com",
+		"GIT_COMMITTER_NAME=C", "GIT_COMMITTER_EMAIL=c@example.com",
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch=main", work},
+		{"init", "--quiet", "--bare", "--initial-branch=main", bare},
+	} {
+		if _, err := git.Run(ctx, args...); err != nil {
+			t.Fatalf("Run(%q) error = %v", args, err)
+		}
+	}
+
+	get := func(dir string) RepoInfo {
+		t.Helper()
+		info, err := Get(ctx, &gitexec.Git{Dir: dir, Env: git.Env})
+		if err != nil {
+			t.Fatalf("Get(%s) error = %v", dir, err)
+		}
+		return info
+	}
+
+	workInfo := RepoInfo{
+		GitDir:         filepath.Join(work, ".git"),
+		CommonDir:      filepath.Join(work, ".git"),
+		InsideWorkTree: true,
+		ObjectFormat:   "sha1",
+		TopLevel:       work,
+		Unborn:         true,
+	}
+	if diff := cmp.Diff(workInfo, get(work)); diff != "" {
+		t.Errorf("Get(unborn) mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := (&gitexec.Git{Dir: work, Env: git.Env}).Run(ctx, "commit", "--quiet", "--allow-empty", "-m", "initial"); err != nil {
+		t.Fatalf("Run(commit) error = %v", err)
+	}
+	workInfo.Unborn = false
+	workInfo.HeadRef = "refs/heads/main"
+	if diff := cmp.Diff(workInfo, get(work)); diff != "" {
+		t.Errorf("Get(work) mismatch (-want +got):\n%s", diff)
+	}
+
+	bareInfo := RepoInfo{
+		GitDir:       bare,
+		CommonDir:    bare,
+		Bare:         true,
+		InsideGitDir: true,
+		ObjectFormat: "sha1",
+		Unborn:       true,
+	}
+	if diff := cmp.Diff(bareInfo, get(bare)); diff != "" {
+		t.Errorf("Get(bare) mismatch (-want +got):\n%s", diff)
+	}
+
+	gitDirInfo := workInfo
+	gitDirInfo.InsideWorkTree = false
+	gitDirInfo.InsideGitDir = true
+	gitDirInfo.TopLevel = ""
+	if diff := cmp.Diff(gitDirInfo, get(filepath.Join(work, ".git"))); diff != "" {
+		t.Errorf("Get(.git) mismatch (-want +got):\n%s", diff)
+	}
+}
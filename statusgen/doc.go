@@ -0,0 +1,18 @@
+/*
+Package statusgen generates synthetic `git status` porcelain data, for
+benchmarks and for load-testing consumers of porcelain output.
+
+# Basic Usage
+
+A [Generator] produces an endless sequence of random but valid entries, with
+the mix of entry kinds and paths set by its [Config]. Generation is
+deterministic for a given seed:
+
+	g := statusgen.New(statusgen.Config{Seed: 1, RenameRatio: 0.1})
+	s := g.V2Status(10000)
+	statusv2.Encode(os.Stdout, s)
+
+Generated paths are unique, and are quoted as Git would without -z if
+[Config.Quote] is set, so that encoding them produces output like git's.
+*/
+package statusgen
@@ -0,0 +1,299 @@
+package statusgen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// Config configures the output of a [Generator].
+//
+// The ratios are the probability of each entry being of the given kind, and
+// should sum to at most 1. The remaining entries are ordinary changes.
+type Config struct {
+	Seed           uint64  // seed for the random number generator
+	RenameRatio    float64 // renamed or copied entries
+	UnmergedRatio  float64 // unmerged entries
+	UntrackedRatio float64 // untracked entries
+	IgnoredRatio   float64 // ignored entries
+	UnicodeRatio   float64 // probability of a path containing non-ASCII characters
+	Quote          bool    // quote paths as git does without -z
+	SHA256         bool    // use 64 character SHA-256 object names, instead of SHA-1
+}
+
+// A Generator generates random status entries.
+type Generator struct {
+	cfg Config
+	rng *rand.Rand
+	n   int // number of paths generated
+}
+
+// New returns a Generator for cfg.
+func New(cfg Config) *Generator {
+	return &Generator{cfg: cfg, rng: rand.New(rand.NewPCG(cfg.Seed, 0))}
+}
+
+// kind is the kind of an entry to generate.
+type kind int
+
+const (
+	kindChanged kind = iota
+	kindRename
+	kindUnmerged
+	kindUntracked
+	kindIgnored
+)
+
+func (g *Generator) kind() kind {
+	f := g.rng.Float64()
+	for _, k := range []struct {
+		ratio float64
+		kind  kind
+	}{
+		{g.cfg.RenameRatio, kindRename},
+		{g.cfg.UnmergedRatio, kindUnmerged},
+		{g.cfg.UntrackedRatio, kindUntracked},
+		{g.cfg.IgnoredRatio, kindIgnored},
+	} {
+		if f < k.ratio {
+			return k.kind
+		}
+		f -= k.ratio
+	}
+	return kindChanged
+}
+
+// Branch returns random branch information with an upstream.
+func (g *Generator) Branch() *statusv2.BranchInfo {
+	return &statusv2.BranchInfo{
+		OID:      g.hash(),
+		Head:     "main",
+		Upstream: "origin/main",
+		Ahead:    g.rng.IntN(10),
+		Behind:   g.rng.IntN(10),
+	}
+}
+
+// V2Status returns a status with branch information and n entries.
+func (g *Generator) V2Status(n int) *statusv2.Status {
+	s := &statusv2.Status{Branch: g.Branch(), Entries: make([]statusv2.Entry, n)}
+	for i := range s.Entries {
+		s.Entries[i] = g.V2()
+	}
+	return s
+}
+
+// V2 returns a random porcelain=v2 entry.
+func (g *Generator) V2() statusv2.Entry {
+	switch g.kind() {
+	case kindRename:
+		score := "R" + strconv.Itoa(50+g.rng.IntN(51))
+		if g.rng.IntN(4) == 0 {
+			score = "C" + score[1:]
+		}
+		return statusv2.RenameOrCopyEntry{
+			XY:    statusv2.XYFlag{X: statusv2.State(score[0]), Y: g.v2State(".M")},
+			Sub:   statusv2.SubmoduleStatus{},
+			ModeH: statusv2.FileModeRegular,
+			ModeI: statusv2.FileModeRegular,
+			ModeW: statusv2.FileModeRegular,
+			HashH: g.hash(),
+			HashI: g.hash(),
+			Score: statusv2.Score(score),
+			Path:  g.path(),
+			Orig:  g.path(),
+		}
+	case kindUnmerged:
+		xy := unmergedXY[g.rng.IntN(len(unmergedXY))]
+		return statusv2.UnmergedEntry{
+			XY:    statusv2.XYFlag{X: statusv2.State(xy[0]), Y: statusv2.State(xy[1])},
+			Mode1: statusv2.FileModeRegular,
+			Mode2: statusv2.FileModeRegular,
+			Mode3: statusv2.FileModeRegular,
+			ModeW: statusv2.FileModeRegular,
+			Hash1: g.hash(),
+			Hash2: g.hash(),
+			Hash3: g.hash(),
+			Path:  g.path(),
+		}
+	case kindUntracked:
+		return statusv2.UntrackedEntry{Path: g.path()}
+	case kindIgnored:
+		return statusv2.IgnoredEntry{Path: g.path()}
+	}
+
+	xy := statusv2.XYFlag{X: g.v2State(".MAD"), Y: g.v2State(".MD")}
+	if xy.X == statusv2.Unmodified && xy.Y == statusv2.Unmodified {
+		xy.Y = statusv2.Modified
+	}
+	e := statusv2.ChangedEntry{
+		XY:    xy,
+		ModeH: statusv2.FileModeRegular,
+		ModeI: statusv2.FileModeRegular,
+		ModeW: statusv2.FileModeRegular,
+		HashH: g.hash(),
+		HashI: g.hash(),
+		Path:  g.path(),
+	}
+	switch {
+	case xy.X == statusv2.Added:
+		e.ModeH, e.HashH = statusv2.FileModeEmpty, string(statusv2.ZeroHash(g.algorithm()))
+	case xy.X == statusv2.Deleted:
+		e.ModeI, e.ModeW, e.HashI = statusv2.FileModeEmpty, statusv2.FileModeEmpty, string(statusv2.ZeroHash(g.algorithm()))
+		e.XY.Y = statusv2.Unmodified
+	case xy.Y == statusv2.Deleted:
+		e.ModeW = statusv2.FileModeEmpty
+	}
+	if xy.X == statusv2.Unmodified {
+		e.HashI = e.HashH
+	}
+	return e
+}
+
+// V1Status returns a status with a branch header and n entries.
+func (g *Generator) V1Status(n int) *statusv1.Status {
+	b := g.Branch()
+	header := fmt.Sprintf("## %s...%s [ahead %d, behind %d]", b.Head, b.Upstream, b.Ahead, b.Behind)
+	s := &statusv1.Status{Headers: []string{header}, Entries: make([]statusv1.Entry, n)}
+	for i := range s.Entries {
+		s.Entries[i] = g.V1()
+	}
+	return s
+}
+
+// V1 returns a random porcelain=v1 entry.
+func (g *Generator) V1() statusv1.Entry {
+	switch g.kind() {
+	case kindRename:
+		return statusv1.Entry{
+			XY:       statusv1.XYFlag{X: g.v1State("RC"), Y: g.v1State(" M")},
+			Path:     g.path(),
+			OrigPath: g.path(),
+		}
+	case kindUnmerged:
+		xy := unmergedXY[g.rng.IntN(len(unmergedXY))]
+		return statusv1.Entry{XY: statusv1.XYFlag{X: statusv1.State(xy[0]), Y: statusv1.State(xy[1])}, Path: g.path()}
+	case kindUntracked:
+		return statusv1.Entry{XY: statusv1.XYFlag{X: statusv1.Untracked, Y: statusv1.Untracked}, Path: g.path()}
+	case kindIgnored:
+		return statusv1.Entry{XY: statusv1.XYFlag{X: statusv1.Ignored, Y: statusv1.Ignored}, Path: g.path()}
+	}
+
+	xy := statusv1.XYFlag{X: g.v1State(" MAD"), Y: g.v1State(" MD")}
+	if xy.X == statusv1.Deleted {
+		xy.Y = statusv1.Unmodified
+	} else if xy.X == statusv1.Unmodified && xy.Y == statusv1.Unmodified {
+		xy.Y = statusv1.Modified
+	}
+	return statusv1.Entry{XY: xy, Path: g.path()}
+}
+
+// unmergedXY are the XY flags of unmerged entries.
+var unmergedXY = []string{"DD", "AU", "UD", "UA", "DU", "AA", "UU"}
+
+func (g *Generator) v2State(states string) statusv2.State {
+	return statusv2.State(states[g.rng.IntN(len(states))])
+}
+
+func (g *Generator) v1State(states string) statusv1.State {
+	return statusv1.State(states[g.rng.IntN(len(states))])
+}
+
+func (g *Generator) algorithm() statusv2.HashAlgorithm {
+	if g.cfg.SHA256 {
+		return statusv2.SHA256
+	}
+	return statusv2.SHA1
+}
+
+func (g *Generator) hash() string {
+	if g.cfg.SHA256 {
+		return fmt.Sprintf("%016x%016x%016x%016x", g.rng.Uint64(), g.rng.Uint64(), g.rng.Uint64(), g.rng.Uint64())
+	}
+	return fmt.Sprintf("%016x%016x%08x", g.rng.Uint64(), g.rng.Uint64(), g.rng.Uint32())
+}
+
+// Words used to build paths. The unicode ones include combining and
+// multi-byte characters.
+var (
+	asciiWords   = []string{"src", "internal", "cmd", "docs", "test", "util", "api", "main", "config", "build"}
+	unicodeWords = []string{"café", "naïve", "日本語", "résumé", "Ünïcødé", "emoji🎉", "Ελληνικά", "é"}
+	extensions   = []string{".go", ".md", ".txt", ".json", ".yaml", ""}
+)
+
+// path returns a new unique path.
+func (g *Generator) path() string {
+	g.n++
+	words := asciiWords
+	if g.rng.Float64() < g.cfg.UnicodeRatio {
+		words = unicodeWords
+	}
+
+	var b strings.Builder
+	for range g.rng.IntN(4) {
+		b.WriteString(asciiWords[g.rng.IntN(len(asciiWords))])
+		b.WriteByte('/')
+	}
+	b.WriteString(words[g.rng.IntN(len(words))])
+	fmt.Fprintf(&b, "%d", g.n)
+	b.WriteString(extensions[g.rng.IntN(len(extensions))])
+
+	if g.cfg.Quote {
+		return quote(b.String())
+	}
+	return b.String()
+}
+
+// quote quotes path as git does with the default core.quotePath setting, if
+// it contains control characters, double quotes, backslashes or bytes outside
+// of ASCII. Generated paths never contain spaces, which git also quotes in
+// porcelain=v1 output.
+func quote(path string) string {
+	needsQuote := false
+	for i := 0; i < len(path); i++ {
+		if c := path[i]; c < 0x20 || c == '"' || c == '\\' || c >= 0x7f {
+			needsQuote = true
+			break
+		}
+	}
+	if !needsQuote {
+		return path
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; c {
+		case '\a':
+			b.WriteString(`\a`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\v':
+			b.WriteString(`\v`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		default:
+			if c < 0x20 || c >= 0x7f {
+				fmt.Fprintf(&b, `\%03o`, c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
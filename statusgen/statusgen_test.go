@@ -0,0 +1,152 @@
+package statusgen
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/statusv1"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+var testConfig = Config{
+	Seed:           42,
+	RenameRatio:    0.2,
+	UnmergedRatio:  0.1,
+	UntrackedRatio: 0.2,
+	IgnoredRatio:   0.1,
+	UnicodeRatio:   0.3,
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	a := New(testConfig).V2Status(100)
+	b := New(testConfig).V2Status(100)
+	if diff := cmp.Diff(a, b); diff != "" {
+		t.Errorf("same seed generated different statuses (-a +b):\n%s", diff)
+	}
+}
+
+func TestGenerator_V2(t *testing.T) {
+	for _, z := range []bool{false, true} {
+		cfg := testConfig
+		cfg.Quote = !z
+		want := New(cfg).V2Status(1000)
+
+		var buf bytes.Buffer
+		encode, newDecoder := statusv2.Encode, statusv2.NewDecoder
+		if z {
+			encode, newDecoder = statusv2.EncodeZ, statusv2.NewDecoderZ
+		}
+		if err := encode(&buf, want); err != nil {
+			t.Fatal(err)
+		}
+
+		// generated output is as git would produce
+		d := newDecoder(bytes.NewReader(buf.Bytes()))
+		d.Strict()
+		var got []statusv2.Entry
+		for {
+			e, err := d.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("z=%v: line %d: invalid output: %v", z, d.Line(), err)
+			}
+			got = append(got, e)
+		}
+		if diff := cmp.Diff(want.Entries, got); diff != "" {
+			t.Errorf("z=%v: round trip mismatch (-want +got):\n%s", z, diff)
+		}
+	}
+}
+
+func TestGenerator_SHA256(t *testing.T) {
+	cfg := testConfig
+	cfg.SHA256 = true
+	s := New(cfg).V2Status(100)
+	if got := statusv2.Hash(s.Branch.OID).Algorithm(); got != statusv2.SHA256 {
+		t.Errorf("Branch.OID algorithm = %v, want sha256", got)
+	}
+	for _, e := range s.Entries {
+		var hashes []statusv2.Hash
+		switch e := e.(type) {
+		case statusv2.ChangedEntry:
+			hashes = []statusv2.Hash{statusv2.Hash(e.HashH), statusv2.Hash(e.HashI)}
+		case statusv2.RenameOrCopyEntry:
+			hashes = []statusv2.Hash{statusv2.Hash(e.HashH), statusv2.Hash(e.HashI)}
+		case statusv2.UnmergedEntry:
+			hashes = []statusv2.Hash{statusv2.Hash(e.Hash1), statusv2.Hash(e.Hash2), statusv2.Hash(e.Hash3)}
+		}
+		for _, h := range hashes {
+			if h.Algorithm() != statusv2.SHA256 {
+				t.Errorf("entry %+v has hash %q, want sha256", e, h)
+			}
+		}
+	}
+}
+
+func TestGenerator_V1(t *testing.T) {
+	for _, z := range []bool{false, true} {
+		cfg := testConfig
+		cfg.Quote = !z
+		want := New(cfg).V1Status(1000)
+
+		var buf bytes.Buffer
+		encode, newDecoder := statusv1.Encode, statusv1.NewDecoder
+		if z {
+			encode, newDecoder = statusv1.EncodeZ, statusv1.NewDecoderZ
+		}
+		if err := encode(&buf, want); err != nil {
+			t.Fatal(err)
+		}
+
+		d := newDecoder(bytes.NewReader(buf.Bytes()))
+		d.Strict()
+		var got []statusv1.Entry
+		for {
+			e, err := d.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("z=%v: line %d: invalid output: %v", z, d.Line(), err)
+			}
+			got = append(got, e)
+		}
+		if diff := cmp.Diff(want.Entries, got); diff != "" {
+			t.Errorf("z=%v: round trip mismatch (-want +got):\n%s", z, diff)
+		}
+	}
+}
+
+func TestGenerator_Ratios(t *testing.T) {
+	g := New(Config{Seed: 1, UntrackedRatio: 1})
+	for range 100 {
+		if e := g.V2(); e.Type() != statusv2.EntryTypeUntracked {
+			t.Fatalf("V2() = %#v, want untracked entry", e)
+		}
+	}
+}
+
+func Test_quote(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"plain.txt", "plain.txt"},
+		{"with space.txt", "with space.txt"},
+		{"tab\there", `"tab\there"`},
+		{"untracked ü.txt", `"untracked \303\274.txt"`},
+		{`quote"back\slash`, `"quote\"back\\slash"`},
+		{"bell\a", `"bell\a"`},
+		{"del\x7f", `"del\177"`},
+	}
+	for _, tt := range tests {
+		if got := quote(tt.path); got != tt.want {
+			t.Errorf("quote(%q) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
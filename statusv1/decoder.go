@@ -0,0 +1,117 @@
+package statusv1
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/mroth/porcelain/parseerr"
+)
+
+// A Decoder reads and parses status entries from an input stream one at a
+// time, without holding the entire output in memory.
+//
+// Header lines are collected as they are read. Git writes all headers before
+// any entries, so they are complete once the first entry has been returned by
+// [Decoder.Next], or once it has returned [io.EOF].
+type Decoder struct {
+	scanner    *bufio.Scanner
+	parseEntry func([]byte) (Entry, error)
+	unit       string // "line" or "entry", for error messages
+	headers    []string
+	strict     bool
+	line       int
+	entries    bool // whether an entry has been read
+	meta       Meta
+	ctx        context.Context // checked between lines, if set
+	input      *teeReader
+}
+
+// NewDecoder returns a Decoder that reads `git status --porcelain=v1` output
+// from r. See [Parse] for details on headers and path handling.
+func NewDecoder(r io.Reader) *Decoder {
+	d := &Decoder{parseEntry: parseEntry, unit: "line", meta: Meta{Version: 1}}
+	d.scanner = d.newScanner(r, bufio.ScanLines)
+	return d
+}
+
+// NewDecoderZ returns a Decoder that reads `git status --porcelain=v1 -z`
+// output from r. See [ParseZ] for details on headers and path handling.
+func NewDecoderZ(r io.Reader) *Decoder {
+	d := &Decoder{parseEntry: parseEntryZ, unit: "entry", meta: Meta{Version: 1, Z: true}}
+	d.scanner = d.newScanner(r, porcelainv1ZSplitFunc)
+	return d
+}
+
+// Next returns the next entry in the input. At the end of the input, Next
+// returns [io.EOF].
+func (d *Decoder) Next() (Entry, error) {
+	for d.scanner.Scan() {
+		if err := d.checkContext(); err != nil {
+			return Entry{}, err
+		}
+		d.line++
+		line := d.scanner.Bytes()
+		if len(line) == 0 {
+			if d.strict {
+				return Entry{}, parseerr.New(parseerr.EmptyLine, "unit", d.unit)
+			}
+			d.meta.Warnings++
+			continue // skip empty lines
+		}
+
+		if isV2Line(line) {
+			return Entry{}, ErrFormatMismatch
+		}
+		if bytes.HasPrefix(line, []byte("##")) {
+			if d.strict && d.entries {
+				return Entry{}, parseerr.New(parseerr.HeaderAfterEntries, "line", line)
+			}
+			d.headers = append(d.headers, string(line))
+			continue
+		}
+
+		entry, err := d.parseEntry(line)
+		if err != nil {
+			return Entry{}, parseerr.Wrap(err, parseerr.ParseFailed, "unit", d.unit, "line", line)
+		}
+		d.entries = true
+		if d.strict {
+			if err := validateEntry(entry); err != nil {
+				return Entry{}, parseerr.Wrap(err, parseerr.InvalidRecord, "unit", d.unit, "line", line)
+			}
+		}
+		d.meta.Entries++
+		return entry, nil
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return Entry{}, fmt.Errorf("scanner error: %w", err)
+	}
+	return Entry{}, io.EOF
+}
+
+// Headers returns the header lines (prefixed with `##`) read so far.
+func (d *Decoder) Headers() []string {
+	return d.headers
+}
+
+// Core parsing function that reads all remaining entries from d and
+// constructs the Status struct.
+func decodeAll(d *Decoder) (*Status, error) {
+	status := &Status{}
+	for {
+		entry, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		status.Entries = append(status.Entries, entry)
+	}
+	status.Headers = d.Headers()
+	return status, nil
+}
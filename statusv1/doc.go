@@ -19,6 +19,73 @@ which is simpler than the more modern porcelain=v2 format.
 [ParseZ] provides a variant that will work with NUL-terminated git status output
 (from -z flag).
 
+[ParseContext] and [ParseZContext] additionally stop once a [context.Context]
+is done, to bound the time spent parsing large or untrusted input.
+
+# Streaming
+
+For very large repositories, [NewDecoder] and [NewDecoderZ] return a [Decoder]
+that parses one entry at a time, rather than collecting all of them in memory:
+
+	d := statusv1.NewDecoder(r)
+	for {
+	    entry, err := d.Next()
+	    if err == io.EOF {
+	        break
+	    }
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+	    // use entry
+	}
+
+Header lines are available from [Decoder.Headers] once the first entry has
+been read, as Git writes all headers before any entries.
+
+[Decoder.Decode] instead reads all remaining entries into a [Status], like
+[Parse], with a [Meta] recording the format, the number of entries and bytes
+read, the time taken, and the number of lines skipped, for logging:
+
+	status, err := statusv1.NewDecoder(r).Decode()
+	if err != nil {
+	    log.Fatal(err)
+	}
+	slog.Info("parsed status", "entries", status.Meta.Entries, "duration", status.Meta.Duration)
+
+[Decoder.Tee] copies the raw input to an [io.Writer] as it is read, to archive
+the exact output of git alongside the parsed result.
+
+# Strict Mode
+
+By default, a [Decoder] is lenient, accepting anything it can make sense of.
+[Decoder.Strict] makes it instead reject input that git itself would never
+produce, which is useful for validating `git status --porcelain=v1` output
+generated by other tools. Together with [Decoder.Line], every violation in an
+input can be reported:
+
+	d.Strict()
+	for {
+	    _, err := d.Next()
+	    if err == io.EOF {
+	        break
+	    }
+	    if err != nil {
+	        fmt.Printf("line %d: %v\n", d.Line(), err)
+	    }
+	}
+
+Errors are of type [parseerr.Error], with a code and parameters for matching
+on the kind of violation and translating its message.
+
+Lines of `git status --porcelain=v2` output, as in a capture mixing up the
+formats, are errors in either mode, [ErrFormatMismatch], suggesting package
+statusv2 instead.
+
+# Encoding
+
+[Encode] and [EncodeZ] perform the reverse of parsing, writing a [Status] in
+the porcelain format, for example to generate test fixtures.
+
 # Working with Results
 
 The [Status] struct contains parsed information, notably the list of file
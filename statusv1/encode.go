@@ -0,0 +1,50 @@
+package statusv1
+
+import (
+	"bufio"
+	"io"
+)
+
+// Encode writes s to w in the `git status --porcelain=v1` format, such that
+// parsing the output with [Parse] returns an equivalent Status.
+//
+// Paths are written as-is, so should already be quoted as Git would for the
+// output to be valid.
+func Encode(w io.Writer, s *Status) error {
+	bw := bufio.NewWriter(w)
+	for _, h := range s.Headers {
+		bw.WriteString(h)
+		bw.WriteByte('\n')
+	}
+	for _, e := range s.Entries {
+		bw.WriteString(e.XY.String() + " ")
+		if e.OrigPath != "" {
+			bw.WriteString(e.OrigPath + " -> ")
+		}
+		bw.WriteString(e.Path)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// EncodeZ writes s to w in the `git status --porcelain=v1 -z` format, such
+// that parsing the output with [ParseZ] returns an equivalent Status.
+//
+// In the -z format, the original path of renamed or copied entries follows the
+// new path, separated by NUL.
+func EncodeZ(w io.Writer, s *Status) error {
+	bw := bufio.NewWriter(w)
+	for _, h := range s.Headers {
+		bw.WriteString(h)
+		bw.WriteByte('\x00')
+	}
+	for _, e := range s.Entries {
+		bw.WriteString(e.XY.String() + " " + e.Path)
+		bw.WriteByte('\x00')
+		if e.OrigPath != "" {
+			bw.WriteString(e.OrigPath)
+			bw.WriteByte('\x00')
+		}
+	}
+	return bw.Flush()
+}
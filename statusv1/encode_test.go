@@ -0,0 +1,52 @@
+package statusv1
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, &sampleParsedStatus); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	got, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if diff := cmp.Diff(&sampleParsedStatus, got); diff != "" {
+		t.Errorf("Parse(Encode()) mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEncodeZ(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeZ(&buf, &sampleParsedStatus); err != nil {
+		t.Fatalf("EncodeZ() error = %v", err)
+	}
+	got, err := ParseZ(&buf)
+	if err != nil {
+		t.Fatalf("ParseZ() error = %v", err)
+	}
+	if diff := cmp.Diff(&sampleParsedStatus, got); diff != "" {
+		t.Errorf("ParseZ(EncodeZ()) mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEncode_ByteExact(t *testing.T) {
+	// output of git status --porcelain=v1 --branch
+	input := "## main...origin/main [ahead 1]\n M f\nR  r -> r2\nA  \"tab\\tname\"\n?? untracked\n"
+	s, err := Parse(bytes.NewReader([]byte(input)))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, s); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if got := buf.String(); got != input {
+		t.Errorf("Encode() = %q, want %q", got, input)
+	}
+}
@@ -2,16 +2,18 @@ package statusv1
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+	"github.com/mroth/porcelain/parseerr"
 )
 
 // FuzzParse tests the Parse function with arbitrary input
 func FuzzParse(f *testing.F) {
 	// Add seed inputs covering various entry types
-	f.Add([]byte(" M file.txt\nA  added.txt\nD  deleted.txt"))
-	f.Add([]byte("R  new.txt -> old.txt\n?? untracked.txt"))
-	f.Add([]byte("!! ignored.txt\nMM conflict.txt"))
-	f.Add([]byte("C  copy.txt -> orig.txt"))
+	fuzzseed.Add[[]byte](f, "statusv1")
 
 	f.Fuzz(func(t *testing.T, data []byte) {
 		// Parser should never panic, only return an error for invalid input
@@ -27,9 +29,7 @@ func FuzzParse(f *testing.F) {
 // FuzzParseZ tests the ParseZ function with arbitrary input
 func FuzzParseZ(f *testing.F) {
 	// Add seed inputs in -z format
-	f.Add([]byte(" M file.txt\x00A  added.txt\x00"))
-	f.Add([]byte("R  new.txt\x00old.txt\x00?? untracked.txt\x00"))
-	f.Add([]byte("C  copy.txt\x00orig.txt\x00"))
+	fuzzseed.Add[[]byte](f, "statusv1z")
 
 	f.Fuzz(func(t *testing.T, data []byte) {
 		// Parser should never panic, only return an error for invalid input
@@ -41,3 +41,58 @@ func FuzzParseZ(f *testing.F) {
 		ParseZ(bytes.NewReader(data))
 	})
 }
+
+// FuzzStrictErrors tests that every error of a strict Decoder is a
+// parseerr.Error with a message in the catalog, so that it can be localized.
+func FuzzStrictErrors(f *testing.F) {
+	for format, z := range map[string]bool{"statusv1": false, "statusv1z": true} {
+		seeds, err := fuzzseed.Seeds(format)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, s := range seeds {
+			f.Add(s.Data, z)
+		}
+	}
+	f.Add([]byte("XY a\n\nM  b\n## main\nR  c\n"), false)
+	f.Add([]byte("R  a\x00M  \x00ZZ"), true)
+
+	f.Fuzz(func(t *testing.T, data []byte, z bool) {
+		d := NewDecoder(bytes.NewReader(data))
+		if z {
+			d = NewDecoderZ(bytes.NewReader(data))
+		}
+		d.Strict()
+		for {
+			_, err := d.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				checkParseError(t, err)
+			}
+		}
+	})
+}
+
+// checkParseError checks that err is a parseerr.Error with a known code and
+// every parameter of its message, as is any error it wraps.
+func checkParseError(t *testing.T, err error) {
+	t.Helper()
+	var perr *parseerr.Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("error %q (%T) is not a *parseerr.Error", err, err)
+	}
+	tmpl, ok := parseerr.Messages[perr.Code]
+	if !ok {
+		t.Fatalf("error %q has code %q without a message", err, perr.Code)
+	}
+	for _, name := range parseerr.Placeholders(tmpl) {
+		if _, ok := perr.Params[name]; !ok {
+			t.Errorf("error %q with code %q is missing param %q", err, perr.Code, name)
+		}
+	}
+	if perr.Err != nil {
+		checkParseError(t, perr.Err)
+	}
+}
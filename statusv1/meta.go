@@ -0,0 +1,51 @@
+package statusv1
+
+import (
+	"bufio"
+	"io"
+	"time"
+)
+
+// Meta records how a [Status] was parsed, for logging and observability.
+type Meta struct {
+	Version  int           // porcelain format version, always 1
+	Z        bool          // whether the input was NUL-terminated, from -z
+	Entries  int           // number of entries returned
+	Bytes    int64         // number of bytes of input consumed
+	Duration time.Duration // time spent parsing, by Decode only
+	Warnings int           // empty lines skipped, outside strict mode
+}
+
+// Meta returns metadata about the input read so far. Its Duration is zero, as
+// only [Decoder.Decode] measures it.
+func (d *Decoder) Meta() Meta {
+	return d.meta
+}
+
+// Decode reads all remaining entries, returning them as a Status with Meta
+// recording the parse. Otherwise, it is the same as [Parse] or [ParseZ] for
+// the input of d, but with any options of d, such as [Decoder.Strict].
+func (d *Decoder) Decode() (*Status, error) {
+	start := time.Now()
+	s, err := decodeAll(d)
+	if err != nil {
+		return nil, err
+	}
+	meta := d.Meta()
+	meta.Duration = time.Since(start)
+	s.Meta = &meta
+	return s, nil
+}
+
+// newScanner returns a scanner splitting r with split, counting the bytes
+// consumed, and reading r by way of the input of d, for [Decoder.Tee].
+func (d *Decoder) newScanner(r io.Reader, split bufio.SplitFunc) *bufio.Scanner {
+	d.input = &teeReader{r: r}
+	scanner := bufio.NewScanner(d.input)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := split(data, atEOF)
+		d.meta.Bytes += int64(advance)
+		return advance, token, err
+	})
+	return scanner
+}
@@ -0,0 +1,68 @@
+package statusv1
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	tests := []struct {
+		name     string
+		dec      *Decoder
+		wantMeta Meta
+	}{
+		{
+			name:     "NewDecoder",
+			dec:      NewDecoder(bytes.NewReader(samplePorcelainV1Output)),
+			wantMeta: Meta{Version: 1, Entries: 8, Bytes: int64(len(samplePorcelainV1Output))},
+		},
+		{
+			name:     "NewDecoderZ",
+			dec:      NewDecoderZ(bytes.NewReader(samplePorcelainV1ZOutput)),
+			wantMeta: Meta{Version: 1, Z: true, Entries: 8, Bytes: int64(len(samplePorcelainV1ZOutput))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.dec.Decode()
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if diff := cmp.Diff(&sampleParsedStatus, got, cmpopts.IgnoreFields(Status{}, "Meta")); diff != "" {
+				t.Errorf("Decode() mismatch (-want +got):\n%s", diff)
+			}
+			if got.Meta == nil {
+				t.Fatal("Decode() Meta = nil")
+			}
+			if got.Meta.Duration <= 0 {
+				t.Errorf("Decode() Meta.Duration = %v, want > 0", got.Meta.Duration)
+			}
+			if diff := cmp.Diff(tt.wantMeta, *got.Meta, cmpopts.IgnoreFields(Meta{}, "Duration")); diff != "" {
+				t.Errorf("Decode() Meta mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_Meta(t *testing.T) {
+	input := "## main\n\n?? a.txt\n\n?? b.txt\n"
+	d := NewDecoder(strings.NewReader(input))
+	if _, err := d.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	want := Meta{Version: 1, Entries: 1, Bytes: int64(strings.Index(input, "?? b") - 1), Warnings: 1}
+	if diff := cmp.Diff(want, d.Meta()); diff != "" {
+		t.Errorf("Meta() after first entry mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := d.Decode(); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want = Meta{Version: 1, Entries: 2, Bytes: int64(len(input)), Warnings: 2}
+	if diff := cmp.Diff(want, d.Meta()); diff != "" {
+		t.Errorf("Meta() at end mismatch (-want +got):\n%s", diff)
+	}
+}
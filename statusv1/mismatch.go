@@ -0,0 +1,26 @@
+package statusv1
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/mroth/porcelain/parseerr"
+)
+
+// ErrFormatMismatch is the error of a [Decoder] reading a line of
+// `git status --porcelain=v2` output, such as a "# branch.oid" header or a
+// "1 .M ..." entry, as from a capture mixing up the formats. Rather than
+// failing to parse the line, the Decoder suggests parsing the input with
+// package statusv2 instead.
+var ErrFormatMismatch error = parseerr.New(parseerr.FormatMismatch, "format", "porcelain=v2", "parser", "statusv2")
+
+// isV2Line reports whether line is a header or entry of porcelain=v2 output,
+// which porcelain=v1 output never has: a "# " header, or the single character
+// and space of the prefixes of porcelain=v2 entries, unlike the two XY states
+// of porcelain=v1 entries.
+func isV2Line(line []byte) bool {
+	if bytes.HasPrefix(line, []byte("# ")) {
+		return true
+	}
+	return len(line) > 2 && line[1] == ' ' && strings.IndexByte("12u?!", line[0]) >= 0
+}
@@ -0,0 +1,50 @@
+package statusv1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mroth/porcelain/parseerr"
+)
+
+func TestParse_FormatMismatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"v2 header", "# branch.oid (initial)\n?? a.txt\n"},
+		{"v2 header after v1 header", "## main\n# branch.head main\n"},
+		{"v2 changed", " M a.txt\n1 .M N... 100644 100644 100644 0000000000000000000000000000000000000000 0000000000000000000000000000000000000000 b.txt\n"},
+		{"v2 rename", "2 R. N... 100644 100644 100644 0000000000000000000000000000000000000000 0000000000000000000000000000000000000000 R100 new\told\n"},
+		{"v2 unmerged", "u UU N... 100644 100644 100644 100644 0000000000000000000000000000000000000000 0000000000000000000000000000000000000000 0000000000000000000000000000000000000000 c\n"},
+		{"v2 untracked", "? a.txt\n"},
+		{"v2 ignored", "! build/\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			if !errors.Is(err, ErrFormatMismatch) {
+				t.Fatalf("Parse() error = %v, want ErrFormatMismatch", err)
+			}
+			var perr *parseerr.Error
+			if !errors.As(err, &perr) || perr.Params["parser"] != "statusv2" {
+				t.Errorf("Parse() error = %#v, want a suggestion of statusv2", err)
+			}
+		})
+	}
+}
+
+func TestParse_NoFormatMismatch(t *testing.T) {
+	inputs := []string{
+		"## main...origin/main [ahead 1]\n?? a.txt\n!! b.log\n",
+		" M 1 a\n",
+		"M  u b\n",
+		"R  ? c -> ! d\n",
+	}
+	for _, input := range inputs {
+		if _, err := Parse(strings.NewReader(input)); err != nil {
+			t.Errorf("Parse(%q) error = %v", input, err)
+		}
+	}
+}
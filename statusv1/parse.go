@@ -1,10 +1,10 @@
 package statusv1
 
 import (
-	"bufio"
 	"bytes"
-	"fmt"
 	"io"
+
+	"github.com/mroth/porcelain/parseerr"
 )
 
 // Parse parses git status --porcelain=v1 output from an io.Reader.
@@ -21,33 +21,7 @@ import (
 // unquoted paths, consider using [ParseZ] with the -z flag instead, as Git
 // does not quote paths in -z format.
 func Parse(r io.Reader) (*Status, error) {
-	scanner := bufio.NewScanner(r)
-	status := &Status{}
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue // skip empty lines
-		}
-
-		if bytes.HasPrefix(line, []byte("##")) {
-			status.Headers = append(status.Headers, string(line))
-			continue
-		}
-
-		entry, err := parseEntry(line)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse line %q: %w", line, err)
-		}
-
-		status.Entries = append(status.Entries, entry)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scanner error: %w", err)
-	}
-
-	return status, nil
+	return decodeAll(NewDecoder(r))
 }
 
 // ParseZ parses git status --porcelain=v1 -z output from an io.Reader.
@@ -65,40 +39,14 @@ func Parse(r io.Reader) (*Status, error) {
 // characters, so all paths are provided as-is. This function preserves paths
 // exactly as provided by Git.
 func ParseZ(r io.Reader) (*Status, error) {
-	scanner := newZScanner(r)
-	status := &Status{}
-
-	for scanner.Scan() {
-		entry := scanner.Bytes()
-		if len(entry) == 0 {
-			continue // skip empty entries
-		}
-
-		if bytes.HasPrefix(entry, []byte("##")) {
-			status.Headers = append(status.Headers, string(entry))
-			continue
-		}
-
-		parsedEntry, err := parseEntryZ(entry)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse entry %q: %w", entry, err)
-		}
-
-		status.Entries = append(status.Entries, parsedEntry)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scanner error: %w", err)
-	}
-
-	return status, nil
+	return decodeAll(NewDecoderZ(r))
 }
 
 // parseEntry parses a single line from git status --porcelain=v1 output.
 // Format: "XY PATH" or "XY ORIG_PATH -> PATH"
 func parseEntry(line []byte) (Entry, error) {
 	if len(line) < 3 {
-		return Entry{}, fmt.Errorf("line too short: %q", line)
+		return Entry{}, parseerr.New(parseerr.TooShort, "unit", "line", "line", line)
 	}
 
 	// Parse XY status
@@ -109,7 +57,7 @@ func parseEntry(line []byte) (Entry, error) {
 
 	// Skip the space after XY
 	if line[2] != ' ' {
-		return Entry{}, fmt.Errorf("expected space after XY status, got %q", line[2])
+		return Entry{}, parseerr.New(parseerr.MissingSpace, "got", line[2:3])
 	}
 
 	pathPart := line[3:]
@@ -119,7 +67,7 @@ func parseEntry(line []byte) (Entry, error) {
 	if origPath, newPath, found := bytes.Cut(pathPart, separator); found {
 		// Check for empty parts
 		if len(origPath) == 0 || len(newPath) == 0 {
-			return Entry{}, fmt.Errorf("invalid rename format: %q", pathPart)
+			return Entry{}, parseerr.New(parseerr.InvalidRenamePaths, "paths", pathPart)
 		}
 
 		return Entry{
@@ -140,7 +88,7 @@ func parseEntry(line []byte) (Entry, error) {
 // In -z format, rename entries contain both paths: "XY to\x00from".
 func parseEntryZ(entry []byte) (Entry, error) {
 	if len(entry) < 3 {
-		return Entry{}, fmt.Errorf("entry too short: %q", entry)
+		return Entry{}, parseerr.New(parseerr.TooShort, "unit", "entry", "line", entry)
 	}
 
 	// Parse XY status
@@ -151,7 +99,7 @@ func parseEntryZ(entry []byte) (Entry, error) {
 
 	// Skip the space after XY
 	if entry[2] != ' ' {
-		return Entry{}, fmt.Errorf("expected space after XY status, got %q", entry[2])
+		return Entry{}, parseerr.New(parseerr.MissingSpace, "got", entry[2:3])
 	}
 
 	pathPart := entry[3:]
@@ -180,7 +128,7 @@ func parseEntryZ(entry []byte) (Entry, error) {
 
 func parseXYFlag(field []byte) (XYFlag, error) {
 	if len(field) != 2 {
-		return XYFlag{}, fmt.Errorf("invalid XY field: expected 2 characters, got %d", len(field))
+		return XYFlag{}, parseerr.New(parseerr.InvalidXYLength, "length", len(field))
 	}
 	return XYFlag{X: State(field[0]), Y: State(field[1])}, nil
 }
@@ -2,9 +2,14 @@ package statusv1
 
 import (
 	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/corpus"
 )
 
 // Sample porcelain=v1 lines
@@ -102,6 +107,67 @@ func TestParseZ(t *testing.T) {
 	}
 }
 
+// TestParseGolden tests the Parse and ParseZ functions against the output
+// of git recorded in the corpus package, which should parse to the golden
+// result of each case.
+//
+// Without -z, git quotes paths containing unusual characters, which Parse
+// leaves as-is, so those paths are unquoted before comparing.
+func TestParseGolden(t *testing.T) {
+	for _, c := range corpusCases(t) {
+		t.Run(c.Name(), func(t *testing.T) {
+			var want Status
+			if err := json.Unmarshal(c.Golden, &want); err != nil {
+				t.Fatalf("invalid golden result: %v", err)
+			}
+			parse := ParseZ
+			if c.Format == "v1" {
+				parse = Parse
+			}
+			got, err := parse(bytes.NewReader(c.Input))
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			if c.Format == "v1" {
+				for i, e := range got.Entries {
+					got.Entries[i].Path = unquotePath(t, e.Path)
+					got.Entries[i].OrigPath = unquotePath(t, e.OrigPath)
+				}
+			}
+			if diff := cmp.Diff(&want, got); diff != "" {
+				t.Errorf("parse mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// corpusCases returns the porcelain=v1 cases of the corpus package.
+func corpusCases(t *testing.T) []corpus.Case {
+	t.Helper()
+	cases, err := corpus.Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases = slices.DeleteFunc(cases, func(c corpus.Case) bool { return !strings.HasPrefix(c.Format, "v1") })
+	if len(cases) == 0 {
+		t.Fatal("no porcelain=v1 cases in the corpus")
+	}
+	return cases
+}
+
+func unquotePath(t *testing.T, path string) string {
+	t.Helper()
+	if !strings.HasPrefix(path, `"`) {
+		return path
+	}
+	// git's C-style quoting is a subset of Go string literal syntax
+	unquoted, err := strconv.Unquote(path)
+	if err != nil {
+		t.Fatalf("failed to unquote path %s: %v", path, err)
+	}
+	return unquoted
+}
+
 func Test_parseEntry(t *testing.T) {
 	testcases := []struct {
 		name    string
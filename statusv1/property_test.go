@@ -0,0 +1,176 @@
+package statusv1
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// randomStatus is a random valid Status, generated by testing/quick. Paths
+// are arbitrary bytes, except for those that cannot appear unquoted in the
+// format: NUL with -z, and newline, carriage return (stripped from the end of
+// CRLF-terminated lines) and the " -> " rename separator without it. Without
+// -z, git also quotes paths containing spaces, which keeps the separator of
+// renames and copies unambiguous, so their paths are generated without spaces.
+type randomStatus struct {
+	Status *Status
+	Z      bool // whether the status is valid for the -z format only
+}
+
+func (randomStatus) Generate(r *rand.Rand, size int) reflect.Value {
+	g := statusGenerator{r: r, z: r.Intn(2) == 0}
+	s := &Status{}
+	if r.Intn(2) == 0 {
+		s.Headers = []string{"## " + g.pick("main", "main...origin/main [ahead 1, behind 2]", "No commits yet on main", "HEAD (no branch)")}
+	}
+	for range r.Intn(size + 1) {
+		s.Entries = append(s.Entries, g.entry())
+	}
+	return reflect.ValueOf(randomStatus{Status: s, Z: g.z})
+}
+
+type statusGenerator struct {
+	r *rand.Rand
+	z bool
+}
+
+func (g statusGenerator) pick(options ...string) string {
+	return options[g.r.Intn(len(options))]
+}
+
+func (g statusGenerator) entry() Entry {
+	const states = " MTADRCU"
+	var xy string
+	switch g.r.Intn(6) {
+	case 0:
+		xy = "??"
+	case 1:
+		xy = "!!"
+	case 2:
+		xy = g.pick("DD", "AU", "UD", "UA", "DU", "AA", "UU")
+	default:
+		for xy == "" || xy == "  " {
+			xy = string([]byte{states[g.r.Intn(len(states))], states[g.r.Intn(len(states))]})
+		}
+	}
+	e := Entry{XY: XYFlag{X: State(xy[0]), Y: State(xy[1])}}
+	if !strings.ContainsAny(xy, "RC") {
+		e.Path = g.path(false)
+		return e
+	}
+	e.Path, e.OrigPath = g.path(true), g.path(true)
+	return e
+}
+
+// path returns a non-empty path of arbitrary bytes, including spaces,
+// separators and invalid UTF-8, that can appear unquoted in the format.
+func (g statusGenerator) path(rename bool) string {
+	for {
+		b := make([]byte, 1+g.r.Intn(20))
+		for i := range b {
+			b[i] = byte(g.r.Intn(256))
+			if g.r.Intn(8) == 0 {
+				b[i] = g.pick(" ", "-", ">")[0] // make separators likely
+			}
+		}
+		path := string(b)
+		if g.z && !strings.Contains(path, "\x00") ||
+			!g.z && !strings.ContainsAny(path, "\x00\n\r") && !strings.Contains(path, " -> ") &&
+				!(rename && strings.Contains(path, " ")) {
+			return path
+		}
+	}
+}
+
+// roundTrip encodes s in the given format, and parses it again.
+func roundTrip(s *Status, z bool) (*Status, error) {
+	var buf bytes.Buffer
+	encode, parse := Encode, Parse
+	if z {
+		encode, parse = EncodeZ, ParseZ
+	}
+	if err := encode(&buf, s); err != nil {
+		return nil, err
+	}
+	return parse(&buf)
+}
+
+func TestProperty_RoundTrip(t *testing.T) {
+	f := func(rs randomStatus) bool {
+		got, err := roundTrip(rs.Status, rs.Z)
+		if err != nil {
+			t.Logf("round trip error = %v", err)
+			return false
+		}
+		if diff := cmp.Diff(rs.Status, got); diff != "" {
+			t.Logf("round trip mismatch (-want +got):\n%s", diff)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProperty_OrderAndPaths(t *testing.T) {
+	paths := func(s *Status) [][]byte {
+		var paths [][]byte
+		for _, e := range s.Entries {
+			paths = append(paths, []byte(e.Path), []byte(e.OrigPath))
+		}
+		return paths
+	}
+	f := func(rs randomStatus) bool {
+		got, err := roundTrip(rs.Status, rs.Z)
+		if err != nil || len(got.Entries) != len(rs.Status.Entries) {
+			return false
+		}
+		for i := range got.Entries {
+			if got.Entries[i].XY != rs.Status.Entries[i].XY {
+				return false
+			}
+		}
+		return slices.Equal(got.Headers, rs.Status.Headers) && reflect.DeepEqual(paths(got), paths(rs.Status))
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProperty_DecoderMatchesParse(t *testing.T) {
+	f := func(rs randomStatus) bool {
+		var buf bytes.Buffer
+		newDecoder := NewDecoder
+		if rs.Z {
+			EncodeZ(&buf, rs.Status)
+			newDecoder = NewDecoderZ
+		} else {
+			Encode(&buf, rs.Status)
+		}
+		d := newDecoder(bytes.NewReader(buf.Bytes()))
+		var entries []Entry
+		for {
+			e, err := d.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Logf("Next() error = %v", err)
+				return false
+			}
+			entries = append(entries, e)
+		}
+		return slices.Equal(entries, rs.Status.Entries) && slices.Equal(d.Headers(), rs.Status.Headers)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
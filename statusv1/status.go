@@ -37,7 +37,8 @@ type XYFlag struct {
 // String returns the XY status as a two-character string.
 func (xy XYFlag) String() string { return string(xy.X) + string(xy.Y) }
 
-// MarshalText implements encoding.TextMarshaler for XYFlag.
+// MarshalText implements encoding.TextMarshaler for XYFlag. It also makes
+// XYFlag encode to JSON as a string, such as "M ".
 func (xy XYFlag) MarshalText() ([]byte, error) {
 	return []byte(xy.String()), nil
 }
@@ -63,7 +64,28 @@ type Entry struct {
 //
 // The Header field contains any header lines from the output, which may be present
 // when using flags such as --branch.  These lines are always prefixed with `##`.
+// Meta records how the output was parsed, if requested.
 type Status struct {
 	Headers []string // header lines (prefixed with `##`), if present
 	Entries []Entry  // file entries
+	Meta    *Meta    `json:",omitempty"` // nil unless from Decoder.Decode
+}
+
+// XYMap returns the XY flag of each path in s, the minimal view of a status
+// wanted by many prompts and hooks. The original path of a rename or copy maps
+// to the flag of its entry too, unless the path has an entry of its own.
+func (s *Status) XYMap() map[string]XYFlag {
+	if s == nil {
+		return nil
+	}
+	m := make(map[string]XYFlag, len(s.Entries))
+	for _, e := range s.Entries {
+		m[e.Path] = e.XY
+	}
+	for _, e := range s.Entries {
+		if _, ok := m[e.OrigPath]; e.OrigPath != "" && !ok {
+			m[e.OrigPath] = e.XY
+		}
+	}
+	return m
 }
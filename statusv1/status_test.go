@@ -2,6 +2,7 @@ package statusv1
 
 import (
 	"encoding"
+	"encoding/json"
 	"testing"
 )
 
@@ -94,3 +95,53 @@ func TestXYFlag_MarshalUnmarshalText(t *testing.T) {
 		t.Errorf("UnmarshalText() should error for input of length != 2")
 	}
 }
+
+func TestXYFlag_JSON(t *testing.T) {
+	entry := struct{ XY XYFlag }{XYFlag{Modified, Unmodified}}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"XY":"M "}`; string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+
+	var got struct{ XY XYFlag }
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got != entry {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", got, entry)
+	}
+	if err := json.Unmarshal([]byte(`{"XY":[77,46]}`), &got); err == nil {
+		t.Error("json.Unmarshal() of an array should error")
+	}
+}
+
+func TestStatus_XYMap(t *testing.T) {
+	s := &Status{Entries: []Entry{
+		{XY: XYFlag{Unmodified, Modified}, Path: "m.txt"},
+		{XY: XYFlag{Renamed, Unmodified}, Path: "new.txt", OrigPath: "old.txt"},
+		{XY: XYFlag{Copied, Unmodified}, Path: "copy.txt", OrigPath: "m.txt"},
+		{XY: XYFlag{Untracked, Untracked}, Path: "dir/"},
+	}}
+	want := map[string]XYFlag{
+		"m.txt":    {Unmodified, Modified},
+		"new.txt":  {Renamed, Unmodified},
+		"old.txt":  {Renamed, Unmodified},
+		"copy.txt": {Copied, Unmodified},
+		"dir/":     {Untracked, Untracked},
+	}
+	got := s.XYMap()
+	if len(got) != len(want) {
+		t.Errorf("XYMap() = %v, want %v", got, want)
+	}
+	for path, xy := range want {
+		if got[path] != xy {
+			t.Errorf("XYMap()[%q] = %q, want %q", path, got[path], xy)
+		}
+	}
+	if got := (*Status)(nil).XYMap(); got != nil {
+		t.Errorf("nil XYMap() = %v, want nil", got)
+	}
+}
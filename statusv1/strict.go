@@ -0,0 +1,64 @@
+package statusv1
+
+import (
+	"strings"
+
+	"github.com/mroth/porcelain/parseerr"
+)
+
+// Strict causes the Decoder to reject input that git itself would never
+// produce, rather than accepting it. In strict mode, Next returns an error
+// for:
+//
+//   - empty lines, and header lines after the first entry
+//   - XY flags with unknown states, or invalid combinations of states
+//   - rename or copy entries without an original path, and other entries
+//     with one
+//   - empty paths
+//
+// After an error, Next may be called again to continue with the following
+// line, as when reporting every violation in an input.
+func (d *Decoder) Strict() {
+	d.strict = true
+}
+
+// Line returns the number of lines (or NUL-terminated entries, for
+// [NewDecoderZ]) read so far, which is the line number of the entry or error
+// most recently returned by [Decoder.Next]. The original path of a rename in
+// -z output is counted as part of its entry.
+func (d *Decoder) Line() int {
+	return d.line
+}
+
+// validateEntry checks a parsed entry in strict mode.
+func validateEntry(e Entry) error {
+	if err := validateXY(e.XY); err != nil {
+		return err
+	}
+	if e.Path == "" {
+		return parseerr.New(parseerr.EmptyPath)
+	}
+	renamed := e.XY.X == Renamed || e.XY.X == Copied || e.XY.Y == Renamed || e.XY.Y == Copied
+	if renamed && e.OrigPath == "" {
+		return parseerr.New(parseerr.MissingOrigPath, "xy", e.XY.String())
+	}
+	if !renamed && e.OrigPath != "" {
+		return parseerr.New(parseerr.UnexpectedOrigPath, "xy", e.XY.String())
+	}
+	return nil
+}
+
+func validateXY(xy XYFlag) error {
+	switch xy.String() {
+	case "??", "!!":
+		return nil
+	case "DD", "AU", "UD", "UA", "DU", "AA", "UU":
+		return nil // unmerged
+	}
+	const states = " MTADRC"
+	if !strings.ContainsRune(states, rune(xy.X)) || !strings.ContainsRune(states, rune(xy.Y)) ||
+		xy.X == Unmodified && xy.Y == Unmodified {
+		return parseerr.New(parseerr.InvalidXY, "xy", xy.String())
+	}
+	return nil
+}
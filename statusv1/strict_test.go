@@ -0,0 +1,76 @@
+package statusv1
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mroth/porcelain/corpus"
+)
+
+func TestDecoder_Strict(t *testing.T) {
+	// everything recorded from git must pass strict mode
+	cases := append(corpusCases(t), corpus.Case{Scenario: "sample", Format: "v1", Input: samplePorcelainV1Output})
+	for _, c := range cases {
+		t.Run(c.Name(), func(t *testing.T) {
+			d := NewDecoder(bytes.NewReader(c.Input))
+			if c.Format == "v1z" {
+				d = NewDecoderZ(bytes.NewReader(c.Input))
+			}
+			d.Strict()
+			for {
+				_, err := d.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("line %d: Next() error = %v", d.Line(), err)
+				}
+			}
+		})
+	}
+}
+
+func TestDecoder_StrictViolations(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty line", " M ok.txt\n\n M ok.txt\n"},
+		{"header after entries", " M ok.txt\n## main\n M ok.txt\n"},
+		{"XY state", " M ok.txt\n Z a.txt\n M ok.txt\n"},
+		{"XY unmodified", " M ok.txt\n   a.txt\n M ok.txt\n"},
+		{"XY untracked", " M ok.txt\n?M a.txt\n M ok.txt\n"},
+		{"rename without orig", " M ok.txt\nR  a.txt\n M ok.txt\n"},
+		{"orig without rename", " M ok.txt\nM  a.txt -> b.txt\n M ok.txt\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the lenient decoder accepts the input
+			if _, err := Parse(strings.NewReader(tt.input)); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			d := NewDecoder(strings.NewReader(tt.input))
+			d.Strict()
+			var errs int
+			for {
+				_, err := d.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					errs++
+					if line := d.Line(); line != 2 {
+						t.Errorf("Line() = %d, want 2", line)
+					}
+				}
+			}
+			if errs != 1 {
+				t.Errorf("got %d errors, want 1", errs)
+			}
+		})
+	}
+}
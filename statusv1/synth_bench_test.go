@@ -0,0 +1,51 @@
+package statusv1_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/statusgen"
+	"github.com/mroth/porcelain/statusv1"
+)
+
+// synthetic returns synthetic output with n entries, in the -z format if z.
+func synthetic(b *testing.B, n int, z bool) []byte {
+	b.Helper()
+	g := statusgen.New(statusgen.Config{
+		Seed:           1,
+		RenameRatio:    0.05,
+		UnmergedRatio:  0.01,
+		UntrackedRatio: 0.2,
+		UnicodeRatio:   0.1,
+		Quote:          !z,
+	})
+	var buf bytes.Buffer
+	encode := statusv1.Encode
+	if z {
+		encode = statusv1.EncodeZ
+	}
+	if err := encode(&buf, g.V1Status(n)); err != nil {
+		b.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func BenchmarkParse_Synthetic10k(b *testing.B) {
+	data := synthetic(b, 10000, false)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		statusv1.Parse(bytes.NewReader(data))
+	}
+}
+
+func BenchmarkParseZ_Synthetic10k(b *testing.B) {
+	data := synthetic(b, 10000, true)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		statusv1.ParseZ(bytes.NewReader(data))
+	}
+}
@@ -0,0 +1,82 @@
+package statusv2
+
+import "slices"
+
+// MinAbbrev is the shortest length of an abbreviated object name, as in git.
+const MinAbbrev = 4
+
+// Abbreviations maps the object names of a status to abbreviations that are
+// unique among them, as returned by [Status.Abbreviate].
+type Abbreviations map[Hash]string
+
+// Abbrev returns the abbreviation of h, or h in full if it has none.
+func (a Abbreviations) Abbrev(h Hash) string {
+	if s, ok := a[h]; ok {
+		return s
+	}
+	return string(h)
+}
+
+// Abbreviate returns abbreviations of the object names in s, those of the
+// entries and of the current commit, for renderers showing short hashes next
+// to entries. Each is n characters long, or at least [MinAbbrev], unless that
+// would make it ambiguous with another object name in s, in which case it is
+// extended just enough to be unique, as git does for the object names in a
+// repository. The empty object name is not abbreviated.
+func (s *Status) Abbreviate(n int) Abbreviations {
+	n = max(n, MinAbbrev)
+	hashes := s.hashes()
+	slices.Sort(hashes)
+	hashes = slices.Compact(hashes)
+
+	abbrevs := make(Abbreviations, len(hashes))
+	for i, h := range hashes {
+		length := n
+		if i > 0 {
+			length = max(length, commonPrefix(hashes[i-1], h)+1)
+		}
+		if i < len(hashes)-1 {
+			length = max(length, commonPrefix(h, hashes[i+1])+1)
+		}
+		abbrevs[h] = string(h[:min(length, len(h))])
+	}
+	return abbrevs
+}
+
+// hashes returns the non-empty object names in s, with duplicates.
+func (s *Status) hashes() []Hash {
+	if s == nil {
+		return nil
+	}
+	var hashes []Hash
+	add := func(hs ...Hash) {
+		for _, h := range hs {
+			if h != "" {
+				hashes = append(hashes, h)
+			}
+		}
+	}
+	if commit, ok := s.Branch.Commit(); ok {
+		add(commit)
+	}
+	for _, e := range s.Entries {
+		switch e := e.(type) {
+		case ChangedEntry:
+			add(Hash(e.HashH), Hash(e.HashI))
+		case RenameOrCopyEntry:
+			add(Hash(e.HashH), Hash(e.HashI))
+		case UnmergedEntry:
+			add(Hash(e.Hash1), Hash(e.Hash2), Hash(e.Hash3))
+		}
+	}
+	return hashes
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b.
+func commonPrefix(a, b Hash) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,77 @@
+package statusv2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStatus_Abbreviate(t *testing.T) {
+	zero := string(ZeroHash(SHA1))
+	s := &Status{
+		Branch: &BranchInfo{OID: "abcdef0123456789abcdef0123456789abcdef01", Head: "main"},
+		Entries: []Entry{
+			ChangedEntry{HashH: "abcdef0123456789abcdef0123456789abcdef01", HashI: "abcdef9999999999999999999999999999999999", Path: "a"},
+			ChangedEntry{HashH: "1234567000000000000000000000000000000000", HashI: zero, Path: "b"},
+			UnmergedEntry{Hash1: zero, Hash2: "fedcba0000000000000000000000000000000000", Hash3: "fedcbb0000000000000000000000000000000000", Path: "c"},
+			UntrackedEntry{Path: "d"},
+		},
+	}
+	got := s.Abbreviate(7)
+	want := Abbreviations{
+		"abcdef0123456789abcdef0123456789abcdef01": "abcdef0",
+		"abcdef9999999999999999999999999999999999": "abcdef9",
+		"1234567000000000000000000000000000000000": "1234567",
+		Hash(zero): "0000000",
+		"fedcba0000000000000000000000000000000000": "fedcba0",
+		"fedcbb0000000000000000000000000000000000": "fedcbb0",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Abbreviate(7) mismatch (-want +got):\n%s", diff)
+	}
+
+	got = s.Abbreviate(0)
+	for h, a := range map[Hash]string{
+		"abcdef0123456789abcdef0123456789abcdef01": "abcdef0",
+		"abcdef9999999999999999999999999999999999": "abcdef9",
+		"1234567000000000000000000000000000000000": "1234",
+		"fedcba0000000000000000000000000000000000": "fedcba",
+		"fedcbb0000000000000000000000000000000000": "fedcbb",
+	} {
+		if got[h] != a {
+			t.Errorf("Abbreviate(0)[%s] = %q, want %q", h, got[h], a)
+		}
+	}
+
+	if got, want := got.Abbrev("not in status"), "not in status"; got != want {
+		t.Errorf("Abbrev() of unknown hash = %q, want %q", got, want)
+	}
+}
+
+func TestStatus_Abbreviate_Unique(t *testing.T) {
+	s := &Status{}
+	for _, prefix := range []string{"aaaa", "aaab", "aaaa1", "aaaa2", "b"} {
+		h := Hash(prefix + strings.Repeat("0", 40-len(prefix)))
+		s.Entries = append(s.Entries, ChangedEntry{HashH: string(h), HashI: string(h)})
+	}
+	abbrevs := s.Abbreviate(1)
+	seen := make(map[string]bool)
+	for h, a := range abbrevs {
+		if !strings.HasPrefix(string(h), a) {
+			t.Errorf("Abbrev(%s) = %q, not a prefix", h, a)
+		}
+		for other := range abbrevs {
+			if other != h && strings.HasPrefix(string(other), a) {
+				t.Errorf("Abbrev(%s) = %q, also a prefix of %s", h, a, other)
+			}
+		}
+		seen[a] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("Abbreviate() = %v, want 5 abbreviations", abbrevs)
+	}
+	if got := (*Status)(nil).Abbreviate(7); len(got) != 0 {
+		t.Errorf("nil Abbreviate() = %v, want empty", got)
+	}
+}
@@ -0,0 +1,165 @@
+package statusv2
+
+// ConflictKind identifies how the two sides of a merge conflict on a path,
+// from the XY flag of its [UnmergedEntry].
+type ConflictKind int
+
+// Kinds of conflicts, with the XY flag git writes for each.
+const (
+	ConflictUnknown ConflictKind = iota // an XY flag git does not write for conflicts
+	BothDeleted                         // "DD"
+	AddedByUs                           // "AU"
+	DeletedByThem                       // "UD"
+	AddedByThem                         // "UA"
+	DeletedByUs                         // "DU"
+	BothAdded                           // "AA"
+	BothModified                        // "UU"
+)
+
+// String returns the description of the kind used by the long format of git
+// status, such as "both modified".
+func (k ConflictKind) String() string {
+	switch k {
+	case BothDeleted:
+		return "both deleted"
+	case AddedByUs:
+		return "added by us"
+	case DeletedByThem:
+		return "deleted by them"
+	case AddedByThem:
+		return "added by them"
+	case DeletedByUs:
+		return "deleted by us"
+	case BothAdded:
+		return "both added"
+	case BothModified:
+		return "both modified"
+	default:
+		return "unknown"
+	}
+}
+
+// IsDeleteModify reports whether one side deleted the path while the other
+// modified it, so that resolving the conflict means choosing whether to keep
+// the file rather than merging its contents.
+func (k ConflictKind) IsDeleteModify() bool {
+	return k == DeletedByUs || k == DeletedByThem
+}
+
+// Hint returns a short suggestion of how to resolve a conflict of the kind.
+func (k ConflictKind) Hint() string {
+	switch k {
+	case BothModified, BothAdded:
+		return `edit the file to resolve the conflict markers, then use "git add"`
+	case DeletedByUs, DeletedByThem:
+		return `use "git add" to keep the modified file, or "git rm" to delete it`
+	case AddedByUs, AddedByThem:
+		return `use "git add" to keep the added file, or "git rm" to delete it`
+	case BothDeleted:
+		return `use "git rm" to confirm the deletion`
+	default:
+		return `use "git add" or "git rm" to mark the resolution`
+	}
+}
+
+// Kind returns the kind of the conflict, from the XY flag of the entry.
+func (e UnmergedEntry) Kind() ConflictKind {
+	return e.XY.conflictKind()
+}
+
+// conflictKind returns the kind of conflict git writes xy for, or
+// ConflictUnknown if git does not write it for conflicts.
+func (xy XYFlag) conflictKind() ConflictKind {
+	switch xy.String() {
+	case "DD":
+		return BothDeleted
+	case "AU":
+		return AddedByUs
+	case "UD":
+		return DeletedByThem
+	case "UA":
+		return AddedByThem
+	case "DU":
+		return DeletedByUs
+	case "AA":
+		return BothAdded
+	case "UU":
+		return BothModified
+	}
+	return ConflictUnknown
+}
+
+// Conflicts is a view over the unmerged entries of a status, for tools
+// assisting with merges. The stages of each entry are available from
+// [UnmergedEntry.Base], [UnmergedEntry.Ours] and [UnmergedEntry.Theirs].
+type Conflicts []UnmergedEntry
+
+// Conflicts returns the unmerged entries of s, in order. It returns nil for a
+// nil s.
+func (s *Status) Conflicts() Conflicts {
+	if s == nil {
+		return nil
+	}
+	var c Conflicts
+	for _, e := range s.Entries {
+		if u, ok := e.(UnmergedEntry); ok {
+			c = append(c, u)
+		}
+	}
+	return c
+}
+
+// ByKind groups the conflicts by kind, keeping their order within each kind.
+func (c Conflicts) ByKind() map[ConflictKind]Conflicts {
+	groups := make(map[ConflictKind]Conflicts)
+	for _, e := range c {
+		groups[e.Kind()] = append(groups[e.Kind()], e)
+	}
+	return groups
+}
+
+// OfKind returns the conflicts of any of the given kinds, in order.
+func (c Conflicts) OfKind(kinds ...ConflictKind) Conflicts {
+	var out Conflicts
+	for _, e := range c {
+		for _, k := range kinds {
+			if e.Kind() == k {
+				out = append(out, e)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Paths returns the paths of the conflicts, in order.
+func (c Conflicts) Paths() []string {
+	paths := make([]string, len(c))
+	for i, e := range c {
+		paths[i] = e.Path
+	}
+	return paths
+}
+
+// OnlyDeleteModifyConflicts reports whether there are conflicts, and all of
+// them are between a deletion and a modification, which can be resolved by
+// choosing sides without merging any contents.
+func (c Conflicts) OnlyDeleteModifyConflicts() bool {
+	for _, e := range c {
+		if !e.Kind().IsDeleteModify() {
+			return false
+		}
+	}
+	return len(c) > 0
+}
+
+// OnlyContentConflicts reports whether there are conflicts, and all of them
+// are on a file both sides modified or added, which needs its contents merged.
+func (c Conflicts) OnlyContentConflicts() bool {
+	for _, e := range c {
+		if k := e.Kind(); k != BothModified && k != BothAdded {
+			return false
+		}
+	}
+	return len(c) > 0
+}
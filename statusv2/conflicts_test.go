@@ -0,0 +1,85 @@
+package statusv2
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func unmergedXY(xy, path string) UnmergedEntry {
+	return UnmergedEntry{XY: XYFlag{State(xy[0]), State(xy[1])}, Path: path}
+}
+
+func TestUnmergedEntry_Kind(t *testing.T) {
+	tests := []struct {
+		xy   string
+		want ConflictKind
+		str  string
+	}{
+		{"DD", BothDeleted, "both deleted"},
+		{"AU", AddedByUs, "added by us"},
+		{"UD", DeletedByThem, "deleted by them"},
+		{"UA", AddedByThem, "added by them"},
+		{"DU", DeletedByUs, "deleted by us"},
+		{"AA", BothAdded, "both added"},
+		{"UU", BothModified, "both modified"},
+		{"MM", ConflictUnknown, "unknown"},
+	}
+	for _, tt := range tests {
+		got := unmergedXY(tt.xy, "f").Kind()
+		if got != tt.want {
+			t.Errorf("Kind() of %s = %v, want %v", tt.xy, got, tt.want)
+		}
+		if got.String() != tt.str {
+			t.Errorf("String() of %s = %q, want %q", tt.xy, got.String(), tt.str)
+		}
+		if got.Hint() == "" {
+			t.Errorf("Hint() of %s is empty", tt.xy)
+		}
+		if want := tt.xy == "UD" || tt.xy == "DU"; got.IsDeleteModify() != want {
+			t.Errorf("IsDeleteModify() of %s = %v, want %v", tt.xy, !want, want)
+		}
+	}
+}
+
+func TestStatus_Conflicts(t *testing.T) {
+	s := &Status{Entries: []Entry{
+		unmergedXY("UU", "a.go"),
+		ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "b.go"},
+		unmergedXY("UD", "c.go"),
+		unmergedXY("UU", "d.go"),
+		UntrackedEntry{Path: "e.go"},
+	}}
+	c := s.Conflicts()
+	if diff := cmp.Diff([]string{"a.go", "c.go", "d.go"}, c.Paths()); diff != "" {
+		t.Errorf("Conflicts() paths mismatch (-want +got):\n%s", diff)
+	}
+
+	groups := c.ByKind()
+	want := map[ConflictKind]Conflicts{
+		BothModified:  {unmergedXY("UU", "a.go"), unmergedXY("UU", "d.go")},
+		DeletedByThem: {unmergedXY("UD", "c.go")},
+	}
+	if diff := cmp.Diff(want, groups); diff != "" {
+		t.Errorf("ByKind() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"c.go"}, c.OfKind(DeletedByUs, DeletedByThem).Paths()); diff != "" {
+		t.Errorf("OfKind() paths mismatch (-want +got):\n%s", diff)
+	}
+
+	if c.OnlyDeleteModifyConflicts() || c.OnlyContentConflicts() {
+		t.Errorf("Only*Conflicts() = true for mixed conflicts")
+	}
+	if !c.OfKind(DeletedByThem).OnlyDeleteModifyConflicts() {
+		t.Errorf("OnlyDeleteModifyConflicts() = false for delete/modify conflicts")
+	}
+	if !c.OfKind(BothModified).OnlyContentConflicts() {
+		t.Errorf("OnlyContentConflicts() = false for content conflicts")
+	}
+	if Conflicts(nil).OnlyDeleteModifyConflicts() || Conflicts(nil).OnlyContentConflicts() {
+		t.Errorf("Only*Conflicts() = true without conflicts")
+	}
+	if (*Status)(nil).Conflicts() != nil {
+		t.Errorf("nil Conflicts() != nil")
+	}
+}
@@ -0,0 +1,36 @@
+package statusv2
+
+import (
+	"context"
+	"io"
+)
+
+// ParseContext is like [Parse], but stops with the error of ctx once it is
+// done, which is checked periodically between lines, so that a deadline can be
+// enforced even on a reader that never blocks.
+func ParseContext(ctx context.Context, r io.Reader) (*Status, error) {
+	d := NewDecoder(r)
+	d.ctx = ctx
+	return decodeAll(d)
+}
+
+// ParseZContext is like [ParseZ], but stops with the error of ctx once it is
+// done, as for [ParseContext].
+func ParseZContext(ctx context.Context, r io.Reader) (*Status, error) {
+	d := NewDecoderZ(r)
+	d.ctx = ctx
+	return decodeAll(d)
+}
+
+// ctxCheckInterval is the number of lines a Decoder reads between checks of
+// its context.
+const ctxCheckInterval = 64
+
+// checkContext returns the error of the context of d, if it has one and is
+// due a check before the next line.
+func (d *Decoder) checkContext() error {
+	if d.ctx == nil || d.line%ctxCheckInterval != 0 {
+		return nil
+	}
+	return d.ctx.Err()
+}
@@ -0,0 +1,67 @@
+package statusv2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// endlessReader returns the same line forever, calling cancel once it has
+// returned n lines, so as to simulate a large stream that never blocks.
+type endlessReader struct {
+	line   []byte
+	n      int
+	cancel func()
+}
+
+func (r *endlessReader) Read(p []byte) (int, error) {
+	if r.n--; r.n == 0 {
+		r.cancel()
+	}
+	return copy(p, r.line), nil
+}
+
+func TestParseContext(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		parse func(context.Context, io.Reader) (*Status, error)
+		input []byte
+	}{
+		{"ParseContext", ParseContext, samplePorcelainV2Output},
+		{"ParseZContext", ParseZContext, samplePorcelainV2ZOutput},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(context.Background(), bytes.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			if diff := cmp.Diff(&sampleParsedStatus, got); diff != "" {
+				t.Errorf("%s() mismatch (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestParseContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ParseContext(ctx, bytes.NewReader(samplePorcelainV2Output)); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseContext() error = %v, want %v", err, context.Canceled)
+	}
+	if _, err := ParseZContext(ctx, bytes.NewReader(samplePorcelainV2ZOutput)); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseZContext() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestParseContext_Endless(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := &endlessReader{line: []byte("? file.txt\n"), n: 1000, cancel: cancel}
+	if _, err := ParseContext(ctx, r); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseContext() error = %v, want %v", err, context.Canceled)
+	}
+}
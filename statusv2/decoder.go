@@ -0,0 +1,178 @@
+package statusv2
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/mroth/porcelain/parseerr"
+)
+
+// A Decoder reads and parses status entries from an input stream one at a
+// time, without holding the entire output in memory.
+//
+// Headers are collected as they are read. Git writes all headers before any
+// entries, so they are complete once the first entry has been returned by
+// [Decoder.Next], or once it has returned [io.EOF].
+type Decoder struct {
+	scanner *bufio.Scanner
+	pathSep renamePathSep
+	headers Status // Branch and Stash only
+	strict  bool
+	line    int
+	entries bool // whether an entry has been read
+	meta    Meta
+	ctx     context.Context // checked between lines, if set
+	input   *teeReader
+
+	keepUnknown  bool
+	unknown      []string
+	transformers []EntryTransformer
+	duplicates   DuplicatePolicy
+	seen         map[string]bool // paths returned, for the duplicate policy
+}
+
+// NewDecoder returns a Decoder that reads `git status --porcelain=v2` output
+// from r. See [Parse] for details on path handling.
+func NewDecoder(r io.Reader) *Decoder {
+	d := &Decoder{pathSep: tabSeparator, meta: Meta{Version: 2}}
+	d.scanner = d.newScanner(r, bufio.ScanLines)
+	return d
+}
+
+// NewDecoderZ returns a Decoder that reads `git status --porcelain=v2 -z`
+// output from r. See [ParseZ] for details on path handling.
+func NewDecoderZ(r io.Reader) *Decoder {
+	d := &Decoder{pathSep: nulSeparator, meta: Meta{Version: 2, Z: true}}
+	d.scanner = d.newScanner(r, porcelainv2ZSplitFunc)
+	return d
+}
+
+// Next returns the next entry in the input. At the end of the input, Next
+// returns nil and [io.EOF].
+func (d *Decoder) Next() (Entry, error) {
+	for d.scanner.Scan() {
+		if err := d.checkContext(); err != nil {
+			return nil, err
+		}
+		d.line++
+		line := d.scanner.Bytes()
+		if len(line) == 0 {
+			if d.strict {
+				return nil, parseerr.New(parseerr.EmptyLine, "unit", "line")
+			}
+			d.meta.Warnings++
+			continue
+		}
+		if isV1Line(line) {
+			return nil, ErrFormatMismatch
+		}
+		if line[0] == '#' {
+			if d.strict {
+				if d.entries {
+					return nil, parseerr.New(parseerr.HeaderAfterEntries, "line", line)
+				}
+				if err := validateHeader(line); err != nil {
+					return nil, err
+				}
+			}
+			parseHeaderEntry(line, &d.headers)
+			continue
+		}
+
+		var e Entry
+		var err error
+		switch line[0] {
+		case '1':
+			e, err = nonNil(parseChangedEntry(line))
+		case '2':
+			e, err = nonNil(parseRenameOrCopyEntry(line, d.pathSep))
+		case 'u':
+			e, err = nonNil(parseUnmergedEntry(line))
+		case '?':
+			e, err = nonNil(parseUntrackedEntry(line))
+		case '!':
+			e, err = nonNil(parseIgnoredEntry(line))
+		default:
+			if d.strict {
+				return nil, parseerr.New(parseerr.UnknownLine, "line", line)
+			}
+			if d.keepUnknown {
+				d.unknown = append(d.unknown, string(line))
+			}
+			d.meta.Warnings++
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		d.entries = true
+		if d.strict {
+			if err := validateEntry(e); err != nil {
+				return nil, err
+			}
+		}
+		if e, err = d.transform(e); err != nil {
+			return nil, err
+		}
+		if e == nil {
+			continue
+		}
+		if ok, err := d.checkDuplicate(e); !ok {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		d.meta.Entries++
+		return e, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// nonNil converts the result of an entry parsing function into an Entry,
+// which is nil if parsing failed.
+func nonNil[E Entry](e E, err error) (Entry, error) {
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Branch returns the branch information read so far, or nil if there was
+// none (`--branch` not passed).
+func (d *Decoder) Branch() *BranchInfo {
+	return d.headers.Branch
+}
+
+// Stash returns the stash information read so far, or nil if there was none
+// (`--show-stash` not passed or count == 0).
+func (d *Decoder) Stash() *StashInfo {
+	return d.headers.Stash
+}
+
+// Core parsing function that reads all remaining entries from d and
+// constructs the Status struct.
+func decodeAll(d *Decoder) (*Status, error) {
+	var entries []Entry
+	for {
+		e, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if d.duplicates == DedupeLast {
+		var removed int
+		entries, removed = dedupeLast(entries)
+		d.meta.Entries -= removed
+		d.meta.Warnings += removed
+	}
+	return &Status{Branch: d.Branch(), Stash: d.Stash(), Entries: entries, UnknownLines: d.UnknownLines()}, nil
+}
@@ -0,0 +1,63 @@
+package statusv2
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecoder(t *testing.T) {
+	tests := []struct {
+		name string
+		dec  *Decoder
+	}{
+		{"NewDecoder", NewDecoder(bytes.NewReader(samplePorcelainV2Output))},
+		{"NewDecoderZ", NewDecoderZ(bytes.NewReader(samplePorcelainV2ZOutput))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.dec
+			var entries []Entry
+			for i := 0; ; i++ {
+				e, err := d.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+				if i == 0 {
+					// headers precede entries, so are complete after the first
+					if diff := cmp.Diff(sampleParsedStatus.Branch, d.Branch()); diff != "" {
+						t.Errorf("Branch() mismatch (-want +got):\n%s", diff)
+					}
+					if diff := cmp.Diff(sampleParsedStatus.Stash, d.Stash()); diff != "" {
+						t.Errorf("Stash() mismatch (-want +got):\n%s", diff)
+					}
+				}
+				entries = append(entries, e)
+			}
+			if diff := cmp.Diff(sampleParsedStatus.Entries, entries); diff != "" {
+				t.Errorf("entries mismatch (-want +got):\n%s", diff)
+			}
+
+			// EOF is sticky
+			if _, err := d.Next(); !errors.Is(err, io.EOF) {
+				t.Errorf("Next() after EOF error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestDecoder_Error(t *testing.T) {
+	d := NewDecoder(bytes.NewReader([]byte("? ok.txt\n1 M. bad\n? never.txt\n")))
+	if _, err := d.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if e, err := d.Next(); err == nil {
+		t.Errorf("Next() = %v, want error", e)
+	}
+}
@@ -0,0 +1,162 @@
+package statusv2
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Change describes how the entry for a single path differs between two
+// statuses.
+type Change struct {
+	Path string
+	Old  Entry // entry in the old status, or nil if the path had none
+	New  Entry // entry in the new status, or nil if the path has none
+}
+
+// Added reports whether the path has an entry only in the new status, e.g. a
+// file that was modified since the old status.
+func (c Change) Added() bool { return c.Old == nil && c.New != nil }
+
+// Removed reports whether the path has an entry only in the old status, e.g.
+// a modified file that has since been committed or reverted.
+func (c Change) Removed() bool { return c.Old != nil && c.New == nil }
+
+// Diff compares the entries of two statuses by path, returning a Change for
+// every path whose entry was added, removed, or differs in any field, sorted
+// by path. Branch and stash information is not compared.
+//
+// Renamed or copied entries are identified by their new path. A nil Status is
+// treated as having no entries.
+func Diff(old, new *Status) []Change {
+	oldEntries := entriesByPath(old)
+	newEntries := entriesByPath(new)
+
+	var changes []Change
+	for path, o := range oldEntries {
+		if n := newEntries[path]; n != o {
+			changes = append(changes, Change{Path: path, Old: o, New: n})
+		}
+	}
+	for path, n := range newEntries {
+		if _, ok := oldEntries[path]; !ok {
+			changes = append(changes, Change{Path: path, New: n})
+		}
+	}
+	slices.SortFunc(changes, func(a, b Change) int { return cmp.Compare(a.Path, b.Path) })
+	return changes
+}
+
+func entriesByPath(s *Status) map[string]Entry {
+	if s == nil {
+		return nil
+	}
+	m := make(map[string]Entry, len(s.Entries))
+	for _, e := range s.Entries {
+		m[e.EntryPath()] = e
+	}
+	return m
+}
+
+// ApplyDiff updates the entries of s with changes, as returned by Diff, so
+// that applying Diff(s, new) makes the entries of s equal to those of new.
+// Branch and stash information is unchanged.
+//
+// Entries are kept in the order git writes them: tracked entries sorted by
+// path, then untracked entries, then ignored entries.
+func (s *Status) ApplyDiff(changes []Change) {
+	byPath := make(map[string]Change, len(changes))
+	for _, c := range changes {
+		byPath[c.Path] = c
+	}
+	entries := s.Entries[:0:0]
+	for _, e := range s.Entries {
+		if c, ok := byPath[e.EntryPath()]; ok {
+			delete(byPath, c.Path)
+			e = c.New
+		}
+		if e != nil {
+			entries = append(entries, e)
+		}
+	}
+	for _, c := range byPath {
+		if c.New != nil {
+			entries = append(entries, c.New)
+		}
+	}
+	s.Entries = entries
+	sortEntries(s.Entries)
+}
+
+// Merge updates s with other, the status of only the given paths, as from
+// `git status -- <paths>`, so that a few files can be checked again without
+// getting the status of the whole worktree. The entries of s for the paths,
+// or beneath them if they are directories, are replaced by the entries of
+// other, including renames from one of the paths, as the original path of a
+// rename is removed. With no paths, other is the status of the whole
+// worktree, and replaces all entries of s.
+//
+// The paths are literal paths relative to the root of the repository, rather
+// than git pathspecs with wildcards. The branch and stash information of
+// other, if present, replaces that of s.
+//
+// Entries are kept in the same order as by [Status.ApplyDiff]. A nil other is
+// treated as having no entries.
+func (s *Status) Merge(other *Status, paths ...string) {
+	if other == nil {
+		other = &Status{}
+	}
+	if other.Branch != nil {
+		s.Branch = other.Branch
+	}
+	if other.Stash != nil {
+		s.Stash = other.Stash
+	}
+	entries := s.Entries[:0:0]
+	if len(paths) > 0 {
+		for _, e := range s.Entries {
+			if !mergedPath(e, paths) {
+				entries = append(entries, e)
+			}
+		}
+	}
+	s.Entries = append(entries, other.Entries...)
+	sortEntries(s.Entries)
+}
+
+// mergedPath reports whether e is replaced by merging the status of paths: it
+// is an entry for one of them, or beneath one, or a rename from one.
+func mergedPath(e Entry, paths []string) bool {
+	if r, ok := e.(RenameOrCopyEntry); ok && r.Score.Kind() == Renamed && underAny(r.Orig, paths) {
+		return true
+	}
+	return underAny(e.EntryPath(), paths)
+}
+
+// underAny reports whether path is one of paths, or beneath one of them.
+func underAny(path string, paths []string) bool {
+	for _, p := range paths {
+		p = strings.TrimSuffix(p, "/")
+		if p == "" || p == "." || path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// sortEntries sorts entries in the order git writes them: tracked entries by
+// path, then untracked entries, then ignored entries, each by path.
+func sortEntries(entries []Entry) {
+	rank := func(e Entry) int {
+		switch e.(type) {
+		case UntrackedEntry:
+			return 1
+		case IgnoredEntry:
+			return 2
+		}
+		return 0
+	}
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		return cmp.Or(cmp.Compare(rank(a), rank(b)), cmp.Compare(a.EntryPath(), b.EntryPath()))
+	})
+}
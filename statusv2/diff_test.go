@@ -0,0 +1,186 @@
+package statusv2
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	modified := ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "a.txt"}
+	staged := ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "a.txt"}
+	untracked := UntrackedEntry{Path: "b.txt"}
+	added := ChangedEntry{XY: XYFlag{Added, Unmodified}, Path: "b.txt"}
+	conflict := UnmergedEntry{XY: XYFlag{UpdatedUnmerged, UpdatedUnmerged}, Path: "c.txt"}
+	ignored := IgnoredEntry{Path: "d.log"}
+
+	tests := []struct {
+		name     string
+		old, new *Status
+		want     []Change
+	}{
+		{
+			name: "identical",
+			old:  &Status{Entries: []Entry{modified, untracked}},
+			new:  &Status{Entries: []Entry{untracked, modified}},
+			want: nil,
+		},
+		{
+			name: "changed, added and removed",
+			old:  &Status{Entries: []Entry{modified, untracked, conflict}},
+			new:  &Status{Entries: []Entry{ignored, staged, added}},
+			want: []Change{
+				{Path: "a.txt", Old: modified, New: staged},
+				{Path: "b.txt", Old: untracked, New: added},
+				{Path: "c.txt", Old: conflict},
+				{Path: "d.log", New: ignored},
+			},
+		},
+		{
+			name: "nil old",
+			old:  nil,
+			new:  &Status{Entries: []Entry{modified}},
+			want: []Change{{Path: "a.txt", New: modified}},
+		},
+		{
+			name: "branch is ignored",
+			old:  &Status{Branch: &BranchInfo{Head: "main"}},
+			new:  &Status{Branch: &BranchInfo{Head: "other"}},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.old, tt.new)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Diff() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestChange_AddedRemoved(t *testing.T) {
+	e := UntrackedEntry{Path: "a"}
+	tests := []struct {
+		c           Change
+		wantAdded   bool
+		wantRemoved bool
+	}{
+		{Change{Path: "a", New: e}, true, false},
+		{Change{Path: "a", Old: e}, false, true},
+		{Change{Path: "a", Old: e, New: IgnoredEntry{Path: "a"}}, false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Added(); got != tt.wantAdded {
+			t.Errorf("%+v.Added() = %v, want %v", tt.c, got, tt.wantAdded)
+		}
+		if got := tt.c.Removed(); got != tt.wantRemoved {
+			t.Errorf("%+v.Removed() = %v, want %v", tt.c, got, tt.wantRemoved)
+		}
+	}
+}
+
+func TestStatus_ApplyDiff(t *testing.T) {
+	modified := ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "a.txt"}
+	staged := ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "a.txt"}
+	renamed := RenameOrCopyEntry{XY: XYFlag{Renamed, Unmodified}, Score: "R100", Path: "b.txt", Orig: "z.txt"}
+	conflict := UnmergedEntry{XY: XYFlag{UpdatedUnmerged, UpdatedUnmerged}, Path: "c.txt"}
+	untracked := UntrackedEntry{Path: "a.new"}
+	ignored := IgnoredEntry{Path: "0.log"}
+
+	statuses := []*Status{
+		{},
+		{Entries: []Entry{modified, untracked}},
+		{Entries: []Entry{staged, renamed, conflict, untracked, ignored}},
+		{Entries: []Entry{renamed, ignored}},
+		{Entries: []Entry{modified, conflict}},
+	}
+	for _, old := range statuses {
+		for _, new := range statuses {
+			s := &Status{Entries: slices.Clone(old.Entries)}
+			s.ApplyDiff(Diff(old, new))
+			if !slices.Equal(s.Entries, new.Entries) {
+				t.Errorf("%v.ApplyDiff(Diff(%v, %v)) = %v", old.Entries, old.Entries, new.Entries, s.Entries)
+			}
+		}
+	}
+}
+
+func TestStatus_Merge(t *testing.T) {
+	branch := &BranchInfo{OID: "(initial)", Head: "main"}
+	full := &Status{
+		Branch: branch,
+		Entries: []Entry{
+			ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "a.txt"},
+			ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "dir/b.txt"},
+			ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "dir/c.txt"},
+			ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "dir2/d.txt"},
+			UntrackedEntry{Path: "dir/new.txt"},
+			UntrackedEntry{Path: "e.txt"},
+		},
+	}
+	// dir/b.txt is staged, dir/c.txt is reverted, dir/new.txt is added, and
+	// a.txt is added to the partial status, which has no branch information.
+	partial := &Status{Entries: []Entry{
+		ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "a.txt"},
+		ChangedEntry{XY: XYFlag{Added, Unmodified}, Path: "dir/new.txt"},
+		ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "dir/b.txt"},
+	}}
+
+	s := &Status{Branch: full.Branch, Entries: slices.Clone(full.Entries)}
+	s.Merge(partial, "dir/", "a.txt")
+	want := &Status{
+		Branch: branch,
+		Entries: []Entry{
+			ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "a.txt"},
+			ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "dir/b.txt"},
+			ChangedEntry{XY: XYFlag{Added, Unmodified}, Path: "dir/new.txt"},
+			ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "dir2/d.txt"},
+			UntrackedEntry{Path: "e.txt"},
+		},
+	}
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Errorf("Merge() mismatch (-want +got):\n%s", diff)
+	}
+
+	// without paths, the other status is the whole worktree
+	s.Merge(&Status{Stash: &StashInfo{Count: 1}, Entries: []Entry{UntrackedEntry{Path: "f.txt"}}})
+	want = &Status{Branch: branch, Stash: &StashInfo{Count: 1}, Entries: []Entry{UntrackedEntry{Path: "f.txt"}}}
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Errorf("Merge() without paths mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestSortEntries checks that entries recorded from git are already in the
+// order that ApplyDiff and Merge keep them in.
+// TestStatus_Merge_Rename checks that a rename from one of the merged paths is
+// replaced, as its original path is.
+func TestStatus_Merge_Rename(t *testing.T) {
+	rename := RenameOrCopyEntry{XY: XYFlag{Renamed, Unmodified}, Score: "R100", Path: "b", Orig: "a"}
+	copied := RenameOrCopyEntry{XY: XYFlag{Copied, Unmodified}, Score: "C100", Path: "d", Orig: "a"}
+	s := &Status{Entries: []Entry{rename, copied}}
+	s.Merge(&Status{Entries: []Entry{ChangedEntry{XY: XYFlag{Deleted, Unmodified}, Path: "a"}}}, "a")
+	want := &Status{Entries: []Entry{
+		ChangedEntry{XY: XYFlag{Deleted, Unmodified}, Path: "a"},
+		copied, // the source of a copy is kept
+	}}
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Errorf("Merge() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSortEntries(t *testing.T) {
+	for _, c := range corpusCases(t) {
+		s, err := NewJSONDecoder(bytes.NewReader(c.Golden)).Decode()
+		if err != nil {
+			t.Fatalf("%s: invalid golden result: %v", c.Name(), err)
+		}
+		sorted := slices.Clone(s.Entries)
+		sortEntries(sorted)
+		if !slices.Equal(sorted, s.Entries) {
+			t.Errorf("%s: sorted entries = %v, want git's order %v", c.Name(), sorted, s.Entries)
+		}
+	}
+}
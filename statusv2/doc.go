@@ -18,6 +18,179 @@ used with the command.
 
 [ParseZ] provides a variant that will work with NUL-terminated git status output (from -z flag).
 
+[ParseContext] and [ParseZContext] additionally stop once a [context.Context]
+is done, to bound the time spent parsing large or untrusted input.
+
+# Streaming
+
+For very large repositories, [NewDecoder] and [NewDecoderZ] return a [Decoder]
+that parses one entry at a time, rather than collecting all of them in memory:
+
+	d := statusv2.NewDecoder(r)
+	for {
+	    entry, err := d.Next()
+	    if err == io.EOF {
+	        break
+	    }
+	    if err != nil {
+	        log.Fatal(err)
+	    }
+	    // use entry
+	}
+
+Branch and stash information is available from [Decoder.Branch] and
+[Decoder.Stash] once the first entry has been read, as Git writes all headers
+before any entries.
+
+[Decoder.Decode] instead reads all remaining entries into a [Status], like
+[Parse], with a [Meta] recording the format, the number of entries and bytes
+read, the time taken, and the number of lines skipped, for logging:
+
+	status, err := statusv2.NewDecoder(r).Decode()
+	if err != nil {
+	    log.Fatal(err)
+	}
+	slog.Info("parsed status", "entries", status.Meta.Entries, "duration", status.Meta.Duration)
+
+[Decoder.Tee] copies the raw input to an [io.Writer] as it is read, to archive
+the exact output of git alongside the parsed result.
+
+# Strict Mode
+
+By default, a [Decoder] is lenient, accepting anything it can make sense of.
+[Decoder.Strict] makes it instead reject input that git itself would never
+produce, which is useful for validating `git status --porcelain=v2` output
+generated by other tools. Together with [Decoder.Line], every violation in an
+input can be reported:
+
+	d.Strict()
+	for {
+	    _, err := d.Next()
+	    if err == io.EOF {
+	        break
+	    }
+	    if err != nil {
+	        fmt.Printf("line %d: %v\n", d.Line(), err)
+	    }
+	}
+
+Parse errors are of type [parseerr.Error], with a [parseerr.Code] for
+matching on the kind of violation, and parameters for translating its message:
+
+	var perr *parseerr.Error
+	if errors.As(err, &perr) {
+	    fmt.Printf("line %d: %s\n", d.Line(), perr.Localize(catalog))
+	}
+
+Outside strict mode, lines with a prefix the Decoder does not know are skipped,
+as are any entries of a type added to the format by a later version of git.
+[Decoder.KeepUnknown] keeps them instead, in [Status.UnknownLines], so that
+tools can alert on them rather than losing the data:
+
+	d.KeepUnknown()
+	status, err := d.Decode()
+	// ...
+	if len(status.UnknownLines) > 0 {
+	    log.Printf("unrecognized status lines: %q", status.UnknownLines)
+	}
+
+Lines of `git status --porcelain=v1` output, as in a capture mixing up the
+formats, are never skipped, as that would leave a partial status. In either
+mode, the Decoder returns [ErrFormatMismatch] for them, suggesting package
+statusv1 instead.
+
+Git never lists a path twice, but concatenated or corrupted input can.
+[Decoder.OnDuplicates] sets a [DuplicatePolicy] for such entries, to keep the
+first or last entry for each path, or to report them as errors, and
+[Status.Duplicates] lists any in a status already parsed:
+
+	d.OnDuplicates(statusv2.DedupeLast)
+
+# Transforming Entries
+
+[Decoder.Transform] adds [EntryTransformer] values that the Decoder applies to
+each entry before returning it, so that transformations compose without a
+separate read-modify-write loop. [UnquotePaths], [RewritePaths] and [Filter]
+cover common cases, and [EntryTransformerFunc] adapts any function:
+
+	d := statusv2.NewDecoder(r)
+	d.Transform(
+	    statusv2.UnquotePaths(),
+	    statusv2.Filter(func(e statusv2.Entry) bool { return e.Type() != statusv2.EntryTypeIgnored }),
+	    statusv2.RewritePaths(func(p string) string { return path.Join("sub", p) }),
+	)
+
+Transformers dropping an entry by returning nil stop the others from seeing
+it, as do those in a [Chain].
+
+[Status.Transform] applies transformers to a status already parsed, such as
+[IgnoreDirtySubmodules], which gives the results of
+`git status --ignore-submodules=dirty` for tools that only care about changes
+relevant to the superproject.
+
+A [PathNormalizer] makes paths comparable with those from other sources, by
+enforcing forward slashes, normalizing their Unicode form, which git writes
+as stored, often decomposed on macOS, and folding their case. It can record
+the paths git wrote, for passing back to git:
+
+	n := &statusv2.PathNormalizer{Unicode: norm.NFC.String, KeepOriginal: true}
+	d.Transform(statusv2.UnquotePaths(), n)
+	// ...
+	git.Run(ctx, "add", "--", n.Original(path))
+
+# Encoding
+
+[Encode] and [EncodeZ] perform the reverse of parsing, writing a [Status] in
+the porcelain format, for example to generate test fixtures.
+
+Entries are interfaces, so to survive a round trip through JSON they need
+their type recorded as well. [MarshalEntryJSON] adds a Type field to the JSON
+of an entry, and [UnmarshalEntryJSON] reconstructs the entry from it. A
+[JSONDecoder] reads such entries as JSON Lines, one at a time, as written by
+porcelain2go -o jsonl:
+
+	d := statusv2.NewJSONDecoder(os.Stdin)
+	status, err := d.Decode()
+
+# Comparing Statuses
+
+[Diff] compares the entries of two statuses by path, for example to find which
+files a build step touched:
+
+	for _, c := range statusv2.Diff(before, after) {
+	    fmt.Printf("%s: %v -> %v\n", c.Path, c.Old, c.New)
+	}
+
+[Status.ApplyDiff] and [Status.Merge] update a status incrementally, from such
+changes, or from the status of only a few paths checked again:
+
+	partial, err := statusv2.Get(ctx, git, "--", "src/main.go")
+	// handle err
+	status.Merge(partial, "src/main.go")
+
+[GetPaths] gets the status of many paths at once, such as the files touched by
+a build, running git as many times as the limits on command lines require, and
+partitions the paths into dirty, untracked, ignored and clean:
+
+	result, err := statusv2.GetPaths(ctx, git, touched)
+	// handle err
+	for _, p := range result.Dirty {
+	    fmt.Println("modified by the build:", p)
+	}
+
+[Events] classifies changes by the states paths entered or left, such as
+becoming staged or conflicted, for reports on what a tool did to a worktree.
+The events marshal to JSON, and [Summarize] counts them on a single line:
+
+	events := statusv2.Events(statusv2.Diff(before, after))
+	fmt.Println(statusv2.Summarize(events)) // 3 files became staged, 2 new untracked
+
+The order of entries from git depends on the enumeration of the filesystem.
+[Status.SortByPath], [Status.SortByType] and [Status.SortFunc] put them in a
+deterministic order instead, for reproducible reports and test output.
+[Status.SortStableByPath] also returns the ordinals of the entries, for
+[Status.RestoreOrder] to undo the sort.
+
 # Working with Results
 
 The [Status] struct contains parsed information:
@@ -42,6 +215,75 @@ The [Status] struct contains parsed information:
 	    }
 	}
 
+The placeholders git writes in the branch headers are interpreted by
+[BranchInfo.IsDetached] and [BranchInfo.IsUnborn] (or its equivalent
+[BranchInfo.IsInitial]), for "(detached)" and "(initial)", and
+[BranchInfo.HasUpstream] reports whether an upstream branch is configured.
+The ahead and behind counts are only set when git reports them, which it does
+not when the upstream branch is gone.
+
+Iterators over the entries of each type save the type switch where only one
+is wanted, such as [Status.UntrackedEntries] and [Status.ChangedEntries]:
+
+	for e := range status.UntrackedEntries() {
+	    fmt.Printf("Untracked: %s\n", e.Path)
+	}
+
+[Status.Filter] selects entries with a [Predicate], such as [ByEntryType],
+[ByXState], [ByYState] and [ByPathPrefix], combined with [And], [Or] and
+[Not]:
+
+	deletions := status.Filter(statusv2.And(
+	    statusv2.ByPathPrefix("src"),
+	    statusv2.Or(statusv2.ByXState(statusv2.Deleted), statusv2.ByYState(statusv2.Deleted)),
+	))
+
+For the common questions of prompts and CI checks, [Status.IsClean],
+[Status.HasStagedChanges], [Status.HasUnstagedChanges] and
+[Status.HasUntracked] save switching over the entry types:
+
+	if !status.IsClean() && !status.HasUnstagedChanges() {
+	    // everything is staged, or in conflict, or untracked
+	}
+
+The same questions about a single file are answered by [XYFlag.HasStaged],
+[XYFlag.HasUnstaged] and [XYFlag.IsConflict], which follow the rules git uses,
+such as that a conflict is neither staged nor unstaged until resolved.
+
+[Status.Summary] counts the files in each state instead, in a single pass:
+
+	sum := status.Summary()
+	fmt.Printf("%d staged, %d unstaged, %d untracked\n", sum.Staged, sum.Unstaged, sum.Untracked)
+
+[Status.IndexView] and [Status.WorktreeView] list the files as the two panes
+of most git UIs do, of the changes staged and not staged, each with the state
+of the file in that pane:
+
+	for _, v := range status.IndexView() {
+	    fmt.Printf("%c %s\n", v.State, v.Path)
+	}
+
+[Status.Conflicts] gives a view over the unmerged entries for merge-assist
+tools, grouping them by [ConflictKind] with a hint for resolving each:
+
+	for kind, conflicts := range status.Conflicts().ByKind() {
+	    fmt.Printf("%s (%s): %v\n", kind, kind.Hint(), conflicts.Paths())
+	}
+
+[Status.Verify] checks the paths of a status against the worktree, reporting
+any whose presence contradicts their entry, as for a stale cached status:
+
+	discrepancies, err := status.Verify(os.DirFS(repoRoot))
+
+[Status.Stat] gets the size, modification time and mode of the file of each
+entry, concurrently, for tools showing them alongside the entries:
+
+	stats, err := status.Stat(ctx, os.DirFS(repoRoot), statusv2.StatConfig{})
+	// handle err
+	for _, st := range stats {
+	    fmt.Printf("%s %d %s\n", st.EntryPath(), st.Size, st.ModTime.Format(time.Kitchen))
+	}
+
 # Entry Types
 
 The package defines several entry types that implement the [Entry] interface:
@@ -53,7 +295,67 @@ The package defines several entry types that implement the [Entry] interface:
   - [IgnoredEntry] - Files ignored by Git
 
 Each entry type has specific fields relevant to its status. Use type switching
-to access the specific fields for each entry type.
+to access the specific fields for each entry type. The path of any entry is
+available from [Entry.EntryPath]. [EntryType] values, from [Entry.Type], print
+and marshal as names such as "rename_or_copy", the same as the Type field
+written by [MarshalEntryJSON].
+
+The similarity [Score] of a [RenameOrCopyEntry] gives its kind and percentage
+with [Score.Kind] and [Score.Percent], for thresholding on similarity:
+
+	if e.Score.Kind() == statusv2.Renamed && e.Score.Percent() < 50 {
+	    // more rewritten than renamed
+	}
+
+File modes classify the kind of file of an entry with [FileMode.IsRegular],
+[FileMode.IsSymlink], [FileMode.IsSubmodule] and others, and convert to an
+[fs.FileMode] with [FileMode.ToOSFileMode].
+
+The [State] values of XY flags describe themselves for messages to users with
+[State.Description], such as "type changed" for [TypeChanged].
+
+# Rename Detection
+
+Whether renamed and copied files appear as a [RenameOrCopyEntry] depends on the
+rename detection git ran with, which the output does not record. To know it,
+get the status with [GetWithRenames], which overrides the configured settings
+and records them in [Status.Renames]:
+
+	s, err := statusv2.GetWithRenames(ctx, git, statusv2.RenameDetection{Mode: statusv2.Copies})
+
+# Untracked Files
+
+Likewise, which untracked files are listed depends on the --untracked-files
+mode: none with "no", and directories of only untracked files as a single
+entry with "normal", git's default. [GetWithUntracked] records the mode in
+[Status.Untracked], or callers running git themselves can set it. Questions
+the mode cannot answer then fail rather than answer wrongly, with
+[ErrUntrackedNotListed] or [ErrUntrackedDirectories]:
+
+	s, err := statusv2.GetWithUntracked(ctx, git, statusv2.UntrackedNo)
+	// ...
+	if _, err := s.HasUntracked(); errors.Is(err, statusv2.ErrUntrackedNotListed) {
+	    // untracked files were not looked for
+	}
+
+# Object Names
+
+Object names are 40 hexadecimal characters in SHA-1 repositories and 64 in
+SHA-256 ones. Nothing in the package assumes either length. The hash fields of
+entries are strings, and accessors such as [ChangedEntry.Index] and
+[UnmergedEntry.Ours] return them as a [Hash], whose [Hash.Algorithm] reports
+which a given name is:
+
+	if _, hash := e.Index(); hash.Algorithm() == statusv2.SHA256 {
+	    // a repository created with git init --object-format=sha256
+	}
+
+[Status.Abbreviate] shortens the object names of a status for display, as git
+does, lengthening any that would otherwise be ambiguous within the status:
+
+	abbrevs := status.Abbreviate(7)
+	_, hash := e.Index()
+	fmt.Println(abbrevs.Abbrev(hash), e.Path)
 
 # Git Status Format
 
@@ -0,0 +1,123 @@
+package statusv2
+
+import (
+	"fmt"
+
+	"github.com/mroth/porcelain/parseerr"
+)
+
+// DuplicatePolicy is what a [Decoder] does with entries for a path it has
+// already returned an entry for, as from concatenated or corrupted input.
+type DuplicatePolicy int
+
+// Duplicate entry policies.
+const (
+	KeepDuplicates  DuplicatePolicy = iota // every entry is returned, as git output never has duplicates
+	DedupeFirst                            // the first entry for a path is kept, and later ones skipped
+	DedupeLast                             // the last entry for a path is kept, by Decode only
+	DuplicatesError                        // a later entry for a path is an error
+)
+
+// String returns the name of the policy, e.g. "dedupe-first".
+func (p DuplicatePolicy) String() string {
+	switch p {
+	case KeepDuplicates:
+		return "keep"
+	case DedupeFirst:
+		return "dedupe-first"
+	case DedupeLast:
+		return "dedupe-last"
+	case DuplicatesError:
+		return "error"
+	}
+	return fmt.Sprintf("DuplicatePolicy(%d)", int(p))
+}
+
+// OnDuplicates sets what the Decoder does with entries for a path it has
+// already returned an entry for, comparing the paths of entries after any
+// transformers. Skipped entries count as warnings in [Meta].
+//
+// As [Decoder.Next] cannot know whether a later entry will replace the one it
+// returns, [DedupeLast] applies only to [Decoder.Decode], which keeps the last
+// entry for each path in the place of the first.
+func (d *Decoder) OnDuplicates(p DuplicatePolicy) {
+	d.duplicates = p
+	if p != KeepDuplicates && d.seen == nil {
+		d.seen = make(map[string]bool)
+	}
+}
+
+// checkDuplicate applies the duplicate policy of d to e, reporting whether e
+// is to be returned.
+func (d *Decoder) checkDuplicate(e Entry) (bool, error) {
+	if d.duplicates == KeepDuplicates {
+		return true, nil
+	}
+	path := e.EntryPath()
+	if !d.seen[path] {
+		d.seen[path] = true
+		return true, nil
+	}
+	switch d.duplicates {
+	case DedupeFirst:
+		d.meta.Warnings++
+		return false, nil
+	case DuplicatesError:
+		return false, parseerr.New(parseerr.DuplicatePath, "path", path)
+	}
+	return true, nil
+}
+
+// dedupeLast replaces the first entry for each path in entries with the last,
+// removing the others, and returns the number removed.
+func dedupeLast(entries []Entry) ([]Entry, int) {
+	first := make(map[string]int, len(entries))
+	out := entries[:0]
+	for _, e := range entries {
+		path := e.EntryPath()
+		if i, ok := first[path]; ok {
+			out[i] = e
+			continue
+		}
+		first[path] = len(out)
+		out = append(out, e)
+	}
+	removed := len(entries) - len(out)
+	clear(entries[len(out):])
+	return out, removed
+}
+
+// A Duplicate is a path with more than one entry in a status.
+type Duplicate struct {
+	Path    string
+	Entries []Entry // in order
+}
+
+// Duplicates returns the paths of s with more than one entry, in the order of
+// their first entries, or nil if there are none, as for any status from git
+// itself.
+func (s *Status) Duplicates() []Duplicate {
+	if s == nil {
+		return nil
+	}
+	counts := make(map[string]int, len(s.Entries))
+	for _, e := range s.Entries {
+		counts[e.EntryPath()]++
+	}
+	var dups []Duplicate
+	index := make(map[string]int)
+	for _, e := range s.Entries {
+		path := e.EntryPath()
+		if counts[path] < 2 {
+			continue
+		}
+		i, ok := index[path]
+		if !ok {
+			i = len(dups)
+			index[path] = i
+			dups = append(dups, Duplicate{Path: path})
+		}
+		dups[i].Entries = append(dups[i].Entries, e)
+	}
+	return dups
+}
@@ -0,0 +1,120 @@
+package statusv2
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/parseerr"
+)
+
+// duplicatesInput is the concatenated output of two runs of git status.
+const duplicatesInput = "? a.txt\n" +
+	"1 .M N... 100644 100644 100644 0000000000000000000000000000000000000000 0000000000000000000000000000000000000000 b.txt\n" +
+	"? a.txt\n" +
+	"1 M. N... 100644 100644 100644 0000000000000000000000000000000000000000 0000000000000000000000000000000000000000 b.txt\n" +
+	"? c.txt\n"
+
+func TestDecoder_OnDuplicates(t *testing.T) {
+	unstagedB := ChangedEntry{XY: XYFlag{Unmodified, Modified}, ModeH: 0o100644, ModeI: 0o100644, ModeW: 0o100644,
+		HashH: "0000000000000000000000000000000000000000", HashI: "0000000000000000000000000000000000000000", Path: "b.txt"}
+	stagedB := unstagedB
+	stagedB.XY = XYFlag{Modified, Unmodified}
+	a, c := UntrackedEntry{Path: "a.txt"}, UntrackedEntry{Path: "c.txt"}
+
+	tests := []struct {
+		policy       DuplicatePolicy
+		want         []Entry
+		wantWarnings int
+	}{
+		{KeepDuplicates, []Entry{a, unstagedB, a, stagedB, c}, 0},
+		{DedupeFirst, []Entry{a, unstagedB, c}, 2},
+		{DedupeLast, []Entry{a, stagedB, c}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			d := NewDecoder(strings.NewReader(duplicatesInput))
+			d.OnDuplicates(tt.policy)
+			s, err := d.Decode()
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, s.Entries); diff != "" {
+				t.Errorf("Entries mismatch (-want +got):\n%s", diff)
+			}
+			if s.Meta.Entries != len(tt.want) || s.Meta.Warnings != tt.wantWarnings {
+				t.Errorf("Meta = %d entries, %d warnings, want %d, %d", s.Meta.Entries, s.Meta.Warnings, len(tt.want), tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestDecoder_OnDuplicatesError(t *testing.T) {
+	d := NewDecoder(strings.NewReader(duplicatesInput))
+	d.OnDuplicates(DuplicatesError)
+	var paths, errs []string
+	for {
+		e, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var perr *parseerr.Error
+			if !errors.As(err, &perr) || perr.Code != parseerr.DuplicatePath {
+				t.Fatalf("Next() error = %v, want %s", err, parseerr.DuplicatePath)
+			}
+			errs = append(errs, err.Error())
+			continue
+		}
+		paths = append(paths, e.EntryPath())
+	}
+	if diff := cmp.Diff([]string{"a.txt", "b.txt", "c.txt"}, paths); diff != "" {
+		t.Errorf("paths mismatch (-want +got):\n%s", diff)
+	}
+	want := []string{`duplicate entry for path "a.txt"`, `duplicate entry for path "b.txt"`}
+	if diff := cmp.Diff(want, errs); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecoder_OnDuplicatesAfterTransform(t *testing.T) {
+	d := NewDecoder(strings.NewReader("? a.txt\n? A.TXT\n"))
+	d.Transform(&PathNormalizer{Lower: true})
+	d.OnDuplicates(DedupeFirst)
+	s, err := d.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if diff := cmp.Diff([]Entry{UntrackedEntry{Path: "a.txt"}}, s.Entries); diff != "" {
+		t.Errorf("Entries mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStatus_Duplicates(t *testing.T) {
+	s, err := Parse(strings.NewReader(duplicatesInput))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	got := s.Duplicates()
+	if len(got) != 2 || got[0].Path != "a.txt" || got[1].Path != "b.txt" {
+		t.Fatalf("Duplicates() = %v, want a.txt and b.txt", got)
+	}
+	if diff := cmp.Diff([]Entry{s.Entries[1], s.Entries[3]}, got[1].Entries); diff != "" {
+		t.Errorf("Duplicates()[1].Entries mismatch (-want +got):\n%s", diff)
+	}
+
+	var nilStatus *Status
+	for _, s := range []*Status{nilStatus, {Entries: []Entry{UntrackedEntry{Path: "a"}, IgnoredEntry{Path: "b"}}}} {
+		if got := s.Duplicates(); got != nil {
+			t.Errorf("Duplicates() = %v, want nil", got)
+		}
+	}
+}
+
+func TestDuplicatePolicy_String(t *testing.T) {
+	if got, want := DuplicatePolicy(9).String(), "DuplicatePolicy(9)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package statusv2
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Encode writes s to w in the `git status --porcelain=v2` format, such that
+// parsing the output with [Parse] returns an equivalent Status.
+//
+// Branch headers are written if s.Branch is non-nil, with the branch.ab header
+// only written if there is an upstream, as with Git. Paths are written as-is,
+// so should already be quoted as Git would for the output to be valid.
+func Encode(w io.Writer, s *Status) error {
+	return encode(w, s, '\n', tabSeparator)
+}
+
+// EncodeZ writes s to w in the `git status --porcelain=v2 -z` format, such that
+// parsing the output with [ParseZ] returns an equivalent Status.
+func EncodeZ(w io.Writer, s *Status) error {
+	return encode(w, s, '\x00', nulSeparator)
+}
+
+func encode(w io.Writer, s *Status, term byte, pathSep renamePathSep) error {
+	bw := bufio.NewWriter(w)
+	line := func(format string, a ...any) {
+		fmt.Fprintf(bw, format, a...)
+		bw.WriteByte(term)
+	}
+
+	if b := s.Branch; b != nil {
+		line("# branch.oid %s", b.OID)
+		line("# branch.head %s", b.Head)
+		if b.Upstream != "" {
+			line("# branch.upstream %s", b.Upstream)
+			line("# branch.ab +%d -%d", b.Ahead, b.Behind)
+		}
+	}
+	if s.Stash != nil {
+		line("# stash %d", s.Stash.Count)
+	}
+
+	for _, entry := range s.Entries {
+		switch e := entry.(type) {
+		case ChangedEntry:
+			line("1 %s %s %06o %06o %06o %s %s %s",
+				e.XY, e.Sub, e.ModeH, e.ModeI, e.ModeW, e.HashH, e.HashI, e.Path)
+		case RenameOrCopyEntry:
+			line("2 %s %s %06o %06o %06o %s %s %s %s%c%s",
+				e.XY, e.Sub, e.ModeH, e.ModeI, e.ModeW, e.HashH, e.HashI, e.Score, e.Path, pathSep, e.Orig)
+		case UnmergedEntry:
+			line("u %s %s %06o %06o %06o %06o %s %s %s %s",
+				e.XY, e.Sub, e.Mode1, e.Mode2, e.Mode3, e.ModeW, e.Hash1, e.Hash2, e.Hash3, e.Path)
+		case UntrackedEntry:
+			line("? %s", e.Path)
+		case IgnoredEntry:
+			line("! %s", e.Path)
+		default:
+			return fmt.Errorf("unsupported entry type %T", entry)
+		}
+	}
+	return bw.Flush()
+}
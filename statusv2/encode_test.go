@@ -0,0 +1,56 @@
+package statusv2
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, &sampleParsedStatus); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	got, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if diff := cmp.Diff(&sampleParsedStatus, got); diff != "" {
+		t.Errorf("Parse(Encode()) mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEncodeZ(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeZ(&buf, &sampleParsedStatus); err != nil {
+		t.Fatalf("EncodeZ() error = %v", err)
+	}
+	got, err := ParseZ(&buf)
+	if err != nil {
+		t.Fatalf("ParseZ() error = %v", err)
+	}
+	if diff := cmp.Diff(&sampleParsedStatus, got); diff != "" {
+		t.Errorf("ParseZ(EncodeZ()) mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEncode_ByteExact(t *testing.T) {
+	// output of git status --porcelain=v2 --branch --show-stash -z, with no upstream
+	input := "# branch.oid 7cd2d7e2a3400e2463239d071c475c09ab410c2d\x00# branch.head main\x00# stash 1\x00" +
+		"1 .M N... 100644 100644 100644 e952784d6ea184b32977bcc43ba488ef9d1ee9a5 e952784d6ea184b32977bcc43ba488ef9d1ee9a5 f\x00" +
+		"1 A. N... 000000 100644 100644 0000000000000000000000000000000000000000 13e7564ea3d6a2bfe5ad4ee4b0ecd2bd07a5f8a6 new file\x00" +
+		"2 R. N... 100644 100644 100644 9e03d3d3fa6e8df05a5ec6e4a3b4c34fb3c1b4ee 9e03d3d3fa6e8df05a5ec6e4a3b4c34fb3c1b4ee R100 r2\x00r\x00" +
+		"? untracked\x00"
+	s, err := ParseZ(bytes.NewReader([]byte(input)))
+	if err != nil {
+		t.Fatalf("ParseZ() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := EncodeZ(&buf, s); err != nil {
+		t.Fatalf("EncodeZ() error = %v", err)
+	}
+	if got := buf.String(); got != input {
+		t.Errorf("EncodeZ() = %q, want %q", got, input)
+	}
+}
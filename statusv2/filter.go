@@ -0,0 +1,117 @@
+package statusv2
+
+import (
+	"slices"
+	"strings"
+)
+
+// A Predicate reports whether an entry is wanted, for [Status.Filter] and the
+// [Filter] transformer. Predicates compose with [And], [Or] and [Not].
+type Predicate func(Entry) bool
+
+// Filter returns the entries of s for which pred returns true, in order. It
+// returns nil, without allocating, if there are none, or for a nil s.
+func (s *Status) Filter(pred func(Entry) bool) []Entry {
+	if s == nil {
+		return nil
+	}
+	var out []Entry
+	for _, e := range s.Entries {
+		if pred(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// ByEntryType returns a predicate matching entries of any of the given types.
+func ByEntryType(types ...EntryType) Predicate {
+	return func(e Entry) bool {
+		return slices.Contains(types, e.Type())
+	}
+}
+
+// ByXState returns a predicate matching entries with any of the given states
+// in the X position of their XY flag, the state of the index. Untracked and
+// ignored entries, having no XY flag, never match.
+func ByXState(states ...State) Predicate {
+	return func(e Entry) bool {
+		xy, ok := entryXY(e)
+		return ok && slices.Contains(states, xy.X)
+	}
+}
+
+// ByYState returns a predicate matching entries with any of the given states
+// in the Y position of their XY flag, the state of the worktree. Untracked and
+// ignored entries, having no XY flag, never match.
+func ByYState(states ...State) Predicate {
+	return func(e Entry) bool {
+		xy, ok := entryXY(e)
+		return ok && slices.Contains(states, xy.Y)
+	}
+}
+
+// ByPathPrefix returns a predicate matching entries at or under the directory
+// prefix, such as "src" or "src/" for "src/main.go", but not "srcs/main.go".
+// Renames and copies match if either of their paths does. An empty prefix
+// matches every entry.
+func ByPathPrefix(prefix string) Predicate {
+	dir := strings.TrimSuffix(prefix, "/")
+	under := func(p string) bool {
+		rest, ok := strings.CutPrefix(p, dir)
+		return ok && (rest == "" || rest[0] == '/')
+	}
+	return func(e Entry) bool {
+		if dir == "" {
+			return true
+		}
+		if r, ok := e.(RenameOrCopyEntry); ok && under(r.Orig) {
+			return true
+		}
+		return under(e.EntryPath())
+	}
+}
+
+// And returns a predicate matching entries matched by all of preds.
+func And(preds ...Predicate) Predicate {
+	return func(e Entry) bool {
+		for _, p := range preds {
+			if !p(e) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a predicate matching entries matched by any of preds.
+func Or(preds ...Predicate) Predicate {
+	return func(e Entry) bool {
+		for _, p := range preds {
+			if p(e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a predicate matching entries not matched by pred.
+func Not(pred Predicate) Predicate {
+	return func(e Entry) bool {
+		return !pred(e)
+	}
+}
+
+// entryXY returns the XY flag of e, if its type has one.
+func entryXY(e Entry) (XYFlag, bool) {
+	switch e := e.(type) {
+	case ChangedEntry:
+		return e.XY, true
+	case RenameOrCopyEntry:
+		return e.XY, true
+	case UnmergedEntry:
+		return e.XY, true
+	}
+	return XYFlag{}, false
+}
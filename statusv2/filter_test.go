@@ -0,0 +1,58 @@
+package statusv2
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStatus_Filter(t *testing.T) {
+	staged := ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "src/main.go"}
+	modified := ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "srcs/other.go"}
+	deleted := ChangedEntry{XY: XYFlag{Unmodified, Deleted}, Path: "README.md"}
+	renamed := RenameOrCopyEntry{XY: XYFlag{Renamed, Modified}, Score: "R90", Path: "lib/util.go", Orig: "src/util.go"}
+	unmerged := UnmergedEntry{XY: XYFlag{UpdatedUnmerged, UpdatedUnmerged}, Path: "src/conflict.go"}
+	untracked := UntrackedEntry{Path: "src/new/"}
+	ignored := IgnoredEntry{Path: "bin/"}
+	s := &Status{Entries: []Entry{staged, modified, deleted, renamed, unmerged, untracked, ignored}}
+
+	tests := []struct {
+		name string
+		pred Predicate
+		want []Entry
+	}{
+		{"type", ByEntryType(EntryTypeUntracked, EntryTypeIgnored), []Entry{untracked, ignored}},
+		{"x state", ByXState(Modified, Renamed), []Entry{staged, renamed}},
+		{"y state", ByYState(Modified), []Entry{modified, renamed}},
+		{"y state unmerged", ByYState(UpdatedUnmerged), []Entry{unmerged}},
+		{"prefix", ByPathPrefix("src"), []Entry{staged, renamed, unmerged, untracked}},
+		{"prefix slash", ByPathPrefix("src/"), []Entry{staged, renamed, unmerged, untracked}},
+		{"prefix directory entry", ByPathPrefix("src/new"), []Entry{untracked}},
+		{"prefix file", ByPathPrefix("README.md"), []Entry{deleted}},
+		{"prefix empty", ByPathPrefix(""), s.Entries},
+		{"and", And(ByPathPrefix("src"), ByEntryType(EntryTypeChanged)), []Entry{staged}},
+		{"or", Or(ByYState(Deleted), ByEntryType(EntryTypeIgnored)), []Entry{deleted, ignored}},
+		{"not", Not(ByEntryType(EntryTypeChanged, EntryTypeRenameOrCopy, EntryTypeUnmerged)), []Entry{untracked, ignored}},
+		{"none", ByXState(Copied), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, s.Filter(tt.pred)); diff != "" {
+				t.Errorf("Filter() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	var nilStatus *Status
+	if got := nilStatus.Filter(ByPathPrefix("")); got != nil {
+		t.Errorf("Filter() of nil = %v, want nil", got)
+	}
+}
+
+func TestStatus_FilterNoMatchAllocs(t *testing.T) {
+	s := &Status{Entries: []Entry{UntrackedEntry{Path: "a"}, IgnoredEntry{Path: "b"}}}
+	pred := ByEntryType(EntryTypeChanged)
+	if n := testing.AllocsPerRun(100, func() { s.Filter(pred) }); n != 0 {
+		t.Errorf("Filter() with no matches allocated %v times, want 0", n)
+	}
+}
@@ -1,9 +1,46 @@
 package statusv2
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
 	"testing"
+
+	"github.com/mroth/porcelain/fuzzseed"
+	"github.com/mroth/porcelain/parseerr"
 )
 
+// FuzzParse tests the Parse function with arbitrary input
+func FuzzParse(f *testing.F) {
+	fuzzseed.Add[[]byte](f, "statusv2")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Parser should never panic, only return an error for invalid input
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("Parse panicked with input %q: %v", data, r)
+			}
+		}()
+		Parse(bytes.NewReader(data))
+	})
+}
+
+// FuzzParseZ tests the ParseZ function with arbitrary input
+func FuzzParseZ(f *testing.F) {
+	fuzzseed.Add[[]byte](f, "statusv2z")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Parser should never panic, only return an error for invalid input
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("ParseZ panicked with input %q: %v", data, r)
+			}
+		}()
+		ParseZ(bytes.NewReader(data))
+	})
+}
+
 // Fuzz test for parseChanged function
 func FuzzParseChanged(f *testing.F) {
 	// Add some seed inputs
@@ -115,3 +152,87 @@ func FuzzParseHeader(f *testing.F) {
 		parseHeaderEntry(data, &s)
 	})
 }
+
+// FuzzJSONRoundTrip tests that entries parsed from arbitrary input are
+// reconstructed by a JSONDecoder from their JSON encoding.
+func FuzzJSONRoundTrip(f *testing.F) {
+	fuzzseed.Add[[]byte](f, "statusv2")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s, err := Parse(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		var buf bytes.Buffer
+		for _, e := range s.Entries {
+			b, err := MarshalEntryJSON(e)
+			if err != nil {
+				t.Fatalf("MarshalEntryJSON(%v) error = %v", e, err)
+			}
+			buf.Write(b)
+			buf.WriteByte('\n')
+		}
+		got, err := NewJSONDecoder(&buf).Decode()
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if !slices.EqualFunc(s.Entries, got.Entries, func(a, b Entry) bool { return a == b }) {
+			t.Errorf("Decode() = %v, want %v", got.Entries, s.Entries)
+		}
+	})
+}
+
+// FuzzStrictErrors tests that every error of a strict Decoder is a
+// parseerr.Error with a message in the catalog, so that it can be localized.
+func FuzzStrictErrors(f *testing.F) {
+	for format, z := range map[string]bool{"statusv2": false, "statusv2z": true} {
+		seeds, err := fuzzseed.Seeds(format)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, s := range seeds {
+			f.Add(s.Data, z)
+		}
+	}
+	f.Add([]byte("# branch.ab +x\n1 ZZ N... 1 100644 100644 00 00 a\n2 R. N... 100644 100644 100644 00 00 R1000 b\tc\nu ZZ N...\n\nx\n# stash 1\n"), false)
+	f.Add([]byte("2 R. N... 100644 100644 100644 00 00 R10 b\x00? \x00"), true)
+
+	f.Fuzz(func(t *testing.T, data []byte, z bool) {
+		d := NewDecoder(bytes.NewReader(data))
+		if z {
+			d = NewDecoderZ(bytes.NewReader(data))
+		}
+		d.Strict()
+		for {
+			_, err := d.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				checkParseError(t, err)
+			}
+		}
+	})
+}
+
+// checkParseError checks that err is a parseerr.Error with a known code and
+// every parameter of its message, as is any error it wraps.
+func checkParseError(t *testing.T, err error) {
+	t.Helper()
+	var perr *parseerr.Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("error %q (%T) is not a *parseerr.Error", err, err)
+	}
+	tmpl, ok := parseerr.Messages[perr.Code]
+	if !ok {
+		t.Fatalf("error %q has code %q without a message", err, perr.Code)
+	}
+	for _, name := range parseerr.Placeholders(tmpl) {
+		if _, ok := perr.Params[name]; !ok {
+			t.Errorf("error %q with code %q is missing param %q", err, perr.Code, name)
+		}
+	}
+	if perr.Err != nil {
+		checkParseError(t, perr.Err)
+	}
+}
@@ -0,0 +1,72 @@
+package statusv2
+
+import (
+	"bytes"
+	"context"
+	"slices"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// Get runs `git status --porcelain=v2 -z` with any additional args, such as
+// "--branch" or "--show-stash", and parses its output.
+func Get(ctx context.Context, git gitexec.Runner, args ...string) (*Status, error) {
+	out, err := git.Run(ctx, append([]string{"status", "--porcelain=v2", "-z"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseZ(bytes.NewReader(out))
+}
+
+// RefreshPaths updates s with the status of only the given paths, by running
+// `git status --porcelain=v2 -z -- <paths>` and merging its result into s with
+// [Status.Merge]. Getting the status of a few files, such as one just saved in
+// an editor, is much faster than that of a whole large worktree. If git fails,
+// s is unchanged.
+//
+// The paths are literal paths relative to the root of the repository, where
+// git must run, and directories refresh every file beneath them. As git only
+// detects a rename when both of its paths are given, the other path of any
+// rename or copy in s involving the paths is refreshed along with them.
+// Renames made since s was got are only detected if both of their paths are
+// given. Untracked files are listed individually (--untracked-files=all), and
+// ignored files are not listed, so s must have been got the same way for the
+// result to match a full status. Branch and stash information is not
+// refreshed.
+func RefreshPaths(ctx context.Context, git gitexec.Runner, s *Status, paths ...string) error {
+	paths = renamePaths(s, paths)
+	args := append([]string{"--literal-pathspecs", "status", "--porcelain=v2", "-z", "--untracked-files=all", "--"}, paths...)
+	out, err := git.Run(ctx, args...)
+	if err != nil {
+		return err
+	}
+	partial, err := ParseZ(bytes.NewReader(out))
+	if err != nil {
+		return err
+	}
+	s.Merge(partial, paths...)
+	return nil
+}
+
+// renamePaths returns paths with the other path of each rename or copy in s
+// that has one of its paths at or beneath them. The original path of a copy
+// is only added for its new path, as a copy does not change it.
+func renamePaths(s *Status, paths []string) []string {
+	if len(paths) == 0 {
+		return paths // the whole worktree
+	}
+	out := slices.Clip(paths)
+	for _, e := range s.Entries {
+		r, ok := e.(RenameOrCopyEntry)
+		if !ok {
+			continue
+		}
+		switch path, orig := underAny(r.Path, paths), underAny(r.Orig, paths); {
+		case path && !orig:
+			out = append(out, r.Orig)
+		case orig && !path && r.Score.Kind() == Renamed:
+			out = append(out, r.Path)
+		}
+	}
+	return out
+}
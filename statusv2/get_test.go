@@ -0,0 +1,126 @@
+package statusv2
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeRunner is a gitexec.Runner returning canned results, recording the
+// arguments it was called with.
+type fakeRunner struct {
+	out  string
+	err  error
+	args []string
+}
+
+func (f *fakeRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+	f.args = args
+	return []byte(f.out), f.err
+}
+
+func TestGet(t *testing.T) {
+	git := &fakeRunner{out: "# branch.oid 7cd2d7e2a3400e2463239d071c475c09ab410c2d\x00# branch.head main\x00? new.txt\x00"}
+	got, err := Get(context.Background(), git, "--branch")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if want := []string{"status", "--porcelain=v2", "-z", "--branch"}; !slices.Equal(git.args, want) {
+		t.Errorf("Get() ran git %q, want %q", git.args, want)
+	}
+	want := &Status{
+		Branch:  &BranchInfo{OID: "7cd2d7e2a3400e2463239d071c475c09ab410c2d", Head: "main"},
+		Entries: []Entry{UntrackedEntry{Path: "new.txt"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGet_Error(t *testing.T) {
+	wantErr := errors.New("boom")
+	if _, err := Get(context.Background(), &fakeRunner{err: wantErr}); !errors.Is(err, wantErr) {
+		t.Errorf("Get() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestRefreshPaths(t *testing.T) {
+	s := &Status{
+		Branch: &BranchInfo{OID: "(initial)", Head: "main"},
+		Entries: []Entry{
+			ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "a.txt"},
+			UntrackedEntry{Path: "b.txt"},
+		},
+	}
+	git := &fakeRunner{out: "1 M. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d a.txt\x00"}
+	if err := RefreshPaths(context.Background(), git, s, "a.txt", "c.txt"); err != nil {
+		t.Fatalf("RefreshPaths() error = %v", err)
+	}
+	if want := []string{"--literal-pathspecs", "status", "--porcelain=v2", "-z", "--untracked-files=all", "--", "a.txt", "c.txt"}; !slices.Equal(git.args, want) {
+		t.Errorf("RefreshPaths() ran git %q, want %q", git.args, want)
+	}
+	want := &Status{
+		Branch: &BranchInfo{OID: "(initial)", Head: "main"},
+		Entries: []Entry{
+			ChangedEntry{
+				XY:    XYFlag{Modified, Unmodified},
+				ModeH: FileModeRegular, ModeI: FileModeRegular, ModeW: FileModeRegular,
+				HashH: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+				HashI: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+				Path:  "a.txt",
+			},
+			UntrackedEntry{Path: "b.txt"},
+		},
+	}
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Errorf("RefreshPaths() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestRefreshPaths_Rename checks that refreshing either path of a rename also
+// refreshes its other path, as git only detects the rename given both.
+func TestRefreshPaths_Rename(t *testing.T) {
+	rename := RenameOrCopyEntry{
+		XY:    XYFlag{Renamed, Unmodified},
+		ModeH: FileModeRegular, ModeI: FileModeRegular, ModeW: FileModeRegular,
+		HashH: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+		HashI: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+		Score: "R100",
+		Path:  "b.txt",
+		Orig:  "a.txt",
+	}
+	for _, tt := range []struct {
+		paths    []string
+		wantArgs []string
+	}{
+		{[]string{"a.txt"}, []string{"a.txt", "b.txt"}},
+		{[]string{"b.txt"}, []string{"b.txt", "a.txt"}},
+		{[]string{"a.txt", "b.txt"}, []string{"a.txt", "b.txt"}},
+	} {
+		s := &Status{Entries: []Entry{rename, UntrackedEntry{Path: "c.txt"}}}
+		git := &fakeRunner{out: "2 R. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d R100 b.txt\x00a.txt\x00"}
+		if err := RefreshPaths(context.Background(), git, s, tt.paths...); err != nil {
+			t.Fatalf("RefreshPaths(%q) error = %v", tt.paths, err)
+		}
+		if got := git.args[slices.Index(git.args, "--")+1:]; !slices.Equal(got, tt.wantArgs) {
+			t.Errorf("RefreshPaths(%q) refreshed %q, want %q", tt.paths, got, tt.wantArgs)
+		}
+		if diff := cmp.Diff(&Status{Entries: []Entry{rename, UntrackedEntry{Path: "c.txt"}}}, s); diff != "" {
+			t.Errorf("RefreshPaths(%q) mismatch (-want +got):\n%s", tt.paths, diff)
+		}
+	}
+}
+
+func TestRefreshPaths_Error(t *testing.T) {
+	s := &Status{Entries: []Entry{UntrackedEntry{Path: "a.txt"}}}
+	wantErr := errors.New("boom")
+	if err := RefreshPaths(context.Background(), &fakeRunner{err: wantErr}, s, "a.txt"); !errors.Is(err, wantErr) {
+		t.Errorf("RefreshPaths() error = %v, want %v", err, wantErr)
+	}
+	if len(s.Entries) != 1 {
+		t.Errorf("RefreshPaths() changed the status on error: %v", s.Entries)
+	}
+}
@@ -0,0 +1,53 @@
+package statusv2
+
+import "iter"
+
+// All returns an iterator over the entries of s, in order. It yields nothing
+// for a nil s.
+func (s *Status) All() iter.Seq[Entry] {
+	return entriesOf[Entry](s)
+}
+
+// ChangedEntries returns an iterator over the [ChangedEntry] values of s, in
+// order.
+func (s *Status) ChangedEntries() iter.Seq[ChangedEntry] {
+	return entriesOf[ChangedEntry](s)
+}
+
+// RenameOrCopyEntries returns an iterator over the [RenameOrCopyEntry] values
+// of s, in order.
+func (s *Status) RenameOrCopyEntries() iter.Seq[RenameOrCopyEntry] {
+	return entriesOf[RenameOrCopyEntry](s)
+}
+
+// UnmergedEntries returns an iterator over the [UnmergedEntry] values of s, in
+// order. [Status.Conflicts] collects them instead.
+func (s *Status) UnmergedEntries() iter.Seq[UnmergedEntry] {
+	return entriesOf[UnmergedEntry](s)
+}
+
+// UntrackedEntries returns an iterator over the [UntrackedEntry] values of s,
+// in order.
+func (s *Status) UntrackedEntries() iter.Seq[UntrackedEntry] {
+	return entriesOf[UntrackedEntry](s)
+}
+
+// IgnoredEntries returns an iterator over the [IgnoredEntry] values of s, in
+// order.
+func (s *Status) IgnoredEntries() iter.Seq[IgnoredEntry] {
+	return entriesOf[IgnoredEntry](s)
+}
+
+// entriesOf returns an iterator over the entries of s of type E.
+func entriesOf[E Entry](s *Status) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		if s == nil {
+			return
+		}
+		for _, e := range s.Entries {
+			if e, ok := e.(E); ok && !yield(e) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package statusv2
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStatus_Iterators(t *testing.T) {
+	changed := ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "a.txt"}
+	renamed := RenameOrCopyEntry{XY: XYFlag{Renamed, Unmodified}, Score: "R100", Path: "b.txt", Orig: "c.txt"}
+	unmerged := UnmergedEntry{XY: XYFlag{UpdatedUnmerged, UpdatedUnmerged}, Path: "d.txt"}
+	untracked1 := UntrackedEntry{Path: "e.txt"}
+	untracked2 := UntrackedEntry{Path: "f.txt"}
+	ignored := IgnoredEntry{Path: "g.log"}
+	s := &Status{Entries: []Entry{untracked1, changed, renamed, unmerged, untracked2, ignored}}
+
+	if diff := cmp.Diff(s.Entries, slices.Collect(s.All())); diff != "" {
+		t.Errorf("All() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]ChangedEntry{changed}, slices.Collect(s.ChangedEntries())); diff != "" {
+		t.Errorf("ChangedEntries() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]RenameOrCopyEntry{renamed}, slices.Collect(s.RenameOrCopyEntries())); diff != "" {
+		t.Errorf("RenameOrCopyEntries() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]UnmergedEntry{unmerged}, slices.Collect(s.UnmergedEntries())); diff != "" {
+		t.Errorf("UnmergedEntries() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]UntrackedEntry{untracked1, untracked2}, slices.Collect(s.UntrackedEntries())); diff != "" {
+		t.Errorf("UntrackedEntries() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]IgnoredEntry{ignored}, slices.Collect(s.IgnoredEntries())); diff != "" {
+		t.Errorf("IgnoredEntries() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Stopping early.
+	for e := range s.UntrackedEntries() {
+		if e != untracked1 {
+			t.Errorf("first of UntrackedEntries() = %v, want %v", e, untracked1)
+		}
+		break
+	}
+
+	var nilStatus *Status
+	if got := slices.Collect(nilStatus.All()); got != nil {
+		t.Errorf("All() of nil = %v, want nothing", got)
+	}
+}
@@ -0,0 +1,232 @@
+package statusv2
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"unicode/utf8"
+)
+
+// MarshalEntryJSON returns the JSON encoding of e: an object with the fields of
+// the entry, preceded by a Type field naming its kind, one of "changed",
+// "rename_or_copy", "unmerged", "untracked" or "ignored", so that
+// [UnmarshalEntryJSON] can reconstruct it.
+//
+// Paths, and any other field, are arbitrary bytes that JSON strings cannot
+// hold when they are not valid UTF-8. Such fields are encoded in base64
+// instead, and listed in a trailing Base64 field, as in
+// {"Type":"untracked","Path":"6w==","Base64":["Path"]}.
+func MarshalEntryJSON(e Entry) ([]byte, error) {
+	if e == nil {
+		return nil, errors.New("nil entry")
+	}
+	name, err := e.Type().MarshalText()
+	if err != nil {
+		return nil, fmt.Errorf("unsupported entry type %T", e)
+	}
+	var b bytes.Buffer
+	b.WriteString(`{"Type":"`)
+	b.Write(name)
+	b.WriteByte('"')
+	var base64Fields []string
+	v := reflect.ValueOf(e)
+	for i := range v.NumField() {
+		f := v.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		value, err := json.Marshal(v.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if text, ok := fieldText(v.Field(i)); ok && !utf8.Valid(text) {
+			value, _ = json.Marshal(base64.StdEncoding.EncodeToString(text))
+			base64Fields = append(base64Fields, f.Name)
+		}
+		fmt.Fprintf(&b, ",%q:%s", f.Name, value)
+	}
+	if len(base64Fields) > 0 {
+		names, _ := json.Marshal(base64Fields)
+		fmt.Fprintf(&b, `,"Base64":%s`, names)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// fieldText returns the bytes of a field encoded as a JSON string, a string
+// or a value marshaled as text, and whether it is one.
+func fieldText(v reflect.Value) ([]byte, bool) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return text, err == nil
+	}
+	if v.Kind() == reflect.String {
+		return []byte(v.String()), true
+	}
+	return nil, false
+}
+
+// UnmarshalEntryJSON returns the entry encoded in data by [MarshalEntryJSON],
+// of the concrete type named by its Type field.
+func UnmarshalEntryJSON(data []byte) (Entry, error) {
+	var typed struct{ Type string }
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+	if typed.Type == "" {
+		return nil, errors.New("entry has no Type")
+	}
+	var t EntryType
+	if err := t.UnmarshalText([]byte(typed.Type)); err != nil {
+		return nil, err
+	}
+	switch t {
+	case EntryTypeChanged:
+		return unmarshalEntry[ChangedEntry](data)
+	case EntryTypeRenameOrCopy:
+		return unmarshalEntry[RenameOrCopyEntry](data)
+	case EntryTypeUnmerged:
+		return unmarshalEntry[UnmergedEntry](data)
+	case EntryTypeUntracked:
+		return unmarshalEntry[UntrackedEntry](data)
+	default:
+		return unmarshalEntry[IgnoredEntry](data)
+	}
+}
+
+func unmarshalEntry[E Entry](data []byte) (Entry, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	var base64Fields []string
+	if raw, ok := fields["Base64"]; ok {
+		if err := json.Unmarshal(raw, &base64Fields); err != nil {
+			return nil, fmt.Errorf("invalid Base64 field: %w", err)
+		}
+		delete(fields, "Base64")
+	}
+	// Decode the base64 fields separately, as they may not be valid as text.
+	encoded := make(map[string][]byte, len(base64Fields))
+	for _, name := range base64Fields {
+		var s string
+		if err := json.Unmarshal(fields[name], &s); err != nil {
+			return nil, fmt.Errorf("invalid base64 field %s: %w", name, err)
+		}
+		text, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 field %s: %w", name, err)
+		}
+		encoded[name] = text
+		delete(fields, name)
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var e E
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(&e).Elem()
+	for name, text := range encoded {
+		f := v.FieldByName(name)
+		if !f.IsValid() {
+			return nil, fmt.Errorf("invalid base64 field %s: no such field", name)
+		}
+		if u, ok := f.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := u.UnmarshalText(text); err != nil {
+				return nil, err
+			}
+		} else if f.Kind() == reflect.String {
+			f.SetString(string(text))
+		} else {
+			return nil, fmt.Errorf("invalid base64 field %s: not text", name)
+		}
+	}
+	return e, nil
+}
+
+// A JSONDecoder reads status entries encoded as JSON from an input stream one
+// at a time, the inverse of writing each entry with [MarshalEntryJSON] as
+// JSON Lines, for pipelines that round-trip statuses through JSON.
+//
+// Besides entries, the stream may hold header records, objects with a Branch
+// or Stash field, which are collected as for a [Decoder], and complete
+// statuses, objects with Branch, Stash and Entries fields, whose entries are
+// returned in turn. Any whitespace may separate the values.
+type JSONDecoder struct {
+	dec     *json.Decoder
+	headers Status // Branch and Stash only
+	pending []json.RawMessage
+}
+
+// NewJSONDecoder returns a JSONDecoder that reads JSON values from r.
+func NewJSONDecoder(r io.Reader) *JSONDecoder {
+	return &JSONDecoder{dec: json.NewDecoder(r)}
+}
+
+// Next returns the next entry in the input. At the end of the input, Next
+// returns nil and [io.EOF].
+func (d *JSONDecoder) Next() (Entry, error) {
+	for len(d.pending) == 0 {
+		var raw json.RawMessage
+		if err := d.dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		var rec struct {
+			Type    string
+			Branch  *BranchInfo
+			Stash   *StashInfo
+			Entries []json.RawMessage
+		}
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			return nil, err
+		}
+		if rec.Type != "" {
+			return UnmarshalEntryJSON(raw)
+		}
+		if rec.Branch != nil {
+			d.headers.Branch = rec.Branch
+		}
+		if rec.Stash != nil {
+			d.headers.Stash = rec.Stash
+		}
+		d.pending = rec.Entries
+	}
+	raw := d.pending[0]
+	d.pending = d.pending[1:]
+	return UnmarshalEntryJSON(raw)
+}
+
+// Decode reads all remaining entries, returning them as a Status with the
+// headers read.
+func (d *JSONDecoder) Decode() (*Status, error) {
+	var entries []Entry
+	for {
+		e, err := d.Next()
+		if err == io.EOF {
+			return &Status{Branch: d.headers.Branch, Stash: d.headers.Stash, Entries: entries}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+}
+
+// Branch returns the branch header read so far, or nil if there is none.
+func (d *JSONDecoder) Branch() *BranchInfo {
+	return d.headers.Branch
+}
+
+// Stash returns the stash header read so far, or nil if there is none.
+func (d *JSONDecoder) Stash() *StashInfo {
+	return d.headers.Stash
+}
@@ -0,0 +1,119 @@
+package statusv2
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalEntryJSON(t *testing.T) {
+	tests := []struct {
+		entry Entry
+		want  string
+	}{
+		{UntrackedEntry{Path: "new.txt"}, `{"Type":"untracked","Path":"new.txt"}`},
+		{IgnoredEntry{Path: "debug.log"}, `{"Type":"ignored","Path":"debug.log"}`},
+		{
+			ChangedEntry{XY: XYFlag{Modified, Unmodified}, Sub: SubmoduleStatus{}, ModeH: FileModeRegular, ModeI: FileModeRegular, ModeW: FileModeRegular, HashH: "a", HashI: "b", Path: "c.txt"},
+			`{"Type":"changed","XY":"M.","Sub":"N...","ModeH":33188,"ModeI":33188,"ModeW":33188,"HashH":"a","HashI":"b","Path":"c.txt"}`,
+		},
+		{UntrackedEntry{Path: "\xeb "}, `{"Type":"untracked","Path":"6yA=","Base64":["Path"]}`},
+		{
+			RenameOrCopyEntry{XY: XYFlag{'\x98', Unmodified}, Score: "R100", Path: "caf\xe9", Orig: "cafe"},
+			`{"Type":"rename_or_copy","XY":"mC4=","Sub":"N...","ModeH":0,"ModeI":0,"ModeW":0,"HashH":"","HashI":"","Score":"R100","Path":"Y2Fm6Q==","Orig":"cafe","Base64":["XY","Path"]}`,
+		},
+	}
+	for _, tt := range tests {
+		got, err := MarshalEntryJSON(tt.entry)
+		if err != nil {
+			t.Errorf("MarshalEntryJSON(%v) error = %v", tt.entry, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("MarshalEntryJSON(%v) = %s, want %s", tt.entry, got, tt.want)
+		}
+		e, err := UnmarshalEntryJSON(got)
+		if err != nil {
+			t.Errorf("UnmarshalEntryJSON(%s) error = %v", got, err)
+			continue
+		}
+		if diff := cmp.Diff(tt.entry, e); diff != "" {
+			t.Errorf("UnmarshalEntryJSON(%s) mismatch (-want +got):\n%s", got, diff)
+		}
+	}
+}
+
+func TestMarshalEntryJSON_Nil(t *testing.T) {
+	if got, err := MarshalEntryJSON(nil); err == nil {
+		t.Errorf("MarshalEntryJSON(nil) = %s, want error", got)
+	}
+}
+
+func TestUnmarshalEntryJSON_Errors(t *testing.T) {
+	for _, input := range []string{
+		`{"Type":"untracked","Path":"!","Base64":["Path"]}`,
+		`{"Type":"untracked","Path":"YQ==","Base64":["Bogus"]}`,
+		`{"Type":"untracked","Path":"YQ==","Base64":"Path"}`,
+		`{"Path":"a"}`,
+		`{"Type":"bogus","Path":"a"}`,
+		`{"Type":"changed","XY":"toolong"}`,
+		`[]`,
+	} {
+		if e, err := UnmarshalEntryJSON([]byte(input)); err == nil {
+			t.Errorf("UnmarshalEntryJSON(%s) = %v, want error", input, e)
+		}
+	}
+}
+
+func TestJSONDecoder(t *testing.T) {
+	want, err := Parse(strings.NewReader(transformInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, e := range want.Entries {
+		b, err := MarshalEntryJSON(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, string(b))
+	}
+	branch := `{"Branch":{"OID":"","Head":"main","Upstream":"","Ahead":0,"Behind":0}}`
+	jsonl := branch + "\n" + strings.Join(lines, "\n") + "\n"
+	status := `{"Branch":{"Head":"main"},"Stash":null,"Entries":[` + strings.Join(lines, ",") + `]}`
+
+	for name, input := range map[string]string{"jsonl": jsonl, "status": status} {
+		t.Run(name, func(t *testing.T) {
+			d := NewJSONDecoder(strings.NewReader(input))
+			got, err := d.Decode()
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Decode() mismatch (-want +got):\n%s", diff)
+			}
+			if _, err := d.Next(); err != io.EOF {
+				t.Errorf("Next() after Decode() error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestJSONDecoder_Next(t *testing.T) {
+	d := NewJSONDecoder(strings.NewReader(`{"Type":"untracked","Path":"a"} {"Stash":{"Count":2}} {"Type":"bogus"}`))
+	e, err := d.Next()
+	if err != nil || e != (UntrackedEntry{Path: "a"}) {
+		t.Errorf("Next() = %v, %v, want untracked a", e, err)
+	}
+	if _, err := d.Next(); err == nil {
+		t.Error("Next() of unknown type, want error")
+	}
+	if got := d.Stash(); got == nil || got.Count != 2 {
+		t.Errorf("Stash() = %v, want count 2", got)
+	}
+	if got := d.Branch(); got != nil {
+		t.Errorf("Branch() = %v, want nil", got)
+	}
+}
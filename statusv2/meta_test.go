@@ -0,0 +1,70 @@
+package statusv2
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	tests := []struct {
+		name     string
+		dec      *Decoder
+		wantMeta Meta
+	}{
+		{
+			name:     "NewDecoder",
+			dec:      NewDecoder(bytes.NewReader(samplePorcelainV2Output)),
+			wantMeta: Meta{Version: 2, Entries: 5, Bytes: int64(len(samplePorcelainV2Output))},
+		},
+		{
+			name:     "NewDecoderZ",
+			dec:      NewDecoderZ(bytes.NewReader(samplePorcelainV2ZOutput)),
+			wantMeta: Meta{Version: 2, Z: true, Entries: 5, Bytes: int64(len(samplePorcelainV2ZOutput))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.dec.Decode()
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !got.Equal(&sampleParsedStatus) {
+				t.Errorf("Decode() = %+v, want %+v", got, sampleParsedStatus)
+			}
+			if got.Meta == nil {
+				t.Fatal("Decode() Meta = nil")
+			}
+			if got.Meta.Duration <= 0 {
+				t.Errorf("Decode() Meta.Duration = %v, want > 0", got.Meta.Duration)
+			}
+			if diff := cmp.Diff(tt.wantMeta, *got.Meta, cmpopts.IgnoreFields(Meta{}, "Duration")); diff != "" {
+				t.Errorf("Decode() Meta mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_Meta(t *testing.T) {
+	input := "# branch.head main\n\n? a.txt\nX unknown\n? b.txt\n"
+	d := NewDecoder(strings.NewReader(input))
+	if _, err := d.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	want := Meta{Version: 2, Entries: 1, Bytes: int64(strings.Index(input, "X")), Warnings: 1}
+	if diff := cmp.Diff(want, d.Meta()); diff != "" {
+		t.Errorf("Meta() after first entry mismatch (-want +got):\n%s", diff)
+	}
+
+	d.Transform(Filter(func(Entry) bool { return false }))
+	if _, err := d.Decode(); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want = Meta{Version: 2, Entries: 1, Bytes: int64(len(input)), Warnings: 2}
+	if diff := cmp.Diff(want, d.Meta()); diff != "" {
+		t.Errorf("Meta() at end mismatch (-want +got):\n%s", diff)
+	}
+}
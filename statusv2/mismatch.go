@@ -0,0 +1,34 @@
+package statusv2
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/mroth/porcelain/parseerr"
+)
+
+// ErrFormatMismatch is the error of a [Decoder] reading a line of
+// `git status --porcelain=v1` output, such as a "## main" header or a
+// " M file" entry, as from a capture mixing up the formats. Rather than
+// skipping the line, or failing to parse it, the Decoder suggests parsing the
+// input with package statusv1 instead.
+var ErrFormatMismatch error = parseerr.New(parseerr.FormatMismatch, "format", "porcelain=v1", "parser", "statusv1")
+
+// isV1Line reports whether line is a header or entry of porcelain=v1 output,
+// which porcelain=v2 output never has: a "## " branch header, or two XY
+// states and a space before the path, unlike the single character and space
+// of the prefixes of porcelain=v2 entries.
+func isV1Line(line []byte) bool {
+	if bytes.HasPrefix(line, []byte("## ")) {
+		return true
+	}
+	if len(line) < 4 || line[2] != ' ' {
+		return false
+	}
+	x, y := line[0], line[1]
+	if y == ' ' {
+		// as "M  file", but not the "? file" and "! file" of porcelain=v2
+		return strings.IndexByte("MTADRCU", x) >= 0
+	}
+	return strings.IndexByte(" MTADRCU?!", x) >= 0 && strings.IndexByte("MTADRCU?!", y) >= 0
+}
@@ -0,0 +1,66 @@
+package statusv2
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mroth/porcelain/parseerr"
+)
+
+func TestParse_FormatMismatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"v1 header", "## main...origin/main\n? a.txt\n"},
+		{"v1 header after v2 header", "# branch.oid (initial)\n## main\n"},
+		{"v1 unstaged", "? a.txt\n M b.txt\n"},
+		{"v1 staged", "M  b.txt\n"},
+		{"v1 untracked", "?? a.txt\n"},
+		{"v1 ignored", "!! build/\n"},
+		{"v1 rename", "R  old -> new\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			if !errors.Is(err, ErrFormatMismatch) {
+				t.Fatalf("Parse() error = %v, want ErrFormatMismatch", err)
+			}
+			var perr *parseerr.Error
+			if !errors.As(err, &perr) || perr.Params["parser"] != "statusv1" {
+				t.Errorf("Parse() error = %#v, want a suggestion of statusv1", err)
+			}
+		})
+	}
+}
+
+func TestParse_NoFormatMismatch(t *testing.T) {
+	inputs := []string{
+		"? a.txt\n! b.log\n",
+		"? M  x\n",
+		"! M  x\n",
+		"1 .M N... 100644 100644 100644 0000000000000000000000000000000000000000 0000000000000000000000000000000000000000 M  file\n",
+		"u UU N... 100644 100644 100644 100644 0000000000000000000000000000000000000000 0000000000000000000000000000000000000000 0000000000000000000000000000000000000000 c\n",
+		"# branch.head main\n",
+		"3 XY future entry\n",
+	}
+	for _, input := range inputs {
+		if _, err := Parse(strings.NewReader(input)); err != nil {
+			t.Errorf("Parse(%q) error = %v", input, err)
+		}
+	}
+}
+
+func TestDecoder_FormatMismatchLine(t *testing.T) {
+	d := NewDecoderZ(strings.NewReader("? a.txt\x00 M b.txt\x00"))
+	if _, err := d.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if _, err := d.Next(); err != ErrFormatMismatch {
+		t.Fatalf("Next() error = %v, want ErrFormatMismatch", err)
+	}
+	if d.Line() != 2 {
+		t.Errorf("Line() = %d, want 2", d.Line())
+	}
+}
@@ -0,0 +1,80 @@
+package statusv2
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// A PathNormalizer is an [EntryTransformer] normalizing the paths of entries,
+// including the original paths of renames and copies, so that they compare
+// equal to paths from other sources. The normalizations are applied in the
+// order of the fields. The zero value leaves paths unchanged.
+//
+// Paths must be unquoted, as by [ParseZ] or [UnquotePaths], before they are
+// normalized.
+type PathNormalizer struct {
+	// Slash replaces the separator of the operating system in paths with
+	// forward slashes, as by [filepath.ToSlash]. Git writes forward slashes
+	// everywhere, so this only matters on Windows, for paths rewritten with
+	// [filepath.Join] or similar.
+	Slash bool
+
+	// Unicode, if set, normalizes the Unicode form of paths, as git writes
+	// them as stored, which on macOS is often decomposed (NFD). Use NFC from
+	// golang.org/x/text/unicode/norm, which this package does not depend on:
+	//
+	//	statusv2.PathNormalizer{Unicode: norm.NFC.String}
+	Unicode func(string) string
+
+	// Lower folds paths to lower case, for comparisons on case-insensitive
+	// filesystems.
+	Lower bool
+
+	// KeepOriginal records the path each normalized path came from, for
+	// [PathNormalizer.Original].
+	KeepOriginal bool
+
+	originals map[string]string
+}
+
+// Normalize returns p normalized, as the paths of entries are, for comparing
+// paths from other sources, such as user input, against them.
+func (n *PathNormalizer) Normalize(p string) string {
+	if n.Slash {
+		p = filepath.ToSlash(p)
+	}
+	if n.Unicode != nil {
+		p = n.Unicode(p)
+	}
+	if n.Lower {
+		p = strings.ToLower(p)
+	}
+	return p
+}
+
+// TransformEntry returns e with its paths normalized.
+func (n *PathNormalizer) TransformEntry(e Entry) (Entry, error) {
+	return mapPaths(e, func(p string) (string, error) {
+		np := n.Normalize(p)
+		if n.KeepOriginal && np != p {
+			if n.originals == nil {
+				n.originals = make(map[string]string)
+			}
+			if _, ok := n.originals[np]; !ok {
+				n.originals[np] = p
+			}
+		}
+		return np, nil
+	})
+}
+
+// Original returns the path that normalized to p, as written by git, if
+// KeepOriginal was set when it was normalized. Otherwise, or if p was not
+// changed by normalization, it returns p. Where several paths normalized to
+// p, as when folding case, the first of them is returned.
+func (n *PathNormalizer) Original(p string) string {
+	if orig, ok := n.originals[p]; ok {
+		return orig
+	}
+	return p
+}
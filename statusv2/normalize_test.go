@@ -0,0 +1,79 @@
+package statusv2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// composeAcute stands in for NFC normalization in tests, composing only "e"
+// followed by a combining acute accent.
+func composeAcute(s string) string {
+	return strings.ReplaceAll(s, "e\u0301", "\u00e9")
+}
+
+func TestPathNormalizer(t *testing.T) {
+	const input = "2 R. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d R100 Cafe\u0301.txt\tOld.txt\n" +
+		"? README.md\n" +
+		"? readme.md\n"
+	n := &PathNormalizer{Unicode: composeAcute, Lower: true, KeepOriginal: true}
+	got, err := decodeAllTransformed(t, input, n)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	want := []Entry{
+		RenameOrCopyEntry{
+			XY:    XYFlag{Renamed, Unmodified},
+			ModeH: FileModeRegular, ModeI: FileModeRegular, ModeW: FileModeRegular,
+			HashH: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+			HashI: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+			Score: "R100",
+			Path:  "caf\u00e9.txt",
+			Orig:  "old.txt",
+		},
+		UntrackedEntry{Path: "readme.md"},
+		UntrackedEntry{Path: "readme.md"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("entries mismatch (-want +got):\n%s", diff)
+	}
+
+	originals := map[string]string{
+		"caf\u00e9.txt": "Cafe\u0301.txt",
+		"old.txt":       "Old.txt",
+		"readme.md":     "README.md",
+		"other.txt":     "other.txt",
+	}
+	for p, want := range originals {
+		if got := n.Original(p); got != want {
+			t.Errorf("Original(%q) = %q, want %q", p, got, want)
+		}
+	}
+	if got, want := n.Normalize("Cafe\u0301.TXT"), "caf\u00e9.txt"; got != want {
+		t.Errorf("Normalize() = %q, want %q", got, want)
+	}
+}
+
+func TestPathNormalizer_Defaults(t *testing.T) {
+	var n PathNormalizer
+	e, err := n.TransformEntry(UntrackedEntry{Path: "Dir/File.txt"})
+	if err != nil {
+		t.Fatalf("TransformEntry() error = %v", err)
+	}
+	if want := (UntrackedEntry{Path: "Dir/File.txt"}); e != want {
+		t.Errorf("TransformEntry() = %v, want %v", e, want)
+	}
+	if got := n.Original("Dir/File.txt"); got != "Dir/File.txt" {
+		t.Errorf("Original() = %q, want the path unchanged", got)
+	}
+
+	// Without KeepOriginal, nothing is recorded.
+	n.Lower = true
+	if _, err := n.TransformEntry(UntrackedEntry{Path: "Dir/File.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := n.Original("dir/file.txt"); got != "dir/file.txt" {
+		t.Errorf("Original() = %q, want the path unchanged", got)
+	}
+}
@@ -0,0 +1,116 @@
+package statusv2
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+// SortStableByPath sorts the entries of s by path, compared as bytes,
+// regardless of their type, for reproducible reports and for comparing
+// normalized snapshots. Entries with the same path, as for a status combined
+// from several, are ordered by type, then by the original path of renames and
+// copies, then by XY flag, and otherwise keep their relative order.
+//
+// It returns the ordinals of the entries, the position each had before the
+// sort, for [Status.RestoreOrder] to undo it, or nil for a nil s.
+func (s *Status) SortStableByPath() []int {
+	if s == nil {
+		return nil
+	}
+	ordinals := make([]int, len(s.Entries))
+	for i := range ordinals {
+		ordinals[i] = i
+	}
+	slices.SortStableFunc(ordinals, func(i, j int) int {
+		return compareEntries(s.Entries[i], s.Entries[j])
+	})
+	entries := make([]Entry, len(s.Entries))
+	for i, o := range ordinals {
+		entries[i] = s.Entries[o]
+	}
+	s.Entries = entries
+	return ordinals
+}
+
+// SortByPath sorts the entries of s by path, in the order of
+// [Status.SortStableByPath], for when the sort need not be undone. It does
+// nothing for a nil s.
+func (s *Status) SortByPath() {
+	if s == nil {
+		return
+	}
+	s.SortFunc(compareEntries)
+}
+
+// SortByType sorts the entries of s by type, in the order of the [EntryType]
+// constants, and then as by [Status.SortByPath] within each type. It does
+// nothing for a nil s.
+func (s *Status) SortByType() {
+	if s == nil {
+		return
+	}
+	s.SortFunc(func(a, b Entry) int {
+		return cmp.Or(cmp.Compare(a.Type(), b.Type()), compareEntries(a, b))
+	})
+}
+
+// SortFunc sorts the entries of s with the comparison function cmp, which
+// returns a negative number when a sorts before b, a positive number when it
+// sorts after, and zero otherwise, as for [slices.SortFunc]. The sort is
+// stable, keeping the order of entries comparing equal. It does nothing for a
+// nil s.
+func (s *Status) SortFunc(cmp func(a, b Entry) int) {
+	if s == nil {
+		return
+	}
+	slices.SortStableFunc(s.Entries, cmp)
+}
+
+// RestoreOrder moves each entry of s to the position given by ordinals, as
+// returned by [Status.SortStableByPath], restoring the order of the entries
+// before the sort. It returns an error, leaving s unchanged, if ordinals is
+// not a permutation of the positions of the entries. A nil s has no entries,
+// so only empty ordinals, as returned for it by [Status.SortStableByPath], are
+// valid for it.
+func (s *Status) RestoreOrder(ordinals []int) error {
+	var n int
+	if s != nil {
+		n = len(s.Entries)
+	}
+	if len(ordinals) != n {
+		return errors.New("ordinals do not match the number of entries")
+	}
+	if s == nil {
+		return nil
+	}
+	entries := make([]Entry, len(s.Entries))
+	for i, o := range ordinals {
+		if o < 0 || o >= len(entries) || entries[o] != nil {
+			return errors.New("ordinals are not a permutation of the entries")
+		}
+		entries[o] = s.Entries[i]
+	}
+	s.Entries = entries
+	return nil
+}
+
+// compareEntries orders entries by path, then type, original path and XY flag.
+func compareEntries(a, b Entry) int {
+	if c := cmp.Compare(a.EntryPath(), b.EntryPath()); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Type(), b.Type()); c != 0 {
+		return c
+	}
+	var origA, origB string
+	if r, ok := a.(RenameOrCopyEntry); ok {
+		origA = r.Orig
+	}
+	if r, ok := b.(RenameOrCopyEntry); ok {
+		origB = r.Orig
+	}
+	xyA, _ := entryXY(a)
+	xyB, _ := entryXY(b)
+	return cmp.Or(cmp.Compare(origA, origB), cmp.Compare(xyA.String(), xyB.String()))
+}
@@ -0,0 +1,102 @@
+package statusv2
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStatus_SortStableByPath(t *testing.T) {
+	entries := []Entry{
+		ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "b.txt"},
+		UntrackedEntry{Path: "a.txt"},
+		IgnoredEntry{Path: "b.txt"},
+		RenameOrCopyEntry{XY: XYFlag{Copied, Unmodified}, Score: "C100", Path: "c.txt", Orig: "z.txt"},
+		ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "b.txt"},
+		RenameOrCopyEntry{XY: XYFlag{Renamed, Unmodified}, Score: "R100", Path: "c.txt", Orig: "y.txt"},
+		ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "b.txt"}, // the same as the first, so kept after it
+	}
+	s := &Status{Entries: append([]Entry(nil), entries...)}
+
+	ordinals := s.SortStableByPath()
+	want := []Entry{entries[1], entries[4], entries[0], entries[6], entries[2], entries[5], entries[3]}
+	if diff := cmp.Diff(want, s.Entries); diff != "" {
+		t.Errorf("SortStableByPath() entries mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{1, 4, 0, 6, 2, 5, 3}, ordinals); diff != "" {
+		t.Errorf("SortStableByPath() ordinals mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := s.RestoreOrder(ordinals); err != nil {
+		t.Fatalf("RestoreOrder() error = %v", err)
+	}
+	if diff := cmp.Diff(entries, s.Entries); diff != "" {
+		t.Errorf("RestoreOrder() entries mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStatus_SortStableByPathNil(t *testing.T) {
+	var s *Status
+	if ordinals := s.SortStableByPath(); ordinals != nil {
+		t.Errorf("SortStableByPath() = %v, want nil", ordinals)
+	}
+}
+
+// TestStatus_SortNil checks that the other sort methods do not panic for a nil
+// status.
+func TestStatus_SortNil(t *testing.T) {
+	var s *Status
+	s.SortByPath()
+	s.SortByType()
+	s.SortFunc(compareEntries)
+	if err := s.RestoreOrder(s.SortStableByPath()); err != nil {
+		t.Errorf("RestoreOrder(nil) error = %v", err)
+	}
+	if err := s.RestoreOrder([]int{0}); err == nil {
+		t.Error("RestoreOrder([0]) error = nil, want error")
+	}
+}
+
+func TestStatus_RestoreOrderInvalid(t *testing.T) {
+	entries := []Entry{UntrackedEntry{Path: "a"}, UntrackedEntry{Path: "b"}}
+	for _, ordinals := range [][]int{nil, {0}, {0, 0}, {0, 2}, {-1, 0}} {
+		s := &Status{Entries: append([]Entry(nil), entries...)}
+		if err := s.RestoreOrder(ordinals); err == nil {
+			t.Errorf("RestoreOrder(%v) error = nil, want error", ordinals)
+		}
+		if diff := cmp.Diff(entries, s.Entries); diff != "" {
+			t.Errorf("RestoreOrder(%v) changed the entries (-want +got):\n%s", ordinals, diff)
+		}
+	}
+}
+
+func TestStatus_Sort(t *testing.T) {
+	entries := []Entry{
+		IgnoredEntry{Path: "ab.log"},
+		UntrackedEntry{Path: "c.txt"},
+		ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "d.txt"},
+		UntrackedEntry{Path: "b.txt"},
+		RenameOrCopyEntry{XY: XYFlag{Renamed, Unmodified}, Score: "R100", Path: "a.txt", Orig: "z.txt"},
+	}
+	tests := []struct {
+		name string
+		sort func(*Status)
+		want []Entry
+	}{
+		{"path", (*Status).SortByPath, []Entry{entries[4], entries[0], entries[3], entries[1], entries[2]}},
+		{"type", (*Status).SortByType, []Entry{entries[2], entries[4], entries[3], entries[1], entries[0]}},
+		{"func", func(s *Status) {
+			// By the length of the path only, which keeps ties in order.
+			s.SortFunc(func(a, b Entry) int { return len(a.EntryPath()) - len(b.EntryPath()) })
+		}, []Entry{entries[1], entries[2], entries[3], entries[4], entries[0]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Status{Entries: append([]Entry(nil), entries...)}
+			tt.sort(s)
+			if diff := cmp.Diff(tt.want, s.Entries); diff != "" {
+				t.Errorf("entries mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
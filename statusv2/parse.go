@@ -1,13 +1,14 @@
 package statusv2
 
 import (
-	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"strconv"
+
+	"github.com/mroth/porcelain/parseerr"
 )
 
 // Enable debug logging by setting this to a valid *slog.Logger
@@ -22,7 +23,7 @@ var debugLogger = slog.New(slog.DiscardHandler)
 // without unquoting. If your application needs unquoted paths, consider using [ParseZ] with
 // the -z flag instead, as Git does not quote paths in -z format.
 func Parse(r io.Reader) (*Status, error) {
-	return parse(bufio.NewScanner(r), tabSeparator)
+	return decodeAll(NewDecoder(r))
 }
 
 // ParseZ parses the output of `git status --porcelain=v2 -z`.
@@ -35,7 +36,7 @@ func Parse(r io.Reader) (*Status, error) {
 // Path Handling: In -z format, Git does not quote paths containing special characters, so
 // all paths are provided as-is. This function preserves paths exactly as provided by Git.
 func ParseZ(r io.Reader) (*Status, error) {
-	return parse(newZScanner(r), nulSeparator)
+	return decodeAll(NewDecoderZ(r))
 }
 
 // renamePathSep represents the byte used to separate paths in rename/copy entries
@@ -46,57 +47,6 @@ const (
 	nulSeparator renamePathSep = '\x00' // -z mode: paths separated by NUL
 )
 
-// Core parsing function that reads lines from the provided scanner and
-// constructs the Status struct. The provided scanner should tokenize entries
-// (or "lines"), omitting the entry terminator. The provided pathSep byte is
-// used to determine how to split paths in rename/copy entries.
-func parse(scanner *bufio.Scanner, pathSep renamePathSep) (*Status, error) {
-	s := Status{}
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
-		}
-		switch line[0] {
-		case '#':
-			// parseHeader manages the Branch or Stash field structs of the
-			// Status struct directly, so we pass a pointer to the whole struct.
-			parseHeaderEntry(line, &s)
-		case '1':
-			entry, err := parseChangedEntry(line)
-			if err != nil {
-				return nil, err
-			}
-			s.Entries = append(s.Entries, entry)
-		case '2':
-			entry, err := parseRenameOrCopyEntry(line, pathSep)
-			if err != nil {
-				return nil, err
-			}
-			s.Entries = append(s.Entries, entry)
-		case 'u':
-			entry, err := parseUnmergedEntry(line)
-			if err != nil {
-				return nil, err
-			}
-			s.Entries = append(s.Entries, entry)
-		case '?':
-			entry, err := parseUntrackedEntry(line)
-			if err != nil {
-				return nil, err
-			}
-			s.Entries = append(s.Entries, entry)
-		case '!':
-			entry, err := parseIgnoredEntry(line)
-			if err != nil {
-				return nil, err
-			}
-			s.Entries = append(s.Entries, entry)
-		}
-	}
-	return &s, scanner.Err()
-}
-
 // Headers take the form of `# <key> <values...>` where <key> is a string like
 // "branch.oid" or "stash". As per the specification, parsers should ignore
 // unknown headers, so we don't return an error if the header is not recognized.
@@ -146,7 +96,7 @@ func parseChangedEntry(line []byte) (ChangedEntry, error) {
 	var zero ChangedEntry
 	fields := bytes.SplitN(line, []byte{' '}, 9)
 	if len(fields) < 9 || !bytes.HasPrefix(fields[0], []byte{'1'}) {
-		return zero, fmt.Errorf("invalid changed entry line: %q", line)
+		return zero, parseerr.New(parseerr.InvalidEntry, "kind", "changed", "line", line)
 	}
 
 	// Field 1: XY status code
@@ -170,8 +120,8 @@ func parseChangedEntry(line []byte) (ChangedEntry, error) {
 	}
 
 	// Fields 6-7: Object names (HEAD, index)
-	// These are currently usually SHA-1 hashes in hex format, but treat as strings
-	// given that they could be other types in the future (e.g. SHA-256 transition)
+	// These are SHA-1 or SHA-256 hashes in hex format, depending on the object
+	// format of the repository, and are not validated here.
 	hashH := string(fields[6])
 	hashI := string(fields[7])
 
@@ -196,7 +146,7 @@ func parseRenameOrCopyEntry(line []byte, pathSep renamePathSep) (RenameOrCopyEnt
 	var zero RenameOrCopyEntry
 	fields := bytes.SplitN(line, []byte{' '}, 10)
 	if len(fields) < 10 || !bytes.HasPrefix(fields[0], []byte{'2'}) {
-		return zero, fmt.Errorf("invalid rename or copy entry line: %q", line)
+		return zero, parseerr.New(parseerr.InvalidEntry, "kind", "rename or copy", "line", line)
 	}
 
 	// Field 1: XY status code
@@ -220,15 +170,15 @@ func parseRenameOrCopyEntry(line []byte, pathSep renamePathSep) (RenameOrCopyEnt
 	}
 
 	// Fields 6-7: Object names (HEAD, index)
-	// These are currently usually SHA-1 hashes in hex format, but treat as strings
-	// given that they could be other types in the future (e.g. SHA-256 transition)
+	// These are SHA-1 or SHA-256 hashes in hex format, depending on the object
+	// format of the repository, and are not validated here.
 	hashH := string(fields[6])
 	hashI := string(fields[7])
 
 	// Field 8: Rename or copy score
 	// The rename or copy score (denoting the percentage of similarity between
 	// the source and target of the move or copy). For example "R100" or "C75".
-	score := string(fields[8])
+	score := Score(fields[8])
 
 	// Field 9: <path><sep><origPath>
 	// The target path (new path) and the origin path (old path) are separated
@@ -237,7 +187,7 @@ func parseRenameOrCopyEntry(line []byte, pathSep renamePathSep) (RenameOrCopyEnt
 	sep := []byte{byte(pathSep)}
 	pathBytes, origBytes, found := bytes.Cut(fields[9], sep)
 	if !found {
-		return zero, fmt.Errorf("invalid rename/copy path entry format: %q", fields[9])
+		return zero, parseerr.New(parseerr.InvalidRenamePaths, "paths", fields[9])
 	}
 	path := string(pathBytes)
 	orig := string(origBytes)
@@ -262,7 +212,7 @@ func parseUnmergedEntry(line []byte) (UnmergedEntry, error) {
 	var zero UnmergedEntry
 	fields := bytes.SplitN(line, []byte{' '}, 11)
 	if len(fields) < 11 || !bytes.HasPrefix(fields[0], []byte{'u'}) {
-		return zero, fmt.Errorf("invalid unmerged entry line: %q", line)
+		return zero, parseerr.New(parseerr.InvalidEntry, "kind", "unmerged", "line", line)
 	}
 
 	// Field 1: XY status code
@@ -313,7 +263,7 @@ func parseUnmergedEntry(line []byte) (UnmergedEntry, error) {
 func parseUntrackedEntry(line []byte) (UntrackedEntry, error) {
 	pathBytes, ok := bytes.CutPrefix(line, []byte{'?', ' '})
 	if !ok {
-		return UntrackedEntry{}, fmt.Errorf("invalid untracked entry line: %q", line)
+		return UntrackedEntry{}, parseerr.New(parseerr.InvalidEntry, "kind", "untracked", "line", line)
 	}
 
 	return UntrackedEntry{Path: string(pathBytes)}, nil
@@ -324,7 +274,7 @@ func parseUntrackedEntry(line []byte) (UntrackedEntry, error) {
 func parseIgnoredEntry(line []byte) (IgnoredEntry, error) {
 	pathBytes, ok := bytes.CutPrefix(line, []byte{'!', ' '})
 	if !ok {
-		return IgnoredEntry{}, fmt.Errorf("invalid ignored entry line: %q", line)
+		return IgnoredEntry{}, parseerr.New(parseerr.InvalidEntry, "kind", "ignored", "line", line)
 	}
 
 	return IgnoredEntry{Path: string(pathBytes)}, nil
@@ -333,7 +283,7 @@ func parseIgnoredEntry(line []byte) (IgnoredEntry, error) {
 func parseSubmoduleStatus(field []byte) (SubmoduleStatus, error) {
 	var s SubmoduleStatus
 	if len(field) != 4 {
-		return s, fmt.Errorf("invalid submodule status field: %q", field)
+		return s, parseerr.New(parseerr.InvalidSubmodule, "field", field)
 	}
 	return SubmoduleStatus{
 		IsSubmodule:      field[0] == 'S',
@@ -346,14 +296,14 @@ func parseSubmoduleStatus(field []byte) (SubmoduleStatus, error) {
 func parseFileMode(field []byte) (FileMode, error) {
 	mode, err := strconv.ParseUint(string(field), 8, 32)
 	if err != nil {
-		return 0, err
+		return 0, parseerr.New(parseerr.InvalidFileMode, "mode", field)
 	}
 	return FileMode(mode), nil
 }
 
 func parseXYFlag(field []byte) (XYFlag, error) {
 	if len(field) != 2 {
-		return XYFlag{}, fmt.Errorf("invalid XY field: expected 2 characters, got %d", len(field))
+		return XYFlag{}, parseerr.New(parseerr.InvalidXYLength, "length", len(field))
 	}
 	return XYFlag{X: State(field[0]), Y: State(field[1])}, nil
 }
@@ -2,10 +2,14 @@ package statusv2
 
 import (
 	"bytes"
-	"os"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/corpus"
+	"github.com/mroth/porcelain/parseerr"
 )
 
 var (
@@ -143,37 +147,55 @@ func TestParseZ(t *testing.T) {
 	}
 }
 
-// TestParseGolden tests the Parse function with various test cases.
-// Each test case specifies a file containing the output of `git status --porcelain=v2`.
-// Files should be placed in the "testdata" directory.
+// TestParseGolden tests the Parse and ParseZ functions against the output
+// of git recorded in the corpus package, which should parse to the golden
+// result of each case.
+//
+// Without -z, git quotes paths containing unusual characters, which Parse
+// leaves as-is, so those paths are unquoted before comparing.
 func TestParseGolden(t *testing.T) {
-	t.Skip("Skipping golden tests, none defined yet")
-	testcases := []struct {
-		file    string
-		desc    string // optional human readable description
-		want    *Status
-		wantErr bool
-	}{}
-
-	for _, tc := range testcases {
-		t.Run(tc.file, func(t *testing.T) {
-			f, err := os.Open("testdata/" + tc.file)
+	for _, c := range corpusCases(t) {
+		t.Run(c.Name(), func(t *testing.T) {
+			want, err := NewJSONDecoder(bytes.NewReader(c.Golden)).Decode()
 			if err != nil {
-				t.Fatalf("fatal: failed to open test file %q: %v", tc.file, err)
+				t.Fatalf("invalid golden result: %v", err)
 			}
-			defer f.Close()
-
-			got, err := Parse(f)
-			if (err != nil) != tc.wantErr {
-				t.Errorf("ParseFile() error = %v, wantErr %v", err, tc.wantErr)
+			parse := ParseZ
+			if c.Format == "v2" {
+				parse = Parse
 			}
-			if diff := cmp.Diff(tc.want, got); diff != "" {
-				t.Errorf("ParseFile() mismatch (-want +got):\n%s", diff)
+			got, err := parse(bytes.NewReader(c.Input))
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			if c.Format == "v2" {
+				for i, e := range got.Entries {
+					if got.Entries[i], err = UnquotePaths().TransformEntry(e); err != nil {
+						t.Fatalf("unquoting paths: %v", err)
+					}
+				}
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("parse mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
+// corpusCases returns the porcelain=v2 cases of the corpus package.
+func corpusCases(t *testing.T) []corpus.Case {
+	t.Helper()
+	cases, err := corpus.Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases = slices.DeleteFunc(cases, func(c corpus.Case) bool { return !strings.HasPrefix(c.Format, "v2") })
+	if len(cases) == 0 {
+		t.Fatal("no porcelain=v2 cases in the corpus")
+	}
+	return cases
+}
+
 // Test_parseHeaderEntry tests the parseHeaderEntry function with various valid and invalid inputs.
 func Test_parseHeaderEntry(t *testing.T) {
 	t.Run("supported headers", func(t *testing.T) {
@@ -1018,6 +1040,34 @@ func Test_parseFileMode(t *testing.T) {
 	}
 }
 
+// TestFileModeErrors checks that every invalid file mode of an entry is
+// reported, each as a coded error.
+func TestFileModeErrors(t *testing.T) {
+	input := []byte("u UU N... 10064g 100644 10064x 100644 " + strings.Repeat("a", 40) + " " + strings.Repeat("b", 40) + " " + strings.Repeat("c", 40) + " file.txt")
+	_, err := parseUnmergedEntry(input)
+	if err == nil {
+		t.Fatal("parseUnmergedEntry() error = nil")
+	}
+	if !strings.HasPrefix(err.Error(), "invalid file mode fields: ") {
+		t.Errorf("parseUnmergedEntry() error = %q, want file mode context", err)
+	}
+	joined, ok := errors.Unwrap(err).(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("parseUnmergedEntry() error = %#v, want joined errors", err)
+	}
+	var modes []string
+	for _, err := range joined.Unwrap() {
+		var perr *parseerr.Error
+		if !errors.As(err, &perr) || perr.Code != parseerr.InvalidFileMode {
+			t.Fatalf("error %v is not an InvalidFileMode parse error", err)
+		}
+		modes = append(modes, perr.Params["mode"].(string))
+	}
+	if want := []string{"10064g", "10064x"}; !slices.Equal(modes, want) {
+		t.Errorf("invalid modes = %q, want %q", modes, want)
+	}
+}
+
 func Test_parseUntrackedEntry(t *testing.T) {
 	testcases := []struct {
 		name    string
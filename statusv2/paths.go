@@ -0,0 +1,139 @@
+package statusv2
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// maxArgBytes is the most bytes of paths in a single git command, keeping the
+// command lines well within the limits of operating systems.
+const maxArgBytes = 16 << 10
+
+// PathsStatus is the status of a set of paths, as returned by [GetPaths],
+// with each of the paths in exactly one of Dirty, Untracked, Ignored and
+// Clean, in the order they were given.
+type PathsStatus struct {
+	Status    *Status  // the entries at or beneath the paths
+	Dirty     []string // paths with changes to tracked files, or in conflict
+	Untracked []string // paths untracked, or with untracked files beneath, and no changes to tracked files
+	Ignored   []string // paths ignored, or with ignored files beneath, and no other changes; only listed with --ignored
+	Clean     []string // paths with no entries
+}
+
+// GetPaths gets the status of many paths, such as the files touched by a
+// build, and partitions them by whether they have changes. It runs
+// `git status --porcelain=v2 -z --untracked-files=all` with any additional
+// args, as many times as needed to keep each command line within the limits
+// of the operating system, and merges the results as by [Status.Merge].
+//
+// The paths are literal paths relative to the root of the repository, where
+// git must run, and directories include every file beneath them. With no
+// paths, git is not run. Branch and stash information, if requested in args,
+// is from the last run.
+func GetPaths(ctx context.Context, git gitexec.Runner, paths []string, args ...string) (*PathsStatus, error) {
+	s := &Status{}
+	prefix := append([]string{"--literal-pathspecs", "status", "--porcelain=v2", "-z", "--untracked-files=all"}, args...)
+	prefix = append(prefix, "--")
+	for _, chunk := range chunkPaths(paths, maxArgBytes) {
+		out, err := git.Run(ctx, append(prefix[:len(prefix):len(prefix)], chunk...)...)
+		if err != nil {
+			return nil, err
+		}
+		partial, err := ParseZ(bytes.NewReader(out))
+		if err != nil {
+			return nil, err
+		}
+		s.Merge(partial, chunk...)
+	}
+	return partitionPaths(s, paths), nil
+}
+
+// chunkPaths splits paths into chunks of at most max bytes, except where a
+// single path is longer.
+func chunkPaths(paths []string, max int) [][]string {
+	var chunks [][]string
+	for len(paths) > 0 {
+		n, size := 0, 0
+		for n < len(paths) && (n == 0 || size+len(paths[n]) <= max) {
+			size += len(paths[n])
+			n++
+		}
+		chunks = append(chunks, paths[:n])
+		paths = paths[n:]
+	}
+	return chunks
+}
+
+// pathKind is a set of the kinds of entries at or beneath a path.
+type pathKind uint8
+
+const (
+	pathDirty pathKind = 1 << iota
+	pathUntracked
+	pathIgnored
+)
+
+// partitionPaths partitions paths by the entries of s at or beneath them. An
+// entry covers the path it is for, and every directory above it, or, as for a
+// directory of untracked files listed as one entry, every path beneath it. The
+// original path of a rename counts as well as its new path, as it was removed.
+func partitionPaths(s *Status, paths []string) *PathsStatus {
+	var all pathKind
+	under := map[string]pathKind{}  // kinds at or beneath each path
+	within := map[string]pathKind{} // kinds of directory entries, by path ending in "/"
+	add := func(ep string, k pathKind) {
+		all |= k
+		if strings.HasSuffix(ep, "/") {
+			within[ep] |= k
+		}
+		ep = strings.TrimSuffix(ep, "/")
+		for {
+			under[ep] |= k
+			i := strings.LastIndexByte(ep, '/')
+			if i < 0 {
+				break
+			}
+			ep = ep[:i]
+		}
+	}
+	for _, e := range s.Entries {
+		k := pathDirty
+		switch e.(type) {
+		case UntrackedEntry:
+			k = pathUntracked
+		case IgnoredEntry:
+			k = pathIgnored
+		}
+		if r, ok := e.(RenameOrCopyEntry); ok && r.Score.Kind() == Renamed {
+			add(r.Orig, k)
+		}
+		add(e.EntryPath(), k)
+	}
+
+	ps := &PathsStatus{Status: s}
+	for _, p := range paths {
+		k := under[strings.TrimSuffix(p, "/")]
+		if p := strings.TrimSuffix(p, "/"); p == "" || p == "." {
+			k = all
+		}
+		for i := range len(p) {
+			if p[i] == '/' {
+				k |= within[p[:i+1]]
+			}
+		}
+		switch {
+		case k&pathDirty != 0:
+			ps.Dirty = append(ps.Dirty, p)
+		case k&pathUntracked != 0:
+			ps.Untracked = append(ps.Untracked, p)
+		case k&pathIgnored != 0:
+			ps.Ignored = append(ps.Ignored, p)
+		default:
+			ps.Clean = append(ps.Clean, p)
+		}
+	}
+	return ps
+}
@@ -0,0 +1,158 @@
+package statusv2
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// pathsRunner is a gitexec.Runner answering git status for the paths after
+// "--" with the entries of entries at or beneath them, recording the runs.
+type pathsRunner struct {
+	entries map[string]string
+	runs    [][]string
+}
+
+func (r *pathsRunner) Run(_ context.Context, args ...string) ([]byte, error) {
+	r.runs = append(r.runs, args)
+	var out strings.Builder
+	paths := args[slices.Index(args, "--")+1:]
+	for path, line := range r.entries {
+		if underAny(path, paths) {
+			out.WriteString(line + "\x00")
+		}
+	}
+	return []byte(out.String()), nil
+}
+
+func TestGetPaths(t *testing.T) {
+	git := &pathsRunner{entries: map[string]string{
+		"src/main.go":     "1 .M N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d src/main.go",
+		"src/gen/out.go":  "? src/gen/out.go",
+		"README.md":       "2 R. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d R100 docs/index.md\x00README.md",
+		"bin/tool":        "! bin/tool",
+		"src/conflict.go": "u UU N... 100644 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 8ab686eafeb1f44702738c8b0f24f2567c36da6d 7cd2d7e2a3400e2463239d071c475c09ab410c2d src/conflict.go",
+	}}
+	paths := []string{"src/gen", "src/main.go", "README.md", "bin", "lib/util.go", "src", "src/conflict.go"}
+	got, err := GetPaths(context.Background(), git, paths, "--ignored")
+	if err != nil {
+		t.Fatalf("GetPaths() error = %v", err)
+	}
+	want := []string{"--literal-pathspecs", "status", "--porcelain=v2", "-z", "--untracked-files=all", "--ignored", "--"}
+	if len(git.runs) != 1 || !slices.Equal(git.runs[0][:len(want)], want) {
+		t.Errorf("GetPaths() ran git %q, want one run starting %q", git.runs, want)
+	}
+	if diff := cmp.Diff([]string{"src/main.go", "README.md", "src", "src/conflict.go"}, got.Dirty); diff != "" {
+		t.Errorf("Dirty mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"src/gen"}, got.Untracked); diff != "" {
+		t.Errorf("Untracked mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"bin"}, got.Ignored); diff != "" {
+		t.Errorf("Ignored mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"lib/util.go"}, got.Clean); diff != "" {
+		t.Errorf("Clean mismatch (-want +got):\n%s", diff)
+	}
+	if n := len(got.Status.Entries); n != 5 {
+		t.Errorf("Status has %d entries, want 5 without duplicates", n)
+	}
+}
+
+func TestGetPaths_Chunks(t *testing.T) {
+	git := &pathsRunner{entries: map[string]string{}}
+	var paths []string
+	for i := range 100 {
+		paths = append(paths, strings.Repeat("x", 1000)+"/"+string(rune('a'+i%26))+strings.Repeat("y", i))
+	}
+	git.entries[paths[42]] = "? " + paths[42]
+	got, err := GetPaths(context.Background(), git, paths)
+	if err != nil {
+		t.Fatalf("GetPaths() error = %v", err)
+	}
+	var n int
+	for _, args := range git.runs {
+		size := 0
+		for _, p := range args[slices.Index(args, "--")+1:] {
+			size += len(p)
+			n++
+		}
+		if size > maxArgBytes {
+			t.Errorf("GetPaths() ran git with %d bytes of paths, more than %d", size, maxArgBytes)
+		}
+	}
+	if len(git.runs) < 2 || n != len(paths) {
+		t.Errorf("GetPaths() ran git %d times with %d paths, want several times with %d", len(git.runs), n, len(paths))
+	}
+	if want := []string{paths[42]}; !slices.Equal(got.Untracked, want) || len(got.Clean) != len(paths)-1 {
+		t.Errorf("GetPaths() untracked = %q and %d clean, want %q and the rest clean", got.Untracked, len(got.Clean), want)
+	}
+}
+
+func TestGetPaths_Empty(t *testing.T) {
+	git := &fakeRunner{err: errors.New("should not run")}
+	got, err := GetPaths(context.Background(), git, nil)
+	if err != nil {
+		t.Fatalf("GetPaths() error = %v", err)
+	}
+	if got.Status == nil || len(got.Status.Entries) != 0 || got.Clean != nil {
+		t.Errorf("GetPaths() = %+v, want an empty status", got)
+	}
+}
+
+func TestGetPaths_Error(t *testing.T) {
+	wantErr := errors.New("boom")
+	if _, err := GetPaths(context.Background(), &fakeRunner{err: wantErr}, []string{"a"}); !errors.Is(err, wantErr) {
+		t.Errorf("GetPaths() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestPartitionPaths(t *testing.T) {
+	s := &Status{Entries: []Entry{
+		ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "src/main.go"},
+		RenameOrCopyEntry{XY: XYFlag{Renamed, Unmodified}, Score: "R100", Path: "docs/index.md", Orig: "README.md"},
+		RenameOrCopyEntry{XY: XYFlag{Copied, Unmodified}, Score: "C75", Path: "docs/copy.md", Orig: "LICENSE"},
+		UntrackedEntry{Path: "build/"},
+		UntrackedEntry{Path: "src/gen/out.go"},
+		IgnoredEntry{Path: "bin/tool"},
+	}}
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"src/main.go", "dirty"},
+		{"src", "dirty"},
+		{"src/", "dirty"},
+		{"src/gen", "untracked"},
+		{"src/gen/out.go", "untracked"},
+		{"src/ma", "clean"},
+		{"README.md", "dirty"},
+		{"docs", "dirty"},
+		{"LICENSE", "clean"},
+		{"build", "untracked"},
+		{"build/", "untracked"},
+		{"build/obj/x.o", "untracked"},
+		{"buil", "clean"},
+		{"bin", "ignored"},
+		{"bin/tool", "ignored"},
+		{"bin/other", "clean"},
+		{".", "dirty"},
+		{"", "dirty"},
+	}
+	for _, tt := range tests {
+		ps := partitionPaths(s, []string{tt.path})
+		got := map[string]bool{
+			"dirty":     len(ps.Dirty) == 1,
+			"untracked": len(ps.Untracked) == 1,
+			"ignored":   len(ps.Ignored) == 1,
+			"clean":     len(ps.Clean) == 1,
+		}
+		if !got[tt.want] {
+			t.Errorf("partitionPaths(%q) = %+v, want %s", tt.path, ps, tt.want)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package statusv2
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// randomStatus is a random valid Status, generated by testing/quick. Paths
+// are arbitrary bytes, except for those that cannot appear unquoted in the
+// format: NUL with -z, and newline, carriage return (stripped from the end of
+// CRLF-terminated lines) and tab without it.
+type randomStatus struct {
+	Status *Status
+	Z      bool // whether the status is valid for the -z format only
+}
+
+func (randomStatus) Generate(r *rand.Rand, size int) reflect.Value {
+	g := statusGenerator{r: r, z: r.Intn(2) == 0}
+	s := &Status{}
+	if r.Intn(2) == 0 {
+		s.Branch = g.branch()
+	}
+	if r.Intn(3) == 0 {
+		s.Stash = &StashInfo{Count: 1 + r.Intn(100)}
+	}
+	for range r.Intn(size + 1) {
+		s.Entries = append(s.Entries, g.entry())
+	}
+	return reflect.ValueOf(randomStatus{Status: s, Z: g.z})
+}
+
+type statusGenerator struct {
+	r *rand.Rand
+	z bool
+}
+
+func (g statusGenerator) pick(options ...string) string {
+	return options[g.r.Intn(len(options))]
+}
+
+func (g statusGenerator) branch() *BranchInfo {
+	b := &BranchInfo{OID: g.hash(), Head: g.pick("main", "feature/x", "(detached)")}
+	if g.r.Intn(4) == 0 {
+		b.OID = "(initial)"
+	}
+	if g.r.Intn(2) == 0 {
+		b.Upstream = g.pick("origin/main", "upstream/feature/x")
+		b.Ahead, b.Behind = g.r.Intn(1000), g.r.Intn(1000)
+	}
+	return b
+}
+
+func (g statusGenerator) entry() Entry {
+	switch g.r.Intn(5) {
+	case 0:
+		return ChangedEntry{
+			XY: g.xy(), Sub: g.sub(),
+			ModeH: g.mode(), ModeI: g.mode(), ModeW: g.mode(),
+			HashH: g.hash(), HashI: g.hash(),
+			Path: g.path(),
+		}
+	case 1:
+		score := Score(g.pick("R", "C") + string(rune('0'+g.r.Intn(10))))
+		return RenameOrCopyEntry{
+			XY: g.xy(), Sub: g.sub(),
+			ModeH: g.mode(), ModeI: g.mode(), ModeW: g.mode(),
+			HashH: g.hash(), HashI: g.hash(),
+			Score: score, Path: g.path(), Orig: g.path(),
+		}
+	case 2:
+		xy := g.pick("DD", "AU", "UD", "UA", "DU", "AA", "UU")
+		return UnmergedEntry{
+			XY: XYFlag{X: State(xy[0]), Y: State(xy[1])}, Sub: g.sub(),
+			Mode1: g.mode(), Mode2: g.mode(), Mode3: g.mode(), ModeW: g.mode(),
+			Hash1: g.hash(), Hash2: g.hash(), Hash3: g.hash(),
+			Path: g.path(),
+		}
+	case 3:
+		return UntrackedEntry{Path: g.path()}
+	default:
+		return IgnoredEntry{Path: g.path()}
+	}
+}
+
+func (g statusGenerator) xy() XYFlag {
+	const states = ".MTADRCU"
+	for {
+		xy := XYFlag{X: State(states[g.r.Intn(len(states))]), Y: State(states[g.r.Intn(len(states))])}
+		if xy.X != Unmodified || xy.Y != Unmodified {
+			return xy
+		}
+	}
+}
+
+func (g statusGenerator) sub() SubmoduleStatus {
+	if g.r.Intn(4) != 0 {
+		return SubmoduleStatus{}
+	}
+	return SubmoduleStatus{
+		IsSubmodule:      true,
+		CommitChanged:    g.r.Intn(2) == 0,
+		HasModifications: g.r.Intn(2) == 0,
+		HasUntracked:     g.r.Intn(2) == 0,
+	}
+}
+
+func (g statusGenerator) mode() FileMode {
+	modes := []FileMode{FileModeEmpty, FileModeRegular, FileModeExecutable, FileModeSymlink, FileModeSubmodule}
+	return modes[g.r.Intn(len(modes))]
+}
+
+// hash returns a SHA-1 or SHA-256 object name.
+func (g statusGenerator) hash() string {
+	const hex = "0123456789abcdef"
+	b := make([]byte, 40+24*g.r.Intn(2))
+	for i := range b {
+		b[i] = hex[g.r.Intn(len(hex))]
+	}
+	return string(b)
+}
+
+// path returns a non-empty path of arbitrary bytes, including spaces,
+// separators and invalid UTF-8, that can appear unquoted in the format.
+func (g statusGenerator) path() string {
+	b := make([]byte, 1+g.r.Intn(20))
+	for i := range b {
+		for {
+			b[i] = byte(g.r.Intn(256))
+			if b[i] != 0 && (g.z || (b[i] != '\n' && b[i] != '\r' && b[i] != '\t')) {
+				break
+			}
+		}
+	}
+	return string(b)
+}
+
+// entryPaths returns the paths of the entries, with the original paths of
+// renames and copies, in order.
+func entryPaths(s *Status) [][]byte {
+	var paths [][]byte
+	for _, e := range s.Entries {
+		paths = append(paths, []byte(e.EntryPath()))
+		if rc, ok := e.(RenameOrCopyEntry); ok {
+			paths = append(paths, []byte(rc.Orig))
+		}
+	}
+	return paths
+}
+
+// roundTrip encodes s in the given format, and parses it again.
+func roundTrip(s *Status, z bool) (*Status, error) {
+	var buf bytes.Buffer
+	encode, parse := Encode, Parse
+	if z {
+		encode, parse = EncodeZ, ParseZ
+	}
+	if err := encode(&buf, s); err != nil {
+		return nil, err
+	}
+	return parse(&buf)
+}
+
+func TestProperty_RoundTrip(t *testing.T) {
+	f := func(rs randomStatus) bool {
+		got, err := roundTrip(rs.Status, rs.Z)
+		if err != nil {
+			t.Logf("round trip error = %v", err)
+			return false
+		}
+		if diff := cmp.Diff(rs.Status, got); diff != "" {
+			t.Logf("round trip mismatch (-want +got):\n%s", diff)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProperty_OrderAndPaths(t *testing.T) {
+	f := func(rs randomStatus) bool {
+		got, err := roundTrip(rs.Status, rs.Z)
+		if err != nil {
+			return false
+		}
+		if len(got.Entries) != len(rs.Status.Entries) {
+			return false
+		}
+		for i := range got.Entries {
+			if got.Entries[i].Type() != rs.Status.Entries[i].Type() {
+				return false
+			}
+		}
+		return reflect.DeepEqual(entryPaths(got), entryPaths(rs.Status))
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProperty_DecoderMatchesParse(t *testing.T) {
+	f := func(rs randomStatus) bool {
+		var buf bytes.Buffer
+		newDecoder := NewDecoder
+		if rs.Z {
+			EncodeZ(&buf, rs.Status)
+			newDecoder = NewDecoderZ
+		} else {
+			Encode(&buf, rs.Status)
+		}
+		d := newDecoder(bytes.NewReader(buf.Bytes()))
+		var entries []Entry
+		for {
+			e, err := d.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Logf("Next() error = %v", err)
+				return false
+			}
+			entries = append(entries, e)
+		}
+		return slices.Equal(entries, rs.Status.Entries) &&
+			equalPtr(d.Branch(), rs.Status.Branch) && equalPtr(d.Stash(), rs.Status.Stash)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,79 @@
+package statusv2
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// RenameMode is the kind of rename detection git status performs.
+type RenameMode int
+
+// Rename detection modes.
+const (
+	RenamesConfigured RenameMode = iota // as configured by status.renames or diff.renames, which detect renames by default
+	NoRenames                           // no detection, so renamed files are a deletion and an addition
+	Renames                             // renames are detected, but not copies
+	Copies                              // renames and copies are detected
+)
+
+// String returns the name of the mode as used by git's status.renames, e.g.
+// "copies", or "configured" for [RenamesConfigured].
+func (m RenameMode) String() string {
+	switch m {
+	case NoRenames:
+		return "false"
+	case Renames:
+		return "true"
+	case Copies:
+		return "copies"
+	}
+	return "configured"
+}
+
+// RenameDetection holds the rename detection settings git status runs with.
+//
+// Without rename detection, or for renames with less similarity than the
+// threshold, the worktree has no [RenameOrCopyEntry], and renamed files
+// appear as a deleted and an added file instead.
+type RenameDetection struct {
+	Mode      RenameMode
+	Threshold int // minimum similarity percentage for Renames and Copies, or 0 for git's default of 50
+}
+
+// args returns the arguments of git to run status with the settings, before
+// and after the status subcommand.
+func (d RenameDetection) args() (global, status []string) {
+	switch d.Mode {
+	case NoRenames:
+		return nil, []string{"--no-renames"}
+	case Renames, Copies:
+		global = []string{"-c", "status.renames=" + d.Mode.String()}
+		if d.Threshold > 0 {
+			status = []string{"--find-renames=" + strconv.Itoa(d.Threshold) + "%"}
+		}
+		return global, status
+	}
+	return nil, nil
+}
+
+// GetWithRenames runs `git status --porcelain=v2 -z` like [Get], with the
+// given rename detection settings overriding those configured, and records
+// them in the Renames field of the result.
+func GetWithRenames(ctx context.Context, git gitexec.Runner, d RenameDetection, args ...string) (*Status, error) {
+	global, status := d.args()
+	cmd := append(global, "status", "--porcelain=v2", "-z")
+	cmd = append(append(cmd, status...), args...)
+	out, err := git.Run(ctx, cmd...)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ParseZ(bytes.NewReader(out))
+	if err != nil {
+		return nil, err
+	}
+	s.Renames = &d
+	return s, nil
+}
@@ -0,0 +1,42 @@
+package statusv2
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestGetWithRenames(t *testing.T) {
+	tests := []struct {
+		detection RenameDetection
+		wantArgs  []string
+	}{
+		{RenameDetection{}, []string{"status", "--porcelain=v2", "-z", "--branch"}},
+		{RenameDetection{Mode: NoRenames, Threshold: 90}, []string{"status", "--porcelain=v2", "-z", "--no-renames", "--branch"}},
+		{RenameDetection{Mode: Renames}, []string{"-c", "status.renames=true", "status", "--porcelain=v2", "-z", "--branch"}},
+		{RenameDetection{Mode: Copies, Threshold: 90}, []string{"-c", "status.renames=copies", "status", "--porcelain=v2", "-z", "--find-renames=90%", "--branch"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.detection.Mode.String(), func(t *testing.T) {
+			git := &fakeRunner{out: "? new.txt\x00"}
+			s, err := GetWithRenames(context.Background(), git, tt.detection, "--branch")
+			if err != nil {
+				t.Fatalf("GetWithRenames() error = %v", err)
+			}
+			if !slices.Equal(git.args, tt.wantArgs) {
+				t.Errorf("GetWithRenames() ran git %q, want %q", git.args, tt.wantArgs)
+			}
+			if s.Renames == nil || *s.Renames != tt.detection {
+				t.Errorf("Renames = %+v, want %+v", s.Renames, tt.detection)
+			}
+		})
+	}
+}
+
+func TestGetWithRenames_Error(t *testing.T) {
+	wantErr := errors.New("boom")
+	if _, err := GetWithRenames(context.Background(), &fakeRunner{err: wantErr}, RenameDetection{}); !errors.Is(err, wantErr) {
+		t.Errorf("GetWithRenames() error = %v, want %v", err, wantErr)
+	}
+}
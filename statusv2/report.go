@@ -0,0 +1,168 @@
+package statusv2
+
+import (
+	"strconv"
+	"strings"
+)
+
+// EventKind is the kind of an [Event], naming the state a path entered or
+// left.
+type EventKind string
+
+// Kinds of events, in the order [Summarize] reports them.
+const (
+	EventStaged           EventKind = "staged"            // path gained changes in the index
+	EventUnstaged         EventKind = "unstaged"          // path lost its changes in the index, as when committed or reset
+	EventModified         EventKind = "modified"          // path gained changes in the worktree
+	EventReverted         EventKind = "reverted"          // path lost its changes in the worktree
+	EventConflicted       EventKind = "conflicted"        // path became unmerged
+	EventResolved         EventKind = "resolved"          // path was unmerged, and no longer is
+	EventUntracked        EventKind = "untracked"         // path became untracked, as a new file
+	EventUntrackedRemoved EventKind = "untracked_removed" // untracked path was deleted
+)
+
+var eventKinds = []EventKind{
+	EventStaged, EventUnstaged, EventModified, EventReverted,
+	EventConflicted, EventResolved, EventUntracked, EventUntrackedRemoved,
+}
+
+// An Event describes a path entering or leaving a state between two
+// statuses, for reporting changes to a worktree, as after running a tool on
+// it.
+type Event struct {
+	Kind EventKind
+	Path string
+	From string `json:",omitempty"` // XY status of the old entry, "??" if untracked, or "" if none
+	To   string `json:",omitempty"` // XY status of the new entry, as for From
+}
+
+// Events returns the events of changes, as returned by [Diff], in the same
+// order, with any events of the same change in the order of the kinds.
+//
+// Changes moving from the worktree to the index or back, as with git add or
+// git reset, are reported as [EventStaged] or [EventUnstaged] alone, and the
+// resolution of a conflict as [EventResolved] alone. Changes moving no path
+// between states, such as further edits to a modified file, and changes of
+// ignored files, have no events.
+func Events(changes []Change) []Event {
+	var events []Event
+	for _, c := range changes {
+		from, to := entryState(c.Old), entryState(c.New)
+		add := func(kind EventKind) {
+			events = append(events, Event{Kind: kind, Path: c.Path, From: from.xy, To: to.xy})
+		}
+		if from.unmerged != to.unmerged {
+			if to.unmerged {
+				add(EventConflicted)
+			} else {
+				add(EventResolved)
+			}
+			continue
+		}
+		staged := !from.staged && to.staged
+		unstaged := from.staged && !to.staged
+		modified := !from.modified && to.modified
+		reverted := from.modified && !to.modified
+		switch {
+		case staged:
+			add(EventStaged)
+			reverted = false
+		case unstaged:
+			add(EventUnstaged)
+			modified = false
+		}
+		if modified {
+			add(EventModified)
+		}
+		if reverted {
+			add(EventReverted)
+		}
+		switch {
+		case !from.untracked && to.untracked:
+			add(EventUntracked)
+		case from.untracked && c.New == nil:
+			add(EventUntrackedRemoved)
+		}
+	}
+	return events
+}
+
+// pathState is the state of a path, as given by its entry.
+type pathState struct {
+	xy        string
+	staged    bool // has changes in the index
+	modified  bool // has changes in the worktree
+	unmerged  bool
+	untracked bool
+}
+
+func entryState(e Entry) pathState {
+	switch e := e.(type) {
+	case ChangedEntry:
+		return trackedState(e.XY)
+	case RenameOrCopyEntry:
+		return trackedState(e.XY)
+	case UnmergedEntry:
+		return pathState{xy: e.XY.String(), unmerged: true}
+	case UntrackedEntry:
+		return pathState{xy: "??", untracked: true}
+	case IgnoredEntry:
+		return pathState{xy: "!!"}
+	}
+	return pathState{}
+}
+
+func trackedState(xy XYFlag) pathState {
+	return pathState{xy: xy.String(), staged: xy.HasStaged(), modified: xy.HasUnstaged()}
+}
+
+// Summarize returns a single line counting the events of each kind, such as
+// "3 files became staged, 1 conflict resolved, 2 new untracked", or
+// "no changes" if there are none.
+func Summarize(events []Event) string {
+	counts := make(map[EventKind]int)
+	for _, e := range events {
+		counts[e.Kind]++
+	}
+	var parts []string
+	for _, kind := range eventKinds {
+		if n := counts[kind]; n > 0 {
+			parts = append(parts, describeEvents(kind, n))
+		}
+	}
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeEvents returns the phrase for n events of a kind.
+func describeEvents(kind EventKind, n int) string {
+	switch kind {
+	case EventStaged:
+		return count(n, "file", "files") + " became staged"
+	case EventUnstaged:
+		return count(n, "file", "files") + " no longer staged"
+	case EventModified:
+		return count(n, "file", "files") + " became modified"
+	case EventReverted:
+		return count(n, "file", "files") + " no longer modified"
+	case EventConflicted:
+		return count(n, "new conflict", "new conflicts")
+	case EventResolved:
+		return count(n, "conflict", "conflicts") + " resolved"
+	case EventUntracked:
+		return count(n, "new untracked", "new untracked")
+	case EventUntrackedRemoved:
+		return count(n, "untracked removed", "untracked removed")
+	}
+	return count(n, string(kind), string(kind))
+}
+
+// count returns n followed by the singular or plural noun.
+func count(n int, singular, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return strconv.Itoa(n) + " " + plural
+}
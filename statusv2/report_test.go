@@ -0,0 +1,92 @@
+package statusv2
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvents(t *testing.T) {
+	changed := func(x, y State) ChangedEntry { return ChangedEntry{XY: XYFlag{x, y}, Path: "f"} }
+	unmerged := UnmergedEntry{XY: XYFlag{UpdatedUnmerged, UpdatedUnmerged}, Path: "f"}
+	tests := []struct {
+		name     string
+		old, new Entry
+		want     []EventKind
+	}{
+		{"codemod edit", nil, changed(Unmodified, Modified), []EventKind{EventModified}},
+		{"git add", changed(Unmodified, Modified), changed(Modified, Unmodified), []EventKind{EventStaged}},
+		{"git reset", changed(Modified, Unmodified), changed(Unmodified, Modified), []EventKind{EventUnstaged}},
+		{"partial add", changed(Unmodified, Modified), changed(Modified, Modified), []EventKind{EventStaged}},
+		{"commit", changed(Modified, Unmodified), nil, []EventKind{EventUnstaged}},
+		{"checkout", changed(Unmodified, Modified), nil, []EventKind{EventReverted}},
+		{"further edit", changed(Unmodified, Modified), changed(Unmodified, Deleted), nil},
+		{"new file", nil, UntrackedEntry{Path: "f"}, []EventKind{EventUntracked}},
+		{"add new file", UntrackedEntry{Path: "f"}, changed(Added, Unmodified), []EventKind{EventStaged}},
+		{"delete untracked", UntrackedEntry{Path: "f"}, nil, []EventKind{EventUntrackedRemoved}},
+		{"conflict", changed(Unmodified, Modified), unmerged, []EventKind{EventConflicted}},
+		{"resolve", unmerged, changed(Modified, Unmodified), []EventKind{EventResolved}},
+		{"ignored", nil, IgnoredEntry{Path: "f"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := Events([]Change{{Path: "f", Old: tt.old, New: tt.new}})
+			var got []EventKind
+			for _, e := range events {
+				if e.Path != "f" {
+					t.Errorf("Event.Path = %q, want %q", e.Path, "f")
+				}
+				got = append(got, e.Kind)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Events() kinds mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvents_FromTo(t *testing.T) {
+	old := &Status{Entries: []Entry{ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "a"}}}
+	new := &Status{Entries: []Entry{
+		ChangedEntry{XY: XYFlag{Modified, Unmodified}, Path: "a"},
+		UntrackedEntry{Path: "b"},
+	}}
+	got := Events(Diff(old, new))
+	want := []Event{
+		{Kind: EventStaged, Path: "a", From: ".M", To: "M."},
+		{Kind: EventUntracked, Path: "b", To: "??"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Events() mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `[{"Kind":"staged","Path":"a","From":".M","To":"M."},{"Kind":"untracked","Path":"b","To":"??"}]`
+	if string(data) != wantJSON {
+		t.Errorf("json.Marshal() = %s, want %s", data, wantJSON)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	events := []Event{
+		{Kind: EventUntracked, Path: "x"},
+		{Kind: EventStaged, Path: "a"},
+		{Kind: EventResolved, Path: "c"},
+		{Kind: EventStaged, Path: "b"},
+		{Kind: EventUntracked, Path: "y"},
+		{Kind: EventStaged, Path: "d"},
+	}
+	if got, want := Summarize(events), "3 files became staged, 1 conflict resolved, 2 new untracked"; got != want {
+		t.Errorf("Summarize() = %q, want %q", got, want)
+	}
+	if got, want := Summarize([]Event{{Kind: EventModified}, {Kind: EventConflicted}}), "1 file became modified, 1 new conflict"; got != want {
+		t.Errorf("Summarize() = %q, want %q", got, want)
+	}
+	if got, want := Summarize(nil), "no changes"; got != want {
+		t.Errorf("Summarize(nil) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+package statusv2
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An EntryStat is an entry with the metadata of its file in the worktree, as
+// from [Status.Stat], for showing alongside it, such as in a TUI.
+type EntryStat struct {
+	Entry
+	Exists  bool        // whether the path exists in the worktree, which it does not if deleted
+	Size    int64       // size in bytes, as reported by the file system
+	ModTime time.Time   // modification time
+	Mode    fs.FileMode // file mode bits, including the type of the file
+	Err     error       // error getting the metadata, other than the path not existing
+}
+
+// StatConfig configures [Status.Stat]. The zero value is ready to use.
+type StatConfig struct {
+	// Workers is the maximum number of files to stat at once. If zero, it
+	// defaults to the number of CPUs.
+	Workers int
+}
+
+// Stat returns the entries of s, in order, with the metadata of their files
+// in fsys, the worktree, as from [os.DirFS] at the root of the repository.
+// Directories of untracked or ignored files, listed as a single entry, have
+// the metadata of the directory. Symbolic links are followed, as [fs.Stat]
+// does, except for those pointing nowhere, which have the metadata of the link
+// if fsys can list their directory.
+//
+// The paths of s must be unquoted, as by [ParseZ] or [UnquotePaths]. Files
+// that cannot be stat'd, including paths invalid in fsys, have Err set; Stat
+// itself only fails if ctx is done first.
+func (s *Status) Stat(ctx context.Context, fsys fs.FS, cfg StatConfig) ([]EntryStat, error) {
+	if s == nil {
+		return nil, nil
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	stats := make([]EntryStat, len(s.Entries))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(s.Entries)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				stats[i] = statEntry(fsys, s.Entries[i])
+			}
+		}()
+	}
+	var err error
+	for i := range s.Entries {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// statEntry returns e with the metadata of its file in fsys.
+func statEntry(fsys fs.FS, e Entry) EntryStat {
+	st := EntryStat{Entry: e}
+	p := strings.TrimSuffix(e.EntryPath(), "/")
+	if !fs.ValidPath(p) {
+		st.Err = &fs.PathError{Op: "stat", Path: p, Err: fs.ErrInvalid}
+		return st
+	}
+	info, err := fs.Stat(fsys, p)
+	if errors.Is(err, fs.ErrNotExist) {
+		info, err = lstatDangling(fsys, p)
+	}
+	switch {
+	case err != nil:
+		st.Err = err
+	case info != nil:
+		st.Exists = true
+		st.Size, st.ModTime, st.Mode = info.Size(), info.ModTime(), info.Mode()
+	}
+	return st
+}
+
+// lstatDangling returns the metadata of p from the listing of its directory,
+// for a symbolic link pointing nowhere, or nil if p is not there.
+func lstatDangling(fsys fs.FS, p string) (fs.FileInfo, error) {
+	entries, err := fs.ReadDir(fsys, path.Dir(p))
+	if err != nil {
+		// The directory is missing, or not a directory, so p is missing too.
+		return nil, nil
+	}
+	name := path.Base(p)
+	for _, entry := range entries {
+		if entry.Name() == name {
+			return entry.Info()
+		}
+	}
+	return nil, nil
+}
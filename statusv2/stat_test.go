@@ -0,0 +1,93 @@
+package statusv2
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestStatus_Stat(t *testing.T) {
+	mtime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"changed.txt":   {Data: []byte("hello\n"), ModTime: mtime, Mode: 0o644},
+		"new.txt":       {Data: []byte("renamed"), ModTime: mtime, Mode: 0o755},
+		"build/out.o":   {Data: []byte("x")},
+		"untracked.txt": {Data: []byte{}, ModTime: mtime},
+	}
+	s := &Status{Entries: []Entry{
+		ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "changed.txt"},
+		ChangedEntry{XY: XYFlag{Unmodified, Deleted}, Path: "deleted.txt"},
+		RenameOrCopyEntry{XY: XYFlag{Renamed, Unmodified}, Score: "R100", Path: "new.txt", Orig: "old.txt"},
+		UntrackedEntry{Path: "build/"},
+		UntrackedEntry{Path: "untracked.txt"},
+		IgnoredEntry{Path: "../outside"},
+		ChangedEntry{XY: XYFlag{Unmodified, Deleted}, Path: "changed.txt/beneath"},
+	}}
+	for _, workers := range []int{0, 1, 3} {
+		got, err := s.Stat(context.Background(), fsys, StatConfig{Workers: workers})
+		if err != nil {
+			t.Fatalf("Stat() error = %v", err)
+		}
+		if len(got) != len(s.Entries) {
+			t.Fatalf("Stat() returned %d entries, want %d", len(got), len(s.Entries))
+		}
+		for i, st := range got {
+			if st.Entry != s.Entries[i] {
+				t.Errorf("Stat()[%d].Entry = %v, want %v", i, st.Entry, s.Entries[i])
+			}
+		}
+		check := func(i int, exists bool, size int64, mode fs.FileMode) {
+			t.Helper()
+			st := got[i]
+			if st.Err != nil || st.Exists != exists || st.Size != size || st.Mode != mode {
+				t.Errorf("Stat()[%d] = exists %v, size %d, mode %v, error %v, want exists %v, size %d, mode %v",
+					i, st.Exists, st.Size, st.Mode, st.Err, exists, size, mode)
+			}
+		}
+		check(0, true, 6, 0o644)
+		check(1, false, 0, 0)
+		check(2, true, 7, 0o755)
+		check(3, true, 0, fs.ModeDir|0o555)
+		check(4, true, 0, 0)
+		check(6, false, 0, 0)
+		if !got[0].ModTime.Equal(mtime) {
+			t.Errorf("Stat()[0].ModTime = %v, want %v", got[0].ModTime, mtime)
+		}
+		if !errors.Is(got[5].Err, fs.ErrInvalid) {
+			t.Errorf("Stat()[5].Err = %v, want %v", got[5].Err, fs.ErrInvalid)
+		}
+	}
+}
+
+func TestStatus_StatDanglingSymlink(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Symlink("nowhere", filepath.Join(dir, "link")); err != nil {
+		t.Skipf("cannot create symbolic links: %v", err)
+	}
+	s := &Status{Entries: []Entry{UntrackedEntry{Path: "link"}}}
+	got, err := s.Stat(context.Background(), os.DirFS(dir), StatConfig{})
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if st := got[0]; !st.Exists || st.Mode.Type() != fs.ModeSymlink || st.Err != nil {
+		t.Errorf("Stat() = exists %v, mode %v, error %v, want an existing symbolic link", st.Exists, st.Mode, st.Err)
+	}
+}
+
+func TestStatus_StatCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := &Status{Entries: []Entry{UntrackedEntry{Path: "a"}}}
+	if _, err := s.Stat(ctx, fstest.MapFS{}, StatConfig{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Stat() error = %v, want %v", err, context.Canceled)
+	}
+	var nilStatus *Status
+	if got, err := nilStatus.Stat(context.Background(), fstest.MapFS{}, StatConfig{}); got != nil || err != nil {
+		t.Errorf("Stat() of nil = %v, %v, want nil, nil", got, err)
+	}
+}
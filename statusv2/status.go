@@ -2,7 +2,10 @@ package statusv2
 
 import (
 	"fmt"
+	"io/fs"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 // Status represents parsed git status --porcelain=v2 output.
@@ -10,10 +13,129 @@ import (
 // Branch contains branch information if --branch was used.
 // Stash contains stash count if --show-stash was used and stashes exist.
 // Entries contains all file status entries in the order they appeared.
+// Renames and Untracked record the rename detection settings and untracked
+// files mode git ran with, if known, Meta how the output was parsed, and
+// UnknownLines any lines the parser did not recognize, if requested.
 type Status struct {
-	Branch  *BranchInfo // nil if `--branch` not passed
-	Stash   *StashInfo  // nil if `--show-stash` not passed or count == 0
-	Entries []Entry     // in the order lines appeared; can be ChangedEntry, RenameOrCopyEntry, UnmergedEntry, UntrackedEntry, or IgnoredEntry
+	Branch       *BranchInfo      // nil if `--branch` not passed
+	Stash        *StashInfo       // nil if `--show-stash` not passed or count == 0
+	Entries      []Entry          // in the order lines appeared; can be ChangedEntry, RenameOrCopyEntry, UnmergedEntry, UntrackedEntry, or IgnoredEntry
+	Renames      *RenameDetection `json:",omitempty"` // nil unless from GetWithRenames, as the output does not record it
+	Untracked    UntrackedMode    `json:",omitempty"` // UntrackedConfigured unless from GetWithUntracked or set by the caller, as the output does not record it
+	Meta         *Meta            `json:",omitempty"` // nil unless from Decoder.Decode
+	UnknownLines []string         `json:",omitempty"` // nil unless from a Decoder with KeepUnknown
+}
+
+// Equal reports whether s and other have the same branch and stash
+// information, and the same entries in the same order. Two nil statuses are
+// equal. The rename detection settings, untracked files mode, parse metadata
+// and unknown lines are not compared.
+func (s *Status) Equal(other *Status) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	return equalPtr(s.Branch, other.Branch) &&
+		equalPtr(s.Stash, other.Stash) &&
+		slices.Equal(s.Entries, other.Entries)
+}
+
+// XY flags standing for untracked and ignored entries in [Status.XYMap], as
+// in porcelain=v1, since porcelain=v2 gives them none.
+var (
+	UntrackedXY = XYFlag{'?', '?'}
+	IgnoredXY   = XYFlag{'!', '!'}
+)
+
+// XYMap returns the XY flag of each path in s, the minimal view of a status
+// wanted by many prompts and hooks, with [UntrackedXY] for untracked paths and
+// [IgnoredXY] for ignored ones. The original path of a rename or copy maps to
+// the flag of its entry too, unless the path has an entry of its own.
+func (s *Status) XYMap() map[string]XYFlag {
+	if s == nil {
+		return nil
+	}
+	m := make(map[string]XYFlag, len(s.Entries))
+	var origs []RenameOrCopyEntry
+	for _, e := range s.Entries {
+		switch e := e.(type) {
+		case ChangedEntry:
+			m[e.Path] = e.XY
+		case RenameOrCopyEntry:
+			m[e.Path] = e.XY
+			origs = append(origs, e)
+		case UnmergedEntry:
+			m[e.Path] = e.XY
+		case UntrackedEntry:
+			m[e.Path] = UntrackedXY
+		case IgnoredEntry:
+			m[e.Path] = IgnoredXY
+		}
+	}
+	for _, e := range origs {
+		if _, ok := m[e.Orig]; !ok {
+			m[e.Orig] = e.XY
+		}
+	}
+	return m
+}
+
+// IsClean reports whether s has no changes, that is no entries other than
+// ignored files. Unmerged files are changes. If untracked files were not
+// listed, as with [UntrackedNo], only tracked files are known to be clean.
+func (s *Status) IsClean() bool {
+	if s == nil {
+		return true
+	}
+	for _, e := range s.Entries {
+		if e.Type() != EntryTypeIgnored {
+			return false
+		}
+	}
+	return true
+}
+
+// HasStagedChanges reports whether any file has changes in the index, to be
+// committed. Unmerged files are not counted, as their changes are neither
+// staged nor unstaged until resolved; see [Status.Conflicts].
+func (s *Status) HasStagedChanges() bool {
+	return s.anyXY(XYFlag.HasStaged)
+}
+
+// HasUnstagedChanges reports whether any tracked file has changes in the
+// worktree that are not staged. Unmerged files are not counted, as for
+// [Status.HasStagedChanges], nor are untracked files; see
+// [Status.HasUntracked].
+func (s *Status) HasUnstagedChanges() bool {
+	return s.anyXY(XYFlag.HasUnstaged)
+}
+
+// anyXY reports whether f is true for the XY flag of any changed, renamed or
+// copied file.
+func (s *Status) anyXY(f func(XYFlag) bool) bool {
+	if s == nil {
+		return false
+	}
+	for _, e := range s.Entries {
+		switch e := e.(type) {
+		case ChangedEntry:
+			if f(e.XY) {
+				return true
+			}
+		case RenameOrCopyEntry:
+			if f(e.XY) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// equalPtr reports whether a and b are both nil, or point to equal values.
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
 
 // BranchInfo contains branch information from git status --branch output.
@@ -21,13 +143,55 @@ type Status struct {
 // Available when --branch flag is used. Contains current branch state,
 // upstream tracking information, and ahead/behind commit counts.
 type BranchInfo struct {
-	OID      string // current commit hash, or "(initial)" for new repos
-	Head     string // current branch name, or "(detached)" for detached HEAD
+	OID      string // current commit hash, or InitialOID for new repos
+	Head     string // current branch name, or DetachedHead for detached HEAD
 	Upstream string // upstream branch name (empty if no upstream set)
 	Ahead    int    // commits ahead of upstream
 	Behind   int    // commits behind upstream
 }
 
+// Placeholders git writes in the branch headers instead of a commit or branch.
+const (
+	InitialOID   = "(initial)"  // branch.oid of a repository without commits
+	DetachedHead = "(detached)" // branch.head when HEAD is detached
+)
+
+// Commit returns the object name of the current commit, and whether there is
+// one, which there is not in a repository without commits, or for a nil b.
+func (b *BranchInfo) Commit() (Hash, bool) {
+	if b == nil || b.OID == "" || b.OID == InitialOID {
+		return "", false
+	}
+	return Hash(b.OID), true
+}
+
+// IsUnborn reports whether the current branch has no commits yet, as in a new
+// repository. It returns false for a nil b.
+func (b *BranchInfo) IsUnborn() bool {
+	return b != nil && b.OID == InitialOID
+}
+
+// IsDetached reports whether HEAD is detached, rather than on a branch. It
+// returns false for a nil b.
+func (b *BranchInfo) IsDetached() bool {
+	return b != nil && b.Head == DetachedHead
+}
+
+// IsInitial reports whether the repository has no commits yet, which git
+// shows as "(initial)" for branch.oid. It is equivalent to
+// [BranchInfo.IsUnborn].
+func (b *BranchInfo) IsInitial() bool {
+	return b.IsUnborn()
+}
+
+// HasUpstream reports whether the current branch has an upstream branch
+// configured. Ahead and Behind are only set when git reports them, which it
+// does not when the upstream branch is gone, so they may be zero even with an
+// upstream. It returns false for a nil b.
+func (b *BranchInfo) HasUpstream() bool {
+	return b != nil && b.Upstream != ""
+}
+
 // StashInfo contains stash information from git status --show-stash output.
 //
 // Available when --show-stash flag is used and stashes exist.
@@ -47,6 +211,44 @@ const (
 	EntryTypeIgnored                       // "!" - ignored files
 )
 
+var entryTypeNames = [...]string{
+	EntryTypeChanged:      "changed",
+	EntryTypeRenameOrCopy: "rename_or_copy",
+	EntryTypeUnmerged:     "unmerged",
+	EntryTypeUntracked:    "untracked",
+	EntryTypeIgnored:      "ignored",
+}
+
+// String returns the name of the entry type, one of "changed",
+// "rename_or_copy", "unmerged", "untracked" or "ignored", or "EntryType(n)"
+// for an unknown type.
+func (t EntryType) String() string {
+	if t < 0 || int(t) >= len(entryTypeNames) {
+		return "EntryType(" + strconv.Itoa(int(t)) + ")"
+	}
+	return entryTypeNames[t]
+}
+
+// MarshalText returns the name of the entry type, as for String, so that it
+// encodes as a string in JSON. It returns an error for an unknown type.
+func (t EntryType) MarshalText() ([]byte, error) {
+	if t < 0 || int(t) >= len(entryTypeNames) {
+		return nil, fmt.Errorf("unknown entry type %d", int(t))
+	}
+	return []byte(entryTypeNames[t]), nil
+}
+
+// UnmarshalText sets the entry type from its name, as returned by String.
+func (t *EntryType) UnmarshalText(text []byte) error {
+	for i, name := range entryTypeNames {
+		if string(text) == name {
+			*t = EntryType(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown entry type %q", text)
+}
+
 // Entry represents a file status entry. Use type switching to access specific fields:
 //
 //	switch e := entry.(type) {
@@ -55,8 +257,12 @@ const (
 //	case RenameOrCopyEntry:
 //		// Access e.Path, e.Orig, etc.
 //	}
+//
+// The path of any entry is available without a type switch from EntryPath,
+// which is not named Path as the entry types have a field of that name.
 type Entry interface {
 	Type() EntryType
+	EntryPath() string // the Path field of the entry; for renames and copies, the new path
 }
 
 // State represents a single character from Git porcelain=v2 XY status codes.
@@ -81,6 +287,50 @@ const (
 	UpdatedUnmerged State = 'U' // updated but unmerged (merge conflict)
 )
 
+// String returns the state as the single character git writes, such as "M".
+func (s State) String() string { return string(s) }
+
+// Description returns a description of the state for messages to users, such
+// as "modified" for [Modified] or "type changed" for [TypeChanged]. The
+// states of [UntrackedXY] and [IgnoredXY] are "untracked" and "ignored", and
+// any other state not valid in porcelain=v2 is "unknown".
+func (s State) Description() string {
+	switch s {
+	case Unmodified:
+		return "unmodified"
+	case Modified:
+		return "modified"
+	case TypeChanged:
+		return "type changed"
+	case Added:
+		return "added"
+	case Deleted:
+		return "deleted"
+	case Renamed:
+		return "renamed"
+	case Copied:
+		return "copied"
+	case UpdatedUnmerged:
+		return "updated but unmerged"
+	case '?':
+		return "untracked"
+	case '!':
+		return "ignored"
+	}
+	return "unknown"
+}
+
+// IsValid reports whether s is one of the states git writes in the XY flags
+// of porcelain=v2, which does not include those of [UntrackedXY] and
+// [IgnoredXY].
+func (s State) IsValid() bool {
+	switch s {
+	case Unmodified, Modified, TypeChanged, Added, Deleted, Renamed, Copied, UpdatedUnmerged:
+		return true
+	}
+	return false
+}
+
 // XYFlag holds the two-character XY status codes (index + worktree).
 // X represents staged changes, Y represents unstaged changes.
 // Unchanged files use "." in porcelain=v2, not space.
@@ -90,11 +340,41 @@ type XYFlag struct {
 }
 
 // String returns the XY status as a two-character string.
-func (xy XYFlag) String() string { return string(xy.X) + string(xy.Y) }
+func (xy XYFlag) String() string { return xy.X.String() + xy.Y.String() }
+
+// HasStaged reports whether xy is of a file with changes in the index, to be
+// committed. Conflicts are neither staged nor unstaged until resolved, nor are
+// [UntrackedXY] and [IgnoredXY].
+func (xy XYFlag) HasStaged() bool {
+	return !xy.IsConflict() && xy.X.isChange()
+}
+
+// HasUnstaged reports whether xy is of a tracked file with changes in the
+// worktree that are not staged. Conflicts are neither staged nor unstaged until
+// resolved, nor are [UntrackedXY] and [IgnoredXY].
+func (xy XYFlag) HasUnstaged() bool {
+	return !xy.IsConflict() && xy.Y.isChange()
+}
+
+// IsConflict reports whether xy is one of the seven combinations git uses for
+// an unmerged file: DD, AU, UD, UA, DU, AA and UU, those of the [ConflictKind]
+// constants.
+func (xy XYFlag) IsConflict() bool {
+	return xy.conflictKind() != ConflictUnknown
+}
+
+// isChange reports whether s is the state of a change to a tracked file, as
+// any state is other than Unmodified and those of UntrackedXY and IgnoredXY,
+// including any a later version of git might add.
+func (s State) isChange() bool {
+	return s != Unmodified && s != UntrackedXY.X && s != IgnoredXY.X
+}
 
-// MarshalText implements encoding.TextMarshaler for XYFlag.
+// MarshalText implements encoding.TextMarshaler for XYFlag, as the two bytes
+// of the states, the inverse of [XYFlag.UnmarshalText]. It also makes XYFlag
+// encode to JSON as a string, such as "M.".
 func (xy XYFlag) MarshalText() ([]byte, error) {
-	return []byte(xy.String()), nil
+	return []byte{byte(xy.X), byte(xy.Y)}, nil
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler for XYFlag.
@@ -128,6 +408,107 @@ func (m FileMode) String() string {
 	return strconv.FormatUint(uint64(m), 8)
 }
 
+// fileModeType masks the bits of a FileMode giving the kind of the entry.
+const fileModeType FileMode = 0170000
+
+// IsRegular reports whether m is of a regular file, executable or not, as for
+// [fs.FileMode.IsRegular].
+func (m FileMode) IsRegular() bool { return m&fileModeType == 0100000 }
+
+// IsExecutable reports whether m is of an executable regular file, such as
+// [FileModeExecutable].
+func (m FileMode) IsExecutable() bool { return m.IsRegular() && m&0111 != 0 }
+
+// IsSymlink reports whether m is of a symbolic link.
+func (m FileMode) IsSymlink() bool { return m&fileModeType == FileModeSymlink }
+
+// IsSubmodule reports whether m is of a submodule, which git records as a
+// commit rather than a tree, although it is a directory in the worktree.
+func (m FileMode) IsSubmodule() bool { return m&fileModeType == FileModeSubmodule }
+
+// IsDir reports whether m is of a directory (a tree). It is false for
+// submodules; see [FileMode.IsSubmodule].
+func (m FileMode) IsDir() bool { return m&fileModeType == FileModeDir }
+
+// ToOSFileMode returns the equivalent of m in package os, with the permissions
+// git gives the files it checks out: 0644 for regular files, 0755 for
+// executables and directories, including submodules, and 0777 for symbolic
+// links. It returns 0 for [FileModeEmpty], and for any mode of a kind git
+// does not use.
+func (m FileMode) ToOSFileMode() fs.FileMode {
+	switch {
+	case m.IsExecutable():
+		return 0755
+	case m.IsRegular():
+		return 0644
+	case m.IsSymlink():
+		return fs.ModeSymlink | 0777
+	case m.IsDir(), m.IsSubmodule():
+		return fs.ModeDir | 0755
+	}
+	return 0
+}
+
+// A Hash is a hexadecimal object name, as in the hash fields of entries.
+//
+// Repositories using the SHA-1 object format have 40 character object names,
+// and those using SHA-256 (git init --object-format=sha256) have 64 character
+// ones. Entries for files missing from the index or HEAD have the all zeros
+// object name of the same length.
+type Hash string
+
+// HashAlgorithm identifies the object format of a [Hash].
+type HashAlgorithm int
+
+// Hash algorithms supported by git.
+const (
+	HashUnknown HashAlgorithm = iota // not a full object name
+	SHA1                             // 40 hexadecimal characters
+	SHA256                           // 64 hexadecimal characters
+)
+
+// String returns the name of the algorithm as used by git's
+// extensions.objectFormat, e.g. "sha1".
+func (a HashAlgorithm) String() string {
+	switch a {
+	case SHA1:
+		return "sha1"
+	case SHA256:
+		return "sha256"
+	}
+	return "unknown"
+}
+
+// Algorithm returns the algorithm of h, detected from its length, or
+// [HashUnknown] if h is not a full lowercase hexadecimal object name.
+func (h Hash) Algorithm() HashAlgorithm {
+	if !isObjectName(h) {
+		return HashUnknown
+	}
+	if len(h) == 64 {
+		return SHA256
+	}
+	return SHA1
+}
+
+// IsZero reports whether h is the all zeros object name, which git uses for
+// files missing from the index or HEAD.
+func (h Hash) IsZero() bool {
+	return h.Algorithm() != HashUnknown && strings.Trim(string(h), "0") == ""
+}
+
+// ZeroHash returns the all zeros object name for the algorithm, or the empty
+// string for [HashUnknown].
+func ZeroHash(a HashAlgorithm) Hash {
+	switch a {
+	case SHA1:
+		return Hash(strings.Repeat("0", 40))
+	case SHA256:
+		return Hash(strings.Repeat("0", 64))
+	}
+	return ""
+}
+
 // SubmoduleStatus represents submodule state information.
 //
 // For regular files, IsSubmodule is false and other fields are ignored.
@@ -165,6 +546,42 @@ func (s SubmoduleStatus) String() string {
 		string(chooseRune(s.HasUntracked, 'U', '.'))
 }
 
+// ParseSubmoduleStatusString parses the 4 character field written by
+// [SubmoduleStatus.String]. Unlike the parsers, which accept any field of 4
+// characters, it returns an error for fields String would never return.
+func ParseSubmoduleStatusString(s string) (SubmoduleStatus, error) {
+	if s == "N..." {
+		return SubmoduleStatus{}, nil
+	}
+	if len(s) != 4 || s[0] != 'S' ||
+		(s[1] != 'C' && s[1] != '.') ||
+		(s[2] != 'M' && s[2] != '.') ||
+		(s[3] != 'U' && s[3] != '.') {
+		return SubmoduleStatus{}, fmt.Errorf("invalid submodule status field: %q", s)
+	}
+	return SubmoduleStatus{
+		IsSubmodule:      true,
+		CommitChanged:    s[1] == 'C',
+		HasModifications: s[2] == 'M',
+		HasUntracked:     s[3] == 'U',
+	}, nil
+}
+
+// MarshalText implements encoding.TextMarshaler for SubmoduleStatus.
+func (s SubmoduleStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for SubmoduleStatus.
+func (s *SubmoduleStatus) UnmarshalText(text []byte) error {
+	sub, err := ParseSubmoduleStatusString(string(text))
+	if err != nil {
+		return fmt.Errorf("SubmoduleStatus.UnmarshalText: %w", err)
+	}
+	*s = sub
+	return nil
+}
+
 // ChangedEntry represents a modified file (added, modified, deleted, etc).
 //
 // Corresponds to porcelain=v2 status lines starting with "1". Does not include
@@ -180,7 +597,52 @@ type ChangedEntry struct {
 	Path  string          // file path relative to repository root
 }
 
-func (ChangedEntry) Type() EntryType { return EntryTypeChanged }
+func (ChangedEntry) Type() EntryType     { return EntryTypeChanged }
+func (e ChangedEntry) EntryPath() string { return e.Path }
+
+// Head returns the file mode and object name of the entry in HEAD. A file
+// missing from HEAD, such as one added to the index, has [FileModeEmpty] and
+// the zero object name.
+func (e ChangedEntry) Head() (FileMode, Hash) { return e.ModeH, Hash(e.HashH) }
+
+// Index returns the file mode and object name of the entry in the index. A
+// file deleted from the index has [FileModeEmpty] and the zero object name.
+func (e ChangedEntry) Index() (FileMode, Hash) { return e.ModeI, Hash(e.HashI) }
+
+// IsNewFile reports whether the file is added to the index, and so is not in
+// HEAD.
+func (e ChangedEntry) IsNewFile() bool {
+	return e.XY.X == Added
+}
+
+// IsDeletedFromIndex reports whether the deletion of the file is staged.
+func (e ChangedEntry) IsDeletedFromIndex() bool {
+	return e.XY.X == Deleted
+}
+
+// IsDeletedFromWorktree reports whether the file is deleted in the worktree
+// but not yet from the index.
+func (e ChangedEntry) IsDeletedFromWorktree() bool {
+	return e.XY.Y == Deleted
+}
+
+// IsTypeChange reports whether the kind of the file (regular file, symbolic
+// link or submodule) changed in the index or worktree.
+func (e ChangedEntry) IsTypeChange() bool {
+	return e.XY.X == TypeChanged || e.XY.Y == TypeChanged
+}
+
+// StagedOnly reports whether the file has staged changes, and no further
+// changes in the worktree.
+func (e ChangedEntry) StagedOnly() bool {
+	return e.XY.HasStaged() && !e.XY.HasUnstaged()
+}
+
+// UnstagedOnly reports whether the file has changes in the worktree, and none
+// staged.
+func (e ChangedEntry) UnstagedOnly() bool {
+	return !e.XY.HasStaged() && e.XY.HasUnstaged()
+}
 
 // RenameOrCopyEntry represents a renamed or copied file.
 //
@@ -194,12 +656,53 @@ type RenameOrCopyEntry struct {
 	ModeW FileMode        // file mode in worktree (unstaged)
 	HashH string          // object hash in HEAD commit
 	HashI string          // object hash in index (staged)
-	Score string          // similarity score (e.g. "R100", "C75")
+	Score Score           // similarity score (e.g. "R100", "C75")
 	Path  string          // new file path
 	Orig  string          // original file path
 }
 
-func (RenameOrCopyEntry) Type() EntryType { return EntryTypeRenameOrCopy }
+func (RenameOrCopyEntry) Type() EntryType     { return EntryTypeRenameOrCopy }
+func (e RenameOrCopyEntry) EntryPath() string { return e.Path }
+
+// Head returns the file mode and object name in HEAD of the original file.
+func (e RenameOrCopyEntry) Head() (FileMode, Hash) { return e.ModeH, Hash(e.HashH) }
+
+// Index returns the file mode and object name of the entry in the index.
+func (e RenameOrCopyEntry) Index() (FileMode, Hash) { return e.ModeI, Hash(e.HashI) }
+
+// A Score is the similarity score of a rename or copy, as git writes it: the
+// kind of the change, R or C, followed by the percentage of the content of the
+// file that is the same, such as "R100" or "C75".
+type Score string
+
+// Kind returns [Renamed] or [Copied], from the first character of sc, or zero
+// if sc starts with neither R nor C.
+func (sc Score) Kind() State {
+	if len(sc) == 0 || (sc[0] != 'R' && sc[0] != 'C') {
+		return 0
+	}
+	return State(sc[0])
+}
+
+// Percent returns the percentage of similarity of sc, from 0 to 100, or -1 if
+// sc is not a valid score.
+func (sc Score) Percent() int {
+	if !sc.IsValid() {
+		return -1
+	}
+	n, _ := strconv.Atoi(string(sc[1:]))
+	return n
+}
+
+// IsValid reports whether sc is a score as git writes it, with a kind of R or
+// C, and a percentage from 0 to 100 without leading zeros or a sign.
+func (sc Score) IsValid() bool {
+	if len(sc) < 2 || sc.Kind() == 0 {
+		return false
+	}
+	n, err := strconv.Atoi(string(sc[1:]))
+	return err == nil && 0 <= n && n <= 100 && string(sc[1:]) == strconv.Itoa(n)
+}
 
 // UnmergedEntry represents a file with merge conflicts.
 //
@@ -218,7 +721,37 @@ type UnmergedEntry struct {
 	Path  string          // file path relative to repository root
 }
 
-func (UnmergedEntry) Type() EntryType { return EntryTypeUnmerged }
+func (UnmergedEntry) Type() EntryType     { return EntryTypeUnmerged }
+func (e UnmergedEntry) EntryPath() string { return e.Path }
+
+// Stage returns the file mode and object name of the entry in stage n of the
+// index: 1 for the common base, 2 for ours and 3 for theirs. A stage missing
+// from the index, such as the base of a file added on both sides, has
+// [FileModeEmpty] and the zero object name. For any other n, Stage returns
+// [FileModeEmpty] and the empty string.
+func (e UnmergedEntry) Stage(n int) (FileMode, Hash) {
+	switch n {
+	case 1:
+		return e.Mode1, Hash(e.Hash1)
+	case 2:
+		return e.Mode2, Hash(e.Hash2)
+	case 3:
+		return e.Mode3, Hash(e.Hash3)
+	}
+	return FileModeEmpty, ""
+}
+
+// Base returns the file mode and object name of the entry in the common
+// ancestor, stage 1.
+func (e UnmergedEntry) Base() (FileMode, Hash) { return e.Stage(1) }
+
+// Ours returns the file mode and object name of the entry on the current
+// branch, stage 2.
+func (e UnmergedEntry) Ours() (FileMode, Hash) { return e.Stage(2) }
+
+// Theirs returns the file mode and object name of the entry on the branch
+// being merged, stage 3.
+func (e UnmergedEntry) Theirs() (FileMode, Hash) { return e.Stage(3) }
 
 // UntrackedEntry represents an untracked file.
 //
@@ -227,7 +760,8 @@ type UntrackedEntry struct {
 	Path string // file path relative to repository root
 }
 
-func (UntrackedEntry) Type() EntryType { return EntryTypeUntracked }
+func (UntrackedEntry) Type() EntryType     { return EntryTypeUntracked }
+func (e UntrackedEntry) EntryPath() string { return e.Path }
 
 // IgnoredEntry represents an ignored file.
 //
@@ -236,4 +770,5 @@ type IgnoredEntry struct {
 	Path string // file path relative to repository root
 }
 
-func (IgnoredEntry) Type() EntryType { return EntryTypeIgnored }
+func (IgnoredEntry) Type() EntryType     { return EntryTypeIgnored }
+func (e IgnoredEntry) EntryPath() string { return e.Path }
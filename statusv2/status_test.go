@@ -2,7 +2,12 @@ package statusv2
 
 import (
 	"encoding"
+	"encoding/json"
+	"io/fs"
+	"slices"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestXYFlag_Accessors(t *testing.T) {
@@ -43,6 +48,48 @@ func TestXYFlag_String(t *testing.T) {
 	}
 }
 
+func TestXYFlag_Predicates(t *testing.T) {
+	testcases := []struct {
+		xy                         string
+		staged, unstaged, conflict bool
+	}{
+		{"..", false, false, false},
+		{"M.", true, false, false},
+		{".M", false, true, false},
+		{"MM", true, true, false},
+		{"AD", true, true, false},
+		{"R.", true, false, false},
+		{".T", false, true, false},
+		{"DD", false, false, true},
+		{"AU", false, false, true},
+		{"UD", false, false, true},
+		{"UA", false, false, true},
+		{"DU", false, false, true},
+		{"AA", false, false, true},
+		{"UU", false, false, true},
+		{"??", false, false, false},
+		{"!!", false, false, false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.xy, func(t *testing.T) {
+			xy := XYFlag{State(tc.xy[0]), State(tc.xy[1])}
+			if got := xy.HasStaged(); got != tc.staged {
+				t.Errorf("HasStaged() = %v, want %v", got, tc.staged)
+			}
+			if got := xy.HasUnstaged(); got != tc.unstaged {
+				t.Errorf("HasUnstaged() = %v, want %v", got, tc.unstaged)
+			}
+			if got := xy.IsConflict(); got != tc.conflict {
+				t.Errorf("IsConflict() = %v, want %v", got, tc.conflict)
+			}
+			if got, want := xy.IsConflict(), (UnmergedEntry{XY: xy}).Kind() != ConflictUnknown; got != want {
+				t.Errorf("IsConflict() = %v, but Kind() gives %v", got, want)
+			}
+		})
+	}
+}
+
 func TestXYFlag_MarshalUnmarshalText(t *testing.T) {
 	// enforce interface compliance
 	var _ encoding.TextMarshaler = (*XYFlag)(nil)
@@ -83,6 +130,28 @@ func TestXYFlag_MarshalUnmarshalText(t *testing.T) {
 	}
 }
 
+func TestXYFlag_JSON(t *testing.T) {
+	entry := struct{ XY XYFlag }{XYFlag{Modified, Unmodified}}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"XY":"M."}`; string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+
+	var got struct{ XY XYFlag }
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got != entry {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", got, entry)
+	}
+	if err := json.Unmarshal([]byte(`{"XY":[77,46]}`), &got); err == nil {
+		t.Error("json.Unmarshal() of an array should error")
+	}
+}
+
 func TestFileMode_String(t *testing.T) {
 	testcases := []struct {
 		name     string
@@ -111,6 +180,220 @@ func TestFileMode_String(t *testing.T) {
 	}
 }
 
+func TestBranchInfo_Accessors(t *testing.T) {
+	const oid = "ce013625030ba8dba906f756967f9e9ca394464a"
+	tests := []struct {
+		name         string
+		branch       *BranchInfo
+		wantCommit   Hash
+		wantOK       bool
+		wantUnborn   bool
+		wantDetached bool
+		wantUpstream bool
+	}{
+		{"nil", nil, "", false, false, false, false},
+		{"on branch", &BranchInfo{OID: oid, Head: "main"}, oid, true, false, false, false},
+		{"upstream", &BranchInfo{OID: oid, Head: "main", Upstream: "origin/main", Ahead: 1}, oid, true, false, false, true},
+		{"upstream gone", &BranchInfo{OID: oid, Head: "main", Upstream: "origin/gone"}, oid, true, false, false, true},
+		{"unborn", &BranchInfo{OID: InitialOID, Head: "main"}, "", false, true, false, false},
+		{"detached", &BranchInfo{OID: oid, Head: DetachedHead}, oid, true, false, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commit, ok := tt.branch.Commit()
+			if commit != tt.wantCommit || ok != tt.wantOK {
+				t.Errorf("Commit() = (%q, %v), want (%q, %v)", commit, ok, tt.wantCommit, tt.wantOK)
+			}
+			if got := tt.branch.IsUnborn(); got != tt.wantUnborn {
+				t.Errorf("IsUnborn() = %v, want %v", got, tt.wantUnborn)
+			}
+			if got := tt.branch.IsInitial(); got != tt.wantUnborn {
+				t.Errorf("IsInitial() = %v, want %v", got, tt.wantUnborn)
+			}
+			if got := tt.branch.IsDetached(); got != tt.wantDetached {
+				t.Errorf("IsDetached() = %v, want %v", got, tt.wantDetached)
+			}
+			if got := tt.branch.HasUpstream(); got != tt.wantUpstream {
+				t.Errorf("HasUpstream() = %v, want %v", got, tt.wantUpstream)
+			}
+		})
+	}
+}
+
+func TestHash_Algorithm(t *testing.T) {
+	tests := []struct {
+		hash Hash
+		want HashAlgorithm
+	}{
+		{"ce013625030ba8dba906f756967f9e9ca394464a", SHA1},
+		{"0000000000000000000000000000000000000000", SHA1},
+		{"346edf8aacb1e350092123c39b5cf717d979a6883bbaf5ed54c0ae221d84de55", SHA256},
+		{"", HashUnknown},
+		{"ce01362", HashUnknown},
+		{"CE013625030BA8DBA906F756967F9E9CA394464A", HashUnknown},
+		{"ce013625030ba8dba906f756967f9e9ca394464g", HashUnknown},
+		{"ce013625030ba8dba906f756967f9e9ca394464a00", HashUnknown},
+	}
+	for _, tt := range tests {
+		if got := tt.hash.Algorithm(); got != tt.want {
+			t.Errorf("Hash(%q).Algorithm() = %v, want %v", tt.hash, got, tt.want)
+		}
+	}
+}
+
+func TestHash_IsZero(t *testing.T) {
+	for _, a := range []HashAlgorithm{SHA1, SHA256} {
+		zero := ZeroHash(a)
+		if !zero.IsZero() || zero.Algorithm() != a {
+			t.Errorf("ZeroHash(%v) = %q, want a zero %v hash", a, zero, a)
+		}
+	}
+	for _, h := range []Hash{"", "0000", "ce013625030ba8dba906f756967f9e9ca394464a"} {
+		if h.IsZero() {
+			t.Errorf("Hash(%q).IsZero() = true", h)
+		}
+	}
+	if got := ZeroHash(HashUnknown); got != "" {
+		t.Errorf("ZeroHash(HashUnknown) = %q, want empty", got)
+	}
+}
+
+func TestSubmoduleStatus_MarshalUnmarshalText(t *testing.T) {
+	// enforce interface compliance
+	var _ encoding.TextMarshaler = (*SubmoduleStatus)(nil)
+	var _ encoding.TextUnmarshaler = (*SubmoduleStatus)(nil)
+
+	tests := []struct {
+		sub    SubmoduleStatus
+		expect string
+	}{
+		{SubmoduleStatus{}, "N..."},
+		{SubmoduleStatus{IsSubmodule: true}, "S..."},
+		{SubmoduleStatus{IsSubmodule: true, CommitChanged: true, HasUntracked: true}, "SC.U"},
+		{SubmoduleStatus{IsSubmodule: true, CommitChanged: true, HasModifications: true, HasUntracked: true}, "SCMU"},
+	}
+
+	for _, tc := range tests {
+		b, err := tc.sub.MarshalText()
+		if err != nil {
+			t.Errorf("MarshalText() error = %v", err)
+		}
+		if string(b) != tc.expect {
+			t.Errorf("MarshalText() = %q, want %q", b, tc.expect)
+		}
+
+		var sub SubmoduleStatus
+		err = sub.UnmarshalText([]byte(tc.expect))
+		if err != nil {
+			t.Errorf("UnmarshalText() error = %v", err)
+		}
+		if sub != tc.sub {
+			t.Errorf("UnmarshalText() = %+v, want %+v", sub, tc.sub)
+		}
+	}
+
+	// Test error cases for UnmarshalText, for fields String never returns
+	for _, input := range []string{"", "N..", "N.M.", "X...", "SMCU", "S...."} {
+		var sub SubmoduleStatus
+		if err := sub.UnmarshalText([]byte(input)); err == nil {
+			t.Errorf("UnmarshalText(%q) should error", input)
+		}
+	}
+}
+
+func TestChangedEntry_Predicates(t *testing.T) {
+	type predicates struct {
+		NewFile, DeletedFromIndex, DeletedFromWorktree, TypeChange, StagedOnly, UnstagedOnly bool
+	}
+	tests := []struct {
+		xy   string
+		want predicates
+	}{
+		{"A.", predicates{NewFile: true, StagedOnly: true}},
+		{"AM", predicates{NewFile: true}},
+		{"AD", predicates{NewFile: true, DeletedFromWorktree: true}},
+		{"D.", predicates{DeletedFromIndex: true, StagedOnly: true}},
+		{".D", predicates{DeletedFromWorktree: true, UnstagedOnly: true}},
+		{"M.", predicates{StagedOnly: true}},
+		{".M", predicates{UnstagedOnly: true}},
+		{"MM", predicates{}},
+		{"T.", predicates{TypeChange: true, StagedOnly: true}},
+		{".T", predicates{TypeChange: true, UnstagedOnly: true}},
+		{"MT", predicates{TypeChange: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.xy, func(t *testing.T) {
+			e := ChangedEntry{XY: XYFlag{State(tt.xy[0]), State(tt.xy[1])}}
+			got := predicates{
+				NewFile:             e.IsNewFile(),
+				DeletedFromIndex:    e.IsDeletedFromIndex(),
+				DeletedFromWorktree: e.IsDeletedFromWorktree(),
+				TypeChange:          e.IsTypeChange(),
+				StagedOnly:          e.StagedOnly(),
+				UnstagedOnly:        e.UnstagedOnly(),
+			}
+			if got != tt.want {
+				t.Errorf("predicates = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntry_HeadIndex(t *testing.T) {
+	const hashH, hashI = "ce013625030ba8dba906f756967f9e9ca394464a", "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"
+	tests := []struct {
+		name  string
+		head  func() (FileMode, Hash)
+		index func() (FileMode, Hash)
+	}{
+		{"ChangedEntry",
+			ChangedEntry{ModeH: FileModeRegular, ModeI: FileModeExecutable, HashH: hashH, HashI: hashI}.Head,
+			ChangedEntry{ModeH: FileModeRegular, ModeI: FileModeExecutable, HashH: hashH, HashI: hashI}.Index},
+		{"RenameOrCopyEntry",
+			RenameOrCopyEntry{ModeH: FileModeRegular, ModeI: FileModeExecutable, HashH: hashH, HashI: hashI}.Head,
+			RenameOrCopyEntry{ModeH: FileModeRegular, ModeI: FileModeExecutable, HashH: hashH, HashI: hashI}.Index},
+	}
+	for _, tt := range tests {
+		if mode, hash := tt.head(); mode != FileModeRegular || hash != hashH {
+			t.Errorf("%s.Head() = (%v, %q), want (%v, %q)", tt.name, mode, hash, FileModeRegular, hashH)
+		}
+		if mode, hash := tt.index(); mode != FileModeExecutable || hash != hashI {
+			t.Errorf("%s.Index() = (%v, %q), want (%v, %q)", tt.name, mode, hash, FileModeExecutable, hashI)
+		}
+	}
+}
+
+func TestUnmergedEntry_Stage(t *testing.T) {
+	e := UnmergedEntry{
+		XY:    XYFlag{Added, UpdatedUnmerged},
+		Mode1: FileModeEmpty, Mode2: FileModeRegular, Mode3: FileModeExecutable, ModeW: FileModeRegular,
+		Hash1: "0000000000000000000000000000000000000000",
+		Hash2: "ce013625030ba8dba906f756967f9e9ca394464a",
+		Hash3: "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391",
+		Path:  "added-by-us.txt",
+	}
+	tests := []struct {
+		name     string
+		stage    func() (FileMode, Hash)
+		wantMode FileMode
+		wantHash Hash
+	}{
+		{"Base", e.Base, FileModeEmpty, Hash(e.Hash1)},
+		{"Ours", e.Ours, FileModeRegular, Hash(e.Hash2)},
+		{"Theirs", e.Theirs, FileModeExecutable, Hash(e.Hash3)},
+		{"Stage(1)", func() (FileMode, Hash) { return e.Stage(1) }, FileModeEmpty, Hash(e.Hash1)},
+		{"Stage(2)", func() (FileMode, Hash) { return e.Stage(2) }, FileModeRegular, Hash(e.Hash2)},
+		{"Stage(3)", func() (FileMode, Hash) { return e.Stage(3) }, FileModeExecutable, Hash(e.Hash3)},
+		{"Stage(0)", func() (FileMode, Hash) { return e.Stage(0) }, FileModeEmpty, ""},
+		{"Stage(4)", func() (FileMode, Hash) { return e.Stage(4) }, FileModeEmpty, ""},
+	}
+	for _, tt := range tests {
+		if mode, hash := tt.stage(); mode != tt.wantMode || hash != tt.wantHash {
+			t.Errorf("%s = (%v, %q), want (%v, %q)", tt.name, mode, hash, tt.wantMode, tt.wantHash)
+		}
+	}
+}
+
 func TestSubmoduleStatus_String(t *testing.T) {
 	testcases := []struct {
 		name     string
@@ -193,3 +476,250 @@ func TestEntry_Type(t *testing.T) {
 		}
 	}
 }
+
+func TestStatus_Equal(t *testing.T) {
+	base := func() *Status {
+		return &Status{
+			Branch:  &BranchInfo{OID: "abc", Head: "main"},
+			Stash:   &StashInfo{Count: 1},
+			Entries: []Entry{UntrackedEntry{Path: "a"}, ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "b"}},
+		}
+	}
+	tests := []struct {
+		name   string
+		a, b   *Status
+		expect bool
+	}{
+		{"identical", base(), base(), true},
+		{"both nil", nil, nil, true},
+		{"one nil", base(), nil, false},
+		{"empty", &Status{}, &Status{}, true},
+		{"nil and empty entries", &Status{}, &Status{Entries: []Entry{}}, true},
+		{"branch", base(), func() *Status { s := base(); s.Branch.Ahead = 1; return s }(), false},
+		{"no branch", base(), func() *Status { s := base(); s.Branch = nil; return s }(), false},
+		{"stash", base(), func() *Status { s := base(); s.Stash = nil; return s }(), false},
+		{"entry", base(), func() *Status { s := base(); s.Entries[0] = IgnoredEntry{Path: "a"}; return s }(), false},
+		{"order", base(), func() *Status { s := base(); slices.Reverse(s.Entries); return s }(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.expect {
+				t.Errorf("a.Equal(b) = %v, want %v", got, tt.expect)
+			}
+			if got := tt.b.Equal(tt.a); got != tt.expect {
+				t.Errorf("b.Equal(a) = %v, want %v", got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestStatus_XYMap(t *testing.T) {
+	s := &Status{Entries: []Entry{
+		ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "m.txt"},
+		RenameOrCopyEntry{XY: XYFlag{Renamed, Unmodified}, Path: "new.txt", Orig: "old.txt"},
+		RenameOrCopyEntry{XY: XYFlag{Copied, Unmodified}, Path: "copy.txt", Orig: "m.txt"},
+		UnmergedEntry{XY: XYFlag{UpdatedUnmerged, UpdatedUnmerged}, Path: "u.txt"},
+		UntrackedEntry{Path: "dir/"},
+		IgnoredEntry{Path: "debug.log"},
+	}}
+	want := map[string]XYFlag{
+		"m.txt":     {Unmodified, Modified},
+		"new.txt":   {Renamed, Unmodified},
+		"old.txt":   {Renamed, Unmodified},
+		"copy.txt":  {Copied, Unmodified},
+		"u.txt":     {UpdatedUnmerged, UpdatedUnmerged},
+		"dir/":      UntrackedXY,
+		"debug.log": IgnoredXY,
+	}
+	if diff := cmp.Diff(want, s.XYMap()); diff != "" {
+		t.Errorf("XYMap() mismatch (-want +got):\n%s", diff)
+	}
+	if got := (*Status)(nil).XYMap(); got != nil {
+		t.Errorf("nil XYMap() = %v, want nil", got)
+	}
+}
+
+func TestStatus_DirtyState(t *testing.T) {
+	tests := []struct {
+		name                    string
+		entries                 []Entry
+		clean, staged, unstaged bool
+	}{
+		{"empty", nil, true, false, false},
+		{"ignored only", []Entry{IgnoredEntry{Path: "debug.log"}}, true, false, false},
+		{"staged", []Entry{ChangedEntry{XY: XYFlag{Modified, Unmodified}}}, false, true, false},
+		{"unstaged", []Entry{ChangedEntry{XY: XYFlag{Unmodified, Deleted}}}, false, false, true},
+		{"both", []Entry{ChangedEntry{XY: XYFlag{Added, Modified}}}, false, true, true},
+		{"renamed", []Entry{RenameOrCopyEntry{XY: XYFlag{Renamed, Unmodified}}}, false, true, false},
+		{"unmerged", []Entry{UnmergedEntry{XY: XYFlag{UpdatedUnmerged, UpdatedUnmerged}}}, false, false, false},
+		{"untracked", []Entry{UntrackedEntry{Path: "new.txt"}}, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Status{Entries: tt.entries}
+			if got := s.IsClean(); got != tt.clean {
+				t.Errorf("IsClean() = %v, want %v", got, tt.clean)
+			}
+			if got := s.HasStagedChanges(); got != tt.staged {
+				t.Errorf("HasStagedChanges() = %v, want %v", got, tt.staged)
+			}
+			if got := s.HasUnstagedChanges(); got != tt.unstaged {
+				t.Errorf("HasUnstagedChanges() = %v, want %v", got, tt.unstaged)
+			}
+		})
+	}
+
+	var s *Status
+	if !s.IsClean() || s.HasStagedChanges() || s.HasUnstagedChanges() {
+		t.Error("nil status is not clean")
+	}
+}
+
+func TestEntry_EntryPath(t *testing.T) {
+	entries := []Entry{
+		ChangedEntry{Path: "changed.txt"},
+		RenameOrCopyEntry{Path: "new.txt", Orig: "old.txt"},
+		UnmergedEntry{Path: "conflict.txt"},
+		UntrackedEntry{Path: "untracked.txt"},
+		IgnoredEntry{Path: "ignored/"},
+	}
+	want := []string{"changed.txt", "new.txt", "conflict.txt", "untracked.txt", "ignored/"}
+	for i, e := range entries {
+		if got := e.EntryPath(); got != want[i] {
+			t.Errorf("%T.EntryPath() = %q, want %q", e, got, want[i])
+		}
+	}
+}
+
+func TestEntryType_Text(t *testing.T) {
+	names := map[EntryType]string{
+		EntryTypeChanged:      "changed",
+		EntryTypeRenameOrCopy: "rename_or_copy",
+		EntryTypeUnmerged:     "unmerged",
+		EntryTypeUntracked:    "untracked",
+		EntryTypeIgnored:      "ignored",
+	}
+	for typ, name := range names {
+		if got := typ.String(); got != name {
+			t.Errorf("%d.String() = %q, want %q", int(typ), got, name)
+		}
+		data, err := json.Marshal(typ)
+		if err != nil || string(data) != `"`+name+`"` {
+			t.Errorf("Marshal(%v) = %s, %v, want %q", typ, data, err, name)
+		}
+		var got EntryType
+		if err := json.Unmarshal(data, &got); err != nil || got != typ {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", data, got, err, typ)
+		}
+	}
+
+	if got := EntryType(9).String(); got != "EntryType(9)" {
+		t.Errorf("String() = %q, want %q", got, "EntryType(9)")
+	}
+	if _, err := json.Marshal(EntryType(9)); err == nil {
+		t.Error("Marshal(EntryType(9)) error = nil, want error")
+	}
+	var typ EntryType
+	if err := typ.UnmarshalText([]byte("modified")); err == nil {
+		t.Error(`UnmarshalText("modified") error = nil, want error`)
+	}
+}
+
+func TestState_Description(t *testing.T) {
+	tests := []struct {
+		state State
+		want  string
+		valid bool
+	}{
+		{Unmodified, "unmodified", true},
+		{Modified, "modified", true},
+		{TypeChanged, "type changed", true},
+		{Added, "added", true},
+		{Deleted, "deleted", true},
+		{Renamed, "renamed", true},
+		{Copied, "copied", true},
+		{UpdatedUnmerged, "updated but unmerged", true},
+		{UntrackedXY.X, "untracked", false},
+		{IgnoredXY.Y, "ignored", false},
+		{' ', "unknown", false},
+		{'X', "unknown", false},
+	}
+	for _, tt := range tests {
+		if got := tt.state.Description(); got != tt.want {
+			t.Errorf("State(%q).Description() = %q, want %q", tt.state, got, tt.want)
+		}
+		if got := tt.state.IsValid(); got != tt.valid {
+			t.Errorf("State(%q).IsValid() = %v, want %v", tt.state, got, tt.valid)
+		}
+		if got := tt.state.String(); got != string(tt.state) {
+			t.Errorf("State(%q).String() = %q, want %q", tt.state, got, string(tt.state))
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	testcases := []struct {
+		score   Score
+		kind    State
+		percent int
+		valid   bool
+	}{
+		{"R100", Renamed, 100, true},
+		{"C75", Copied, 75, true},
+		{"R0", Renamed, 0, true},
+		{"R", Renamed, -1, false},
+		{"R101", Renamed, -1, false},
+		{"R050", Renamed, -1, false},
+		{"C+5", Copied, -1, false},
+		{"M90", 0, -1, false},
+		{"", 0, -1, false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(string(tc.score), func(t *testing.T) {
+			if got := tc.score.Kind(); got != tc.kind {
+				t.Errorf("Kind() = %q, want %q", got, tc.kind)
+			}
+			if got := tc.score.Percent(); got != tc.percent {
+				t.Errorf("Percent() = %d, want %d", got, tc.percent)
+			}
+			if got := tc.score.IsValid(); got != tc.valid {
+				t.Errorf("IsValid() = %v, want %v", got, tc.valid)
+			}
+		})
+	}
+}
+
+func TestFileMode_Kinds(t *testing.T) {
+	testcases := []struct {
+		mode                                   FileMode
+		regular, exec, symlink, submodule, dir bool
+		os                                     fs.FileMode
+	}{
+		{FileModeEmpty, false, false, false, false, false, 0},
+		{FileModeRegular, true, false, false, false, false, 0644},
+		{0100664, true, false, false, false, false, 0644},
+		{FileModeExecutable, true, true, false, false, false, 0755},
+		{FileModeSymlink, false, false, true, false, false, fs.ModeSymlink | 0777},
+		{FileModeSubmodule, false, false, false, true, false, fs.ModeDir | 0755},
+		{FileModeDir, false, false, false, false, true, fs.ModeDir | 0755},
+		{0170000, false, false, false, false, false, 0},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.mode.String(), func(t *testing.T) {
+			m := tc.mode
+			got := []bool{m.IsRegular(), m.IsExecutable(), m.IsSymlink(), m.IsSubmodule(), m.IsDir()}
+			want := []bool{tc.regular, tc.exec, tc.symlink, tc.submodule, tc.dir}
+			if !slices.Equal(got, want) {
+				t.Errorf("IsRegular, IsExecutable, IsSymlink, IsSubmodule, IsDir = %v, want %v", got, want)
+			}
+			if got := m.ToOSFileMode(); got != tc.os {
+				t.Errorf("ToOSFileMode() = %v, want %v", got, tc.os)
+			}
+			if got := m.ToOSFileMode(); got != 0 && got.IsRegular() != m.IsRegular() {
+				t.Errorf("ToOSFileMode().IsRegular() = %v, want %v", got.IsRegular(), m.IsRegular())
+			}
+		})
+	}
+}
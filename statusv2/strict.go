@@ -0,0 +1,183 @@
+package statusv2
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/mroth/porcelain/parseerr"
+)
+
+// Strict causes the Decoder to reject input that git itself would never
+// produce, rather than skipping or accepting it. In strict mode, Next returns
+// an error for:
+//
+//   - lines with an unknown prefix, and empty lines
+//   - malformed branch.* and stash headers, and headers after the first entry
+//   - XY flags with unknown states, or states invalid for the entry type
+//   - malformed submodule fields, unknown file modes and non-hex object names
+//   - malformed rename or copy scores, and empty paths
+//
+// Unknown header keys are still ignored, as the format reserves the right to
+// add them. After an error, Next may be called again to continue with the
+// following line, as when reporting every violation in an input.
+func (d *Decoder) Strict() {
+	d.strict = true
+}
+
+// Line returns the number of lines (or NUL-terminated records, for
+// [NewDecoderZ]) read so far, which is the line number of the entry or error
+// most recently returned by [Decoder.Next].
+func (d *Decoder) Line() int {
+	return d.line
+}
+
+// validateHeader checks a header line in strict mode.
+func validateHeader(line []byte) error {
+	rest, ok := bytes.CutPrefix(line, []byte("# "))
+	if !ok {
+		return parseerr.New(parseerr.InvalidHeader, "line", line)
+	}
+	key, value, found := bytes.Cut(rest, []byte{' '})
+	if !found || len(value) == 0 {
+		return parseerr.New(parseerr.InvalidHeader, "line", line)
+	}
+
+	switch string(key) {
+	case "branch.oid":
+		if string(value) != InitialOID && !isObjectName(value) {
+			return parseerr.New(parseerr.InvalidHeaderValue, "key", "branch.oid", "value", value)
+		}
+	case "branch.ab":
+		var ahead, behind int
+		n, err := fmt.Sscanf(string(value), "+%d -%d", &ahead, &behind)
+		if err != nil || n != 2 || ahead < 0 || behind < 0 ||
+			string(value) != fmt.Sprintf("+%d -%d", ahead, behind) {
+			return parseerr.New(parseerr.InvalidHeaderValue, "key", "branch.ab", "value", value)
+		}
+	case "stash":
+		n, err := strconv.Atoi(string(value))
+		if err != nil || n < 0 {
+			return parseerr.New(parseerr.InvalidHeaderValue, "key", "stash", "value", value)
+		}
+	}
+	return nil
+}
+
+// validateEntry checks a parsed entry in strict mode.
+func validateEntry(e Entry) error {
+	switch e := e.(type) {
+	case ChangedEntry:
+		return firstError(
+			validateXY(e.XY),
+			validateSub(e.Sub),
+			validateModes(e.ModeH, e.ModeI, e.ModeW),
+			validateObjectNames(e.HashH, e.HashI),
+			validatePath(e.Path),
+		)
+	case RenameOrCopyEntry:
+		return firstError(
+			validateXY(e.XY),
+			validateSub(e.Sub),
+			validateModes(e.ModeH, e.ModeI, e.ModeW),
+			validateObjectNames(e.HashH, e.HashI),
+			validateScore(e.Score),
+			validatePath(e.Path),
+			validatePath(e.Orig),
+		)
+	case UnmergedEntry:
+		return firstError(
+			validateUnmergedXY(e.XY),
+			validateSub(e.Sub),
+			validateModes(e.Mode1, e.Mode2, e.Mode3, e.ModeW),
+			validateObjectNames(e.Hash1, e.Hash2, e.Hash3),
+			validatePath(e.Path),
+		)
+	case UntrackedEntry:
+		return validatePath(e.Path)
+	case IgnoredEntry:
+		return validatePath(e.Path)
+	}
+	return nil
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateXY(xy XYFlag) error {
+	if !xy.X.IsValid() || !xy.Y.IsValid() {
+		return parseerr.New(parseerr.InvalidXY, "xy", xy.String())
+	}
+	if xy.X == Unmodified && xy.Y == Unmodified {
+		return parseerr.New(parseerr.InvalidXYForEntry, "kind", "changed", "xy", xy.String())
+	}
+	return nil
+}
+
+// validateUnmergedXY checks for the seven combinations git uses for conflicts.
+func validateUnmergedXY(xy XYFlag) error {
+	if xy.IsConflict() {
+		return nil
+	}
+	return parseerr.New(parseerr.InvalidXYForEntry, "kind", "unmerged", "xy", xy.String())
+}
+
+func validateSub(s SubmoduleStatus) error {
+	if !s.IsSubmodule && (s.CommitChanged || s.HasModifications || s.HasUntracked) {
+		return parseerr.New(parseerr.InvalidSubmodule, "field", s.String())
+	}
+	return nil
+}
+
+func validateModes(modes ...FileMode) error {
+	for _, m := range modes {
+		switch m {
+		case FileModeEmpty, FileModeRegular, FileModeExecutable, FileModeSymlink, FileModeSubmodule:
+		default:
+			return parseerr.New(parseerr.InvalidFileMode, "mode", m.String())
+		}
+	}
+	return nil
+}
+
+func validateObjectNames(names ...string) error {
+	for _, name := range names {
+		if !isObjectName(name) {
+			return parseerr.New(parseerr.InvalidObjectName, "name", string(name))
+		}
+	}
+	return nil
+}
+
+// isObjectName reports whether b is a full SHA-1 or SHA-256 object name.
+func isObjectName[T ~string | ~[]byte](b T) bool {
+	if len(b) != 40 && len(b) != 64 {
+		return false
+	}
+	for i := range len(b) {
+		if c := b[i]; !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func validateScore(score Score) error {
+	if !score.IsValid() {
+		return parseerr.New(parseerr.InvalidScore, "score", string(score))
+	}
+	return nil
+}
+
+func validatePath(path string) error {
+	if path == "" {
+		return parseerr.New(parseerr.EmptyPath)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package statusv2
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mroth/porcelain/corpus"
+)
+
+func TestDecoder_Strict(t *testing.T) {
+	// everything recorded from git must pass strict mode
+	cases := append(corpusCases(t), corpus.Case{Scenario: "sample", Format: "v2", Input: samplePorcelainV2Output})
+	for _, c := range cases {
+		t.Run(c.Name(), func(t *testing.T) {
+			d := NewDecoder(bytes.NewReader(c.Input))
+			if c.Format == "v2z" {
+				d = NewDecoderZ(bytes.NewReader(c.Input))
+			}
+			d.Strict()
+			for {
+				_, err := d.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("line %d: Next() error = %v", d.Line(), err)
+				}
+			}
+		})
+	}
+}
+
+func TestDecoder_StrictViolations(t *testing.T) {
+	const (
+		hash = "ce013625030ba8dba906f756967f9e9ca394464a"
+		ok   = "1 .M N... 100644 100644 100644 " + hash + " " + hash + " ok.txt"
+	)
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"unknown prefix", "x foo"},
+		{"empty line", ""},
+		{"malformed header", "#branch.oid " + hash},
+		{"branch.oid", "# branch.oid abc"},
+		{"branch.ab", "# branch.ab 1 2"},
+		{"stash", "# stash -1"},
+		{"XY state", "1 .Z N... 100644 100644 100644 " + hash + " " + hash + " a.txt"},
+		{"XY unmodified", "1 .. N... 100644 100644 100644 " + hash + " " + hash + " a.txt"},
+		{"submodule", "1 .M N.M. 100644 100644 100644 " + hash + " " + hash + " a.txt"},
+		{"file mode", "1 .M N... 100600 100644 100644 " + hash + " " + hash + " a.txt"},
+		{"object name", "1 .M N... 100644 100644 100644 abc " + hash + " a.txt"},
+		{"score", "2 R. N... 100644 100644 100644 " + hash + " " + hash + " R101 a.txt\tb.txt"},
+		{"empty orig", "2 R. N... 100644 100644 100644 " + hash + " " + hash + " R100 a.txt\t"},
+		{"unmerged XY", "u MM N... 100644 100644 100644 100644 " + hash + " " + hash + " " + hash + " a.txt"},
+		{"empty path", "? "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := ok + "\n" + tt.line + "\n" + ok + "\n"
+			if strings.HasPrefix(tt.line, "#") {
+				input = tt.line + "\n" + ok + "\n" + ok + "\n"
+			}
+
+			// the lenient decoder accepts the input
+			if _, err := Parse(strings.NewReader(input)); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			d := NewDecoder(strings.NewReader(input))
+			d.Strict()
+			var errs int
+			for {
+				_, err := d.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					errs++
+					if line := d.Line(); line != 2 && !strings.HasPrefix(tt.line, "#") {
+						t.Errorf("Line() = %d, want 2", line)
+					}
+				}
+			}
+			if errs != 1 {
+				t.Errorf("got %d errors, want 1", errs)
+			}
+		})
+	}
+}
+
+func TestDecoder_StrictHeaderAfterEntries(t *testing.T) {
+	input := "? a.txt\n# stash 1\n"
+	d := NewDecoder(strings.NewReader(input))
+	d.Strict()
+	if _, err := d.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if _, err := d.Next(); err == nil {
+		t.Errorf("Next() error = nil, want header after entries error")
+	}
+}
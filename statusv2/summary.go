@@ -0,0 +1,60 @@
+package statusv2
+
+// Summary counts the files of a status in each state, the aggregation wanted
+// by prompts and dashboards. A file may be counted in several of the fields,
+// such as one both staged and unstaged, or staged as added.
+type Summary struct {
+	Staged     int // files with changes in the index
+	Unstaged   int // tracked files with changes in the worktree not staged
+	Untracked  int
+	Ignored    int
+	Conflicted int // unmerged files, which are counted in none of the fields above
+	Renamed    int // files renamed, in the index or the worktree; copies are not counted
+	Deleted    int // files deleted, in the index or the worktree
+	Added      int // files added to the index, or intended to be with git add -N
+}
+
+// Summary returns the counts of the files of s in each state, in a single pass
+// over its entries. Files are counted as by [Status.HasStagedChanges],
+// [Status.HasUnstagedChanges] and [Status.Conflicts]. It returns the zero
+// Summary for a nil s.
+func (s *Status) Summary() Summary {
+	var sum Summary
+	if s == nil {
+		return sum
+	}
+	for _, e := range s.Entries {
+		switch e := e.(type) {
+		case ChangedEntry:
+			sum.addXY(e.XY)
+		case RenameOrCopyEntry:
+			sum.addXY(e.XY)
+		case UnmergedEntry:
+			sum.Conflicted++
+		case UntrackedEntry:
+			sum.Untracked++
+		case IgnoredEntry:
+			sum.Ignored++
+		}
+	}
+	return sum
+}
+
+// addXY counts a changed, renamed or copied file with the flag xy.
+func (sum *Summary) addXY(xy XYFlag) {
+	if xy.HasStaged() {
+		sum.Staged++
+	}
+	if xy.HasUnstaged() {
+		sum.Unstaged++
+	}
+	if xy.X == Renamed || xy.Y == Renamed {
+		sum.Renamed++
+	}
+	if xy.X == Deleted || xy.Y == Deleted {
+		sum.Deleted++
+	}
+	if xy.X == Added || xy.Y == Added {
+		sum.Added++
+	}
+}
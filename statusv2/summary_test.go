@@ -0,0 +1,45 @@
+package statusv2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStatus_Summary(t *testing.T) {
+	const input = "# branch.oid 7cd2d7e2a3400e2463239d071c475c09ab410c2d\n" +
+		"# branch.head main\n" +
+		"1 M. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 8ab686eafeb1f44702738c8b0f24f2567c36da6d staged.txt\n" +
+		"1 MM N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 8ab686eafeb1f44702738c8b0f24f2567c36da6d both.txt\n" +
+		"1 A. N... 000000 100644 100644 0000000000000000000000000000000000000000 8ab686eafeb1f44702738c8b0f24f2567c36da6d added.txt\n" +
+		"1 .A N... 000000 000000 100644 0000000000000000000000000000000000000000 0000000000000000000000000000000000000000 intent.txt\n" +
+		"1 D. N... 100644 000000 000000 7cd2d7e2a3400e2463239d071c475c09ab410c2d 0000000000000000000000000000000000000000 removed.txt\n" +
+		"1 .D N... 100644 100644 000000 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d missing.txt\n" +
+		"2 R. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d R100 new.txt\told.txt\n" +
+		"2 C. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d C100 copy.txt\tnew.txt\n" +
+		"u UU N... 100644 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 8ab686eafeb1f44702738c8b0f24f2567c36da6d 7cd2d7e2a3400e2463239d071c475c09ab410c2d conflict.txt\n" +
+		"? new1.txt\n" +
+		"? new2.txt\n" +
+		"! build/\n"
+	s, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Summary{
+		Staged:     6,
+		Unstaged:   3,
+		Untracked:  2,
+		Ignored:    1,
+		Conflicted: 1,
+		Renamed:    1,
+		Deleted:    2,
+		Added:      2,
+	}
+	if got := s.Summary(); got != want {
+		t.Errorf("Summary() = %+v, want %+v", got, want)
+	}
+
+	var nilStatus *Status
+	if got := nilStatus.Summary(); got != (Summary{}) {
+		t.Errorf("Summary() of nil = %+v, want zero", got)
+	}
+}
@@ -0,0 +1,51 @@
+package statusv2_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mroth/porcelain/statusgen"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// synthetic returns synthetic output with n entries, in the -z format if z.
+func synthetic(b *testing.B, n int, z bool) []byte {
+	b.Helper()
+	g := statusgen.New(statusgen.Config{
+		Seed:           1,
+		RenameRatio:    0.05,
+		UnmergedRatio:  0.01,
+		UntrackedRatio: 0.2,
+		UnicodeRatio:   0.1,
+		Quote:          !z,
+	})
+	var buf bytes.Buffer
+	encode := statusv2.Encode
+	if z {
+		encode = statusv2.EncodeZ
+	}
+	if err := encode(&buf, g.V2Status(n)); err != nil {
+		b.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func BenchmarkParse_Synthetic10k(b *testing.B) {
+	data := synthetic(b, 10000, false)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		statusv2.Parse(bytes.NewReader(data))
+	}
+}
+
+func BenchmarkParseZ_Synthetic10k(b *testing.B) {
+	data := synthetic(b, 10000, true)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		statusv2.ParseZ(bytes.NewReader(data))
+	}
+}
@@ -0,0 +1,32 @@
+package statusv2
+
+import "io"
+
+// Tee makes d write the raw input it reads to w, as when archiving the exact
+// output of git alongside the parsed result for debugging or replay. Input is
+// written as it is read, which can be ahead of the entries returned by
+// [Decoder.Next], and all of it once Next has returned [io.EOF]. An error
+// writing to w is returned by Next.
+//
+// Tee must be called before the first call to Next.
+func (d *Decoder) Tee(w io.Writer) {
+	d.input.w = w
+}
+
+// teeReader reads from r, writing what it reads to w if set, as with
+// [io.TeeReader], except that input which could not be written is discarded,
+// so that a Decoder stops at once.
+type teeReader struct {
+	r io.Reader
+	w io.Writer
+}
+
+func (t *teeReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if n > 0 && t.w != nil {
+		if _, werr := t.w.Write(p[:n]); werr != nil {
+			return 0, werr
+		}
+	}
+	return n, err
+}
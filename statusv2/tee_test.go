@@ -0,0 +1,45 @@
+package statusv2
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDecoder_Tee(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		new   func(io.Reader) *Decoder
+		input []byte
+	}{
+		{"NewDecoder", NewDecoder, samplePorcelainV2Output},
+		{"NewDecoderZ", NewDecoderZ, samplePorcelainV2ZOutput},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var raw bytes.Buffer
+			d := tt.new(bytes.NewReader(tt.input))
+			d.Tee(&raw)
+			if _, err := d.Decode(); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !bytes.Equal(raw.Bytes(), tt.input) {
+				t.Errorf("Tee() wrote %q, want %q", raw.Bytes(), tt.input)
+			}
+		})
+	}
+}
+
+// errWriter fails every write with err.
+type errWriter struct{ err error }
+
+func (w errWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func TestDecoder_TeeError(t *testing.T) {
+	wantErr := errors.New("disk full")
+	d := NewDecoder(bytes.NewReader(samplePorcelainV2Output))
+	d.Tee(errWriter{wantErr})
+	if _, err := d.Next(); !errors.Is(err, wantErr) {
+		t.Errorf("Next() error = %v, want %v", err, wantErr)
+	}
+}
@@ -0,0 +1,240 @@
+package statusv2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// An EntryTransformer transforms entries as a [Decoder] returns them, such as
+// to rewrite their paths or to filter them out.
+type EntryTransformer interface {
+	// TransformEntry returns e transformed, or nil to drop it, in which case
+	// the Decoder continues with the next entry. An error is returned from
+	// [Decoder.Next].
+	TransformEntry(e Entry) (Entry, error)
+}
+
+// EntryTransformerFunc adapts a function to an [EntryTransformer].
+type EntryTransformerFunc func(e Entry) (Entry, error)
+
+// TransformEntry returns f(e).
+func (f EntryTransformerFunc) TransformEntry(e Entry) (Entry, error) {
+	return f(e)
+}
+
+// Transform adds transformers to those applied by [Decoder.Next] to each
+// entry, in the order they were added, after any validation of [Decoder.Strict].
+func (d *Decoder) Transform(t ...EntryTransformer) {
+	d.transformers = append(d.transformers, t...)
+}
+
+// transform applies the transformers of d to e, returning nil if one of them
+// dropped it.
+func (d *Decoder) transform(e Entry) (Entry, error) {
+	for _, t := range d.transformers {
+		var err error
+		if e, err = t.TransformEntry(e); err != nil || e == nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Transform applies transformers to the entries of s after parsing, as a
+// [Decoder] would have, removing the entries they drop. On error, s is
+// unchanged.
+func (s *Status) Transform(t ...EntryTransformer) error {
+	d := Decoder{transformers: t}
+	entries := make([]Entry, 0, len(s.Entries))
+	for _, e := range s.Entries {
+		e, err := d.transform(e)
+		if err != nil {
+			return err
+		}
+		if e != nil {
+			entries = append(entries, e)
+		}
+	}
+	s.Entries = entries
+	return nil
+}
+
+// Chain returns a transformer applying each of ts in turn, stopping when one
+// of them drops the entry.
+func Chain(ts ...EntryTransformer) EntryTransformer {
+	return EntryTransformerFunc(func(e Entry) (Entry, error) {
+		d := Decoder{transformers: ts}
+		return d.transform(e)
+	})
+}
+
+// Filter returns a transformer dropping entries for which keep returns false.
+func Filter(keep func(Entry) bool) EntryTransformer {
+	return EntryTransformerFunc(func(e Entry) (Entry, error) {
+		if !keep(e) {
+			return nil, nil
+		}
+		return e, nil
+	})
+}
+
+// RewritePaths returns a transformer replacing the paths of entries, including
+// the original paths of renames and copies, with the result of f, as when
+// adding or removing a prefix.
+func RewritePaths(f func(path string) string) EntryTransformer {
+	return EntryTransformerFunc(func(e Entry) (Entry, error) {
+		return mapPaths(e, func(p string) (string, error) { return f(p), nil })
+	})
+}
+
+// UnquotePaths returns a transformer unquoting the paths of entries that git
+// quoted for containing special characters, which it does without -z, such as
+// "\"caf\\303\\251.txt\"" for "café.txt". Other paths are left as they are.
+func UnquotePaths() EntryTransformer {
+	return EntryTransformerFunc(func(e Entry) (Entry, error) {
+		return mapPaths(e, unquotePath)
+	})
+}
+
+// unquotePath reverses the C-style quoting of paths by git.
+func unquotePath(p string) (string, error) {
+	if !strings.HasPrefix(p, `"`) {
+		return p, nil
+	}
+	s, ok := unquoteC(p)
+	if !ok {
+		return "", fmt.Errorf("invalid quoted path: %s", p)
+	}
+	return s, nil
+}
+
+// unquoteC unquotes the C-style quoted string q byte by byte, so that octal
+// escapes and raw bytes, as git writes with core.quotePath=false, are kept as
+// they are even if they are not valid UTF-8. It reports false if q is not
+// quoted as git quotes.
+func unquoteC(q string) (string, bool) {
+	if len(q) < 2 || q[0] != '"' || q[len(q)-1] != '"' {
+		return "", false
+	}
+	q = q[1 : len(q)-1]
+	b := make([]byte, 0, len(q))
+	for i := 0; i < len(q); i++ {
+		switch c := q[i]; c {
+		case '"':
+			return "", false
+		case '\\':
+			if i++; i == len(q) {
+				return "", false
+			}
+			c, n, ok := unescape(q[i:])
+			if !ok {
+				return "", false
+			}
+			b = append(b, c)
+			i += n - 1
+		default:
+			b = append(b, c)
+		}
+	}
+	return string(b), true
+}
+
+// unescape returns the byte of the escape sequence at the start of s, after a
+// backslash, and the length of the sequence. It reports false if s does not
+// start with one of the escapes git writes: \a \b \t \n \v \f \r \" \\ and
+// three octal digits.
+func unescape(s string) (byte, int, bool) {
+	switch s[0] {
+	case 'a':
+		return '\a', 1, true
+	case 'b':
+		return '\b', 1, true
+	case 't':
+		return '\t', 1, true
+	case 'n':
+		return '\n', 1, true
+	case 'v':
+		return '\v', 1, true
+	case 'f':
+		return '\f', 1, true
+	case 'r':
+		return '\r', 1, true
+	case '"', '\\':
+		return s[0], 1, true
+	}
+	if len(s) < 3 || s[0] < '0' || s[0] > '3' {
+		return 0, 0, false
+	}
+	var c byte
+	for _, d := range []byte(s[:3]) {
+		if d < '0' || d > '7' {
+			return 0, 0, false
+		}
+		c = c<<3 | (d - '0')
+	}
+	return c, 3, true
+}
+
+// mapPaths returns e with f applied to each of its paths.
+func mapPaths(e Entry, f func(string) (string, error)) (Entry, error) {
+	var err error
+	switch e := e.(type) {
+	case ChangedEntry:
+		e.Path, err = f(e.Path)
+		return e, err
+	case RenameOrCopyEntry:
+		if e.Path, err = f(e.Path); err != nil {
+			return e, err
+		}
+		e.Orig, err = f(e.Orig)
+		return e, err
+	case UnmergedEntry:
+		e.Path, err = f(e.Path)
+		return e, err
+	case UntrackedEntry:
+		e.Path, err = f(e.Path)
+		return e, err
+	case IgnoredEntry:
+		e.Path, err = f(e.Path)
+		return e, err
+	}
+	return e, nil
+}
+
+// IgnoreDirtySubmodules returns a transformer giving the results of
+// `git status --ignore-submodules=dirty`, for tools that only care about
+// changes relevant to the superproject. Modified or untracked files in a
+// submodule are disregarded: an entry for a submodule whose commit is
+// unchanged loses its worktree modification, and is dropped if it has no
+// changes staged either.
+func IgnoreDirtySubmodules() EntryTransformer {
+	return EntryTransformerFunc(func(e Entry) (Entry, error) {
+		switch e := e.(type) {
+		case ChangedEntry:
+			if !cleanSubmodule(&e.XY, &e.Sub) {
+				return nil, nil
+			}
+			return e, nil
+		case RenameOrCopyEntry:
+			if !cleanSubmodule(&e.XY, &e.Sub) {
+				return nil, nil
+			}
+			return e, nil
+		}
+		return e, nil
+	})
+}
+
+// cleanSubmodule clears the modified and untracked content of a submodule,
+// with the worktree modification of its XY flag if its commit is unchanged,
+// and reports whether the entry still has changes.
+func cleanSubmodule(xy *XYFlag, sub *SubmoduleStatus) bool {
+	if !sub.IsSubmodule || (!sub.HasModifications && !sub.HasUntracked) {
+		return true
+	}
+	sub.HasModifications, sub.HasUntracked = false, false
+	if !sub.CommitChanged {
+		xy.Y = Unmodified
+	}
+	return xy.X != Unmodified || xy.Y != Unmodified
+}
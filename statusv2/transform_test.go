@@ -0,0 +1,187 @@
+package statusv2
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// decodeAllTransformed returns the entries of input decoded with the
+// transformers ts.
+func decodeAllTransformed(t *testing.T, input string, ts ...EntryTransformer) ([]Entry, error) {
+	t.Helper()
+	d := NewDecoder(strings.NewReader(input))
+	d.Transform(ts...)
+	var entries []Entry
+	for {
+		e, err := d.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, e)
+	}
+}
+
+const transformInput = "# branch.head main\n" +
+	"2 R. N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d R100 \"new \\303\\251.txt\"\told.txt\n" +
+	"? \"tab\\there.txt\"\n" +
+	"? plain.txt\n" +
+	"! debug.log\n"
+
+func TestDecoder_Transform(t *testing.T) {
+	got, err := decodeAllTransformed(t, transformInput,
+		UnquotePaths(),
+		Filter(func(e Entry) bool { return e.Type() != EntryTypeIgnored }),
+		RewritePaths(func(p string) string { return "sub/" + p }),
+	)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	want := []Entry{
+		RenameOrCopyEntry{
+			XY:    XYFlag{Renamed, Unmodified},
+			ModeH: FileModeRegular, ModeI: FileModeRegular, ModeW: FileModeRegular,
+			HashH: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+			HashI: "7cd2d7e2a3400e2463239d071c475c09ab410c2d",
+			Score: "R100",
+			Path:  "sub/new é.txt",
+			Orig:  "sub/old.txt",
+		},
+		UntrackedEntry{Path: "sub/tab\there.txt"},
+		UntrackedEntry{Path: "sub/plain.txt"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("entries mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecoder_TransformError(t *testing.T) {
+	wantErr := errors.New("boom")
+	fail := EntryTransformerFunc(func(e Entry) (Entry, error) {
+		if e.(UntrackedEntry).Path == "b.txt" {
+			return nil, wantErr
+		}
+		return e, nil
+	})
+	got, err := decodeAllTransformed(t, "? a.txt\n? b.txt\n", fail)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Next() error = %v, want %v", err, wantErr)
+	}
+	if want := []Entry{UntrackedEntry{Path: "a.txt"}}; !cmp.Equal(want, got) {
+		t.Errorf("entries = %v, want %v", got, want)
+	}
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	record := func(name string, keep bool) EntryTransformer {
+		return EntryTransformerFunc(func(e Entry) (Entry, error) {
+			calls = append(calls, name)
+			if !keep {
+				return nil, nil
+			}
+			return e, nil
+		})
+	}
+	e, err := Chain(record("a", true), record("b", false), record("c", true)).TransformEntry(UntrackedEntry{Path: "x"})
+	if e != nil || err != nil {
+		t.Errorf("TransformEntry() = %v, %v, want nil, nil", e, err)
+	}
+	if want := []string{"a", "b"}; !cmp.Equal(want, calls) {
+		t.Errorf("transformers called = %q, want %q", calls, want)
+	}
+}
+
+func TestUnquotePaths(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "plain.txt", want: "plain.txt"},
+		{path: `"with \"quotes\".txt"`, want: `with "quotes".txt`},
+		{path: `"caf\303\251.txt"`, want: "café.txt"},
+		{path: `"back\\slash"`, want: `back\slash`},
+		{path: `"caf\351.txt"`, want: "caf\xe9.txt"},
+		{path: "\"caf\xe9 \\\"q\\\".txt\"", want: "caf\xe9 \"q\".txt"},
+		{path: `"\a\b\t\n\v\f\r\000\377"`, want: "\a\b\t\n\v\f\r\x00\xff"},
+		{path: `"unterminated`, wantErr: true},
+		{path: `"`, wantErr: true},
+		{path: `"trailing\"`, wantErr: true},
+		{path: `"bare"quote"`, wantErr: true},
+		{path: `"\x41"`, wantErr: true},
+		{path: `"\u00e9"`, wantErr: true},
+		{path: `"\400"`, wantErr: true},
+		{path: `"\38"`, wantErr: true},
+		{path: `"\1"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		e, err := UnquotePaths().TransformEntry(IgnoredEntry{Path: tt.path})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("TransformEntry(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			continue
+		}
+		if err == nil && e.(IgnoredEntry).Path != tt.want {
+			t.Errorf("TransformEntry(%q) path = %q, want %q", tt.path, e.(IgnoredEntry).Path, tt.want)
+		}
+	}
+}
+
+func TestStatus_Transform(t *testing.T) {
+	s := &Status{Entries: []Entry{UntrackedEntry{Path: "a.txt"}, IgnoredEntry{Path: "b.log"}}}
+	err := s.Transform(
+		Filter(func(e Entry) bool { return e.Type() != EntryTypeIgnored }),
+		RewritePaths(strings.ToUpper),
+	)
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+	if want := []Entry{UntrackedEntry{Path: "A.TXT"}}; !cmp.Equal(want, s.Entries) {
+		t.Errorf("Transform() entries = %v, want %v", s.Entries, want)
+	}
+
+	wantErr := errors.New("boom")
+	fail := EntryTransformerFunc(func(Entry) (Entry, error) { return nil, wantErr })
+	if err := s.Transform(fail); !errors.Is(err, wantErr) {
+		t.Errorf("Transform() error = %v, want %v", err, wantErr)
+	}
+	if len(s.Entries) != 1 {
+		t.Errorf("Transform() changed the entries on error: %v", s.Entries)
+	}
+}
+
+// TestIgnoreDirtySubmodules checks the transformer against the output of
+// git status --ignore-submodules=dirty, recorded for submodules with modified
+// files (s1), untracked files (s2), a new commit (s3), a new commit and
+// modified files (s4), and a staged new commit and modified files (s5).
+func TestIgnoreDirtySubmodules(t *testing.T) {
+	const (
+		plain = "1 .M S.M. 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 s1\n" +
+			"1 .M S..U 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 s2\n" +
+			"1 .M SC.. 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 s3\n" +
+			"1 .M SCM. 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 s4\n" +
+			"1 MM S.M. 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 16135106c0abea8d5b5bd0d2d4e5b0d1e4d1d36b s5\n" +
+			"1 .M N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d file.txt\n"
+		dirty = "1 .M SC.. 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 s3\n" +
+			"1 .M SC.. 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 s4\n" +
+			"1 M. S... 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 16135106c0abea8d5b5bd0d2d4e5b0d1e4d1d36b s5\n" +
+			"1 .M N... 100644 100644 100644 7cd2d7e2a3400e2463239d071c475c09ab410c2d 7cd2d7e2a3400e2463239d071c475c09ab410c2d file.txt\n"
+	)
+	got, err := decodeAllTransformed(t, plain, IgnoreDirtySubmodules())
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	want, err := Parse(strings.NewReader(dirty))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want.Entries, got); diff != "" {
+		t.Errorf("entries mismatch (-want +got):\n%s", diff)
+	}
+}
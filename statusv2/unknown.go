@@ -0,0 +1,18 @@
+package statusv2
+
+// KeepUnknown causes the Decoder to keep the lines it skips for having an
+// unknown prefix, rather than dropping them, so that entries of a type added
+// to the format by a later version of git are not lost, and tools can alert
+// on them. The lines are available from [Decoder.UnknownLines], and in
+// [Status.UnknownLines] for [Decoder.Decode]. In strict mode, such lines are
+// errors instead.
+func (d *Decoder) KeepUnknown() {
+	d.keepUnknown = true
+}
+
+// UnknownLines returns the lines with an unknown prefix read so far, in the
+// order they appeared, without their terminators. It returns nil unless
+// [Decoder.KeepUnknown] was called.
+func (d *Decoder) UnknownLines() []string {
+	return d.unknown
+}
@@ -0,0 +1,68 @@
+package statusv2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecoder_KeepUnknown(t *testing.T) {
+	const input = "# branch.head main\n" +
+		"? a.txt\n" +
+		"3 XY future entry.txt\n" +
+		"\n" +
+		"! b.log\n" +
+		"~ another\n"
+	tests := []struct {
+		name    string
+		decoder *Decoder
+	}{
+		{"lines", NewDecoder(strings.NewReader(input))},
+		{"z", NewDecoderZ(strings.NewReader(strings.ReplaceAll(input, "\n", "\x00")))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.decoder.KeepUnknown()
+			s, err := tt.decoder.Decode()
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			want := []string{"3 XY future entry.txt", "~ another"}
+			if diff := cmp.Diff(want, s.UnknownLines); diff != "" {
+				t.Errorf("UnknownLines mismatch (-want +got):\n%s", diff)
+			}
+			if len(s.Entries) != 2 {
+				t.Errorf("got %d entries, want 2", len(s.Entries))
+			}
+			if s.Meta.Warnings != 3 {
+				t.Errorf("Meta.Warnings = %d, want 3", s.Meta.Warnings)
+			}
+		})
+	}
+}
+
+func TestDecoder_UnknownDropped(t *testing.T) {
+	s, err := Parse(strings.NewReader("3 XY future entry.txt\n? a.txt\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if s.UnknownLines != nil {
+		t.Errorf("UnknownLines = %q, want nil without KeepUnknown", s.UnknownLines)
+	}
+	if want := (&Status{Entries: []Entry{UntrackedEntry{Path: "a.txt"}}, UnknownLines: []string{"3 XY future entry.txt"}}); !s.Equal(want) {
+		t.Errorf("Equal() = false for statuses differing in unknown lines only")
+	}
+}
+
+func TestDecoder_KeepUnknownStrict(t *testing.T) {
+	d := NewDecoder(strings.NewReader("3 XY future entry.txt\n"))
+	d.Strict()
+	d.KeepUnknown()
+	if _, err := d.Next(); err == nil {
+		t.Error("Next() error = nil, want error for an unknown line in strict mode")
+	}
+	if got := d.UnknownLines(); got != nil {
+		t.Errorf("UnknownLines() = %q, want nil in strict mode", got)
+	}
+}
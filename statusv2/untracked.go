@@ -0,0 +1,118 @@
+package statusv2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// UntrackedMode is the --untracked-files mode of git status, which decides
+// how much of the untracked files a status lists.
+type UntrackedMode int
+
+// Untracked files modes.
+const (
+	UntrackedConfigured UntrackedMode = iota // as configured by status.showUntrackedFiles, which lists them as for UntrackedNormal by default
+	UntrackedNo                              // no untracked files are listed
+	UntrackedNormal                          // untracked files are listed, with a directory of only untracked files as the directory
+	UntrackedAll                             // each untracked file is listed, including those in untracked directories
+)
+
+// String returns the name of the mode as used by --untracked-files, e.g.
+// "normal", or "configured" for [UntrackedConfigured].
+func (m UntrackedMode) String() string {
+	switch m {
+	case UntrackedNo:
+		return "no"
+	case UntrackedNormal:
+		return "normal"
+	case UntrackedAll:
+		return "all"
+	}
+	return "configured"
+}
+
+// ParseUntrackedMode returns the mode named s, as by --untracked-files: "no",
+// "normal" or "all".
+func ParseUntrackedMode(s string) (UntrackedMode, error) {
+	switch s {
+	case "no":
+		return UntrackedNo, nil
+	case "normal":
+		return UntrackedNormal, nil
+	case "all":
+		return UntrackedAll, nil
+	}
+	return UntrackedConfigured, fmt.Errorf("invalid untracked files mode %q", s)
+}
+
+// Errors returned by the methods of a [Status] asking about untracked files
+// that its [UntrackedMode] does not allow to answer.
+var (
+	ErrUntrackedNotListed   = errors.New("statusv2: untracked files not listed (--untracked-files=no)")
+	ErrUntrackedDirectories = errors.New("statusv2: untracked directories not expanded into files (--untracked-files=normal)")
+)
+
+// GetWithUntracked runs `git status --porcelain=v2 -z` like [Get], with the
+// given untracked files mode overriding that configured, and records it in the
+// Untracked field of the result.
+func GetWithUntracked(ctx context.Context, git gitexec.Runner, mode UntrackedMode, args ...string) (*Status, error) {
+	cmd := []string{"status", "--porcelain=v2", "-z"}
+	if mode != UntrackedConfigured {
+		cmd = append(cmd, "--untracked-files="+mode.String())
+	}
+	out, err := git.Run(ctx, append(cmd, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ParseZ(bytes.NewReader(out))
+	if err != nil {
+		return nil, err
+	}
+	s.Untracked = mode
+	return s, nil
+}
+
+// HasUntracked reports whether s lists any untracked files. It returns
+// [ErrUntrackedNotListed] if s was got with [UntrackedNo], under which the
+// answer is unknown rather than false.
+func (s *Status) HasUntracked() (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	if s.Untracked == UntrackedNo {
+		return false, ErrUntrackedNotListed
+	}
+	for _, e := range s.Entries {
+		if _, ok := e.(UntrackedEntry); ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// UntrackedFiles returns the path of each untracked file, in order. It returns
+// [ErrUntrackedNotListed] if s was got with [UntrackedNo], and
+// [ErrUntrackedDirectories] if s lists an untracked directory in place of the
+// files in it, as with [UntrackedNormal], for which [UntrackedAll] is needed.
+// Either way, an untracked mode of [UntrackedConfigured] is taken to allow
+// the answer unless the entries show otherwise.
+func (s *Status) UntrackedFiles() ([]string, error) {
+	if s.Untracked == UntrackedNo {
+		return nil, ErrUntrackedNotListed
+	}
+	var paths []string
+	for _, e := range s.Entries {
+		if u, ok := e.(UntrackedEntry); ok {
+			if strings.HasSuffix(u.Path, "/") {
+				return nil, ErrUntrackedDirectories
+			}
+			paths = append(paths, u.Path)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,89 @@
+package statusv2
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestGetWithUntracked(t *testing.T) {
+	tests := []struct {
+		mode     UntrackedMode
+		wantArgs []string
+	}{
+		{UntrackedConfigured, []string{"status", "--porcelain=v2", "-z", "--branch"}},
+		{UntrackedNo, []string{"status", "--porcelain=v2", "-z", "--untracked-files=no", "--branch"}},
+		{UntrackedNormal, []string{"status", "--porcelain=v2", "-z", "--untracked-files=normal", "--branch"}},
+		{UntrackedAll, []string{"status", "--porcelain=v2", "-z", "--untracked-files=all", "--branch"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			git := &fakeRunner{out: "? new.txt\x00"}
+			s, err := GetWithUntracked(context.Background(), git, tt.mode, "--branch")
+			if err != nil {
+				t.Fatalf("GetWithUntracked() error = %v", err)
+			}
+			if !slices.Equal(git.args, tt.wantArgs) {
+				t.Errorf("GetWithUntracked() ran git %q, want %q", git.args, tt.wantArgs)
+			}
+			if s.Untracked != tt.mode {
+				t.Errorf("Untracked = %v, want %v", s.Untracked, tt.mode)
+			}
+		})
+	}
+
+	wantErr := errors.New("boom")
+	if _, err := GetWithUntracked(context.Background(), &fakeRunner{err: wantErr}, UntrackedAll); !errors.Is(err, wantErr) {
+		t.Errorf("GetWithUntracked() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestParseUntrackedMode(t *testing.T) {
+	for _, m := range []UntrackedMode{UntrackedNo, UntrackedNormal, UntrackedAll} {
+		if got, err := ParseUntrackedMode(m.String()); got != m || err != nil {
+			t.Errorf("ParseUntrackedMode(%q) = %v, %v, want %v", m.String(), got, err, m)
+		}
+	}
+	if _, err := ParseUntrackedMode("configured"); err == nil {
+		t.Error(`ParseUntrackedMode("configured") error = nil, want error`)
+	}
+}
+
+func TestStatus_UntrackedFiles(t *testing.T) {
+	files := []Entry{ChangedEntry{Path: "m.txt"}, UntrackedEntry{Path: "a.txt"}, UntrackedEntry{Path: "dir/b.txt"}}
+	dirs := []Entry{UntrackedEntry{Path: "a.txt"}, UntrackedEntry{Path: "dir/"}}
+	tests := []struct {
+		name    string
+		status  *Status
+		want    []string
+		wantHas bool
+		wantErr error
+		hasErr  error
+	}{
+		{"configured", &Status{Entries: files}, []string{"a.txt", "dir/b.txt"}, true, nil, nil},
+		{"all", &Status{Untracked: UntrackedAll, Entries: files}, []string{"a.txt", "dir/b.txt"}, true, nil, nil},
+		{"none", &Status{Untracked: UntrackedNormal}, nil, false, nil, nil},
+		{"directories", &Status{Untracked: UntrackedNormal, Entries: dirs}, nil, true, ErrUntrackedDirectories, nil},
+		{"configured directories", &Status{Entries: dirs}, nil, true, ErrUntrackedDirectories, nil},
+		{"no", &Status{Untracked: UntrackedNo}, nil, false, ErrUntrackedNotListed, ErrUntrackedNotListed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.status.UntrackedFiles()
+			if !errors.Is(err, tt.wantErr) || !slices.Equal(got, tt.want) {
+				t.Errorf("UntrackedFiles() = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+			has, err := tt.status.HasUntracked()
+			if !errors.Is(err, tt.hasErr) || has != tt.wantHas {
+				t.Errorf("HasUntracked() = %v, %v, want %v, %v", has, err, tt.wantHas, tt.hasErr)
+			}
+		})
+	}
+}
+
+func TestStatus_HasUntrackedNil(t *testing.T) {
+	if has, err := (*Status)(nil).HasUntracked(); has || err != nil {
+		t.Errorf("nil HasUntracked() = %v, %v, want false, nil", has, err)
+	}
+}
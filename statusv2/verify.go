@@ -0,0 +1,160 @@
+package statusv2
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// A Discrepancy is a path whose presence in the worktree contradicts the
+// entry reporting it, as found by [Status.Verify].
+type Discrepancy struct {
+	Path    string // path in the worktree
+	Entry   Entry  // entry reporting the path
+	Problem string // description, such as "reported missing, but exists"
+}
+
+// String returns the path and problem, such as
+// "a.txt: reported missing, but exists".
+func (d Discrepancy) String() string {
+	return d.Path + ": " + d.Problem
+}
+
+// Verify checks the paths reported by s against the worktree fsys, such as
+// os.DirFS of the repository root, returning the discrepancies found in the
+// order of the entries. A path must be missing if its entry has no worktree
+// mode, as when deleted, including the original path of a rename, and must
+// otherwise exist, as a directory for submodules and untracked or ignored
+// directories. Discrepancies mean that s is stale, as for a snapshot kept by a
+// cache past changes to the worktree.
+//
+// The paths of s must be unquoted, as by [ParseZ] or [UnquotePaths]. Verify
+// returns an error if fsys fails other than for missing paths.
+func (s *Status) Verify(fsys fs.FS) ([]Discrepancy, error) {
+	if s == nil {
+		return nil, nil
+	}
+	// Paths deleted from the index may be back in the worktree, reported by
+	// an untracked or ignored entry as well.
+	listed := make(map[string]bool)
+	for _, e := range s.Entries {
+		switch e := e.(type) {
+		case UntrackedEntry:
+			listed[strings.TrimSuffix(e.Path, "/")] = true
+		case IgnoredEntry:
+			listed[strings.TrimSuffix(e.Path, "/")] = true
+		}
+	}
+
+	v := verifier{fsys: fsys}
+	for _, e := range s.Entries {
+		switch e := e.(type) {
+		case ChangedEntry:
+			if e.ModeW != FileModeEmpty || !listed[e.Path] {
+				v.check(e, e.Path, e.ModeW)
+			}
+		case RenameOrCopyEntry:
+			if e.ModeW != FileModeEmpty || !listed[e.Path] {
+				v.check(e, e.Path, e.ModeW)
+			}
+			if e.XY.X == Renamed && !listed[e.Orig] {
+				v.check(e, e.Orig, FileModeEmpty)
+			}
+		case UnmergedEntry:
+			v.check(e, e.Path, e.ModeW)
+		case UntrackedEntry:
+			v.checkListed(e, e.Path)
+		case IgnoredEntry:
+			v.checkListed(e, e.Path)
+		}
+		if v.err != nil {
+			return nil, v.err
+		}
+	}
+	return v.found, nil
+}
+
+// verifier collects the discrepancies of paths in a worktree, stopping at the
+// first error.
+type verifier struct {
+	fsys  fs.FS
+	found []Discrepancy
+	err   error
+}
+
+// checkListed checks the path of an untracked or ignored entry, which is a
+// directory if it ends with a slash.
+func (v *verifier) checkListed(e Entry, p string) {
+	if dir, ok := strings.CutSuffix(p, "/"); ok {
+		v.check(e, dir, FileModeDir)
+		return
+	}
+	v.check(e, p, FileModeRegular)
+}
+
+// check checks that p is in the worktree as mode, or missing if mode is
+// FileModeEmpty.
+func (v *verifier) check(e Entry, p string, mode FileMode) {
+	if v.err != nil {
+		return
+	}
+	if !fs.ValidPath(p) {
+		v.err = &fs.PathError{Op: "verify", Path: p, Err: fs.ErrInvalid}
+		return
+	}
+	info, exists, err := v.stat(p)
+	if err != nil {
+		v.err = err
+		return
+	}
+
+	var problem string
+	switch {
+	case mode == FileModeEmpty && exists:
+		problem = "reported missing, but exists"
+	case mode != FileModeEmpty && !exists:
+		problem = "reported present, but missing"
+	case info == nil || mode.IsSymlink():
+		// The kind of a symbolic link is that of its target.
+	case (mode.IsDir() || mode.IsSubmodule()) && !info.IsDir():
+		problem = "reported as a directory, but is not one"
+	case mode.IsRegular() && info.IsDir():
+		problem = "reported as a file, but is a directory"
+	}
+	if problem != "" {
+		v.found = append(v.found, Discrepancy{Path: p, Entry: e, Problem: problem})
+	}
+}
+
+// stat returns information about p, and whether it exists. As fs.Stat
+// follows symbolic links, p may exist without information, as a dangling
+// link, which stat finds by looking for p in its directory.
+func (v *verifier) stat(p string) (fs.FileInfo, bool, error) {
+	info, err := fs.Stat(v.fsys, p)
+	if err == nil {
+		return info, true, nil
+	}
+	dir := path.Dir(p)
+	entries, err := fs.ReadDir(v.fsys, dir)
+	if err != nil {
+		if dir == "." {
+			return nil, false, err
+		}
+		// p is missing if its directory is missing or not a directory.
+		dirInfo, ok, derr := v.stat(dir)
+		if derr != nil {
+			return nil, false, derr
+		}
+		if !ok || dirInfo == nil || !dirInfo.IsDir() {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	name := path.Base(p)
+	for _, entry := range entries {
+		if entry.Name() == name {
+			return nil, true, nil
+		}
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,106 @@
+package statusv2
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// verifyInput is the status of a worktree in which a.txt was deleted from
+// the index and recreated, b.txt deleted, c.txt deleted from the worktree,
+// x.txt renamed to y.txt, e.txt left in conflict, and sub a submodule.
+const verifyInput = "1 D. N... 100644 000000 000000 78981922613b2afb6025042ff6bd878ac1994e85 0000000000000000000000000000000000000000 a.txt\n" +
+	"1 D. N... 100644 000000 000000 61780798228d17af2d34fce4cfbdf35556832472 0000000000000000000000000000000000000000 b.txt\n" +
+	"1 .D N... 100644 100644 000000 f2ad6c76f0115a6ba5b00456a849810e7ec0af20 f2ad6c76f0115a6ba5b00456a849810e7ec0af20 c.txt\n" +
+	"1 .M N... 100644 100644 100644 f2ad6c76f0115a6ba5b00456a849810e7ec0af20 f2ad6c76f0115a6ba5b00456a849810e7ec0af20 dir/m.txt\n" +
+	"1 .M S.M. 160000 160000 160000 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 169713993fdb8856e85da1a0e2ad8e1d0c2ed5b5 sub\n" +
+	"2 R. N... 100644 100644 100644 587be6b4c3f93f93c489c0111bba5596147a26cb 587be6b4c3f93f93c489c0111bba5596147a26cb R100 y.txt\tx.txt\n" +
+	"u UU N... 100644 100644 100644 100644 78981922613b2afb6025042ff6bd878ac1994e85 c1827f07e114c20547dc6a7296588870a4b5b62c e6bfff5c1d0f0ecd501552b43a1e13d8008abc31 e.txt\n" +
+	"? a.txt\n" +
+	"? new/\n" +
+	"! debug.log\n"
+
+func verifyStatus(t *testing.T) *Status {
+	t.Helper()
+	s, err := Parse(strings.NewReader(verifyInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestStatus_Verify(t *testing.T) {
+	s := verifyStatus(t)
+	fsys := fstest.MapFS{
+		"a.txt":     {},
+		"dir/m.txt": {},
+		"sub/.git":  {},
+		"y.txt":     {},
+		"e.txt":     {},
+		"new/n.txt": {},
+		"debug.log": {},
+	}
+	got, err := s.Verify(fsys)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Verify() = %v, want no discrepancies", got)
+	}
+}
+
+func TestStatus_Verify_Stale(t *testing.T) {
+	s := verifyStatus(t)
+	fsys := fstest.MapFS{
+		"a.txt":     {},
+		"b.txt":     {}, // restored
+		"dir":       {}, // replaced by a file
+		"sub/.git":  {},
+		"x.txt":     {}, // renamed back
+		"e.txt":     {Mode: fs.ModeDir},
+		"new":       {},
+		"debug.log": {},
+	}
+	got, err := s.Verify(fsys)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	var lines []string
+	for _, d := range got {
+		lines = append(lines, d.String())
+	}
+	want := []string{
+		"b.txt: reported missing, but exists",
+		"dir/m.txt: reported present, but missing",
+		"y.txt: reported present, but missing",
+		"x.txt: reported missing, but exists",
+		"e.txt: reported as a file, but is a directory",
+		"new: reported as a directory, but is not one",
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("Verify() mismatch (-want +got):\n%s", diff)
+	}
+	if got[0].Entry.(ChangedEntry).Path != "b.txt" {
+		t.Errorf("Verify()[0].Entry = %v, want the entry of b.txt", got[0].Entry)
+	}
+}
+
+func TestStatus_Verify_Errors(t *testing.T) {
+	s := &Status{Entries: []Entry{UntrackedEntry{Path: `"quoted\303\251.txt"`}}}
+	if _, err := s.Verify(fstest.MapFS{}); err != nil {
+		t.Errorf("Verify() error = %v, want none for a quoted path", err)
+	}
+
+	s = &Status{Entries: []Entry{UntrackedEntry{Path: "../outside"}}}
+	if _, err := s.Verify(fstest.MapFS{}); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("Verify() error = %v, want %v", err, fs.ErrInvalid)
+	}
+
+	if got, err := (*Status)(nil).Verify(fstest.MapFS{}); got != nil || err != nil {
+		t.Errorf("nil Verify() = %v, %v, want nil, nil", got, err)
+	}
+}
@@ -0,0 +1,57 @@
+package statusv2
+
+// A ViewEntry is a file as listed in one of the two panes almost every git
+// UI renders, of the changes staged and of those not staged, from
+// [Status.IndexView] and [Status.WorktreeView].
+type ViewEntry struct {
+	Path  string
+	Orig  string // the original path, if State is Renamed or Copied
+	State State  // the change in the view: the X state for the index, the Y state for the worktree
+	Entry Entry  // the entry the file is from
+}
+
+// IndexView returns the files with changes staged in the index, in order,
+// with the X state of their entries. Unmerged files, which have no changes
+// staged until resolved, are left out, as for [Status.HasStagedChanges]; UIs
+// list them separately, from [Status.Conflicts].
+func (s *Status) IndexView() []ViewEntry {
+	return s.view(func(xy XYFlag) State { return xy.X })
+}
+
+// WorktreeView returns the files with changes in the worktree not staged, in
+// order, with the Y state of their entries, followed by the untracked files,
+// with State '?', as in [UntrackedXY]. Unmerged files are left out, as for
+// [Status.IndexView], and so are ignored files.
+func (s *Status) WorktreeView() []ViewEntry {
+	view := s.view(func(xy XYFlag) State { return xy.Y })
+	for e := range s.UntrackedEntries() {
+		view = append(view, ViewEntry{Path: e.Path, State: UntrackedXY.Y, Entry: e})
+	}
+	return view
+}
+
+// view returns the changed, renamed and copied files of s for which state
+// returns a state other than Unmodified.
+func (s *Status) view(state func(XYFlag) State) []ViewEntry {
+	if s == nil {
+		return nil
+	}
+	var view []ViewEntry
+	for _, e := range s.Entries {
+		switch e := e.(type) {
+		case ChangedEntry:
+			if st := state(e.XY); st != Unmodified {
+				view = append(view, ViewEntry{Path: e.Path, State: st, Entry: e})
+			}
+		case RenameOrCopyEntry:
+			if st := state(e.XY); st != Unmodified {
+				v := ViewEntry{Path: e.Path, State: st, Entry: e}
+				if st == Renamed || st == Copied {
+					v.Orig = e.Orig
+				}
+				view = append(view, v)
+			}
+		}
+	}
+	return view
+}
@@ -0,0 +1,46 @@
+package statusv2
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStatus_Views(t *testing.T) {
+	staged := ChangedEntry{XY: XYFlag{Added, Unmodified}, Path: "added.txt"}
+	both := ChangedEntry{XY: XYFlag{Modified, Deleted}, Path: "both.txt"}
+	unstaged := ChangedEntry{XY: XYFlag{Unmodified, Modified}, Path: "unstaged.txt"}
+	renamed := RenameOrCopyEntry{XY: XYFlag{Renamed, Modified}, Score: "R90", Path: "new.txt", Orig: "old.txt"}
+	intentRename := RenameOrCopyEntry{XY: XYFlag{Unmodified, Renamed}, Score: "R100", Path: "moved.txt", Orig: "here.txt"}
+	unmerged := UnmergedEntry{XY: XYFlag{UpdatedUnmerged, UpdatedUnmerged}, Path: "conflict.txt"}
+	untracked := UntrackedEntry{Path: "untracked.txt"}
+	ignored := IgnoredEntry{Path: "ignored.log"}
+	s := &Status{Entries: []Entry{staged, both, untracked, unstaged, renamed, intentRename, unmerged, ignored}}
+
+	wantIndex := []ViewEntry{
+		{Path: "added.txt", State: Added, Entry: staged},
+		{Path: "both.txt", State: Modified, Entry: both},
+		{Path: "new.txt", Orig: "old.txt", State: Renamed, Entry: renamed},
+	}
+	if diff := cmp.Diff(wantIndex, s.IndexView()); diff != "" {
+		t.Errorf("IndexView() mismatch (-want +got):\n%s", diff)
+	}
+	wantWorktree := []ViewEntry{
+		{Path: "both.txt", State: Deleted, Entry: both},
+		{Path: "unstaged.txt", State: Modified, Entry: unstaged},
+		{Path: "new.txt", State: Modified, Entry: renamed},
+		{Path: "moved.txt", Orig: "here.txt", State: Renamed, Entry: intentRename},
+		{Path: "untracked.txt", State: '?', Entry: untracked},
+	}
+	if diff := cmp.Diff(wantWorktree, s.WorktreeView()); diff != "" {
+		t.Errorf("WorktreeView() mismatch (-want +got):\n%s", diff)
+	}
+
+	var nilStatus *Status
+	if got := nilStatus.IndexView(); got != nil {
+		t.Errorf("IndexView() of nil = %v, want nil", got)
+	}
+	if got := nilStatus.WorktreeView(); got != nil {
+		t.Errorf("WorktreeView() of nil = %v, want nil", got)
+	}
+}
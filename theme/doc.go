@@ -0,0 +1,42 @@
+/*
+Package theme defines the glyphs and colors used to render status, shared by
+the prompt and other renderers of the commands, so that output can be
+customized without forking them.
+
+# Basic Usage
+
+A [Theme] has a set of Unicode [Glyphs], a set of ASCII-only glyphs to fall
+back to on terminals without UTF-8 support, and a [Palette] of ANSI colors.
+[Default] and [ASCII] are built in, and [Builtin] looks them up by name:
+
+	t := theme.Default
+	g := t.LocaleGlyphs() // t.ASCII unless the locale supports UTF-8
+	fmt.Printf("%s%d %s%d\n", g.Ahead, ahead, g.Behind, behind)
+
+# Configuration
+
+[Load] and [Parse] read a theme from a simple configuration file, with a
+"key = value" setting on each line, and comment lines starting with "#".
+Settings override those of the [Default] theme, or of the built-in theme named
+by a "base" setting preceding them:
+
+	# ~/.config/porcelain/theme.conf
+	base = default
+	staged = S
+	ascii.staged = S
+	color.branch = bright-blue
+	color.untracked = none
+
+The glyph keys are ahead, behind, staged, unstaged, untracked, conflicts,
+stash and clean, with an "ascii." prefix for the fallback glyphs. The color
+keys have a "color." prefix, and are the same with the addition of branch and
+detached. Colors are SGR parameters such as "1;34", or one of the names black,
+red, green, yellow, blue, magenta, cyan and white, optionally prefixed with
+"bright-", or "none" for no color. Values are taken verbatim after trimming
+spaces, unless they are double-quoted Go strings, so that glyphs can be empty
+or contain spaces.
+
+[Encode] writes a theme in the same format, as a starting point for
+customization. [DefaultPath] returns the conventional location of the file.
+*/
+package theme
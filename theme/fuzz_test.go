@@ -0,0 +1,34 @@
+package theme
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// Fuzz test checking that any parsed theme parses to the same theme after
+// being encoded.
+func FuzzParse(f *testing.F) {
+	// Add some seed inputs
+	fuzzseed.Add[[]byte](f, "theme")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		th, err := Parse(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		var b bytes.Buffer
+		if err := Encode(&b, th); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		again, err := Parse(&b)
+		if err != nil {
+			t.Fatalf("Parse() of encoded theme %q failed: %v", b.String(), err)
+		}
+		if diff := cmp.Diff(th, again); diff != "" {
+			t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package theme
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultPath returns the conventional location of the theme configuration
+// file, porcelain/theme.conf in the user's configuration directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "porcelain", "theme.conf"), nil
+}
+
+// Load reads a theme from the configuration file at path.
+func Load(path string) (Theme, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	defer f.Close()
+	t, err := Parse(f)
+	if err != nil {
+		return Theme{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// Parse reads a theme from configuration, as described in the package
+// documentation.
+func Parse(r io.Reader) (Theme, error) {
+	t := Default
+	settings := false
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, err := parseLine(line)
+		if err != nil {
+			return Theme{}, fmt.Errorf("line %d: %w", n, err)
+		}
+		if key == "base" {
+			if settings {
+				return Theme{}, fmt.Errorf("line %d: base must precede other settings", n)
+			}
+			base, ok := Builtin(value)
+			if !ok {
+				return Theme{}, fmt.Errorf("line %d: unknown base theme: %q", n, value)
+			}
+			t, settings = base, true
+			continue
+		}
+		settings = true
+		if err := t.set(key, value); err != nil {
+			return Theme{}, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	return t, scanner.Err()
+}
+
+func parseLine(line string) (key, value string, err error) {
+	key, value, ok := strings.Cut(line, "=")
+	if !ok {
+		return "", "", fmt.Errorf("missing \"=\": %q", line)
+	}
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if strings.HasPrefix(value, `"`) {
+		if value, err = strconv.Unquote(value); err != nil {
+			return "", "", fmt.Errorf("invalid quoted value: %q", line)
+		}
+	}
+	return key, value, nil
+}
+
+// set applies a single setting to the theme.
+func (t *Theme) set(key, value string) error {
+	for _, s := range t.settings() {
+		if s.key != key {
+			continue
+		}
+		if s.color {
+			code, err := parseColor(value)
+			if err != nil {
+				return err
+			}
+			value = code
+		}
+		*s.value = value
+		return nil
+	}
+	return fmt.Errorf("unknown key: %q", key)
+}
+
+// setting is a configurable field of a theme.
+type setting struct {
+	key   string
+	value *string
+	color bool
+}
+
+// settings returns the configurable fields of the theme, in the order they
+// are encoded.
+func (t *Theme) settings() []setting {
+	var s []setting
+	glyphs := func(prefix string, g *Glyphs) {
+		s = append(s,
+			setting{prefix + "ahead", &g.Ahead, false},
+			setting{prefix + "behind", &g.Behind, false},
+			setting{prefix + "staged", &g.Staged, false},
+			setting{prefix + "unstaged", &g.Unstaged, false},
+			setting{prefix + "untracked", &g.Untracked, false},
+			setting{prefix + "conflicts", &g.Conflicts, false},
+			setting{prefix + "stash", &g.Stash, false},
+			setting{prefix + "clean", &g.Clean, false},
+		)
+	}
+	glyphs("", &t.Glyphs)
+	glyphs("ascii.", &t.ASCII)
+	c := &t.Colors
+	s = append(s,
+		setting{"color.branch", &c.Branch, true},
+		setting{"color.detached", &c.Detached, true},
+		setting{"color.ahead", &c.Ahead, true},
+		setting{"color.behind", &c.Behind, true},
+		setting{"color.staged", &c.Staged, true},
+		setting{"color.unstaged", &c.Unstaged, true},
+		setting{"color.untracked", &c.Untracked, true},
+		setting{"color.conflicts", &c.Conflicts, true},
+		setting{"color.stash", &c.Stash, true},
+		setting{"color.clean", &c.Clean, true},
+	)
+	return s
+}
+
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// parseColor returns the SGR parameters for a color name or parameters.
+func parseColor(value string) (string, error) {
+	if value == "none" || value == "" {
+		return "", nil
+	}
+	name, bright := strings.CutPrefix(value, "bright-")
+	for i, n := range colorNames {
+		if n == name {
+			if bright {
+				return strconv.Itoa(90 + i), nil
+			}
+			return strconv.Itoa(30 + i), nil
+		}
+	}
+	if err := validSGR(value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// validSGR checks that value is a list of numeric SGR parameters.
+func validSGR(value string) error {
+	for _, p := range strings.Split(value, ";") {
+		if p == "" || strings.Trim(p, "0123456789") != "" {
+			return fmt.Errorf("invalid color: %q", value)
+		}
+	}
+	return nil
+}
+
+// Encode writes the theme to w in the configuration format read by [Parse].
+func Encode(w io.Writer, t Theme) error {
+	bw := bufio.NewWriter(w)
+	for _, s := range t.settings() {
+		value := *s.value
+		if s.color {
+			if value == "" {
+				value = "none"
+			} else if err := validSGR(value); err != nil {
+				return err
+			}
+		} else if value == "" || strconv.Quote(value) != `"`+value+`"` || strings.TrimSpace(value) != value {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(bw, "%s = %s\n", s.key, value)
+	}
+	return bw.Flush()
+}
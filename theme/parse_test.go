@@ -0,0 +1,121 @@
+package theme
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	input := `# my theme
+base = ascii
+
+staged = S
+unstaged = "  "
+ascii.clean = "#"
+clean = ok
+color.branch = bright-blue
+color.detached = 1;33
+color.untracked = none
+`
+	want := ASCII
+	want.Glyphs.Staged = "S"
+	want.Glyphs.Unstaged = "  "
+	want.Glyphs.Clean = "ok"
+	want.ASCII.Clean = "#"
+	want.Colors.Branch = "94"
+	want.Colors.Detached = "1;33"
+	want.Colors.Untracked = ""
+
+	got, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	got, err := Parse(strings.NewReader("\n# nothing\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if diff := cmp.Diff(Default, got); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"missing equals", "staged S", `line 1: missing "="`},
+		{"unknown key", "\nbogus = x", `line 2: unknown key: "bogus"`},
+		{"bad quoting", `staged = "S`, "line 1: invalid quoted value"},
+		{"bad color", "color.staged = greenish", `line 1: invalid color: "greenish"`},
+		{"empty color parameter", "color.staged = 1;", `line 1: invalid color: "1;"`},
+		{"unknown base", "base = neon", `line 1: unknown base theme: "neon"`},
+		{"late base", "staged = S\nbase = ascii", "line 2: base must precede other settings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	th := Default
+	th.Glyphs.Clean = ""
+	th.Glyphs.Stash = " s "
+	th.Colors.Stash = ""
+
+	var b bytes.Buffer
+	if err := Encode(&b, th); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	for _, line := range []string{`clean = ""`, `stash = " s "`, "color.stash = none", "ahead = ↑", "ascii.ahead = ^"} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("Encode() output missing %q:\n%s", line, b.String())
+		}
+	}
+	got, err := Parse(&b)
+	if err != nil {
+		t.Fatalf("Parse() of encoded theme error = %v", err)
+	}
+	if diff := cmp.Diff(th, got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.conf")
+	if err := os.WriteFile(path, []byte("staged = S\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	th, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if th.Glyphs.Staged != "S" {
+		t.Errorf("Load() staged glyph = %q, want %q", th.Glyphs.Staged, "S")
+	}
+
+	if err := os.WriteFile(path, []byte("bogus = x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.HasPrefix(err.Error(), path+": line 1:") {
+		t.Errorf("Load() error = %v, want it prefixed with the path and line", err)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Errorf("Load() of missing file error = %v, want not exist", err)
+	}
+}
@@ -0,0 +1,123 @@
+package theme
+
+import (
+	"os"
+	"strings"
+)
+
+// Theme holds the glyphs and colors used to render status.
+type Theme struct {
+	Glyphs Glyphs  // glyphs for terminals supporting UTF-8
+	ASCII  Glyphs  // ASCII-only fallback glyphs
+	Colors Palette // colors of each part
+}
+
+// Glyphs holds the glyph preceding the count of each part of a status.
+type Glyphs struct {
+	Ahead     string
+	Behind    string
+	Staged    string
+	Unstaged  string
+	Untracked string
+	Conflicts string
+	Stash     string
+	Clean     string // shown when there are no changes
+}
+
+// Palette holds the color of each part of a status, as the parameters of an
+// ANSI SGR escape sequence, such as "32" or "1;34", or "" for no color.
+type Palette struct {
+	Branch    string
+	Detached  string
+	Ahead     string
+	Behind    string
+	Staged    string
+	Unstaged  string
+	Untracked string
+	Conflicts string
+	Stash     string
+	Clean     string
+}
+
+var asciiGlyphs = Glyphs{
+	Ahead:     "^",
+	Behind:    "v",
+	Staged:    "+",
+	Unstaged:  "*",
+	Untracked: "?",
+	Conflicts: "!",
+	Stash:     "$",
+	Clean:     "",
+}
+
+var defaultPalette = Palette{
+	Branch:    "36", // cyan
+	Detached:  "33", // yellow
+	Ahead:     "32", // green
+	Behind:    "31", // red
+	Staged:    "32", // green
+	Unstaged:  "33", // yellow
+	Untracked: "90", // bright black
+	Conflicts: "31", // red
+	Stash:     "35", // magenta
+	Clean:     "32", // green
+}
+
+// Default is the default theme, with Unicode glyphs.
+var Default = Theme{
+	Glyphs: Glyphs{
+		Ahead:     "↑",
+		Behind:    "↓",
+		Staged:    "●",
+		Unstaged:  "✚",
+		Untracked: "…",
+		Conflicts: "✖",
+		Stash:     "⚑",
+		Clean:     "✔",
+	},
+	ASCII:  asciiGlyphs,
+	Colors: defaultPalette,
+}
+
+// ASCII is a theme using only ASCII glyphs, regardless of the locale.
+var ASCII = Theme{
+	Glyphs: asciiGlyphs,
+	ASCII:  asciiGlyphs,
+	Colors: defaultPalette,
+}
+
+var builtins = map[string]*Theme{
+	"default": &Default,
+	"ascii":   &ASCII,
+}
+
+// Builtin returns the built-in theme with the given name, "default" or
+// "ascii", and whether it exists.
+func Builtin(name string) (Theme, bool) {
+	t, ok := builtins[name]
+	if !ok {
+		return Theme{}, false
+	}
+	return *t, true
+}
+
+// LocaleGlyphs returns the Unicode glyphs of the theme if the locale supports
+// UTF-8, or the ASCII glyphs otherwise.
+func (t *Theme) LocaleGlyphs() Glyphs {
+	if UTF8Locale() {
+		return t.Glyphs
+	}
+	return t.ASCII
+}
+
+// UTF8Locale reports whether the locale supports UTF-8, following the
+// precedence of the LC_ALL, LC_CTYPE and LANG environment variables.
+func UTF8Locale() bool {
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package theme
+
+import "testing"
+
+func TestBuiltin(t *testing.T) {
+	for _, name := range []string{"default", "ascii"} {
+		if _, ok := Builtin(name); !ok {
+			t.Errorf("Builtin(%q) not found", name)
+		}
+	}
+	if _, ok := Builtin("nope"); ok {
+		t.Error(`Builtin("nope") found`)
+	}
+	if th, _ := Builtin("ascii"); th.Glyphs != ASCII.Glyphs {
+		t.Errorf(`Builtin("ascii") = %+v, want ASCII`, th)
+	}
+}
+
+func TestUTF8Locale(t *testing.T) {
+	tests := []struct {
+		lcAll, lcCtype, lang string
+		want                 bool
+	}{
+		{"", "", "", false},
+		{"", "", "en_US.UTF-8", true},
+		{"", "", "C", false},
+		{"", "en_US.utf8", "C", true},
+		{"C", "en_US.UTF-8", "en_US.UTF-8", false},
+	}
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_CTYPE", tt.lcCtype)
+		t.Setenv("LANG", tt.lang)
+		if got := UTF8Locale(); got != tt.want {
+			t.Errorf("UTF8Locale() with LC_ALL=%q LC_CTYPE=%q LANG=%q = %v, want %v", tt.lcAll, tt.lcCtype, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestTheme_LocaleGlyphs(t *testing.T) {
+	t.Setenv("LC_ALL", "en_US.UTF-8")
+	if got := Default.LocaleGlyphs(); got != Default.Glyphs {
+		t.Errorf("LocaleGlyphs() = %+v, want Unicode glyphs", got)
+	}
+	t.Setenv("LC_ALL", "C")
+	if got := Default.LocaleGlyphs(); got != Default.ASCII {
+		t.Errorf("LocaleGlyphs() = %+v, want ASCII glyphs", got)
+	}
+}
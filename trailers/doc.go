@@ -0,0 +1,38 @@
+/*
+Package trailers parses and manipulates commit message trailers.
+
+Trailers are the "Key: value" lines at the end of a commit message, such as
+Signed-off-by or Co-authored-by. This package follows the rules of
+`git interpret-trailers`, so that it finds the same trailers git does:
+
+  - Trailers live in the last paragraph of the message. The first paragraph
+    (the title) never contains trailers.
+  - A patch following a "---" line, a scissors line, and trailing comment
+    and blank lines are not part of the message.
+  - The last paragraph is a trailer block if all of its lines are trailers,
+    or if it contains a git-generated trailer (e.g. Signed-off-by) or a
+    configured key and at least 25% of its lines are trailers.
+  - Lines starting with whitespace continue the value of the previous
+    trailer, and are folded into it.
+
+# Basic Usage
+
+[Parse] returns the trailers of a message:
+
+	for _, t := range trailers.Parse(msg) {
+	    fmt.Printf("%s = %s\n", t.Key, t.Value)
+	}
+
+[Add] and [Remove] return a modified copy of a message:
+
+	msg = trailers.Add(msg, trailers.Trailer{Key: "Signed-off-by", Value: "A U Thor <author@example.com>"})
+
+The package-level functions behave like git's defaults. A [Config] mirrors the
+relevant git configuration (trailer.separators, core.commentChar, and
+configured trailer keys) for repositories that customize it.
+
+For more information, see the Git documentation for [git interpret-trailers].
+
+[git interpret-trailers]: https://git-scm.com/docs/git-interpret-trailers
+*/
+package trailers
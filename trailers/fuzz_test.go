@@ -0,0 +1,30 @@
+package trailers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/mroth/porcelain/fuzzseed"
+)
+
+// Fuzz test checking that a trailer added to any message is parsed back
+// after the trailers the message already had.
+func FuzzAdd(f *testing.F) {
+	// Add some seed inputs
+	fuzzseed.Add[string](f, "trailers")
+
+	sob := Trailer{Key: "Signed-off-by", Value: "X"}
+	f.Fuzz(func(t *testing.T, msg string) {
+		if !strings.HasSuffix(msg, "\n") {
+			// terminating the last line may turn it into a patch divider
+			return
+		}
+		want := append(Parse(msg), sob)
+		got := Parse(Add(msg, sob))
+		if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("Parse(Add(%q)) mismatch (-want +got):\n%s", msg, diff)
+		}
+	})
+}
@@ -0,0 +1,324 @@
+package trailers
+
+import "strings"
+
+// gitGeneratedPrefixes are trailer-like lines inserted by git itself. Their
+// presence lets a paragraph that also contains other lines count as a
+// trailer block.
+var gitGeneratedPrefixes = []string{
+	"Signed-off-by: ",
+	"(cherry picked from commit ",
+}
+
+// block describes the location of the trailer block within a message.
+type block struct {
+	start, end int  // byte offsets of the block within the message
+	found      bool // whether a trailer block exists
+}
+
+// Parse returns the trailers of msg.
+func (c Config) Parse(msg string) Trailers {
+	b := c.locate(msg)
+	var trailers Trailers
+	for _, item := range c.items(msg[b.start:b.end]) {
+		if t, ok := c.parseTrailer(item); ok {
+			trailers = append(trailers, t)
+		}
+	}
+	return trailers
+}
+
+// Add returns msg with the trailers appended to its trailer block. If msg has
+// no trailer block, a new one is started after a blank line at the end of the
+// message, before any patch or trailing comments.
+func (c Config) Add(msg string, trailers ...Trailer) string {
+	if len(trailers) == 0 {
+		return msg
+	}
+	b := c.locate(msg)
+	before, after := msg[:b.end], msg[b.end:]
+
+	var sb strings.Builder
+	sb.WriteString(before)
+	if before != "" && !strings.HasSuffix(before, "\n") {
+		sb.WriteByte('\n')
+	}
+	if !b.found && !endsWithBlankLine(before) {
+		sb.WriteByte('\n')
+	}
+	for _, t := range trailers {
+		sb.WriteString(t.String())
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(after)
+	return sb.String()
+}
+
+// Remove returns msg with all trailers whose key matches key removed. Keys are
+// compared case-insensitively. If the trailer block becomes empty, it is
+// removed along with the blank line separating it from the message body.
+func (c Config) Remove(msg string, key string) string {
+	b := c.locate(msg)
+	if !b.found {
+		return msg
+	}
+
+	var kept strings.Builder
+	for _, item := range c.items(msg[b.start:b.end]) {
+		if t, ok := c.parseTrailer(item); ok && strings.EqualFold(t.Key, key) {
+			continue
+		}
+		kept.WriteString(item)
+	}
+
+	start := b.start
+	if kept.Len() == 0 {
+		// drop the blank line preceding the block
+		if prev := lastLine(msg, start); prev >= 0 && isBlankLine(msg[prev:start]) {
+			start = prev
+		}
+	}
+	return msg[:start] + kept.String() + msg[b.end:]
+}
+
+// items splits a trailer block into items: a line along with any
+// continuation lines that follow it, when the line is a trailer.
+func (c Config) items(blk string) []string {
+	var items []string
+	last := -1 // index of the last item, if it is a trailer
+	for len(blk) > 0 {
+		line := blk[:nextLine(blk, 0)]
+		blk = blk[len(line):]
+		if last >= 0 && isSpace(line[0]) {
+			items[last] += line
+			continue
+		}
+		items = append(items, line)
+		last = -1
+		if c.findSeparator(strings.TrimSuffix(line, "\n")) >= 1 {
+			last = len(items) - 1
+		}
+	}
+	return items
+}
+
+// parseTrailer parses a single trailer item, which may span multiple lines.
+func (c Config) parseTrailer(item string) (Trailer, bool) {
+	if item[0] == c.commentChar() {
+		return Trailer{}, false
+	}
+	pos := c.findSeparator(item)
+	if pos < 1 {
+		return Trailer{}, false
+	}
+	return Trailer{
+		Key:   strings.TrimSpace(item[:pos]),
+		Value: unfold(item[pos+1:]),
+	}, true
+}
+
+// findSeparator returns the position of the separator in line, or -1 if line
+// does not start with a key followed by a separator. A key consists of
+// alphanumerics and hyphens, and may be followed by whitespace.
+func (c Config) findSeparator(line string) int {
+	seps := c.separators()
+	whitespace := false
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case strings.IndexByte(seps, ch) >= 0:
+			return i
+		case !whitespace && (isAlnum(ch) || ch == '-'):
+			continue
+		case i != 0 && (ch == ' ' || ch == '\t'):
+			whitespace = true
+			continue
+		}
+		break
+	}
+	return -1
+}
+
+// locate finds the trailer block of msg.
+func (c Config) locate(msg string) block {
+	end := c.endOfMessage(msg)
+	none := block{start: end, end: end}
+
+	// The first paragraph is the title and cannot contain trailers.
+	titleEnd := 0
+	for titleEnd < end && !isBlankLine(msg[titleEnd:nextLine(msg, titleEnd)]) {
+		titleEnd = nextLine(msg, titleEnd)
+	}
+	if titleEnd >= end {
+		return none
+	}
+
+	var (
+		onlySpaces       = true
+		recognizedPrefix = false
+		trailerLines     = 0
+		nonTrailerLines  = 0
+		continuations    = 0 // possible continuation lines
+		blockEnd         = end
+	)
+lines:
+	for l := lastLine(msg, end); l >= titleEnd; l = lastLine(msg, l) {
+		line := strings.TrimSuffix(msg[l:nextLine(msg, l)], "\n")
+
+		if strings.HasPrefix(line, string(c.commentChar())) {
+			nonTrailerLines += continuations
+			continuations = 0
+			continue
+		}
+		if isBlankLine(line) {
+			if onlySpaces {
+				// trailing whitespace lines are not part of the block
+				blockEnd = l
+				continue
+			}
+			nonTrailerLines += continuations
+			if (recognizedPrefix && trailerLines*3 >= nonTrailerLines) ||
+				(trailerLines > 0 && nonTrailerLines == 0) {
+				return block{start: nextLine(msg, l), end: blockEnd, found: true}
+			}
+			return none
+		}
+		onlySpaces = false
+
+		for _, prefix := range gitGeneratedPrefixes {
+			if strings.HasPrefix(line, prefix) {
+				trailerLines++
+				continuations = 0
+				recognizedPrefix = true
+				continue lines
+			}
+		}
+
+		switch pos := c.findSeparator(line); {
+		case pos >= 1 && !isSpace(line[0]):
+			trailerLines++
+			continuations = 0
+			if !recognizedPrefix && c.isConfiguredKey(strings.TrimSpace(line[:pos])) {
+				recognizedPrefix = true
+			}
+		case isSpace(line[0]):
+			continuations++
+		default:
+			nonTrailerLines += 1 + continuations
+			continuations = 0
+		}
+	}
+	return none
+}
+
+func (c Config) isConfiguredKey(key string) bool {
+	for _, k := range c.Keys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// endOfMessage returns the offset at which the log message of msg ends,
+// excluding any patch, scissors line and trailing comments or blank lines.
+func (c Config) endOfMessage(msg string) int {
+	end := len(msg)
+	for l := 0; l < len(msg); l = nextLine(msg, l) {
+		if strings.HasPrefix(msg[l:], "---") && l+3 < len(msg) && isSpace(msg[l+3]) {
+			end = l
+			break
+		}
+	}
+	return end - c.ignoredBytes(msg[:end])
+}
+
+// ignoredBytes returns the length of the trailing portion of msg that git
+// ignores: a scissors line and everything after it, and any trailing run of
+// comment lines, empty lines and old-style "Conflicts:" blocks.
+func (c Config) ignoredBytes(msg string) int {
+	cutoff := len(msg)
+	scissors := string(c.commentChar()) + " ------------------------ >8 ------------------------\n"
+	for l := 0; l < len(msg); l = nextLine(msg, l) {
+		if strings.HasPrefix(msg[l:], scissors) {
+			cutoff = l
+			break
+		}
+	}
+
+	boc := -1 // beginning of the trailing comment run
+	inConflicts := false
+	for l := 0; l < cutoff; l = nextLine(msg, l) {
+		switch {
+		case msg[l] == c.commentChar() || msg[l] == '\n':
+			if boc < 0 {
+				boc = l
+			}
+		case strings.HasPrefix(msg[l:], "Conflicts:\n"):
+			inConflicts = true
+			if boc < 0 {
+				boc = l
+			}
+		case inConflicts && msg[l] == '\t':
+			// a pathname in the conflicts block
+		case boc >= 0:
+			boc = -1
+			inConflicts = false
+		}
+	}
+	if boc >= 0 {
+		return len(msg) - boc
+	}
+	return len(msg) - cutoff
+}
+
+// unfold collapses each line break in a folded value, along with the
+// whitespace following it, into a single space.
+func unfold(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			for i+1 < len(s) && isSpace(s[i+1]) {
+				i++
+			}
+			sb.WriteByte(' ')
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// nextLine returns the offset of the line following the one at offset l.
+func nextLine(s string, l int) int {
+	if i := strings.IndexByte(s[l:], '\n'); i >= 0 {
+		return l + i + 1
+	}
+	return len(s)
+}
+
+// lastLine returns the offset of the line preceding offset l, or -1 if there
+// is none.
+func lastLine(s string, l int) int {
+	if l <= 0 {
+		return -1
+	}
+	return strings.LastIndexByte(s[:l-1], '\n') + 1
+}
+
+func endsWithBlankLine(s string) bool {
+	l := lastLine(s, len(s))
+	return l >= 0 && isBlankLine(s[l:])
+}
+
+func isBlankLine(line string) bool {
+	return strings.TrimLeft(line, " \t\n\v\f\r") == ""
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\v' || ch == '\f' || ch == '\r'
+}
+
+func isAlnum(ch byte) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || '0' <= ch && ch <= '9'
+}
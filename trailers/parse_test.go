@@ -0,0 +1,232 @@
+package trailers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Expected results in these tests were verified against the output of
+// `git interpret-trailers --parse`.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want Trailers
+	}{
+		{
+			name: "basic",
+			msg:  "Title\n\nSigned-off-by: A <a@b>\nCo-authored-by: B <b@c>\n",
+			want: Trailers{
+				{Key: "Signed-off-by", Value: "A <a@b>"},
+				{Key: "Co-authored-by", Value: "B <b@c>"},
+			},
+		},
+		{
+			name: "title only",
+			msg:  "Signed-off-by: A\n",
+			want: nil,
+		},
+		{
+			name: "folded value",
+			msg:  "Title\n\nbody\n\nReviewed-by: X\n  continued here\n\tand here\n",
+			want: Trailers{{Key: "Reviewed-by", Value: "X continued here and here"}},
+		},
+		{
+			name: "folded value trailing space",
+			msg:  "Title\n\nKey: a  \n  b\n",
+			want: Trailers{{Key: "Key", Value: "a   b"}},
+		},
+		{
+			name: "whitespace before separator and trailing comments",
+			msg:  "Title\n\nKey : value\nOther:v2\n# comment\n\n# trailing comment\n",
+			want: Trailers{{Key: "Key", Value: "value"}, {Key: "Other", Value: "v2"}},
+		},
+		{
+			name: "patch",
+			msg:  "Title\n\nKey: v\n---\nPatch: not\n",
+			want: Trailers{{Key: "Key", Value: "v"}},
+		},
+		{
+			name: "scissors",
+			msg:  "Title\n\nHelped-by: A\n# ------------------------ >8 ------------------------\nKey: b\n",
+			want: Trailers{{Key: "Helped-by", Value: "A"}},
+		},
+		{
+			name: "git-generated with 25% trailers",
+			msg:  "Title\n\nline1\nline2\nline3\nSigned-off-by: A\n",
+			want: Trailers{{Key: "Signed-off-by", Value: "A"}},
+		},
+		{
+			name: "git-generated with too few trailers",
+			msg:  "Title\n\nline1\nline2\nline3\nline4\nSigned-off-by: A\n",
+			want: nil,
+		},
+		{
+			name: "mixed without git-generated",
+			msg:  "Title\n\nKey: v\nnot a trailer\n",
+			want: nil,
+		},
+		{
+			name: "cherry picked",
+			msg:  "Title\n\n(cherry picked from commit abc)\nSigned-off-by: A\n",
+			want: Trailers{{Key: "Signed-off-by", Value: "A"}},
+		},
+		{
+			name: "trailing blank lines",
+			msg:  "Title\n\nKey: v\n\n\n",
+			want: Trailers{{Key: "Key", Value: "v"}},
+		},
+		{
+			name: "trailing whitespace line",
+			msg:  "Title\n\nKey: v\n   \n",
+			want: Trailers{{Key: "Key", Value: "v"}},
+		},
+		{
+			name: "no trailing newline",
+			msg:  "Title\n\nKey: v",
+			want: Trailers{{Key: "Key", Value: "v"}},
+		},
+		{
+			name: "url is not split at scheme",
+			msg:  "Title\n\nhttps://example.com: not\n",
+			want: Trailers{{Key: "https", Value: "//example.com: not"}},
+		},
+		{
+			name: "leading whitespace",
+			msg:  "Title\n\n key: v\n",
+			want: nil,
+		},
+		{
+			name: "unknown separator",
+			msg:  "Title\n\nBug= 123\nKey: v\n",
+			want: nil,
+		},
+		{
+			name: "empty",
+			msg:  "",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.msg)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConfig_Parse(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		msg  string
+		want Trailers
+	}{
+		{
+			name: "separators",
+			cfg:  Config{Separators: ":=#"},
+			msg:  "Title\n\nBug= 123\nKey: v\n",
+			want: Trailers{{Key: "Bug", Value: "123"}, {Key: "Key", Value: "v"}},
+		},
+		{
+			name: "configured key",
+			cfg:  Config{Keys: []string{"bug"}},
+			msg:  "Title\n\nx\ny\nz\nBug: 1\n",
+			want: Trailers{{Key: "Bug", Value: "1"}},
+		},
+		{
+			name: "configured key absent",
+			cfg:  Config{},
+			msg:  "Title\n\nx\ny\nz\nBug: 1\n",
+			want: nil,
+		},
+		{
+			name: "comment char",
+			cfg:  Config{CommentChar: ';'},
+			msg:  "Title\n\nKey: v\n; comment\n",
+			want: Trailers{{Key: "Key", Value: "v"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cfg.Parse(tt.msg)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// Expected results in these tests were verified against the output of
+// `git interpret-trailers --trailer 'Signed-off-by: X'`.
+func TestAdd(t *testing.T) {
+	sob := Trailer{Key: "Signed-off-by", Value: "X"}
+	tests := []struct {
+		name string
+		msg  string
+		want string
+	}{
+		{"title", "Title\n", "Title\n\nSigned-off-by: X\n"},
+		{"body", "Title\n\nbody\n", "Title\n\nbody\n\nSigned-off-by: X\n"},
+		{"existing block", "Title\n\nKey: v\n", "Title\n\nKey: v\nSigned-off-by: X\n"},
+		{"no trailing newline", "Title\n\nKey: v", "Title\n\nKey: v\nSigned-off-by: X\n"},
+		{"before comments", "Title\n\nKey: v\n# comment\n", "Title\n\nKey: v\nSigned-off-by: X\n# comment\n"},
+		{"before whitespace line", "Title\n\nKey: v\n \n", "Title\n\nKey: v\nSigned-off-by: X\n \n"},
+		{"before patch", "Title\n\nKey: v\n---\npatch\n", "Title\n\nKey: v\nSigned-off-by: X\n---\npatch\n"},
+		{"trailing blank lines", "Title\n\nbody\n\n\n", "Title\n\nbody\n\nSigned-off-by: X\n\n\n"},
+		{"empty", "", "\nSigned-off-by: X\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Add(tt.msg, sob)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Add() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		key  string
+		want string
+	}{
+		{
+			name: "one of many",
+			msg:  "Title\n\nbody\n\nSigned-off-by: A\nReviewed-by: B\n  folded\nsigned-off-by: C\n",
+			key:  "Reviewed-By",
+			want: "Title\n\nbody\n\nSigned-off-by: A\nsigned-off-by: C\n",
+		},
+		{
+			name: "whole block",
+			msg:  "Title\n\nbody\n\nSigned-off-by: A\nsigned-off-by: C\n# comment\n",
+			key:  "Signed-off-by",
+			want: "Title\n\nbody\n# comment\n",
+		},
+		{
+			name: "keeps other lines",
+			msg:  "Title\n\n(cherry picked from commit abc)\nSigned-off-by: A\n",
+			key:  "Signed-off-by",
+			want: "Title\n\n(cherry picked from commit abc)\n",
+		},
+		{
+			name: "no block",
+			msg:  "Title\n\nSigned-off-by: A\nline1\nline2\nline3\nline4\n",
+			key:  "Signed-off-by",
+			want: "Title\n\nSigned-off-by: A\nline1\nline2\nline3\nline4\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Remove(tt.msg, tt.key)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Remove() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
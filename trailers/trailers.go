@@ -0,0 +1,71 @@
+package trailers
+
+import "strings"
+
+// Trailer represents a single trailer.
+type Trailer struct {
+	Key   string // trailer key (token), as written
+	Value string // trailer value, with continuation lines folded
+}
+
+// String returns the trailer in its canonical "Key: value" form.
+func (t Trailer) String() string {
+	return t.Key + ": " + t.Value
+}
+
+// Trailers is a list of trailers, in the order they appear in a message.
+type Trailers []Trailer
+
+// Values returns the values of all trailers whose key matches key, compared
+// case-insensitively as git does.
+func (ts Trailers) Values(key string) []string {
+	var values []string
+	for _, t := range ts {
+		if strings.EqualFold(t.Key, key) {
+			values = append(values, t.Value)
+		}
+	}
+	return values
+}
+
+// Config controls how trailers are recognized, mirroring git configuration.
+//
+// The zero Config matches git's defaults.
+type Config struct {
+	// Separators is the set of characters that separate a key from its value
+	// (trailer.separators). Defaults to ":".
+	Separators string
+
+	// CommentChar starts comment lines (core.commentChar). Defaults to '#'.
+	CommentChar byte
+
+	// Keys are configured trailer keys (trailer.<token>.key). Like
+	// git-generated trailers, they allow a paragraph mixing trailers and
+	// other lines to be recognized as a trailer block.
+	Keys []string
+}
+
+// Parse returns the trailers of msg, using git's default configuration.
+func Parse(msg string) Trailers { return Config{}.Parse(msg) }
+
+// Add returns msg with the trailers appended to its trailer block, using
+// git's default configuration.
+func Add(msg string, trailers ...Trailer) string { return Config{}.Add(msg, trailers...) }
+
+// Remove returns msg with all trailers whose key matches key removed, using
+// git's default configuration.
+func Remove(msg string, key string) string { return Config{}.Remove(msg, key) }
+
+func (c Config) separators() string {
+	if c.Separators == "" {
+		return ":"
+	}
+	return c.Separators
+}
+
+func (c Config) commentChar() byte {
+	if c.CommentChar == 0 {
+		return '#'
+	}
+	return c.CommentChar
+}
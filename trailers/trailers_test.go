@@ -0,0 +1,28 @@
+package trailers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTrailers_Values(t *testing.T) {
+	ts := Trailers{
+		{Key: "Signed-off-by", Value: "A"},
+		{Key: "Reviewed-by", Value: "B"},
+		{Key: "signed-off-by", Value: "C"},
+	}
+	if diff := cmp.Diff([]string{"A", "C"}, ts.Values("Signed-Off-By")); diff != "" {
+		t.Errorf("Values() mismatch (-want +got):\n%s", diff)
+	}
+	if got := ts.Values("Acked-by"); got != nil {
+		t.Errorf("Values() = %q, want nil", got)
+	}
+}
+
+func TestTrailer_String(t *testing.T) {
+	tr := Trailer{Key: "Co-authored-by", Value: "B <b@example.com>"}
+	if got, want := tr.String(), "Co-authored-by: B <b@example.com>"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Package watch delivers live updates of the status of a git repository, for
+prompts, TUIs and editors.
+
+# Basic Usage
+
+[New] starts a [Watcher], which polls `git status` in the background and
+sends each new status on its [Watcher.Updates] channel:
+
+	w := watch.New("/path/to/repo", watch.Interval(2*time.Second))
+	defer w.Close()
+	for s := range w.Updates() {
+	    fmt.Printf("%s: %d entries\n", s.Branch.Head, len(s.Entries))
+	}
+
+Statuses are gathered with branch and stash information, as with
+`git status --porcelain=v2 --branch --show-stash`, and only delivered when
+they differ from the previous one (see [statusv2.Status.Equal]). The first
+status is always delivered.
+
+# Timing
+
+Polls are spread out by a random [Jitter], so that many watchers started at
+once do not all run git at the same time. [Watcher.Refresh] requests a poll
+ahead of schedule, for example when an editor saves a file. Refreshes are
+debounced (see [Debounce]), so a burst of them results in a single poll once
+it has settled.
+
+[Triggers] connect a Watcher to sources of refreshes, such as filesystem
+events, so that updates arrive within milliseconds of a change rather than on
+the next poll. The fswatch module provides a [Trigger] using fsnotify, kept
+separate so that this package has no dependencies:
+
+	w := watch.New(repo, watch.Triggers(fswatch.Trigger{}), watch.Debounce(20*time.Millisecond))
+
+If consumers fall behind, only the latest status is kept, so a slow consumer
+never blocks polling and never sees a stale status after a newer one.
+
+# File Events
+
+With the [FileEvents] option, a Watcher also delivers batches of [FileEvent]
+on [Watcher.FileEvents], describing how individual files changed, such as
+becoming dirty, staged or resolved. Editors can use them to update the
+decorations of just the affected paths:
+
+	w := watch.New(repo, watch.FileEvents())
+	for events := range w.FileEvents() {
+	    for _, ev := range events {
+	        fmt.Println(ev.Kind, ev.Path())
+	    }
+	}
+
+Events are computed by comparing statuses with [statusv2.Diff], and are also
+available for any two statuses from [DiffEvents].
+
+# Many Repositories
+
+A [Registry] manages watchers for many repositories, such as in a dashboard
+daemon tracking dozens of checkouts. The watchers share a pool of workers,
+limiting how many git processes run at once and how often they start, and
+their updates are merged into a single stream of events tagged by repository:
+
+	r := watch.NewRegistry(watch.RegistryConfig{Workers: 4, MinGap: 50 * time.Millisecond})
+	defer r.Close()
+	for _, repo := range repos {
+	    r.Add(repo)
+	}
+	for ev := range r.Events() {
+	    fmt.Println(ev.Repo, ev.Status, ev.Err)
+	}
+
+# Errors
+
+Failures to run or parse `git status` are sent on [Watcher.Errors], with
+polling continuing regardless, as they are often transient (e.g. a concurrent
+git process holding the index lock). The first status after an error is
+always delivered, so that consumers can tell that polling has recovered.
+
+By default, git runs without taking optional locks, as [gitexec.Git] does, so
+that polling does not interfere with git commands run by the user.
+*/
+package watch
@@ -0,0 +1,119 @@
+package watch
+
+import (
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// A FileEventKind classifies how the status of a file changed.
+type FileEventKind int
+
+// File event kinds. Each change of a file is classified as the first kind in
+// this list that applies.
+const (
+	FileConflicted  FileEventKind = iota // became unmerged
+	FileResolved                         // was unmerged, and no longer is
+	FileTracked                          // was untracked, and is now tracked (e.g. by `git add`)
+	FileUntracked                        // became untracked, or appeared as untracked
+	FileIgnored                          // became ignored, or appeared as ignored
+	FileBecameDirty                      // had no entry, and now has changes
+	FileBecameClean                      // had an entry, and now has none (e.g. committed or reverted)
+	FileStaged                           // gained changes in the index
+	FileUnstaged                         // no longer has changes in the index
+	FileModified                         // any other change of its entry
+)
+
+var fileEventKindNames = [...]string{
+	FileConflicted:  "conflicted",
+	FileResolved:    "resolved",
+	FileTracked:     "tracked",
+	FileUntracked:   "untracked",
+	FileIgnored:     "ignored",
+	FileBecameDirty: "dirty",
+	FileBecameClean: "clean",
+	FileStaged:      "staged",
+	FileUnstaged:    "unstaged",
+	FileModified:    "modified",
+}
+
+func (k FileEventKind) String() string {
+	if k < 0 || int(k) >= len(fileEventKindNames) {
+		return "unknown"
+	}
+	return fileEventKindNames[k]
+}
+
+// A FileEvent describes how the status of a single file changed.
+type FileEvent struct {
+	Kind   FileEventKind
+	Change statusv2.Change // the entries of the file before and after
+}
+
+// Path returns the path of the file.
+func (e FileEvent) Path() string { return e.Change.Path }
+
+// DiffEvents returns the events for the files that differ between two
+// statuses, in path order, as computed by [statusv2.Diff].
+func DiffEvents(old, new *statusv2.Status) []FileEvent {
+	changes := statusv2.Diff(old, new)
+	if len(changes) == 0 {
+		return nil
+	}
+	events := make([]FileEvent, len(changes))
+	for i, c := range changes {
+		events[i] = FileEvent{Kind: classify(c), Change: c}
+	}
+	return events
+}
+
+func classify(c statusv2.Change) FileEventKind {
+	oldType, newType := entryType(c.Old), entryType(c.New)
+	switch {
+	case newType == statusv2.EntryTypeUnmerged && oldType != statusv2.EntryTypeUnmerged:
+		return FileConflicted
+	case oldType == statusv2.EntryTypeUnmerged && newType != statusv2.EntryTypeUnmerged:
+		return FileResolved
+	case oldType == statusv2.EntryTypeUntracked && isTracked(newType):
+		return FileTracked
+	case newType == statusv2.EntryTypeUntracked:
+		return FileUntracked
+	case newType == statusv2.EntryTypeIgnored:
+		return FileIgnored
+	case c.Added():
+		return FileBecameDirty
+	case c.Removed():
+		return FileBecameClean
+	}
+
+	oldX, newX := indexState(c.Old), indexState(c.New)
+	switch {
+	case newX != statusv2.Unmodified && newX != oldX:
+		return FileStaged
+	case newX == statusv2.Unmodified && oldX != statusv2.Unmodified:
+		return FileUnstaged
+	default:
+		return FileModified
+	}
+}
+
+// entryType returns the type of e, or -1 if it is nil.
+func entryType(e statusv2.Entry) statusv2.EntryType {
+	if e == nil {
+		return -1
+	}
+	return e.Type()
+}
+
+func isTracked(t statusv2.EntryType) bool {
+	return t == statusv2.EntryTypeChanged || t == statusv2.EntryTypeRenameOrCopy
+}
+
+// indexState returns the X state of a changed or renamed entry.
+func indexState(e statusv2.Entry) statusv2.State {
+	switch e := e.(type) {
+	case statusv2.ChangedEntry:
+		return e.XY.X
+	case statusv2.RenameOrCopyEntry:
+		return e.XY.X
+	}
+	return statusv2.Unmodified
+}
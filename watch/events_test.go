@@ -0,0 +1,111 @@
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mroth/porcelain/statusv2"
+)
+
+func changed(xy string) statusv2.ChangedEntry {
+	return statusv2.ChangedEntry{XY: statusv2.XYFlag{X: statusv2.State(xy[0]), Y: statusv2.State(xy[1])}, Path: "f"}
+}
+
+func Test_classify(t *testing.T) {
+	var (
+		unmerged  = statusv2.UnmergedEntry{XY: statusv2.XYFlag{X: 'U', Y: 'U'}, Path: "f"}
+		untracked = statusv2.UntrackedEntry{Path: "f"}
+		ignored   = statusv2.IgnoredEntry{Path: "f"}
+	)
+	tests := []struct {
+		name     string
+		old, new statusv2.Entry
+		want     FileEventKind
+	}{
+		{"conflict", changed(".M"), unmerged, FileConflicted},
+		{"conflict appeared", nil, unmerged, FileConflicted},
+		{"resolved", unmerged, changed("M."), FileResolved},
+		{"resolved and committed", unmerged, nil, FileResolved},
+		{"added", untracked, changed("A."), FileTracked},
+		{"untracked appeared", nil, untracked, FileUntracked},
+		{"removed from index", changed("D."), untracked, FileUntracked},
+		{"ignored", nil, ignored, FileIgnored},
+		{"became dirty", nil, changed(".M"), FileBecameDirty},
+		{"committed", changed("M."), nil, FileBecameClean},
+		{"untracked deleted", untracked, nil, FileBecameClean},
+		{"staged", changed(".M"), changed("M."), FileStaged},
+		{"staged partially", changed(".M"), changed("MM"), FileStaged},
+		{"unstaged", changed("M."), changed(".M"), FileUnstaged},
+		{"worktree changed", changed(".M"), changed(".D"), FileModified},
+		{"modified again", changed("M."), changed("MM"), FileModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classify(statusv2.Change{Path: "f", Old: tt.old, New: tt.new}); got != tt.want {
+				t.Errorf("classify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffEvents(t *testing.T) {
+	old := &statusv2.Status{Entries: []statusv2.Entry{statusv2.UntrackedEntry{Path: "a"}, changed(".M")}}
+	new := &statusv2.Status{Entries: []statusv2.Entry{statusv2.ChangedEntry{XY: statusv2.XYFlag{X: 'A', Y: '.'}, Path: "a"}, changed(".M")}}
+
+	events := DiffEvents(old, new)
+	if len(events) != 1 || events[0].Path() != "a" || events[0].Kind != FileTracked {
+		t.Errorf("DiffEvents() = %v, want a tracked event for a", events)
+	}
+	if events := DiffEvents(old, old); events != nil {
+		t.Errorf("DiffEvents() of equal statuses = %v, want nil", events)
+	}
+}
+
+func TestFileEventKind_String(t *testing.T) {
+	if got := FileStaged.String(); got != "staged" {
+		t.Errorf("String() = %q, want %q", got, "staged")
+	}
+	if got := FileEventKind(-1).String(); got != "unknown" {
+		t.Errorf("String() = %q, want %q", got, "unknown")
+	}
+}
+
+func TestWatcher_FileEvents(t *testing.T) {
+	const (
+		clean    = "# branch.oid (initial)\x00# branch.head main\x00"
+		modified = clean + "1 .M N... 100644 100644 100644 ce013625030ba8dba906f756967f9e9ca394464a ce013625030ba8dba906f756967f9e9ca394464a a.txt\x00"
+		staged   = clean + "1 M. N... 100644 100644 100644 ce013625030ba8dba906f756967f9e9ca394464a 1e0138f2e1b34b2c2a6a8e1b4e0fd8a8e6e04a0d a.txt\x00"
+	)
+	git := &fakeRunner{out: clean}
+	w := New("", Runner(git), Interval(time.Hour), Debounce(time.Millisecond), FileEvents())
+	defer w.Close()
+	receive(t, w.Updates())
+
+	// no events for the first status
+	select {
+	case events := <-w.FileEvents():
+		t.Fatalf("got events %v for the first status", events)
+	default:
+	}
+
+	git.set(modified, nil)
+	w.Refresh()
+	receive(t, w.Updates())
+	if events := receive(t, w.FileEvents()); len(events) != 1 || events[0].Kind != FileBecameDirty {
+		t.Fatalf("got events %v, want a.txt dirty", events)
+	}
+
+	// unreceived batches are combined
+	git.set(staged, nil)
+	w.Refresh()
+	receive(t, w.Updates())
+	git.set(clean, nil)
+	w.Refresh()
+	receive(t, w.Updates())
+	git.set(staged, nil)
+	w.Refresh()
+	receive(t, w.Updates())
+	if events := receive(t, w.FileEvents()); len(events) != 1 || events[0].Kind != FileStaged {
+		t.Fatalf("got events %v, want a.txt staged", events)
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Package fswatch provides a [watch.Trigger] that refreshes a watcher on
+filesystem events, using fsnotify, so that status updates arrive within
+milliseconds of a change instead of on the next poll.
+
+# Basic Usage
+
+	w := watch.New(repo,
+	    watch.Triggers(fswatch.Trigger{}),
+	    watch.Debounce(20*time.Millisecond),
+	)
+
+The worktree is watched recursively, along with the files in the git
+directory that affect the status: the index, HEAD and the state files of
+operations such as merges, and the refs, for ahead/behind counts. The churn of
+.git/objects, and of lock files, is ignored, so that git commands do not
+trigger refreshes until they have updated what they lock.
+
+Directories created in the worktree are watched as they appear. Each watched
+directory uses an inotify watch (or the equivalent) on most platforms, so very
+large worktrees may exceed the system limit, in which case the Trigger fails
+and the watcher falls back to polling.
+
+This package is a separate module, so that users of the watch package who do
+not need it do not depend on fsnotify.
+*/
+package fswatch
@@ -0,0 +1,174 @@
+package fswatch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/mroth/porcelain/gitexec"
+)
+
+// Trigger is a [watch.Trigger] that requests refreshes on filesystem events in
+// the repository.
+type Trigger struct {
+	// SkipDir, if set, reports whether to not watch a directory in the
+	// worktree, such as a large ignored build directory, given its path
+	// relative to the top of the worktree.
+	SkipDir func(rel string) bool
+}
+
+// Watch implements [watch.Trigger].
+func (t Trigger) Watch(ctx context.Context, repoPath string, refresh func()) error {
+	paths, err := findPaths(ctx, gitexec.New(repoPath))
+	if err != nil {
+		return err
+	}
+
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fw.Close()
+
+	if err := t.addTree(fw, paths, paths.top); err != nil {
+		return err
+	}
+	if err := fw.Add(paths.gitDir); err != nil {
+		return err
+	}
+	if err := addDirs(fw, filepath.Join(paths.commonDir, "refs")); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-fw.Errors:
+			return err
+		case ev := <-fw.Events:
+			if paths.ignore(ev.Name) {
+				continue
+			}
+			if ev.Has(fsnotify.Create) {
+				if err := t.addCreated(fw, paths, ev.Name); err != nil {
+					return err
+				}
+			}
+			refresh()
+		}
+	}
+}
+
+// repoPaths are the locations watched for a repository.
+type repoPaths struct {
+	top       string // top of the worktree
+	gitDir    string // git directory of the worktree
+	commonDir string // git directory shared by all worktrees
+}
+
+func findPaths(ctx context.Context, git gitexec.Runner) (repoPaths, error) {
+	out, err := git.Run(ctx, "rev-parse", "--show-toplevel", "--absolute-git-dir", "--git-common-dir")
+	if err != nil {
+		return repoPaths{}, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 3 {
+		return repoPaths{}, errors.New("fswatch: unexpected git rev-parse output")
+	}
+	p := repoPaths{top: lines[0], gitDir: lines[1], commonDir: lines[2]}
+	if !filepath.IsAbs(p.commonDir) {
+		// relative to the current directory, which is repoPath
+		p.commonDir = filepath.Join(p.gitDir, "..", p.commonDir)
+		if rel, err := filepath.Rel(p.gitDir, p.commonDir); err == nil && rel == "." {
+			p.commonDir = p.gitDir
+		}
+	}
+	return p, nil
+}
+
+// inGitDir reports whether path is inside the git directory.
+func (p repoPaths) inGitDir(path string) bool {
+	return within(path, p.gitDir) || within(path, p.commonDir)
+}
+
+// ignore reports whether an event for path should not trigger a refresh.
+func (p repoPaths) ignore(path string) bool {
+	if strings.HasSuffix(path, ".lock") {
+		return true // the locked file is updated when the lock is released
+	}
+	for _, dir := range []string{p.gitDir, p.commonDir} {
+		if within(path, filepath.Join(dir, "objects")) || within(path, filepath.Join(dir, "logs")) {
+			return true
+		}
+	}
+	return false
+}
+
+// within reports whether path is dir or inside it.
+func within(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// addCreated watches a newly created directory in the worktree or the refs,
+// whose contents may also be new.
+func (t Trigger) addCreated(fw *fsnotify.Watcher, paths repoPaths, path string) error {
+	fi, err := os.Lstat(path)
+	if err != nil || !fi.IsDir() {
+		return nil
+	}
+	switch {
+	case within(path, filepath.Join(paths.commonDir, "refs")):
+		return addDirs(fw, path)
+	case !paths.inGitDir(path):
+		return t.addTree(fw, paths, path)
+	}
+	return nil
+}
+
+// addTree watches the worktree directory root and its subdirectories, except
+// for git directories (including those of submodules and nested repositories)
+// and those skipped by SkipDir.
+func (t Trigger) addTree(fw *fsnotify.Watcher, paths repoPaths, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil // removed while walking
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if d.Name() == ".git" || paths.inGitDir(path) {
+			return filepath.SkipDir
+		}
+		if t.SkipDir != nil {
+			if rel, err := filepath.Rel(paths.top, path); err == nil && rel != "." && t.SkipDir(filepath.ToSlash(rel)) {
+				return filepath.SkipDir
+			}
+		}
+		return fw.Add(path)
+	})
+}
+
+// addDirs watches root and all its subdirectories.
+func addDirs(fw *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return fw.Add(path)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,131 @@
+package fswatch
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+func gitInit(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+	// resolve symlinks in the temporary directory, as git does
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// startTrigger runs a Trigger on dir, returning a counter of its refreshes.
+func startTrigger(t *testing.T, trigger Trigger, dir string) *atomic.Int32 {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	var refreshes atomic.Int32
+	go func() { done <- trigger.Watch(ctx, dir, func() { refreshes.Add(1) }) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Watch() error = %v", err)
+		}
+	})
+	time.Sleep(100 * time.Millisecond) // let the watches be added
+	return &refreshes
+}
+
+// waitFor waits for the count of refreshes to increase from before.
+func waitFor(t *testing.T, refreshes *atomic.Int32, before int32, what string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for refreshes.Load() == before {
+		if time.Now().After(deadline) {
+			t.Fatalf("no refresh after %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	dir := gitInit(t)
+	refreshes := startTrigger(t, Trigger{}, dir)
+
+	before := refreshes.Load()
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, refreshes, before, "writing a file")
+
+	// new directories are watched
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	before = refreshes.Load()
+	if err := os.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("b"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, refreshes, before, "writing a file in a new directory")
+
+	// staging updates the index
+	time.Sleep(50 * time.Millisecond)
+	before = refreshes.Load()
+	if _, err := gitexec.New(dir).Run(context.Background(), "add", "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, refreshes, before, "git add")
+}
+
+func TestTrigger_SkipDir(t *testing.T) {
+	dir := gitInit(t)
+	if err := os.Mkdir(filepath.Join(dir, "build"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	trigger := Trigger{SkipDir: func(rel string) bool { return rel == "build" }}
+	refreshes := startTrigger(t, trigger, dir)
+
+	before := refreshes.Load()
+	if err := os.WriteFile(filepath.Join(dir, "build", "out.o"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if n := refreshes.Load(); n != before {
+		t.Errorf("got %d refreshes for a skipped directory", n-before)
+	}
+}
+
+func Test_repoPaths_ignore(t *testing.T) {
+	p := repoPaths{top: "/repo", gitDir: "/repo/.git", commonDir: "/repo/.git"}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/repo/a.txt", false},
+		{"/repo/.git/index", false},
+		{"/repo/.git/HEAD", false},
+		{"/repo/.git/refs/heads/main", false},
+		{"/repo/.git/index.lock", true},
+		{"/repo/.git/refs/heads/main.lock", true},
+		{"/repo/.git/objects", true},
+		{"/repo/.git/objects/ab/cdef", true},
+		{"/repo/.git/logs/HEAD", true},
+		{"/repo/objects/a.txt", false},
+	}
+	for _, tt := range tests {
+		if got := p.ignore(tt.path); got != tt.want {
+			t.Errorf("ignore(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
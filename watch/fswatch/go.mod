@@ -0,0 +1,12 @@
+module github.com/mroth/porcelain/watch/fswatch
+
+go 1.24
+
+require (
+	github.com/fsnotify/fsnotify v1.9.0
+	github.com/mroth/porcelain v0.0.0
+)
+
+require golang.org/x/sys v0.13.0 // indirect
+
+replace github.com/mroth/porcelain => ../..
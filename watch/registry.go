@@ -0,0 +1,256 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// An Event is an update from one of the watchers of a [Registry].
+type Event struct {
+	Repo   string           // repository path, as passed to Registry.Add
+	Status *statusv2.Status // nil if Err is set
+	Err    error
+}
+
+// RegistryConfig configures a Registry. The zero value is ready to use.
+type RegistryConfig struct {
+	// Workers is the maximum number of git processes to run at once across
+	// all repositories. If zero, it defaults to the number of CPUs.
+	Workers int
+
+	// MinGap is the minimum time between starting git processes across all
+	// repositories, to limit the load of polling many repositories. If zero,
+	// processes are only limited by Workers.
+	MinGap time.Duration
+
+	// Options are applied to every watcher, before those passed to Add.
+	Options []Option
+
+	// NewRunner returns the Runner used for the repository at dir. If nil,
+	// git runs in dir without taking optional locks, as with [New].
+	NewRunner func(dir string) gitexec.Runner
+}
+
+// A Registry manages watchers for many repositories, for example in a
+// dashboard daemon, sharing a pool of workers between them and merging their
+// updates into a single stream of events.
+type Registry struct {
+	cfg    RegistryConfig
+	pool   *pool
+	events chan Event
+
+	mu       sync.Mutex
+	watchers map[string]*registered
+	closed   bool
+}
+
+// registered is a watcher in a Registry.
+type registered struct {
+	watcher *Watcher
+	stop    chan struct{} // closed to stop forwarding events
+	done    chan struct{} // closed once forwarding has stopped
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry(cfg RegistryConfig) *Registry {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if cfg.NewRunner == nil {
+		cfg.NewRunner = newRunner
+	}
+	return &Registry{
+		cfg:      cfg,
+		pool:     &pool{sem: make(chan struct{}, workers), gap: cfg.MinGap},
+		events:   make(chan Event),
+		watchers: map[string]*registered{},
+	}
+}
+
+// Events returns the channel on which the updates and errors of all watchers
+// are delivered. It is closed once the Registry has been closed.
+func (r *Registry) Events() <-chan Event {
+	return r.events
+}
+
+// Add starts watching the repository at repoPath, with opts applied after
+// those of the [RegistryConfig]. It is an error to add a repository twice.
+//
+// Options setting the [Runner] are ignored, in favor of
+// [RegistryConfig.NewRunner], so that git runs in the shared pool.
+func (r *Registry) Add(repoPath string, opts ...Option) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return fmt.Errorf("watch: registry closed")
+	}
+	if _, ok := r.watchers[repoPath]; ok {
+		return fmt.Errorf("watch: repository already registered: %s", repoPath)
+	}
+
+	git := &pooledRunner{pool: r.pool, git: r.cfg.NewRunner(repoPath)}
+	opts = append(slices.Concat(r.cfg.Options, opts), Runner(git))
+	reg := &registered{
+		watcher: New(repoPath, opts...),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	r.watchers[repoPath] = reg
+	go r.forward(repoPath, reg)
+	return nil
+}
+
+// forward sends the updates and errors of a watcher as events, until the
+// watcher is closed or removed.
+func (r *Registry) forward(repo string, reg *registered) {
+	defer close(reg.done)
+	updates, errs := reg.watcher.Updates(), reg.watcher.Errors()
+	for updates != nil || errs != nil {
+		var ev Event
+		select {
+		case <-reg.stop:
+			return
+		case s, ok := <-updates:
+			if !ok {
+				updates = nil
+				continue
+			}
+			ev = Event{Repo: repo, Status: s}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			ev = Event{Repo: repo, Err: err}
+		}
+		select {
+		case <-reg.stop:
+			return
+		case r.events <- ev:
+		}
+	}
+}
+
+// Remove stops watching the repository at repoPath, if it is registered. No
+// further events are delivered for it once Remove returns.
+func (r *Registry) Remove(repoPath string) {
+	r.mu.Lock()
+	reg, ok := r.watchers[repoPath]
+	delete(r.watchers, repoPath)
+	r.mu.Unlock()
+	if ok {
+		reg.shutdown()
+	}
+}
+
+func (reg *registered) shutdown() {
+	close(reg.stop)
+	<-reg.done
+	reg.watcher.Close()
+}
+
+// Repos returns the paths of the registered repositories, sorted.
+func (r *Registry) Repos() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	repos := make([]string, 0, len(r.watchers))
+	for repo := range r.watchers {
+		repos = append(repos, repo)
+	}
+	slices.Sort(repos)
+	return repos
+}
+
+// Close stops all watchers, and closes the Events channel.
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	watchers := r.watchers
+	r.watchers = nil
+	r.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, reg := range watchers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reg.shutdown()
+		}()
+	}
+	wg.Wait()
+	close(r.events)
+	return nil
+}
+
+// pool limits the git processes run by the watchers of a Registry.
+type pool struct {
+	sem chan struct{} // holds a token for each running process
+	gap time.Duration
+
+	mu   sync.Mutex
+	next time.Time // earliest time the next process may start
+}
+
+// acquire waits for a worker, and for the minimum gap since the last process
+// started.
+func (p *pool) acquire(ctx context.Context) error {
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if p.gap <= 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	start := p.next
+	if start.Before(now) {
+		start = now
+	}
+	p.next = start.Add(p.gap)
+	p.mu.Unlock()
+
+	if wait := start.Sub(now); wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			p.release()
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (p *pool) release() {
+	<-p.sem
+}
+
+// pooledRunner runs git in a pool.
+type pooledRunner struct {
+	pool *pool
+	git  gitexec.Runner
+}
+
+func (r *pooledRunner) Run(ctx context.Context, args ...string) ([]byte, error) {
+	if err := r.pool.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer r.pool.release()
+	return r.git.Run(ctx, args...)
+}
@@ -0,0 +1,136 @@
+package watch
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mroth/porcelain/gitexec"
+)
+
+func TestRegistry(t *testing.T) {
+	runners := map[string]*fakeRunner{
+		"/a": {out: statusA},
+		"/b": {out: statusB},
+	}
+	r := NewRegistry(RegistryConfig{
+		Options:   []Option{Interval(5 * time.Millisecond)},
+		NewRunner: func(dir string) gitexec.Runner { return runners[dir] },
+	})
+	defer r.Close()
+
+	for _, repo := range []string{"/b", "/a"} {
+		if err := r.Add(repo); err != nil {
+			t.Fatalf("Add(%q) error = %v", repo, err)
+		}
+	}
+	if err := r.Add("/a"); err == nil {
+		t.Errorf("Add() of a registered repository succeeded")
+	}
+	if got, want := r.Repos(), []string{"/a", "/b"}; !slices.Equal(got, want) {
+		t.Errorf("Repos() = %v, want %v", got, want)
+	}
+
+	// events are tagged with their repository
+	entries := map[string]int{}
+	for len(entries) < 2 {
+		ev := receive(t, r.Events())
+		if ev.Err != nil {
+			t.Fatalf("event error = %v", ev.Err)
+		}
+		entries[ev.Repo] = len(ev.Status.Entries)
+	}
+	if entries["/a"] != 1 || entries["/b"] != 2 {
+		t.Errorf("got entry counts %v", entries)
+	}
+
+	// no events after Remove
+	r.Remove("/a")
+	runners["/a"].set(statusB, nil)
+	runners["/b"].set(statusA, nil)
+	if ev := receive(t, r.Events()); ev.Repo != "/b" {
+		t.Errorf("got event for %s after removing it", ev.Repo)
+	}
+	if got, want := r.Repos(), []string{"/b"}; !slices.Equal(got, want) {
+		t.Errorf("Repos() = %v, want %v", got, want)
+	}
+}
+
+func TestRegistry_Close(t *testing.T) {
+	r := NewRegistry(RegistryConfig{
+		NewRunner: func(dir string) gitexec.Runner { return &fakeRunner{out: statusA} },
+	})
+	if err := r.Add("/a"); err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	for range r.Events() {
+	}
+	if err := r.Add("/b"); err == nil {
+		t.Errorf("Add() after Close succeeded")
+	}
+	r.Close() // closing twice is fine
+}
+
+// slowRunner records the maximum number of concurrent runs.
+type slowRunner struct {
+	mu        sync.Mutex
+	running   int
+	maxActive int
+}
+
+func (s *slowRunner) Run(ctx context.Context, args ...string) ([]byte, error) {
+	s.mu.Lock()
+	s.running++
+	s.maxActive = max(s.maxActive, s.running)
+	s.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	s.mu.Lock()
+	s.running--
+	s.mu.Unlock()
+	return []byte(statusA), nil
+}
+
+func TestRegistry_Workers(t *testing.T) {
+	git := &slowRunner{}
+	r := NewRegistry(RegistryConfig{
+		Workers:   2,
+		Options:   []Option{Interval(time.Millisecond)},
+		NewRunner: func(dir string) gitexec.Runner { return git },
+	})
+	defer r.Close()
+	for _, repo := range []string{"/a", "/b", "/c", "/d", "/e"} {
+		r.Add(repo)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	git.mu.Lock()
+	defer git.mu.Unlock()
+	if git.maxActive > 2 {
+		t.Errorf("got %d concurrent runs, want at most 2", git.maxActive)
+	}
+}
+
+func TestPool_MinGap(t *testing.T) {
+	p := &pool{sem: make(chan struct{}, 10), gap: 20 * time.Millisecond}
+	start := time.Now()
+	for range 4 {
+		if err := p.acquire(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		p.release()
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("4 acquires took %v, want at least 3 gaps of 20ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.acquire(ctx); err == nil {
+		t.Errorf("acquire() with canceled context succeeded")
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Package teawatch provides a Bubble Tea component showing the live status of a
+git repository, so that terminal user interfaces can embed it with a few lines
+of code.
+
+# Basic Usage
+
+A [Model] wraps a [watch.Watcher], and renders its latest status as a panel
+with the branch, and the conflicted, staged, unstaged, untracked and ignored
+files in sections. To embed it in another model, create it with the watcher,
+start it from Init, pass it all messages in Update, and include its View:
+
+	w := watch.New(repo)
+	defer w.Close()
+	m := model{status: teawatch.New(w)}
+	m.status.MaxLines = 20
+
+	func (m model) Init() tea.Cmd { return m.status.Init() }
+
+	func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	    _, cmd := m.status.Update(msg)
+	    // handle other messages...
+	    return m, cmd
+	}
+
+	func (m model) View() string { return m.status.View() }
+
+Models only act on the messages of their own watcher, so several can be
+embedded in the same program, such as one for each of a set of repositories.
+[StatusMsg] and [ErrorMsg] are exported so that the embedding model can react
+to updates as well.
+
+This package is a separate module, so that users of the watch package who do
+not need it do not depend on Bubble Tea.
+*/
+package teawatch
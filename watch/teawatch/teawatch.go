@@ -0,0 +1,226 @@
+package teawatch
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mroth/porcelain/statusv2"
+	"github.com/mroth/porcelain/watch"
+)
+
+// StatusMsg is sent when the watcher delivers a new status.
+type StatusMsg struct {
+	Status  *statusv2.Status
+	watcher *watch.Watcher
+}
+
+// ErrorMsg is sent when the watcher fails to get the status.
+type ErrorMsg struct {
+	Err     error
+	watcher *watch.Watcher
+}
+
+// Styles holds the styles used to render the panel.
+type Styles struct {
+	Header    lipgloss.Style // branch line
+	Section   lipgloss.Style // section titles
+	Error     lipgloss.Style // error message
+	Muted     lipgloss.Style // clean worktree and truncation messages
+	Conflicts lipgloss.Style // state of conflicted files
+	Staged    lipgloss.Style // state of staged files
+	Unstaged  lipgloss.Style // state of unstaged files
+	Untracked lipgloss.Style // state of untracked and ignored files
+}
+
+// DefaultStyles returns the default styles.
+func DefaultStyles() Styles {
+	return Styles{
+		Header:    lipgloss.NewStyle().Bold(true),
+		Section:   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("4")),
+		Error:     lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+		Muted:     lipgloss.NewStyle().Faint(true),
+		Conflicts: lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+		Staged:    lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
+		Unstaged:  lipgloss.NewStyle().Foreground(lipgloss.Color("3")),
+		Untracked: lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+	}
+}
+
+// Model is a Bubble Tea model showing the latest status of a watcher.
+//
+// Its methods have pointer receivers, so that embedding models can ignore the
+// model returned by Update.
+type Model struct {
+	// MaxLines limits the number of lines of files shown, summarizing the
+	// rest, or is zero for no limit.
+	MaxLines int
+	Styles   Styles
+
+	watcher *watch.Watcher
+	status  *statusv2.Status
+	err     error
+}
+
+// New returns a model showing the status of w. The caller remains responsible
+// for closing w.
+func New(w *watch.Watcher) *Model {
+	return &Model{Styles: DefaultStyles(), watcher: w}
+}
+
+// Status returns the latest status, or nil if none has been delivered yet.
+func (m *Model) Status() *statusv2.Status { return m.status }
+
+// Err returns the error of the latest attempt to get the status, or nil if it
+// succeeded.
+func (m *Model) Err() error { return m.err }
+
+// Init starts waiting for updates from the watcher.
+func (m *Model) Init() tea.Cmd {
+	return m.wait()
+}
+
+// wait returns a command that waits for the next status or error from the
+// watcher.
+func (m *Model) wait() tea.Cmd {
+	w := m.watcher
+	return func() tea.Msg {
+		select {
+		case s, ok := <-w.Updates():
+			if ok {
+				return StatusMsg{Status: s, watcher: w}
+			}
+		case err, ok := <-w.Errors():
+			if ok {
+				return ErrorMsg{Err: err, watcher: w}
+			}
+		}
+		return nil // watcher closed
+	}
+}
+
+// Update handles the messages of the model's watcher, ignoring all others.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case StatusMsg:
+		if msg.watcher == m.watcher {
+			m.status, m.err = msg.Status, nil
+			return m, m.wait()
+		}
+	case ErrorMsg:
+		if msg.watcher == m.watcher {
+			m.err = msg.Err
+			return m, m.wait()
+		}
+	}
+	return m, nil
+}
+
+// section is a group of files in the panel.
+type section struct {
+	title string
+	style lipgloss.Style
+	lines []string
+}
+
+// View renders the panel.
+func (m *Model) View() string {
+	var b strings.Builder
+	b.WriteString(m.Styles.Header.Render(m.header()) + "\n")
+	if m.err != nil {
+		b.WriteString(m.Styles.Error.Render(m.err.Error()) + "\n")
+	}
+	if m.status == nil {
+		return b.String()
+	}
+
+	sections := m.sections()
+	if len(sections) == 0 {
+		b.WriteString(m.Styles.Muted.Render("nothing to commit, working tree clean") + "\n")
+		return b.String()
+	}
+	var lines []string
+	for _, sec := range sections {
+		lines = append(lines, m.Styles.Section.Render(fmt.Sprintf("%s (%d)", sec.title, len(sec.lines))))
+		lines = append(lines, sec.lines...)
+	}
+	if m.MaxLines > 0 && len(lines) > m.MaxLines {
+		more := len(lines) - m.MaxLines + 1
+		lines = append(lines[:m.MaxLines-1], m.Styles.Muted.Render(fmt.Sprintf("… %d more lines", more)))
+	}
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// header returns the branch line of the panel.
+func (m *Model) header() string {
+	if m.status == nil || m.status.Branch == nil {
+		return "git status"
+	}
+	br := m.status.Branch
+	h := "On branch " + br.Head
+	if commit, ok := br.Commit(); br.IsDetached() && ok && len(commit) >= 7 {
+		h = "HEAD detached at " + string(commit[:7])
+	}
+	if br.HasUpstream() {
+		h += fmt.Sprintf(" (%s, ahead %d, behind %d)", br.Upstream, br.Ahead, br.Behind)
+	}
+	if m.status.Stash != nil {
+		h += fmt.Sprintf(", %d stashed", m.status.Stash.Count)
+	}
+	return h
+}
+
+// sections groups the entries of the status into non-empty sections, in the
+// order of urgency. Files with both staged and unstaged changes appear in
+// both.
+func (m *Model) sections() []section {
+	st := m.Styles
+	all := []section{
+		{title: "Conflicts", style: st.Conflicts},
+		{title: "Staged", style: st.Staged},
+		{title: "Unstaged", style: st.Unstaged},
+		{title: "Untracked", style: st.Untracked},
+		{title: "Ignored", style: st.Untracked},
+	}
+	const conflicts, staged, unstaged, untracked, ignored = 0, 1, 2, 3, 4
+	add := func(i int, state, path string) {
+		line := "  " + all[i].style.Render(fmt.Sprintf("%-2s", state)) + " " + path
+		all[i].lines = append(all[i].lines, line)
+	}
+	for _, entry := range m.status.Entries {
+		switch e := entry.(type) {
+		case statusv2.ChangedEntry:
+			if e.XY.HasStaged() {
+				add(staged, string(e.XY.X), e.Path)
+			}
+			if e.XY.HasUnstaged() {
+				add(unstaged, string(e.XY.Y), e.Path)
+			}
+		case statusv2.RenameOrCopyEntry:
+			if e.XY.HasStaged() {
+				add(staged, string(e.XY.X), e.Orig+" -> "+e.Path)
+			}
+			if e.XY.HasUnstaged() {
+				add(unstaged, string(e.XY.Y), e.Path)
+			}
+		case statusv2.UnmergedEntry:
+			add(conflicts, e.XY.String(), e.Path)
+		case statusv2.UntrackedEntry:
+			add(untracked, "?", e.Path)
+		case statusv2.IgnoredEntry:
+			add(ignored, "!", e.Path)
+		}
+	}
+
+	var sections []section
+	for _, sec := range all {
+		if len(sec.lines) > 0 {
+			sections = append(sections, sec)
+		}
+	}
+	return sections
+}
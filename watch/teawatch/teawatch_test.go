@@ -0,0 +1,162 @@
+package teawatch
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mroth/porcelain/statusv2"
+	"github.com/mroth/porcelain/watch"
+)
+
+// fakeRunner returns the porcelain output or error it was last set to.
+type fakeRunner struct {
+	mu  sync.Mutex
+	out string
+	err error
+}
+
+func (f *fakeRunner) Run(ctx context.Context, args ...string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return []byte(f.out), f.err
+}
+
+func (f *fakeRunner) set(out string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.out, f.err = out, err
+}
+
+func newWatcher(t *testing.T, git *fakeRunner) *watch.Watcher {
+	t.Helper()
+	w := watch.New(t.TempDir(), watch.Runner(git), watch.Interval(time.Hour), watch.Debounce(time.Millisecond))
+	t.Cleanup(func() { w.Close() })
+	return w
+}
+
+// next runs cmd, failing if it takes too long, and returns its message.
+func next(t *testing.T, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("command is nil")
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	select {
+	case msg := <-done:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil
+	}
+}
+
+func TestModel(t *testing.T) {
+	git := &fakeRunner{out: "# branch.oid ce013625030ba8dba906f756967f9e9ca394464a\x00# branch.head main\x00? new.txt\x00"}
+	w := newWatcher(t, git)
+	m := New(w)
+
+	if got := m.View(); got != "git status\n" {
+		t.Errorf("View() before status = %q", got)
+	}
+
+	msg := next(t, m.Init())
+	if _, ok := msg.(StatusMsg); !ok {
+		t.Fatalf("Init() message = %T, want StatusMsg", msg)
+	}
+	_, cmd := m.Update(msg)
+	if m.Status() == nil || len(m.Status().Entries) != 1 {
+		t.Fatalf("Status() = %+v, want one entry", m.Status())
+	}
+	want := "On branch main\nUntracked (1)\n  ?  new.txt\n"
+	if got := m.View(); got != want {
+		t.Errorf("View() = %q, want %q", got, want)
+	}
+
+	git.set("", errors.New("boom"))
+	w.Refresh()
+	msg = next(t, cmd)
+	if msg, ok := msg.(ErrorMsg); !ok || msg.Err.Error() != "boom" {
+		t.Fatalf("message = %#v, want ErrorMsg", msg)
+	}
+	_, cmd = m.Update(msg)
+	if m.Err() == nil || !strings.Contains(m.View(), "boom\n") {
+		t.Errorf("View() with error = %q", m.View())
+	}
+	if m.Status() == nil {
+		t.Error("Status() cleared by error, want the last status kept")
+	}
+
+	w.Close()
+	if msg := next(t, cmd); msg != nil {
+		t.Errorf("message after Close = %#v, want nil", msg)
+	}
+}
+
+func TestModel_OtherWatcher(t *testing.T) {
+	m := New(newWatcher(t, &fakeRunner{}))
+	other := New(newWatcher(t, &fakeRunner{out: "? x\x00"}))
+	msg := next(t, other.Init())
+	if _, cmd := m.Update(msg); cmd != nil {
+		t.Error("Update() of another watcher's message returned a command")
+	}
+	if m.Status() != nil {
+		t.Error("Update() applied another watcher's status")
+	}
+}
+
+func TestModel_View(t *testing.T) {
+	xy := func(x, y byte) statusv2.XYFlag {
+		return statusv2.XYFlag{X: statusv2.State(x), Y: statusv2.State(y)}
+	}
+	status := &statusv2.Status{
+		Branch: &statusv2.BranchInfo{OID: "ce013625030ba8dba906f756967f9e9ca394464a", Head: "(detached)"},
+		Stash:  &statusv2.StashInfo{Count: 2},
+		Entries: []statusv2.Entry{
+			statusv2.ChangedEntry{XY: xy('M', 'M'), Path: "both.go"},
+			statusv2.RenameOrCopyEntry{XY: xy('R', '.'), Path: "new.go", Orig: "old.go"},
+			statusv2.UnmergedEntry{XY: xy('U', 'U'), Path: "conflict.go"},
+			statusv2.IgnoredEntry{Path: "build/"},
+		},
+	}
+	tests := []struct {
+		name     string
+		status   *statusv2.Status
+		maxLines int
+		want     string
+	}{
+		{
+			name:   "clean",
+			status: &statusv2.Status{Branch: &statusv2.BranchInfo{Head: "main", Upstream: "origin/main", Ahead: 1}},
+			want:   "On branch main (origin/main, ahead 1, behind 0)\nnothing to commit, working tree clean\n",
+		},
+		{
+			name:   "sections",
+			status: status,
+			want: "HEAD detached at ce01362, 2 stashed\n" +
+				"Conflicts (1)\n  UU conflict.go\n" +
+				"Staged (2)\n  M  both.go\n  R  old.go -> new.go\n" +
+				"Unstaged (1)\n  M  both.go\n" +
+				"Ignored (1)\n  !  build/\n",
+		},
+		{
+			name:     "truncated",
+			status:   status,
+			maxLines: 3,
+			want:     "HEAD detached at ce01362, 2 stashed\nConflicts (1)\n  UU conflict.go\n… 7 more lines\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Model{Styles: DefaultStyles(), MaxLines: tt.maxLines, status: tt.status}
+			if got := m.View(); got != tt.want {
+				t.Errorf("View() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
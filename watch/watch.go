@@ -0,0 +1,282 @@
+package watch
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/mroth/porcelain/gitexec"
+	"github.com/mroth/porcelain/statusv2"
+)
+
+// Default timings for a Watcher.
+const (
+	DefaultInterval = 2 * time.Second
+	DefaultDebounce = 100 * time.Millisecond
+	DefaultJitter   = 0.1
+)
+
+// A Watcher polls the status of a repository in the background.
+type Watcher struct {
+	path     string
+	git      gitexec.Runner
+	args     []string
+	interval time.Duration
+	debounce time.Duration
+	jitter   float64
+	triggers []Trigger
+
+	// With the FileEvents option, the status the last batch of file events
+	// led to, and the status the batch waiting to be received started from.
+	fileEvents  chan []FileEvent
+	eventBase   *statusv2.Status
+	pendingBase *statusv2.Status
+
+	updates chan *statusv2.Status
+	errors  chan error
+	refresh chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// An Option configures a Watcher.
+type Option func(*Watcher)
+
+// Interval sets the time between polls. The default is [DefaultInterval].
+func Interval(d time.Duration) Option {
+	return func(w *Watcher) { w.interval = d }
+}
+
+// Debounce sets how long a Watcher waits after a call to [Watcher.Refresh]
+// for further calls before polling. The default is [DefaultDebounce].
+func Debounce(d time.Duration) Option {
+	return func(w *Watcher) { w.debounce = d }
+}
+
+// Jitter sets the fraction of the interval by which each poll is randomly
+// advanced or delayed, e.g. 0.1 for ±10%. The default is [DefaultJitter].
+func Jitter(fraction float64) Option {
+	return func(w *Watcher) { w.jitter = fraction }
+}
+
+// Args sets additional arguments for `git status`, such as
+// "--untracked-files=no".
+func Args(args ...string) Option {
+	return func(w *Watcher) { w.args = append(w.args, args...) }
+}
+
+// Runner sets the Runner used to run git, instead of one running git in the
+// repository path.
+func Runner(git gitexec.Runner) Option {
+	return func(w *Watcher) { w.git = git }
+}
+
+// FileEvents enables the delivery of file events on [Watcher.FileEvents].
+func FileEvents() Option {
+	return func(w *Watcher) { w.fileEvents = make(chan []FileEvent, 1) }
+}
+
+// A Trigger requests refreshes of a Watcher when the status may have changed,
+// for example on filesystem events, so that updates arrive sooner than the
+// next poll.
+//
+// Watch is called in its own goroutine when the Watcher starts. It should call
+// refresh whenever the status may have changed, which is cheap and does not
+// block, until ctx is canceled. An error returned before then is sent on
+// [Watcher.Errors], after which the Watcher continues by polling alone.
+type Trigger interface {
+	Watch(ctx context.Context, repoPath string, refresh func()) error
+}
+
+// Triggers adds triggers requesting refreshes of the Watcher, in addition to
+// polling. As refreshes are debounced, consider a shorter [Debounce] for
+// updates to follow triggers closely.
+func Triggers(triggers ...Trigger) Option {
+	return func(w *Watcher) { w.triggers = append(w.triggers, triggers...) }
+}
+
+// New starts watching the repository at repoPath. The first poll happens
+// immediately. Call [Watcher.Close] to stop watching.
+func New(repoPath string, opts ...Option) *Watcher {
+	w := &Watcher{
+		path:     repoPath,
+		git:      newRunner(repoPath),
+		args:     []string{"--branch", "--show-stash"},
+		interval: DefaultInterval,
+		debounce: DefaultDebounce,
+		jitter:   DefaultJitter,
+		updates:  make(chan *statusv2.Status, 1),
+		errors:   make(chan error, 1),
+		refresh:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	go w.run(ctx)
+	return w
+}
+
+// FileEvents returns the channel on which batches of file events are
+// delivered, if enabled with the [FileEvents] option, or nil otherwise. It is
+// closed once the Watcher has been closed.
+//
+// Each batch describes how the files changed between two statuses delivered
+// on [Watcher.Updates], starting from the first. If a batch has not been
+// received by the time the status changes again, it is replaced by a batch
+// describing both changes together, so no change is missed. Files that
+// changed and then changed back in the meantime are not included.
+func (w *Watcher) FileEvents() <-chan []FileEvent {
+	return w.fileEvents
+}
+
+// newRunner returns the default Runner for the repository at dir.
+func newRunner(dir string) gitexec.Runner {
+	return gitexec.New(dir)
+}
+
+// Updates returns the channel on which new statuses are delivered. It is
+// closed once the Watcher has been closed.
+func (w *Watcher) Updates() <-chan *statusv2.Status {
+	return w.updates
+}
+
+// Errors returns the channel on which polling errors are delivered. It is
+// closed once the Watcher has been closed.
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Refresh requests a poll ahead of schedule, once the debounce period has
+// passed without further calls. It does not block.
+func (w *Watcher) Refresh() {
+	select {
+	case w.refresh <- struct{}{}:
+	default: // a refresh is already pending
+	}
+}
+
+// Close stops the Watcher, canceling any running git process, and waits for
+// it to finish.
+func (w *Watcher) Close() error {
+	w.cancel()
+	<-w.done
+	return nil
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer func() {
+		close(w.updates)
+		close(w.errors)
+		if w.fileEvents != nil {
+			close(w.fileEvents)
+		}
+		close(w.done)
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait() // before closing the channels
+	for _, t := range w.triggers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := t.Watch(ctx, w.path, w.Refresh); err != nil && ctx.Err() == nil {
+				sendLatest(w.errors, err)
+			}
+		}()
+	}
+
+	var last *statusv2.Status
+	poll := func() {
+		s, err := statusv2.Get(ctx, w.git, w.args...)
+		switch {
+		case ctx.Err() != nil:
+			// closed while polling
+		case err != nil:
+			last = nil // deliver the next status, even if unchanged
+			sendLatest(w.errors, err)
+		case last == nil || !s.Equal(last):
+			last = s
+			sendLatest(w.updates, s)
+			if w.fileEvents != nil {
+				w.sendFileEvents(s)
+			}
+		}
+	}
+
+	poll()
+	tick := time.NewTimer(w.nextPoll())
+	defer tick.Stop()
+	var settled <-chan time.Time // fires once refreshes have been debounced
+	var debounce *time.Timer
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			poll()
+			tick.Reset(w.nextPoll())
+		case <-w.refresh:
+			if debounce == nil {
+				debounce = time.NewTimer(w.debounce)
+				defer debounce.Stop()
+			} else {
+				debounce.Reset(w.debounce)
+			}
+			settled = debounce.C
+		case <-settled:
+			settled = nil
+			poll()
+			tick.Reset(w.nextPoll())
+		}
+	}
+}
+
+// sendFileEvents sends the file events leading to s, combined with those of
+// any batch that has not been received yet.
+func (w *Watcher) sendFileEvents(s *statusv2.Status) {
+	if w.eventBase == nil {
+		w.eventBase = s // events start from the first status
+		return
+	}
+	base := w.eventBase
+	select {
+	case <-w.fileEvents:
+		base = w.pendingBase // not received, so replace it
+	default:
+	}
+	w.eventBase = s
+	if events := DiffEvents(base, s); len(events) > 0 {
+		w.pendingBase = base
+		w.fileEvents <- events
+	}
+}
+
+// nextPoll returns the time until the next scheduled poll, with jitter.
+func (w *Watcher) nextPoll() time.Duration {
+	d := w.interval
+	if w.jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * w.jitter * float64(d))
+	}
+	return max(d, 0)
+}
+
+// sendLatest sends v on ch, a channel with a buffer of one, replacing any
+// value that has not yet been received.
+func sendLatest[T any](ch chan T, v T) {
+	for {
+		select {
+		case ch <- v:
+			return
+		default:
+		}
+		select {
+		case <-ch:
+		default:
+		}
+	}
+}
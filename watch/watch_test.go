@@ -0,0 +1,194 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRunner returns the output it is set to, counting calls.
+type fakeRunner struct {
+	mu    sync.Mutex
+	out   string
+	err   error
+	calls int
+}
+
+func (f *fakeRunner) Run(ctx context.Context, args ...string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return []byte(f.out), f.err
+}
+
+func (f *fakeRunner) set(out string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.out, f.err = out, err
+}
+
+func (f *fakeRunner) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+const (
+	statusA = "# branch.oid (initial)\x00# branch.head main\x00? a.txt\x00"
+	statusB = "# branch.oid (initial)\x00# branch.head main\x00? a.txt\x00? b.txt\x00"
+)
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed")
+		}
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for value")
+	}
+	panic("unreachable")
+}
+
+func TestWatcher_OnlyOnChange(t *testing.T) {
+	git := &fakeRunner{out: statusA}
+	w := New("", Runner(git), Interval(5*time.Millisecond), Jitter(0))
+	defer w.Close()
+
+	if s := receive(t, w.Updates()); len(s.Entries) != 1 {
+		t.Fatalf("first update has %d entries, want 1", len(s.Entries))
+	}
+
+	// unchanged statuses are not delivered
+	for git.callCount() < 5 {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case s := <-w.Updates():
+		t.Fatalf("got update %v for unchanged status", s)
+	default:
+	}
+
+	git.set(statusB, nil)
+	if s := receive(t, w.Updates()); len(s.Entries) != 2 {
+		t.Fatalf("changed update has %d entries, want 2", len(s.Entries))
+	}
+}
+
+func TestWatcher_Refresh(t *testing.T) {
+	git := &fakeRunner{out: statusA}
+	w := New("", Runner(git), Interval(time.Hour), Debounce(10*time.Millisecond))
+	defer w.Close()
+	receive(t, w.Updates())
+
+	git.set(statusB, nil)
+	before := git.callCount()
+	for range 10 {
+		w.Refresh()
+	}
+	if s := receive(t, w.Updates()); len(s.Entries) != 2 {
+		t.Fatalf("refreshed update has %d entries, want 2", len(s.Entries))
+	}
+	// the burst of refreshes is debounced into a single poll
+	time.Sleep(50 * time.Millisecond)
+	if calls := git.callCount() - before; calls != 1 {
+		t.Errorf("got %d polls after refreshes, want 1", calls)
+	}
+}
+
+func TestWatcher_Errors(t *testing.T) {
+	git := &fakeRunner{err: errors.New("not a git repository")}
+	w := New("", Runner(git), Interval(5*time.Millisecond))
+	defer w.Close()
+
+	if err := receive(t, w.Errors()); err.Error() != "not a git repository" {
+		t.Errorf("got error %v", err)
+	}
+
+	// polling continues after errors
+	git.set(statusA, nil)
+	receive(t, w.Updates())
+
+	// the status after an error is delivered, even if unchanged
+	git.set("", errors.New("index.lock exists"))
+	receive(t, w.Errors())
+	git.set(statusA, nil)
+	receive(t, w.Updates())
+}
+
+func TestWatcher_Close(t *testing.T) {
+	git := &fakeRunner{out: statusA}
+	w := New("", Runner(git), Interval(time.Millisecond))
+	w.Close()
+
+	// channels are closed, after delivering any pending values
+	for range w.Updates() {
+	}
+	for range w.Errors() {
+	}
+	calls := git.callCount()
+	time.Sleep(10 * time.Millisecond)
+	if git.callCount() != calls {
+		t.Errorf("polling continued after Close")
+	}
+}
+
+func TestWatcher_nextPoll(t *testing.T) {
+	w := &Watcher{interval: time.Second, jitter: 0.1}
+	for range 100 {
+		if d := w.nextPoll(); d < 900*time.Millisecond || d > 1100*time.Millisecond {
+			t.Fatalf("nextPoll() = %v, want within 10%% of 1s", d)
+		}
+	}
+}
+
+// fakeTrigger calls refresh whenever it receives on fire.
+type fakeTrigger struct {
+	fire chan struct{}
+	err  error
+}
+
+func (f *fakeTrigger) Watch(ctx context.Context, repoPath string, refresh func()) error {
+	if f.err != nil {
+		return f.err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-f.fire:
+			refresh()
+		}
+	}
+}
+
+func TestWatcher_Triggers(t *testing.T) {
+	git := &fakeRunner{out: statusA}
+	trigger := &fakeTrigger{fire: make(chan struct{})}
+	w := New("", Runner(git), Interval(time.Hour), Debounce(time.Millisecond), Triggers(trigger))
+	defer w.Close()
+	receive(t, w.Updates())
+
+	git.set(statusB, nil)
+	trigger.fire <- struct{}{}
+	if s := receive(t, w.Updates()); len(s.Entries) != 2 {
+		t.Fatalf("triggered update has %d entries, want 2", len(s.Entries))
+	}
+}
+
+func TestWatcher_TriggerError(t *testing.T) {
+	git := &fakeRunner{out: statusA}
+	trigger := &fakeTrigger{err: errors.New("too many open files")}
+	w := New("", Runner(git), Interval(5*time.Millisecond), Triggers(trigger))
+	defer w.Close()
+
+	if err := receive(t, w.Errors()); err != trigger.err {
+		t.Errorf("got error %v, want %v", err, trigger.err)
+	}
+	// polling continues
+	receive(t, w.Updates())
+}
@@ -0,0 +1,19 @@
+/*
+Package zscan splits the NUL-terminated output git writes with -z, such as
+that of git status, git ls-files or git diff --raw, into records for a
+[bufio.Scanner].
+
+Most such output is a sequence of NUL-terminated records, split by
+[ScanNUL]. Some records embed a NUL of their own, such as the entries of
+renamed files in git status, which hold two paths separated by a NUL. For
+those, [ScanRecords] takes a predicate deciding from the start of a record
+whether it spans a second NUL-terminated field:
+
+	scanner := zscan.NewScanner(r, func(head []byte) bool {
+	    return bytes.HasPrefix(head, []byte("2 ")) // a rename or copy
+	})
+	for scanner.Scan() {
+	    record := scanner.Bytes() // "2 R. ... new.txt\x00old.txt"
+	}
+*/
+package zscan
@@ -0,0 +1,79 @@
+package zscan
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/mroth/porcelain/parseerr"
+)
+
+// ErrMissingField is the error of a scanner when the input ends after the
+// first field of a record that embeds a NUL.
+var ErrMissingField error = parseerr.New(parseerr.MissingField)
+
+// NewScanner returns a scanner reading the NUL-terminated records of r, split
+// as by [ScanRecords] with embedded, or by [ScanNUL] if embedded is nil.
+func NewScanner(r io.Reader, embedded func(head []byte) bool) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	if embedded == nil {
+		scanner.Split(ScanNUL)
+	} else {
+		scanner.Split(ScanRecords(embedded))
+	}
+	return scanner
+}
+
+// ScanNUL is a [bufio.SplitFunc] returning each NUL-terminated token, without
+// its NUL. A final token without a NUL is returned as is. Empty tokens are
+// returned, as they can be significant, such as the empty field separating
+// the sections of git merge-tree output.
+func ScanNUL(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexByte(data, '\x00'); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		// No NUL found but we're at EOF, return remaining data
+		return len(data), data, nil
+	}
+	// Need more data
+	return 0, nil, nil
+}
+
+// ScanRecords returns a [bufio.SplitFunc] returning each NUL-terminated
+// record, like [ScanNUL], except that a record for which embedded reports true
+// spans two NUL-terminated fields, and is returned with the NUL between them.
+// Embedded is given the record up to its first NUL.
+//
+// At the end of the input, a record with an embedded NUL and a second field
+// without its NUL is returned as is, while one lacking the second field is the
+// error [ErrMissingField].
+func ScanRecords(embedded func(head []byte) bool) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		// Look for the first NUL byte. For records with an embedded NUL, this
+		// is the field separator, and for all others, the terminator.
+		firstNUL := bytes.IndexByte(data, '\x00')
+		if firstNUL == -1 || !embedded(data[:firstNUL]) {
+			return ScanNUL(data, atEOF)
+		}
+
+		// Look for the second NUL byte, the terminator.
+		secondNUL := bytes.IndexByte(data[firstNUL+1:], '\x00')
+		if secondNUL == -1 {
+			if !atEOF {
+				// Need more data to find the second NUL
+				return 0, nil, nil
+			}
+			if firstNUL+1 < len(data) {
+				// We have data after the first NUL, treat it as the second field
+				return len(data), data, nil
+			}
+			return 0, nil, ErrMissingField
+		}
+
+		// Return the entire record including the embedded NUL, advancing past
+		// the terminator.
+		end := firstNUL + 1 + secondNUL
+		return end + 1, data[:end], nil
+	}
+}
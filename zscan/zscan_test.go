@@ -0,0 +1,73 @@
+package zscan
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// isPair reports whether a test record embeds a NUL, as those starting with
+// "2 " do.
+func isPair(head []byte) bool {
+	return bytes.HasPrefix(head, []byte("2 "))
+}
+
+func scanAll(t *testing.T, input string, embedded func([]byte) bool) ([]string, error) {
+	t.Helper()
+	// Read one byte at a time, to exercise requests for more data.
+	scanner := NewScanner(iotest.OneByteReader(strings.NewReader(input)), embedded)
+	var tokens []string
+	for scanner.Scan() {
+		tokens = append(tokens, scanner.Text())
+	}
+	return tokens, scanner.Err()
+}
+
+func TestScanNUL(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"a\x00b\x00", []string{"a", "b"}},
+		{"a\x00\x00b", []string{"a", "", "b"}},
+		{"2 a\x00b\x00", []string{"2 a", "b"}},
+	}
+	for _, tt := range tests {
+		got, err := scanAll(t, tt.input, nil)
+		if err != nil {
+			t.Errorf("scanning %q: error = %v", tt.input, err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("scanning %q: mismatch (-want +got):\n%s", tt.input, diff)
+		}
+	}
+}
+
+func TestScanRecords(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []string
+		wantErr error
+	}{
+		{"", nil, nil},
+		{"1 a\x002 b\x00c\x00? d\x00", []string{"1 a", "2 b\x00c", "? d"}, nil},
+		{"2 b\x00c", []string{"2 b\x00c"}, nil},
+		{"2 b\x00\x00", []string{"2 b\x00"}, nil},
+		{"? d", []string{"? d"}, nil},
+		{"1 a\x002 b\x00", []string{"1 a"}, ErrMissingField},
+	}
+	for _, tt := range tests {
+		got, err := scanAll(t, tt.input, isPair)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("scanning %q: error = %v, want %v", tt.input, err, tt.wantErr)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("scanning %q: mismatch (-want +got):\n%s", tt.input, diff)
+		}
+	}
+}